@@ -3,30 +3,42 @@ package configurations
 import (
 	"os"
 	"strconv"
+	"time"
 )
 
 type appConfiguration struct {
 	appName            string
 	env                string
 	port               string
+	grpcPort           string
 	corsAllowedOrigins string
 	otelCollector      string
 	enableTracer       bool
 	enableMetric       bool
+	enablePrometheus   bool
+	drainTimeout       string
+	readinessGrace     string
+	logLevel           string
 }
 
 func NewAppConfiguration() *appConfiguration {
 	enableTracer, _ := strconv.ParseBool(os.Getenv("ENABLE_TRACER"))
 	enableMetric, _ := strconv.ParseBool(os.Getenv("ENABLE_METRIC"))
+	enablePrometheus, _ := strconv.ParseBool(os.Getenv("ENABLE_PROMETHEUS"))
 
 	return &appConfiguration{
 		appName:            os.Getenv("APP_NAME"),
 		env:                os.Getenv("APP_ENV"),
 		port:               os.Getenv("APP_PORT"),
+		grpcPort:           os.Getenv("GRPC_PORT"),
 		corsAllowedOrigins: os.Getenv("CORS_ALLOWED_ORIGINS"),
 		otelCollector:      os.Getenv("OTEL_COLLECTOR_URL"),
 		enableTracer:       enableTracer,
 		enableMetric:       enableMetric,
+		enablePrometheus:   enablePrometheus,
+		drainTimeout:       os.Getenv("SHUTDOWN_DRAIN_TIMEOUT_SECOND"),
+		readinessGrace:     os.Getenv("SHUTDOWN_READINESS_GRACE_SECOND"),
+		logLevel:           os.Getenv("LOG_LEVEL"),
 	}
 }
 
@@ -35,10 +47,15 @@ type IAppConfiguration interface {
 	GetAppName() string
 	GetEnv() string
 	GetPort() string
+	GetGRPCPort() string
 	GetCorsAllowedOrigins() string
 	GetOtelCollector() string
 	GetEnableMetric() bool
 	GetEnableTracer() bool
+	GetEnablePrometheus() bool
+	GetShutdownDrainTimeout() time.Duration
+	GetShutdownReadinessGrace() time.Duration
+	GetLogLevel() string
 }
 
 func (ac *appConfiguration) GetAppName() string {
@@ -61,6 +78,13 @@ func (ac *appConfiguration) GetPort() string {
 	return ac.port
 }
 
+func (ac *appConfiguration) GetGRPCPort() string {
+	if ac.grpcPort == "" {
+		return "50051"
+	}
+	return ac.grpcPort
+}
+
 func (ac *appConfiguration) GetCorsAllowedOrigins() string {
 	return ac.corsAllowedOrigins
 }
@@ -76,3 +100,30 @@ func (ac *appConfiguration) GetEnableTracer() bool {
 func (ac *appConfiguration) GetEnableMetric() bool {
 	return ac.enableMetric
 }
+
+func (ac *appConfiguration) GetEnablePrometheus() bool {
+	return ac.enablePrometheus
+}
+
+func (ac *appConfiguration) GetShutdownDrainTimeout() time.Duration {
+	drainTimeout, err := strconv.Atoi(ac.drainTimeout)
+	if err != nil {
+		return 15 * time.Second // default 15 seconds
+	}
+	return time.Duration(drainTimeout) * time.Second
+}
+
+func (ac *appConfiguration) GetShutdownReadinessGrace() time.Duration {
+	readinessGrace, err := strconv.Atoi(ac.readinessGrace)
+	if err != nil {
+		return 0 // no grace by default, readiness fails as drain starts
+	}
+	return time.Duration(readinessGrace) * time.Second
+}
+
+func (ac *appConfiguration) GetLogLevel() string {
+	if ac.logLevel == "" {
+		return "info"
+	}
+	return ac.logLevel
+}
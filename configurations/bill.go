@@ -0,0 +1,43 @@
+package configurations
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+type billConfiguration struct {
+	maxParticipants  string
+	reminderInterval string
+}
+
+//go:generate mockgen -destination=mocks/mock_bill.go -source=bill.go IBillConfiguration
+type IBillConfiguration interface {
+	GetMaxParticipants() int
+	GetReminderInterval() time.Duration
+}
+
+func NewBillConfiguration() *billConfiguration {
+	return &billConfiguration{
+		maxParticipants:  os.Getenv("BILL_MAX_PARTICIPANTS"),
+		reminderInterval: os.Getenv("BILL_REMINDER_INTERVAL_HOUR"),
+	}
+}
+
+// GetMaxParticipants bounds the participants of a bill, the creator aside
+func (c *billConfiguration) GetMaxParticipants() int {
+	maxParticipants, err := strconv.Atoi(c.maxParticipants)
+	if err != nil || maxParticipants <= 0 {
+		return 20 // default 20 participants
+	}
+	return maxParticipants
+}
+
+// GetReminderInterval is how long a participant is left alone after a reminder
+func (c *billConfiguration) GetReminderInterval() time.Duration {
+	reminderInterval, err := strconv.Atoi(c.reminderInterval)
+	if err != nil || reminderInterval <= 0 {
+		return 24 * time.Hour // default 1 day
+	}
+	return time.Duration(reminderInterval) * time.Hour
+}
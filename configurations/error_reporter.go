@@ -0,0 +1,61 @@
+package configurations
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	ErrorReporterLog    = "log"
+	ErrorReporterSentry = "sentry"
+)
+
+type errorReporterConfiguration struct {
+	reporter     string
+	sentryDSN    string
+	ignoredTypes string
+}
+
+//go:generate mockgen -destination=mocks/mock_error_reporter.go -source=error_reporter.go IErrorReporterConfiguration
+type IErrorReporterConfiguration interface {
+	GetReporter() string
+	GetSentryDSN() string
+	GetIgnoredTypes() []int
+}
+
+func NewErrorReporterConfiguration() *errorReporterConfiguration {
+	return &errorReporterConfiguration{
+		reporter:     os.Getenv("ERROR_REPORTER"),
+		sentryDSN:    os.Getenv("SENTRY_DSN"),
+		ignoredTypes: os.Getenv("ERROR_REPORT_IGNORED_TYPES"),
+	}
+}
+
+func (c *errorReporterConfiguration) GetReporter() string {
+	if c.reporter == "" {
+		return ErrorReporterLog
+	}
+	return c.reporter
+}
+
+func (c *errorReporterConfiguration) GetSentryDSN() string {
+	return c.sentryDSN
+}
+
+// GetIgnoredTypes returns the error types that are never reported, e.g. "401,403,404"
+func (c *errorReporterConfiguration) GetIgnoredTypes() []int {
+	if c.ignoredTypes == "" {
+		return []int{401, 403, 404, 422, 429} // default client errors
+	}
+
+	var ignoredTypes []int
+	for _, value := range strings.Split(c.ignoredTypes, ",") {
+		ignoredType, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			continue
+		}
+		ignoredTypes = append(ignoredTypes, ignoredType)
+	}
+	return ignoredTypes
+}
@@ -0,0 +1,31 @@
+package configurations
+
+import (
+	"os"
+	"strconv"
+)
+
+type feeConfiguration struct {
+	revenueUserID string
+}
+
+//go:generate mockgen -destination=mocks/mock_fee.go -source=fee.go IFeeConfiguration
+type IFeeConfiguration interface {
+	GetRevenueUserID() int32
+}
+
+func NewFeeConfiguration() *feeConfiguration {
+	return &feeConfiguration{
+		revenueUserID: os.Getenv("FEE_REVENUE_USER_ID"),
+	}
+}
+
+// GetRevenueUserID is the account the charged fees are credited to, a fee can't
+// be posted while it is unset
+func (c *feeConfiguration) GetRevenueUserID() int32 {
+	revenueUserID, err := strconv.ParseInt(c.revenueUserID, 10, 32)
+	if err != nil || revenueUserID <= 0 {
+		return 0 // default unset
+	}
+	return int32(revenueUserID)
+}
@@ -0,0 +1,65 @@
+package configurations
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type fxConfiguration struct {
+	rates         string
+	spreadPercent string
+	quoteTTL      string
+}
+
+//go:generate mockgen -destination=mocks/mock_fx.go -source=fx.go IFXConfiguration
+type IFXConfiguration interface {
+	GetRates() map[string]float64
+	GetSpreadPercent() float64
+	GetQuoteTTL() time.Duration
+}
+
+func NewFXConfiguration() *fxConfiguration {
+	return &fxConfiguration{
+		rates:         os.Getenv("FX_RATES"),
+		spreadPercent: os.Getenv("FX_SPREAD_PERCENT"),
+		quoteTTL:      os.Getenv("FX_QUOTE_TTL_SECOND"),
+	}
+}
+
+// GetRates are the mid-market rates keyed by pair, e.g. "USD/IDR=16250,EUR/IDR=17650",
+// an entry that can't be parsed is skipped
+func (c *fxConfiguration) GetRates() map[string]float64 {
+	rates := map[string]float64{}
+	for _, entry := range strings.Split(c.rates, ",") {
+		pair, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			continue
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || rate <= 0 {
+			continue
+		}
+		rates[strings.ToUpper(strings.TrimSpace(pair))] = rate
+	}
+	return rates
+}
+
+// GetSpreadPercent is the share of the mid-market rate kept on each exchange
+func (c *fxConfiguration) GetSpreadPercent() float64 {
+	spreadPercent, err := strconv.ParseFloat(c.spreadPercent, 64)
+	if err != nil || spreadPercent < 0 || spreadPercent >= 100 {
+		return 0.5 // default 0.5 percent
+	}
+	return spreadPercent
+}
+
+// GetQuoteTTL is how long an exchange quote can be executed at its quoted rate
+func (c *fxConfiguration) GetQuoteTTL() time.Duration {
+	ttl, err := strconv.Atoi(c.quoteTTL)
+	if err != nil || ttl <= 0 {
+		return 30 * time.Second // default 30 seconds
+	}
+	return time.Duration(ttl) * time.Second
+}
@@ -0,0 +1,54 @@
+package configurations
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+type merchantConfiguration struct {
+	keyRotationOverlap string
+	signatureTolerance string
+	secretEncryption   string
+}
+
+//go:generate mockgen -destination=mocks/mock_merchant.go -source=merchant.go IMerchantConfiguration
+type IMerchantConfiguration interface {
+	GetKeyRotationOverlap() time.Duration
+	GetSignatureTolerance() time.Duration
+	GetSecretEncryptionKey() string
+}
+
+func NewMerchantConfiguration() *merchantConfiguration {
+	return &merchantConfiguration{
+		keyRotationOverlap: os.Getenv("MERCHANT_KEY_ROTATION_OVERLAP_HOUR"),
+		signatureTolerance: os.Getenv("MERCHANT_SIGNATURE_TOLERANCE_SECOND"),
+		secretEncryption:   os.Getenv("MERCHANT_SECRET_ENCRYPTION_KEY"),
+	}
+}
+
+// GetKeyRotationOverlap is how long the previous keys stay valid after a rotation,
+// so the merchant can roll the new key out without failing requests
+func (c *merchantConfiguration) GetKeyRotationOverlap() time.Duration {
+	overlap, err := strconv.Atoi(c.keyRotationOverlap)
+	if err != nil || overlap < 0 {
+		return 24 * time.Hour // default 24 hours
+	}
+	return time.Duration(overlap) * time.Hour
+}
+
+// GetSignatureTolerance is how far the signed timestamp may be from now, nonces
+// are remembered for twice as long so a request can't be replayed inside the window
+func (c *merchantConfiguration) GetSignatureTolerance() time.Duration {
+	tolerance, err := strconv.Atoi(c.signatureTolerance)
+	if err != nil || tolerance <= 0 {
+		return 5 * time.Minute // default 5 minutes
+	}
+	return time.Duration(tolerance) * time.Second
+}
+
+// GetSecretEncryptionKey is the hex AES-256 key the api key secrets are encrypted
+// with at rest, there is no default since a key shipped with the code protects nothing
+func (c *merchantConfiguration) GetSecretEncryptionKey() string {
+	return c.secretEncryption
+}
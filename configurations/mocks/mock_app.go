@@ -6,6 +6,7 @@ package mock_configuration
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -47,20 +48,6 @@ func (mr *MockIAppConfigurationMockRecorder) GetAppName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppName", reflect.TypeOf((*MockIAppConfiguration)(nil).GetAppName))
 }
 
-// GetAuthenticationService mocks base method.
-func (m *MockIAppConfiguration) GetAuthenticationService() string {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAuthenticationService")
-	ret0, _ := ret[0].(string)
-	return ret0
-}
-
-// GetAuthenticationService indicates an expected call of GetAuthenticationService.
-func (mr *MockIAppConfigurationMockRecorder) GetAuthenticationService() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthenticationService", reflect.TypeOf((*MockIAppConfiguration)(nil).GetAuthenticationService))
-}
-
 // GetCorsAllowedOrigins mocks base method.
 func (m *MockIAppConfiguration) GetCorsAllowedOrigins() string {
 	m.ctrl.T.Helper()
@@ -89,6 +76,20 @@ func (mr *MockIAppConfigurationMockRecorder) GetEnableMetric() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnableMetric", reflect.TypeOf((*MockIAppConfiguration)(nil).GetEnableMetric))
 }
 
+// GetEnablePrometheus mocks base method.
+func (m *MockIAppConfiguration) GetEnablePrometheus() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnablePrometheus")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// GetEnablePrometheus indicates an expected call of GetEnablePrometheus.
+func (mr *MockIAppConfigurationMockRecorder) GetEnablePrometheus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnablePrometheus", reflect.TypeOf((*MockIAppConfiguration)(nil).GetEnablePrometheus))
+}
+
 // GetEnableTracer mocks base method.
 func (m *MockIAppConfiguration) GetEnableTracer() bool {
 	m.ctrl.T.Helper()
@@ -117,6 +118,34 @@ func (mr *MockIAppConfigurationMockRecorder) GetEnv() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnv", reflect.TypeOf((*MockIAppConfiguration)(nil).GetEnv))
 }
 
+// GetGRPCPort mocks base method.
+func (m *MockIAppConfiguration) GetGRPCPort() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGRPCPort")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetGRPCPort indicates an expected call of GetGRPCPort.
+func (mr *MockIAppConfigurationMockRecorder) GetGRPCPort() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGRPCPort", reflect.TypeOf((*MockIAppConfiguration)(nil).GetGRPCPort))
+}
+
+// GetLogLevel mocks base method.
+func (m *MockIAppConfiguration) GetLogLevel() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLogLevel")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetLogLevel indicates an expected call of GetLogLevel.
+func (mr *MockIAppConfigurationMockRecorder) GetLogLevel() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogLevel", reflect.TypeOf((*MockIAppConfiguration)(nil).GetLogLevel))
+}
+
 // GetOtelCollector mocks base method.
 func (m *MockIAppConfiguration) GetOtelCollector() string {
 	m.ctrl.T.Helper()
@@ -144,3 +173,31 @@ func (mr *MockIAppConfigurationMockRecorder) GetPort() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPort", reflect.TypeOf((*MockIAppConfiguration)(nil).GetPort))
 }
+
+// GetShutdownDrainTimeout mocks base method.
+func (m *MockIAppConfiguration) GetShutdownDrainTimeout() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShutdownDrainTimeout")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetShutdownDrainTimeout indicates an expected call of GetShutdownDrainTimeout.
+func (mr *MockIAppConfigurationMockRecorder) GetShutdownDrainTimeout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShutdownDrainTimeout", reflect.TypeOf((*MockIAppConfiguration)(nil).GetShutdownDrainTimeout))
+}
+
+// GetShutdownReadinessGrace mocks base method.
+func (m *MockIAppConfiguration) GetShutdownReadinessGrace() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShutdownReadinessGrace")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetShutdownReadinessGrace indicates an expected call of GetShutdownReadinessGrace.
+func (mr *MockIAppConfigurationMockRecorder) GetShutdownReadinessGrace() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShutdownReadinessGrace", reflect.TypeOf((*MockIAppConfiguration)(nil).GetShutdownReadinessGrace))
+}
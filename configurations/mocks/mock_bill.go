@@ -0,0 +1,63 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: bill.go
+
+// Package mock_configuration is a generated GoMock package.
+package mock_configuration
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIBillConfiguration is a mock of IBillConfiguration interface.
+type MockIBillConfiguration struct {
+	ctrl     *gomock.Controller
+	recorder *MockIBillConfigurationMockRecorder
+}
+
+// MockIBillConfigurationMockRecorder is the mock recorder for MockIBillConfiguration.
+type MockIBillConfigurationMockRecorder struct {
+	mock *MockIBillConfiguration
+}
+
+// NewMockIBillConfiguration creates a new mock instance.
+func NewMockIBillConfiguration(ctrl *gomock.Controller) *MockIBillConfiguration {
+	mock := &MockIBillConfiguration{ctrl: ctrl}
+	mock.recorder = &MockIBillConfigurationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIBillConfiguration) EXPECT() *MockIBillConfigurationMockRecorder {
+	return m.recorder
+}
+
+// GetMaxParticipants mocks base method.
+func (m *MockIBillConfiguration) GetMaxParticipants() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxParticipants")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetMaxParticipants indicates an expected call of GetMaxParticipants.
+func (mr *MockIBillConfigurationMockRecorder) GetMaxParticipants() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxParticipants", reflect.TypeOf((*MockIBillConfiguration)(nil).GetMaxParticipants))
+}
+
+// GetReminderInterval mocks base method.
+func (m *MockIBillConfiguration) GetReminderInterval() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReminderInterval")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetReminderInterval indicates an expected call of GetReminderInterval.
+func (mr *MockIBillConfigurationMockRecorder) GetReminderInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReminderInterval", reflect.TypeOf((*MockIBillConfiguration)(nil).GetReminderInterval))
+}
@@ -0,0 +1,76 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: error_reporter.go
+
+// Package mock_configuration is a generated GoMock package.
+package mock_configuration
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIErrorReporterConfiguration is a mock of IErrorReporterConfiguration interface.
+type MockIErrorReporterConfiguration struct {
+	ctrl     *gomock.Controller
+	recorder *MockIErrorReporterConfigurationMockRecorder
+}
+
+// MockIErrorReporterConfigurationMockRecorder is the mock recorder for MockIErrorReporterConfiguration.
+type MockIErrorReporterConfigurationMockRecorder struct {
+	mock *MockIErrorReporterConfiguration
+}
+
+// NewMockIErrorReporterConfiguration creates a new mock instance.
+func NewMockIErrorReporterConfiguration(ctrl *gomock.Controller) *MockIErrorReporterConfiguration {
+	mock := &MockIErrorReporterConfiguration{ctrl: ctrl}
+	mock.recorder = &MockIErrorReporterConfigurationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIErrorReporterConfiguration) EXPECT() *MockIErrorReporterConfigurationMockRecorder {
+	return m.recorder
+}
+
+// GetIgnoredTypes mocks base method.
+func (m *MockIErrorReporterConfiguration) GetIgnoredTypes() []int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIgnoredTypes")
+	ret0, _ := ret[0].([]int)
+	return ret0
+}
+
+// GetIgnoredTypes indicates an expected call of GetIgnoredTypes.
+func (mr *MockIErrorReporterConfigurationMockRecorder) GetIgnoredTypes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIgnoredTypes", reflect.TypeOf((*MockIErrorReporterConfiguration)(nil).GetIgnoredTypes))
+}
+
+// GetReporter mocks base method.
+func (m *MockIErrorReporterConfiguration) GetReporter() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReporter")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetReporter indicates an expected call of GetReporter.
+func (mr *MockIErrorReporterConfigurationMockRecorder) GetReporter() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReporter", reflect.TypeOf((*MockIErrorReporterConfiguration)(nil).GetReporter))
+}
+
+// GetSentryDSN mocks base method.
+func (m *MockIErrorReporterConfiguration) GetSentryDSN() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSentryDSN")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetSentryDSN indicates an expected call of GetSentryDSN.
+func (mr *MockIErrorReporterConfigurationMockRecorder) GetSentryDSN() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSentryDSN", reflect.TypeOf((*MockIErrorReporterConfiguration)(nil).GetSentryDSN))
+}
@@ -0,0 +1,48 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: fee.go
+
+// Package mock_configuration is a generated GoMock package.
+package mock_configuration
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIFeeConfiguration is a mock of IFeeConfiguration interface.
+type MockIFeeConfiguration struct {
+	ctrl     *gomock.Controller
+	recorder *MockIFeeConfigurationMockRecorder
+}
+
+// MockIFeeConfigurationMockRecorder is the mock recorder for MockIFeeConfiguration.
+type MockIFeeConfigurationMockRecorder struct {
+	mock *MockIFeeConfiguration
+}
+
+// NewMockIFeeConfiguration creates a new mock instance.
+func NewMockIFeeConfiguration(ctrl *gomock.Controller) *MockIFeeConfiguration {
+	mock := &MockIFeeConfiguration{ctrl: ctrl}
+	mock.recorder = &MockIFeeConfigurationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIFeeConfiguration) EXPECT() *MockIFeeConfigurationMockRecorder {
+	return m.recorder
+}
+
+// GetRevenueUserID mocks base method.
+func (m *MockIFeeConfiguration) GetRevenueUserID() int32 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevenueUserID")
+	ret0, _ := ret[0].(int32)
+	return ret0
+}
+
+// GetRevenueUserID indicates an expected call of GetRevenueUserID.
+func (mr *MockIFeeConfigurationMockRecorder) GetRevenueUserID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevenueUserID", reflect.TypeOf((*MockIFeeConfiguration)(nil).GetRevenueUserID))
+}
@@ -0,0 +1,77 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: fx.go
+
+// Package mock_configuration is a generated GoMock package.
+package mock_configuration
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIFXConfiguration is a mock of IFXConfiguration interface.
+type MockIFXConfiguration struct {
+	ctrl     *gomock.Controller
+	recorder *MockIFXConfigurationMockRecorder
+}
+
+// MockIFXConfigurationMockRecorder is the mock recorder for MockIFXConfiguration.
+type MockIFXConfigurationMockRecorder struct {
+	mock *MockIFXConfiguration
+}
+
+// NewMockIFXConfiguration creates a new mock instance.
+func NewMockIFXConfiguration(ctrl *gomock.Controller) *MockIFXConfiguration {
+	mock := &MockIFXConfiguration{ctrl: ctrl}
+	mock.recorder = &MockIFXConfigurationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIFXConfiguration) EXPECT() *MockIFXConfigurationMockRecorder {
+	return m.recorder
+}
+
+// GetQuoteTTL mocks base method.
+func (m *MockIFXConfiguration) GetQuoteTTL() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuoteTTL")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetQuoteTTL indicates an expected call of GetQuoteTTL.
+func (mr *MockIFXConfigurationMockRecorder) GetQuoteTTL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuoteTTL", reflect.TypeOf((*MockIFXConfiguration)(nil).GetQuoteTTL))
+}
+
+// GetRates mocks base method.
+func (m *MockIFXConfiguration) GetRates() map[string]float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRates")
+	ret0, _ := ret[0].(map[string]float64)
+	return ret0
+}
+
+// GetRates indicates an expected call of GetRates.
+func (mr *MockIFXConfigurationMockRecorder) GetRates() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRates", reflect.TypeOf((*MockIFXConfiguration)(nil).GetRates))
+}
+
+// GetSpreadPercent mocks base method.
+func (m *MockIFXConfiguration) GetSpreadPercent() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSpreadPercent")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetSpreadPercent indicates an expected call of GetSpreadPercent.
+func (mr *MockIFXConfigurationMockRecorder) GetSpreadPercent() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpreadPercent", reflect.TypeOf((*MockIFXConfiguration)(nil).GetSpreadPercent))
+}
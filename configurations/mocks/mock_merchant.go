@@ -0,0 +1,77 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: merchant.go
+
+// Package mock_configuration is a generated GoMock package.
+package mock_configuration
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIMerchantConfiguration is a mock of IMerchantConfiguration interface.
+type MockIMerchantConfiguration struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMerchantConfigurationMockRecorder
+}
+
+// MockIMerchantConfigurationMockRecorder is the mock recorder for MockIMerchantConfiguration.
+type MockIMerchantConfigurationMockRecorder struct {
+	mock *MockIMerchantConfiguration
+}
+
+// NewMockIMerchantConfiguration creates a new mock instance.
+func NewMockIMerchantConfiguration(ctrl *gomock.Controller) *MockIMerchantConfiguration {
+	mock := &MockIMerchantConfiguration{ctrl: ctrl}
+	mock.recorder = &MockIMerchantConfigurationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMerchantConfiguration) EXPECT() *MockIMerchantConfigurationMockRecorder {
+	return m.recorder
+}
+
+// GetKeyRotationOverlap mocks base method.
+func (m *MockIMerchantConfiguration) GetKeyRotationOverlap() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeyRotationOverlap")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetKeyRotationOverlap indicates an expected call of GetKeyRotationOverlap.
+func (mr *MockIMerchantConfigurationMockRecorder) GetKeyRotationOverlap() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeyRotationOverlap", reflect.TypeOf((*MockIMerchantConfiguration)(nil).GetKeyRotationOverlap))
+}
+
+// GetSecretEncryptionKey mocks base method.
+func (m *MockIMerchantConfiguration) GetSecretEncryptionKey() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretEncryptionKey")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetSecretEncryptionKey indicates an expected call of GetSecretEncryptionKey.
+func (mr *MockIMerchantConfigurationMockRecorder) GetSecretEncryptionKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretEncryptionKey", reflect.TypeOf((*MockIMerchantConfiguration)(nil).GetSecretEncryptionKey))
+}
+
+// GetSignatureTolerance mocks base method.
+func (m *MockIMerchantConfiguration) GetSignatureTolerance() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSignatureTolerance")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetSignatureTolerance indicates an expected call of GetSignatureTolerance.
+func (mr *MockIMerchantConfigurationMockRecorder) GetSignatureTolerance() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSignatureTolerance", reflect.TypeOf((*MockIMerchantConfiguration)(nil).GetSignatureTolerance))
+}
@@ -0,0 +1,77 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: payment_request.go
+
+// Package mock_configuration is a generated GoMock package.
+package mock_configuration
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIPaymentRequestConfiguration is a mock of IPaymentRequestConfiguration interface.
+type MockIPaymentRequestConfiguration struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPaymentRequestConfigurationMockRecorder
+}
+
+// MockIPaymentRequestConfigurationMockRecorder is the mock recorder for MockIPaymentRequestConfiguration.
+type MockIPaymentRequestConfigurationMockRecorder struct {
+	mock *MockIPaymentRequestConfiguration
+}
+
+// NewMockIPaymentRequestConfiguration creates a new mock instance.
+func NewMockIPaymentRequestConfiguration(ctrl *gomock.Controller) *MockIPaymentRequestConfiguration {
+	mock := &MockIPaymentRequestConfiguration{ctrl: ctrl}
+	mock.recorder = &MockIPaymentRequestConfigurationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPaymentRequestConfiguration) EXPECT() *MockIPaymentRequestConfigurationMockRecorder {
+	return m.recorder
+}
+
+// GetDefaultTTL mocks base method.
+func (m *MockIPaymentRequestConfiguration) GetDefaultTTL() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefaultTTL")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetDefaultTTL indicates an expected call of GetDefaultTTL.
+func (mr *MockIPaymentRequestConfigurationMockRecorder) GetDefaultTTL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultTTL", reflect.TypeOf((*MockIPaymentRequestConfiguration)(nil).GetDefaultTTL))
+}
+
+// GetExpiryPollInterval mocks base method.
+func (m *MockIPaymentRequestConfiguration) GetExpiryPollInterval() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiryPollInterval")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetExpiryPollInterval indicates an expected call of GetExpiryPollInterval.
+func (mr *MockIPaymentRequestConfigurationMockRecorder) GetExpiryPollInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiryPollInterval", reflect.TypeOf((*MockIPaymentRequestConfiguration)(nil).GetExpiryPollInterval))
+}
+
+// GetMaxTTL mocks base method.
+func (m *MockIPaymentRequestConfiguration) GetMaxTTL() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxTTL")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetMaxTTL indicates an expected call of GetMaxTTL.
+func (mr *MockIPaymentRequestConfigurationMockRecorder) GetMaxTTL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxTTL", reflect.TypeOf((*MockIPaymentRequestConfiguration)(nil).GetMaxTTL))
+}
@@ -0,0 +1,63 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: quote.go
+
+// Package mock_configuration is a generated GoMock package.
+package mock_configuration
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIQuoteConfiguration is a mock of IQuoteConfiguration interface.
+type MockIQuoteConfiguration struct {
+	ctrl     *gomock.Controller
+	recorder *MockIQuoteConfigurationMockRecorder
+}
+
+// MockIQuoteConfigurationMockRecorder is the mock recorder for MockIQuoteConfiguration.
+type MockIQuoteConfigurationMockRecorder struct {
+	mock *MockIQuoteConfiguration
+}
+
+// NewMockIQuoteConfiguration creates a new mock instance.
+func NewMockIQuoteConfiguration(ctrl *gomock.Controller) *MockIQuoteConfiguration {
+	mock := &MockIQuoteConfiguration{ctrl: ctrl}
+	mock.recorder = &MockIQuoteConfigurationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIQuoteConfiguration) EXPECT() *MockIQuoteConfigurationMockRecorder {
+	return m.recorder
+}
+
+// GetSigningKey mocks base method.
+func (m *MockIQuoteConfiguration) GetSigningKey() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSigningKey")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetSigningKey indicates an expected call of GetSigningKey.
+func (mr *MockIQuoteConfigurationMockRecorder) GetSigningKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSigningKey", reflect.TypeOf((*MockIQuoteConfiguration)(nil).GetSigningKey))
+}
+
+// GetTTL mocks base method.
+func (m *MockIQuoteConfiguration) GetTTL() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTTL")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetTTL indicates an expected call of GetTTL.
+func (mr *MockIQuoteConfigurationMockRecorder) GetTTL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTTL", reflect.TypeOf((*MockIQuoteConfiguration)(nil).GetTTL))
+}
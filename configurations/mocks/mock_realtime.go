@@ -0,0 +1,77 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: realtime.go
+
+// Package mock_configuration is a generated GoMock package.
+package mock_configuration
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIRealtimeConfiguration is a mock of IRealtimeConfiguration interface.
+type MockIRealtimeConfiguration struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRealtimeConfigurationMockRecorder
+}
+
+// MockIRealtimeConfigurationMockRecorder is the mock recorder for MockIRealtimeConfiguration.
+type MockIRealtimeConfigurationMockRecorder struct {
+	mock *MockIRealtimeConfiguration
+}
+
+// NewMockIRealtimeConfiguration creates a new mock instance.
+func NewMockIRealtimeConfiguration(ctrl *gomock.Controller) *MockIRealtimeConfiguration {
+	mock := &MockIRealtimeConfiguration{ctrl: ctrl}
+	mock.recorder = &MockIRealtimeConfigurationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRealtimeConfiguration) EXPECT() *MockIRealtimeConfigurationMockRecorder {
+	return m.recorder
+}
+
+// GetHeartbeatInterval mocks base method.
+func (m *MockIRealtimeConfiguration) GetHeartbeatInterval() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeartbeatInterval")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetHeartbeatInterval indicates an expected call of GetHeartbeatInterval.
+func (mr *MockIRealtimeConfigurationMockRecorder) GetHeartbeatInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeartbeatInterval", reflect.TypeOf((*MockIRealtimeConfiguration)(nil).GetHeartbeatInterval))
+}
+
+// GetMaxConnectionsPerUser mocks base method.
+func (m *MockIRealtimeConfiguration) GetMaxConnectionsPerUser() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxConnectionsPerUser")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetMaxConnectionsPerUser indicates an expected call of GetMaxConnectionsPerUser.
+func (mr *MockIRealtimeConfigurationMockRecorder) GetMaxConnectionsPerUser() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxConnectionsPerUser", reflect.TypeOf((*MockIRealtimeConfiguration)(nil).GetMaxConnectionsPerUser))
+}
+
+// GetReplaySize mocks base method.
+func (m *MockIRealtimeConfiguration) GetReplaySize() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReplaySize")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetReplaySize indicates an expected call of GetReplaySize.
+func (mr *MockIRealtimeConfigurationMockRecorder) GetReplaySize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReplaySize", reflect.TypeOf((*MockIRealtimeConfiguration)(nil).GetReplaySize))
+}
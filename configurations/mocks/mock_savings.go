@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: savings.go
+
+// Package mock_configuration is a generated GoMock package.
+package mock_configuration
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockISavingsConfiguration is a mock of ISavingsConfiguration interface.
+type MockISavingsConfiguration struct {
+	ctrl     *gomock.Controller
+	recorder *MockISavingsConfigurationMockRecorder
+}
+
+// MockISavingsConfigurationMockRecorder is the mock recorder for MockISavingsConfiguration.
+type MockISavingsConfigurationMockRecorder struct {
+	mock *MockISavingsConfiguration
+}
+
+// NewMockISavingsConfiguration creates a new mock instance.
+func NewMockISavingsConfiguration(ctrl *gomock.Controller) *MockISavingsConfiguration {
+	mock := &MockISavingsConfiguration{ctrl: ctrl}
+	mock.recorder = &MockISavingsConfigurationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISavingsConfiguration) EXPECT() *MockISavingsConfigurationMockRecorder {
+	return m.recorder
+}
+
+// GetSweepPollInterval mocks base method.
+func (m *MockISavingsConfiguration) GetSweepPollInterval() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSweepPollInterval")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetSweepPollInterval indicates an expected call of GetSweepPollInterval.
+func (mr *MockISavingsConfigurationMockRecorder) GetSweepPollInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSweepPollInterval", reflect.TypeOf((*MockISavingsConfiguration)(nil).GetSweepPollInterval))
+}
@@ -0,0 +1,77 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: schedule.go
+
+// Package mock_configuration is a generated GoMock package.
+package mock_configuration
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIScheduleConfiguration is a mock of IScheduleConfiguration interface.
+type MockIScheduleConfiguration struct {
+	ctrl     *gomock.Controller
+	recorder *MockIScheduleConfigurationMockRecorder
+}
+
+// MockIScheduleConfigurationMockRecorder is the mock recorder for MockIScheduleConfiguration.
+type MockIScheduleConfigurationMockRecorder struct {
+	mock *MockIScheduleConfiguration
+}
+
+// NewMockIScheduleConfiguration creates a new mock instance.
+func NewMockIScheduleConfiguration(ctrl *gomock.Controller) *MockIScheduleConfiguration {
+	mock := &MockIScheduleConfiguration{ctrl: ctrl}
+	mock.recorder = &MockIScheduleConfigurationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIScheduleConfiguration) EXPECT() *MockIScheduleConfigurationMockRecorder {
+	return m.recorder
+}
+
+// GetMaxAttempts mocks base method.
+func (m *MockIScheduleConfiguration) GetMaxAttempts() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxAttempts")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetMaxAttempts indicates an expected call of GetMaxAttempts.
+func (mr *MockIScheduleConfigurationMockRecorder) GetMaxAttempts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxAttempts", reflect.TypeOf((*MockIScheduleConfiguration)(nil).GetMaxAttempts))
+}
+
+// GetPollInterval mocks base method.
+func (m *MockIScheduleConfiguration) GetPollInterval() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPollInterval")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetPollInterval indicates an expected call of GetPollInterval.
+func (mr *MockIScheduleConfigurationMockRecorder) GetPollInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPollInterval", reflect.TypeOf((*MockIScheduleConfiguration)(nil).GetPollInterval))
+}
+
+// GetRetryInterval mocks base method.
+func (m *MockIScheduleConfiguration) GetRetryInterval() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRetryInterval")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetRetryInterval indicates an expected call of GetRetryInterval.
+func (mr *MockIScheduleConfigurationMockRecorder) GetRetryInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRetryInterval", reflect.TypeOf((*MockIScheduleConfiguration)(nil).GetRetryInterval))
+}
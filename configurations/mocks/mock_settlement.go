@@ -0,0 +1,63 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: settlement.go
+
+// Package mock_configuration is a generated GoMock package.
+package mock_configuration
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockISettlementConfiguration is a mock of ISettlementConfiguration interface.
+type MockISettlementConfiguration struct {
+	ctrl     *gomock.Controller
+	recorder *MockISettlementConfigurationMockRecorder
+}
+
+// MockISettlementConfigurationMockRecorder is the mock recorder for MockISettlementConfiguration.
+type MockISettlementConfigurationMockRecorder struct {
+	mock *MockISettlementConfiguration
+}
+
+// NewMockISettlementConfiguration creates a new mock instance.
+func NewMockISettlementConfiguration(ctrl *gomock.Controller) *MockISettlementConfiguration {
+	mock := &MockISettlementConfiguration{ctrl: ctrl}
+	mock.recorder = &MockISettlementConfigurationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISettlementConfiguration) EXPECT() *MockISettlementConfigurationMockRecorder {
+	return m.recorder
+}
+
+// GetFeePercent mocks base method.
+func (m *MockISettlementConfiguration) GetFeePercent() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeePercent")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetFeePercent indicates an expected call of GetFeePercent.
+func (mr *MockISettlementConfigurationMockRecorder) GetFeePercent() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeePercent", reflect.TypeOf((*MockISettlementConfiguration)(nil).GetFeePercent))
+}
+
+// GetPollInterval mocks base method.
+func (m *MockISettlementConfiguration) GetPollInterval() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPollInterval")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetPollInterval indicates an expected call of GetPollInterval.
+func (mr *MockISettlementConfigurationMockRecorder) GetPollInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPollInterval", reflect.TypeOf((*MockISettlementConfiguration)(nil).GetPollInterval))
+}
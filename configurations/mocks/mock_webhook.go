@@ -0,0 +1,105 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhook.go
+
+// Package mock_configuration is a generated GoMock package.
+package mock_configuration
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIWebhookConfiguration is a mock of IWebhookConfiguration interface.
+type MockIWebhookConfiguration struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWebhookConfigurationMockRecorder
+}
+
+// MockIWebhookConfigurationMockRecorder is the mock recorder for MockIWebhookConfiguration.
+type MockIWebhookConfigurationMockRecorder struct {
+	mock *MockIWebhookConfiguration
+}
+
+// NewMockIWebhookConfiguration creates a new mock instance.
+func NewMockIWebhookConfiguration(ctrl *gomock.Controller) *MockIWebhookConfiguration {
+	mock := &MockIWebhookConfiguration{ctrl: ctrl}
+	mock.recorder = &MockIWebhookConfigurationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWebhookConfiguration) EXPECT() *MockIWebhookConfigurationMockRecorder {
+	return m.recorder
+}
+
+// GetBackoffBase mocks base method.
+func (m *MockIWebhookConfiguration) GetBackoffBase() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBackoffBase")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetBackoffBase indicates an expected call of GetBackoffBase.
+func (mr *MockIWebhookConfigurationMockRecorder) GetBackoffBase() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBackoffBase", reflect.TypeOf((*MockIWebhookConfiguration)(nil).GetBackoffBase))
+}
+
+// GetBackoffMax mocks base method.
+func (m *MockIWebhookConfiguration) GetBackoffMax() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBackoffMax")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetBackoffMax indicates an expected call of GetBackoffMax.
+func (mr *MockIWebhookConfigurationMockRecorder) GetBackoffMax() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBackoffMax", reflect.TypeOf((*MockIWebhookConfiguration)(nil).GetBackoffMax))
+}
+
+// GetMaxAttempts mocks base method.
+func (m *MockIWebhookConfiguration) GetMaxAttempts() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxAttempts")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetMaxAttempts indicates an expected call of GetMaxAttempts.
+func (mr *MockIWebhookConfigurationMockRecorder) GetMaxAttempts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxAttempts", reflect.TypeOf((*MockIWebhookConfiguration)(nil).GetMaxAttempts))
+}
+
+// GetPollInterval mocks base method.
+func (m *MockIWebhookConfiguration) GetPollInterval() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPollInterval")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetPollInterval indicates an expected call of GetPollInterval.
+func (mr *MockIWebhookConfigurationMockRecorder) GetPollInterval() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPollInterval", reflect.TypeOf((*MockIWebhookConfiguration)(nil).GetPollInterval))
+}
+
+// GetTimeout mocks base method.
+func (m *MockIWebhookConfiguration) GetTimeout() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimeout")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetTimeout indicates an expected call of GetTimeout.
+func (mr *MockIWebhookConfigurationMockRecorder) GetTimeout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeout", reflect.TypeOf((*MockIWebhookConfiguration)(nil).GetTimeout))
+}
@@ -0,0 +1,55 @@
+package configurations
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+type paymentRequestConfiguration struct {
+	defaultTTL         string
+	maxTTL             string
+	expiryPollInterval string
+}
+
+//go:generate mockgen -destination=mocks/mock_payment_request.go -source=payment_request.go IPaymentRequestConfiguration
+type IPaymentRequestConfiguration interface {
+	GetDefaultTTL() time.Duration
+	GetMaxTTL() time.Duration
+	GetExpiryPollInterval() time.Duration
+}
+
+func NewPaymentRequestConfiguration() *paymentRequestConfiguration {
+	return &paymentRequestConfiguration{
+		defaultTTL:         os.Getenv("PAYMENT_REQUEST_DEFAULT_TTL_HOUR"),
+		maxTTL:             os.Getenv("PAYMENT_REQUEST_MAX_TTL_HOUR"),
+		expiryPollInterval: os.Getenv("PAYMENT_REQUEST_EXPIRY_POLL_INTERVAL_SECOND"),
+	}
+}
+
+// GetDefaultTTL is how long a request created without expiry stays payable
+func (c *paymentRequestConfiguration) GetDefaultTTL() time.Duration {
+	defaultTTL, err := strconv.Atoi(c.defaultTTL)
+	if err != nil || defaultTTL <= 0 {
+		return 7 * 24 * time.Hour // default 7 days
+	}
+	return time.Duration(defaultTTL) * time.Hour
+}
+
+// GetMaxTTL bounds the expiry a requester can set
+func (c *paymentRequestConfiguration) GetMaxTTL() time.Duration {
+	maxTTL, err := strconv.Atoi(c.maxTTL)
+	if err != nil || maxTTL <= 0 {
+		return 30 * 24 * time.Hour // default 30 days
+	}
+	return time.Duration(maxTTL) * time.Hour
+}
+
+// GetExpiryPollInterval is how often the sweeper expires the requests past their expiry
+func (c *paymentRequestConfiguration) GetExpiryPollInterval() time.Duration {
+	pollInterval, err := strconv.Atoi(c.expiryPollInterval)
+	if err != nil || pollInterval <= 0 {
+		return time.Minute // default 1 minute
+	}
+	return time.Duration(pollInterval) * time.Second
+}
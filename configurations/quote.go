@@ -0,0 +1,44 @@
+package configurations
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+type quoteConfiguration struct {
+	signingKey string
+	jwtSecret  string
+	ttl        string
+}
+
+//go:generate mockgen -destination=mocks/mock_quote.go -source=quote.go IQuoteConfiguration
+type IQuoteConfiguration interface {
+	GetSigningKey() string
+	GetTTL() time.Duration
+}
+
+func NewQuoteConfiguration() *quoteConfiguration {
+	return &quoteConfiguration{
+		signingKey: os.Getenv("QUOTE_SIGNING_KEY"),
+		jwtSecret:  os.Getenv("JWT_SECRET"),
+		ttl:        os.Getenv("QUOTE_TTL_SECOND"),
+	}
+}
+
+// GetSigningKey is the HMAC key of the quote ids, the JWT secret when unset
+func (c *quoteConfiguration) GetSigningKey() string {
+	if c.signingKey == "" {
+		return c.jwtSecret
+	}
+	return c.signingKey
+}
+
+// GetTTL is how long a quote id can be executed with its quoted terms
+func (c *quoteConfiguration) GetTTL() time.Duration {
+	ttl, err := strconv.Atoi(c.ttl)
+	if err != nil || ttl <= 0 {
+		return 30 * time.Second // default 30 seconds
+	}
+	return time.Duration(ttl) * time.Second
+}
@@ -0,0 +1,55 @@
+package configurations
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+type realtimeConfiguration struct {
+	maxConnectionsPerUser string
+	heartbeatInterval     string
+	replaySize            string
+}
+
+//go:generate mockgen -destination=mocks/mock_realtime.go -source=realtime.go IRealtimeConfiguration
+type IRealtimeConfiguration interface {
+	GetMaxConnectionsPerUser() int
+	GetHeartbeatInterval() time.Duration
+	GetReplaySize() int
+}
+
+func NewRealtimeConfiguration() *realtimeConfiguration {
+	return &realtimeConfiguration{
+		maxConnectionsPerUser: os.Getenv("REALTIME_MAX_CONNECTIONS_PER_USER"),
+		heartbeatInterval:     os.Getenv("REALTIME_HEARTBEAT_SECOND"),
+		replaySize:            os.Getenv("REALTIME_REPLAY_SIZE"),
+	}
+}
+
+// GetMaxConnectionsPerUser limits the open event streams of one user on one instance
+func (c *realtimeConfiguration) GetMaxConnectionsPerUser() int {
+	maxConnections, err := strconv.Atoi(c.maxConnectionsPerUser)
+	if err != nil || maxConnections <= 0 {
+		return 5 // default 5 connections, a few devices and tabs
+	}
+	return maxConnections
+}
+
+// GetHeartbeatInterval keeps idle streams alive through proxies that cut silent connections
+func (c *realtimeConfiguration) GetHeartbeatInterval() time.Duration {
+	heartbeat, err := strconv.Atoi(c.heartbeatInterval)
+	if err != nil || heartbeat <= 0 {
+		return 15 * time.Second // default 15 seconds
+	}
+	return time.Duration(heartbeat) * time.Second
+}
+
+// GetReplaySize is how many recent events per user are kept to resume from Last-Event-ID
+func (c *realtimeConfiguration) GetReplaySize() int {
+	replaySize, err := strconv.Atoi(c.replaySize)
+	if err != nil || replaySize <= 0 {
+		return 100 // default 100 events
+	}
+	return replaySize
+}
@@ -0,0 +1,32 @@
+package configurations
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+type savingsConfiguration struct {
+	sweepPollInterval string
+}
+
+//go:generate mockgen -destination=mocks/mock_savings.go -source=savings.go ISavingsConfiguration
+type ISavingsConfiguration interface {
+	GetSweepPollInterval() time.Duration
+}
+
+func NewSavingsConfiguration() *savingsConfiguration {
+	return &savingsConfiguration{
+		sweepPollInterval: os.Getenv("SAVINGS_SWEEP_POLL_INTERVAL_MINUTE"),
+	}
+}
+
+// GetSweepPollInterval is how often the job looks for due sweeps, a sweep runs at
+// most this late
+func (c *savingsConfiguration) GetSweepPollInterval() time.Duration {
+	pollInterval, err := strconv.Atoi(c.sweepPollInterval)
+	if err != nil || pollInterval <= 0 {
+		return 15 * time.Minute // default 15 minutes
+	}
+	return time.Duration(pollInterval) * time.Minute
+}
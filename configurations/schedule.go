@@ -0,0 +1,55 @@
+package configurations
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+type scheduleConfiguration struct {
+	pollInterval  string
+	retryInterval string
+	maxAttempts   string
+}
+
+//go:generate mockgen -destination=mocks/mock_schedule.go -source=schedule.go IScheduleConfiguration
+type IScheduleConfiguration interface {
+	GetPollInterval() time.Duration
+	GetRetryInterval() time.Duration
+	GetMaxAttempts() int
+}
+
+func NewScheduleConfiguration() *scheduleConfiguration {
+	return &scheduleConfiguration{
+		pollInterval:  os.Getenv("SCHEDULED_TRANSFER_POLL_INTERVAL_SECOND"),
+		retryInterval: os.Getenv("SCHEDULED_TRANSFER_RETRY_INTERVAL_MINUTE"),
+		maxAttempts:   os.Getenv("SCHEDULED_TRANSFER_MAX_ATTEMPTS"),
+	}
+}
+
+// GetPollInterval is how often the worker looks for due scheduled transfers
+func (c *scheduleConfiguration) GetPollInterval() time.Duration {
+	pollInterval, err := strconv.Atoi(c.pollInterval)
+	if err != nil || pollInterval <= 0 {
+		return 30 * time.Second // default 30 seconds
+	}
+	return time.Duration(pollInterval) * time.Second
+}
+
+// GetRetryInterval is the wait before an occurrence that failed is tried again
+func (c *scheduleConfiguration) GetRetryInterval() time.Duration {
+	retryInterval, err := strconv.Atoi(c.retryInterval)
+	if err != nil || retryInterval <= 0 {
+		return time.Hour // default 1 hour
+	}
+	return time.Duration(retryInterval) * time.Minute
+}
+
+// GetMaxAttempts is how many times an occurrence is tried before it is skipped
+func (c *scheduleConfiguration) GetMaxAttempts() int {
+	maxAttempts, err := strconv.Atoi(c.maxAttempts)
+	if err != nil || maxAttempts <= 0 {
+		return 4 // default 4 attempts, spread over 3 hours with the default retry interval
+	}
+	return maxAttempts
+}
@@ -0,0 +1,44 @@
+package configurations
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+type settlementConfiguration struct {
+	feePercent   string
+	pollInterval string
+}
+
+//go:generate mockgen -destination=mocks/mock_settlement.go -source=settlement.go ISettlementConfiguration
+type ISettlementConfiguration interface {
+	GetFeePercent() float64
+	GetPollInterval() time.Duration
+}
+
+func NewSettlementConfiguration() *settlementConfiguration {
+	return &settlementConfiguration{
+		feePercent:   os.Getenv("SETTLEMENT_FEE_PERCENT"),
+		pollInterval: os.Getenv("SETTLEMENT_POLL_INTERVAL_MINUTE"),
+	}
+}
+
+// GetFeePercent is the share of the gross amount kept on each settlement
+func (c *settlementConfiguration) GetFeePercent() float64 {
+	feePercent, err := strconv.ParseFloat(c.feePercent, 64)
+	if err != nil || feePercent < 0 || feePercent > 100 {
+		return 0.7 // default 0.7 percent
+	}
+	return feePercent
+}
+
+// GetPollInterval is how often the job checks for a day left to settle, a day
+// is settled once so a short interval only catches up sooner after a downtime
+func (c *settlementConfiguration) GetPollInterval() time.Duration {
+	pollInterval, err := strconv.Atoi(c.pollInterval)
+	if err != nil || pollInterval <= 0 {
+		return time.Hour // default 60 minutes
+	}
+	return time.Duration(pollInterval) * time.Minute
+}
@@ -0,0 +1,79 @@
+package configurations
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+type webhookConfiguration struct {
+	maxAttempts  string
+	backoffBase  string
+	backoffMax   string
+	timeout      string
+	pollInterval string
+}
+
+//go:generate mockgen -destination=mocks/mock_webhook.go -source=webhook.go IWebhookConfiguration
+type IWebhookConfiguration interface {
+	GetMaxAttempts() int
+	GetBackoffBase() time.Duration
+	GetBackoffMax() time.Duration
+	GetTimeout() time.Duration
+	GetPollInterval() time.Duration
+}
+
+func NewWebhookConfiguration() *webhookConfiguration {
+	return &webhookConfiguration{
+		maxAttempts:  os.Getenv("WEBHOOK_MAX_ATTEMPTS"),
+		backoffBase:  os.Getenv("WEBHOOK_BACKOFF_BASE_SECOND"),
+		backoffMax:   os.Getenv("WEBHOOK_BACKOFF_MAX_SECOND"),
+		timeout:      os.Getenv("WEBHOOK_TIMEOUT_SECOND"),
+		pollInterval: os.Getenv("WEBHOOK_POLL_INTERVAL_SECOND"),
+	}
+}
+
+// GetMaxAttempts is how many times a delivery is tried before it is dead-lettered
+func (c *webhookConfiguration) GetMaxAttempts() int {
+	maxAttempts, err := strconv.Atoi(c.maxAttempts)
+	if err != nil || maxAttempts <= 0 {
+		return 8 // default 8 attempts, spread over about an hour with the default backoff
+	}
+	return maxAttempts
+}
+
+// GetBackoffBase is the wait after the first failed attempt, doubled after each one
+func (c *webhookConfiguration) GetBackoffBase() time.Duration {
+	backoffBase, err := strconv.Atoi(c.backoffBase)
+	if err != nil || backoffBase <= 0 {
+		return 30 * time.Second // default 30 seconds
+	}
+	return time.Duration(backoffBase) * time.Second
+}
+
+// GetBackoffMax caps the wait between two attempts
+func (c *webhookConfiguration) GetBackoffMax() time.Duration {
+	backoffMax, err := strconv.Atoi(c.backoffMax)
+	if err != nil || backoffMax <= 0 {
+		return time.Hour // default 1 hour
+	}
+	return time.Duration(backoffMax) * time.Second
+}
+
+// GetTimeout bounds one delivery request, the partner should acknowledge and process later
+func (c *webhookConfiguration) GetTimeout() time.Duration {
+	timeout, err := strconv.Atoi(c.timeout)
+	if err != nil || timeout <= 0 {
+		return 10 * time.Second // default 10 seconds
+	}
+	return time.Duration(timeout) * time.Second
+}
+
+// GetPollInterval is how often the worker looks for due deliveries
+func (c *webhookConfiguration) GetPollInterval() time.Duration {
+	pollInterval, err := strconv.Atoi(c.pollInterval)
+	if err != nil || pollInterval <= 0 {
+		return 5 * time.Second // default 5 seconds
+	}
+	return time.Duration(pollInterval) * time.Second
+}
@@ -1,7 +1,23 @@
 package constants
 
 const (
-	ApiV1BasePath   = "/api"
-	UserPath        = "/users"
-	TransactionPath = "/transactions"
+	ApiV1BasePath      = "/api"
+	UserPath           = "/users"
+	TransactionPath    = "/transactions"
+	EventPath          = "/events"
+	WebhookPath        = "/webhooks"
+	PaymentPath        = "/payments"
+	SettlementPath     = "/settlements"
+	WalletPath         = "/wallets"
+	ExchangePath       = "/exchanges"
+	PocketPath         = "/pockets"
+	SavingsPath        = "/savings-goals"
+	TransferPath       = "/transfers"
+	SchedulePath       = "/scheduled-transfers"
+	PaymentRequestPath = "/payment-requests"
+	BillPath           = "/bills"
+	// MerchantPath serves the merchants' signed requests, AdminMerchantPath manages them
+	MerchantPath      = "/merchant"
+	AdminMerchantPath = "/admin/merchants"
+	AdminFeePath      = "/admin/fees"
 )
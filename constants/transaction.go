@@ -1,6 +1,45 @@
 package constants
 
 const (
-	TransactionTypeCredit = "credit"
-	TransactionTypeDebit  = "debit"
+	TransactionTypeCredit     = "credit"
+	TransactionTypeDebit      = "debit"
+	TransactionTypePayment    = "payment"
+	TransactionTypeSettlement = "settlement"
+	// TransactionTypeFee charges the user, TransactionTypeFeeRevenue credits the revenue account
+	TransactionTypeFee        = "fee"
+	TransactionTypeFeeRevenue = "fee_revenue"
+	// TransactionTypeExchangeOut debits the sold currency, TransactionTypeExchangeIn credits the bought one
+	TransactionTypeExchangeOut = "exchange_out"
+	TransactionTypeExchangeIn  = "exchange_in"
+	// TransactionTypeTransferOut debits the sender of a transfer, TransactionTypeTransferIn credits the recipient
+	TransactionTypeTransferOut = "transfer_out"
+	TransactionTypeTransferIn  = "transfer_in"
+	// TransactionTypeTransfer is the fee rule and quote type of a transfer
+	TransactionTypeTransfer = "transfer"
+)
+
+// Channels a transaction comes in through, fee rules can target one
+const (
+	ChannelApp  = "app"
+	ChannelGRPC = "grpc"
+)
+
+// DefaultCurrency is the wallet opened on registration, merchant payments are taken in it too
+const DefaultCurrency = "IDR"
+
+// Entries of a pocket history, a credit is received by the default pocket of the wallet,
+// round ups and sweeps are saved by the rules of a savings goal
+const (
+	PocketEntryCredit  = "credit"
+	PocketEntryMoveIn  = "move_in"
+	PocketEntryMoveOut = "move_out"
+	PocketEntryRoundUp = "round_up"
+	PocketEntrySweep   = "sweep"
+)
+
+// Frequencies of the sweeps of a savings goal
+const (
+	SweepDaily   = "daily"
+	SweepWeekly  = "weekly"
+	SweepMonthly = "monthly"
 )
@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"context"
+	goerrors "errors"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+type nonceCache struct {
+	pool   *redis.Pool
+	tracer trace.Tracer
+}
+
+// NewNonceCache keeps each claimed nonce as a redis key expiring after its ttl
+func NewNonceCache(pool *redis.Pool, tracer trace.Tracer) *nonceCache {
+	if tracer == nil {
+		tracer = noop.NewTracerProvider().Tracer("")
+	}
+
+	return &nonceCache{
+		pool:   pool,
+		tracer: tracer,
+	}
+}
+
+// Claim sets the key only if it is missing, in one command so two
+// concurrent requests with the same nonce can't both succeed
+func (c *nonceCache) Claim(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	_, span := c.tracer.Start(ctx, "redis.SET",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemNameRedis,
+			semconv.DBOperationName("SET"),
+			semconv.DBQueryText("SET "+key+" NX"),
+		),
+	)
+	defer span.End()
+
+	conn, err := c.pool.GetContext(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return false, err
+	}
+	defer conn.Close()
+
+	_, err = redis.String(conn.Do("SET", key, 1, "NX", "PX", ttl.Milliseconds()))
+	if goerrors.Is(err, redis.ErrNil) {
+		return false, nil
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return false, err
+	}
+
+	return true, nil
+}
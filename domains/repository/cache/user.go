@@ -0,0 +1,152 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"kc-ewallet/domains/repository/postgres"
+	redis_service "kc-ewallet/internals/helpers/redis/service"
+	"time"
+
+	goerrors "errors"
+
+	"github.com/gomodule/redigo/redis"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const (
+	userKeyPrefix     = "user-profile:%d"
+	balancesKeyPrefix = "user-balances:%d"
+
+	// keep it short, a fill that races with an invalidation
+	// can only serve a stale balance until the key expires
+	DefaultUserTTL = 60 * time.Second
+)
+
+// balances are the wallets and pockets of a user, cached together so they always agree
+type balances struct {
+	Wallets []postgres.Wallet `json:"wallets"`
+	Pockets []postgres.Pocket `json:"pockets"`
+}
+
+type userCache struct {
+	redis  redis_service.RedisServiceInterface
+	ttl    time.Duration
+	tracer trace.Tracer
+}
+
+func NewUserCache(redis redis_service.RedisServiceInterface, ttl time.Duration, tracer trace.Tracer) *userCache {
+	if ttl <= 0 {
+		ttl = DefaultUserTTL
+	}
+	if tracer == nil {
+		tracer = noop.NewTracerProvider().Tracer("")
+	}
+
+	return &userCache{
+		redis:  redis,
+		ttl:    ttl,
+		tracer: tracer,
+	}
+}
+
+// GetUser returns the cached user, any error (including a miss) means
+// the caller should fall back to the database
+func (c *userCache) GetUser(ctx context.Context, id int32) (*postgres.User, error) {
+	_, span := c.startSpan(ctx, "GET", userKey(id))
+	defer span.End()
+
+	var user postgres.User
+	if err := c.redis.Get(userKey(id), &user); err != nil {
+		// a miss is expected, only flag real failures
+		if !goerrors.Is(err, redis.ErrNil) {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (c *userCache) SetUser(ctx context.Context, user postgres.User) error {
+	_, span := c.startSpan(ctx, "SET", userKey(user.ID))
+	defer span.End()
+
+	user.Password = ""
+	err := c.redis.SetWithExpiry(userKey(user.ID), user, int(c.ttl.Seconds()))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// GetBalances returns the cached wallets and pockets of the user, any error (including a
+// miss) means the caller should fall back to the database
+func (c *userCache) GetBalances(ctx context.Context, userID int32) ([]postgres.Wallet, []postgres.Pocket, error) {
+	_, span := c.startSpan(ctx, "GET", balancesKey(userID))
+	defer span.End()
+
+	var cached balances
+	if err := c.redis.Get(balancesKey(userID), &cached); err != nil {
+		if !goerrors.Is(err, redis.ErrNil) {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return nil, nil, err
+	}
+
+	return cached.Wallets, cached.Pockets, nil
+}
+
+func (c *userCache) SetBalances(ctx context.Context, userID int32, wallets []postgres.Wallet, pockets []postgres.Pocket) error {
+	_, span := c.startSpan(ctx, "SET", balancesKey(userID))
+	defer span.End()
+
+	err := c.redis.SetWithExpiry(balancesKey(userID), balances{Wallets: wallets, Pockets: pockets}, int(c.ttl.Seconds()))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// DeleteBalances drops the cached balances of the users, the first error is returned
+// once every key was tried
+func (c *userCache) DeleteBalances(ctx context.Context, userIDs ...int32) error {
+	var firstErr error
+	for _, userID := range userIDs {
+		_, span := c.startSpan(ctx, "DEL", balancesKey(userID))
+		if _, err := c.redis.Delete(balancesKey(userID)); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+		span.End()
+	}
+	return firstErr
+}
+
+func (c *userCache) startSpan(ctx context.Context, command, key string) (context.Context, trace.Span) {
+	return c.tracer.Start(ctx, "redis."+command,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemNameRedis,
+			semconv.DBOperationName(command),
+			semconv.DBQueryText(command+" "+key),
+		),
+	)
+}
+
+func userKey(id int32) string {
+	return fmt.Sprintf(userKeyPrefix, id)
+}
+
+func balancesKey(userID int32) string {
+	return fmt.Sprintf(balancesKeyPrefix, userID)
+}
@@ -0,0 +1,32 @@
+package fx
+
+import (
+	"context"
+	goerrors "errors"
+)
+
+// ErrRateNotFound is returned for a currency pair without a rate either way
+var ErrRateNotFound = goerrors.New("exchange rate not found")
+
+type staticRateSource struct {
+	rates map[string]float64
+}
+
+// NewStaticRateSource serves the configured rates keyed "FROM/TO", a pair only
+// configured the other way round is served its inverse
+func NewStaticRateSource(rates map[string]float64) *staticRateSource {
+	return &staticRateSource{
+		rates: rates,
+	}
+}
+
+// GetRate returns the units of to bought by one unit of from
+func (s *staticRateSource) GetRate(ctx context.Context, from, to string) (float64, error) {
+	if rate, ok := s.rates[from+"/"+to]; ok && rate > 0 {
+		return rate, nil
+	}
+	if rate, ok := s.rates[to+"/"+from]; ok && rate > 0 {
+		return 1 / rate, nil
+	}
+	return 0, ErrRateNotFound
+}
@@ -0,0 +1,56 @@
+package fx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaticRateSource_GetRate(t *testing.T) {
+	source := NewStaticRateSource(map[string]float64{
+		"USD/IDR": 16000,
+		"EUR/USD": 0,
+	})
+
+	testCases := []struct {
+		name         string
+		from         string
+		to           string
+		expectedRate float64
+		expectedErr  error
+	}{
+		{
+			name:         "should return the configured rate",
+			from:         "USD",
+			to:           "IDR",
+			expectedRate: 16000,
+		},
+		{
+			name:         "should return the inverse of the reverse pair",
+			from:         "IDR",
+			to:           "USD",
+			expectedRate: 1.0 / 16000,
+		},
+		{
+			name:        "should not serve a zero rate",
+			from:        "EUR",
+			to:          "USD",
+			expectedErr: ErrRateNotFound,
+		},
+		{
+			name:        "should return an error for an unknown pair",
+			from:        "JPY",
+			to:          "IDR",
+			expectedErr: ErrRateNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rate, err := source.GetRate(context.Background(), tc.from, tc.to)
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedRate, rate)
+		})
+	}
+}
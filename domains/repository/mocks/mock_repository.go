@@ -8,7 +8,9 @@ import (
 	context "context"
 	sql "database/sql"
 	postgres "kc-ewallet/domains/repository/postgres"
+	stream "kc-ewallet/domains/repository/stream"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -36,78 +38,1491 @@ func (m *MockIRepository) EXPECT() *MockIRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CancelPaymentRequest mocks base method.
+func (m *MockIRepository) CancelPaymentRequest(ctx context.Context, arg postgres.CancelPaymentRequestParams) (postgres.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelPaymentRequest", ctx, arg)
+	ret0, _ := ret[0].(postgres.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelPaymentRequest indicates an expected call of CancelPaymentRequest.
+func (mr *MockIRepositoryMockRecorder) CancelPaymentRequest(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelPaymentRequest", reflect.TypeOf((*MockIRepository)(nil).CancelPaymentRequest), ctx, arg)
+}
+
+// CancelScheduledTransfer mocks base method.
+func (m *MockIRepository) CancelScheduledTransfer(ctx context.Context, arg postgres.CancelScheduledTransferParams) (postgres.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelScheduledTransfer", ctx, arg)
+	ret0, _ := ret[0].(postgres.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelScheduledTransfer indicates an expected call of CancelScheduledTransfer.
+func (mr *MockIRepositoryMockRecorder) CancelScheduledTransfer(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelScheduledTransfer", reflect.TypeOf((*MockIRepository)(nil).CancelScheduledTransfer), ctx, arg)
+}
+
+// ClaimScheduledTransfers mocks base method.
+func (m *MockIRepository) ClaimScheduledTransfers(ctx context.Context, arg postgres.ClaimScheduledTransfersParams) ([]postgres.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimScheduledTransfers", ctx, arg)
+	ret0, _ := ret[0].([]postgres.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimScheduledTransfers indicates an expected call of ClaimScheduledTransfers.
+func (mr *MockIRepositoryMockRecorder) ClaimScheduledTransfers(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimScheduledTransfers", reflect.TypeOf((*MockIRepository)(nil).ClaimScheduledTransfers), ctx, arg)
+}
+
+// ClaimWebhookDeliveries mocks base method.
+func (m *MockIRepository) ClaimWebhookDeliveries(ctx context.Context, arg postgres.ClaimWebhookDeliveriesParams) ([]postgres.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimWebhookDeliveries", ctx, arg)
+	ret0, _ := ret[0].([]postgres.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimWebhookDeliveries indicates an expected call of ClaimWebhookDeliveries.
+func (mr *MockIRepositoryMockRecorder) ClaimWebhookDeliveries(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimWebhookDeliveries", reflect.TypeOf((*MockIRepository)(nil).ClaimWebhookDeliveries), ctx, arg)
+}
+
+// ClearDefaultPocket mocks base method.
+func (m *MockIRepository) ClearDefaultPocket(ctx context.Context, arg postgres.ClearDefaultPocketParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearDefaultPocket", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearDefaultPocket indicates an expected call of ClearDefaultPocket.
+func (mr *MockIRepositoryMockRecorder) ClearDefaultPocket(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearDefaultPocket", reflect.TypeOf((*MockIRepository)(nil).ClearDefaultPocket), ctx, arg)
+}
+
+// CountBillSharesByUserID mocks base method.
+func (m *MockIRepository) CountBillSharesByUserID(ctx context.Context, arg postgres.CountBillSharesByUserIDParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBillSharesByUserID", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountBillSharesByUserID indicates an expected call of CountBillSharesByUserID.
+func (mr *MockIRepositoryMockRecorder) CountBillSharesByUserID(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBillSharesByUserID", reflect.TypeOf((*MockIRepository)(nil).CountBillSharesByUserID), ctx, arg)
+}
+
+// CountBillsByCreatorID mocks base method.
+func (m *MockIRepository) CountBillsByCreatorID(ctx context.Context, creatorID int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBillsByCreatorID", ctx, creatorID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountBillsByCreatorID indicates an expected call of CountBillsByCreatorID.
+func (mr *MockIRepositoryMockRecorder) CountBillsByCreatorID(ctx, creatorID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBillsByCreatorID", reflect.TypeOf((*MockIRepository)(nil).CountBillsByCreatorID), ctx, creatorID)
+}
+
+// CountPaymentRequests mocks base method.
+func (m *MockIRepository) CountPaymentRequests(ctx context.Context, arg postgres.CountPaymentRequestsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPaymentRequests", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPaymentRequests indicates an expected call of CountPaymentRequests.
+func (mr *MockIRepositoryMockRecorder) CountPaymentRequests(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPaymentRequests", reflect.TypeOf((*MockIRepository)(nil).CountPaymentRequests), ctx, arg)
+}
+
+// CountPocketEntries mocks base method.
+func (m *MockIRepository) CountPocketEntries(ctx context.Context, pocketID int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPocketEntries", ctx, pocketID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPocketEntries indicates an expected call of CountPocketEntries.
+func (mr *MockIRepositoryMockRecorder) CountPocketEntries(ctx, pocketID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPocketEntries", reflect.TypeOf((*MockIRepository)(nil).CountPocketEntries), ctx, pocketID)
+}
+
+// CountScheduledTransferRuns mocks base method.
+func (m *MockIRepository) CountScheduledTransferRuns(ctx context.Context, scheduledTransferID int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountScheduledTransferRuns", ctx, scheduledTransferID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountScheduledTransferRuns indicates an expected call of CountScheduledTransferRuns.
+func (mr *MockIRepositoryMockRecorder) CountScheduledTransferRuns(ctx, scheduledTransferID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountScheduledTransferRuns", reflect.TypeOf((*MockIRepository)(nil).CountScheduledTransferRuns), ctx, scheduledTransferID)
+}
+
+// CountSettlementsByMerchantID mocks base method.
+func (m *MockIRepository) CountSettlementsByMerchantID(ctx context.Context, merchantID int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSettlementsByMerchantID", ctx, merchantID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSettlementsByMerchantID indicates an expected call of CountSettlementsByMerchantID.
+func (mr *MockIRepositoryMockRecorder) CountSettlementsByMerchantID(ctx, merchantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSettlementsByMerchantID", reflect.TypeOf((*MockIRepository)(nil).CountSettlementsByMerchantID), ctx, merchantID)
+}
+
+// CountWebhookDeliveries mocks base method.
+func (m *MockIRepository) CountWebhookDeliveries(ctx context.Context, arg postgres.CountWebhookDeliveriesParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountWebhookDeliveries", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountWebhookDeliveries indicates an expected call of CountWebhookDeliveries.
+func (mr *MockIRepositoryMockRecorder) CountWebhookDeliveries(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountWebhookDeliveries", reflect.TypeOf((*MockIRepository)(nil).CountWebhookDeliveries), ctx, arg)
+}
+
+// CreateAuditEvent mocks base method.
+func (m *MockIRepository) CreateAuditEvent(ctx context.Context, arg postgres.CreateAuditEventParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuditEvent", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAuditEvent indicates an expected call of CreateAuditEvent.
+func (mr *MockIRepositoryMockRecorder) CreateAuditEvent(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuditEvent", reflect.TypeOf((*MockIRepository)(nil).CreateAuditEvent), ctx, arg)
+}
+
+// CreateBill mocks base method.
+func (m *MockIRepository) CreateBill(ctx context.Context, arg postgres.CreateBillParams) (postgres.Bill, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBill", ctx, arg)
+	ret0, _ := ret[0].(postgres.Bill)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBill indicates an expected call of CreateBill.
+func (mr *MockIRepositoryMockRecorder) CreateBill(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBill", reflect.TypeOf((*MockIRepository)(nil).CreateBill), ctx, arg)
+}
+
+// CreateBillShare mocks base method.
+func (m *MockIRepository) CreateBillShare(ctx context.Context, arg postgres.CreateBillShareParams) (postgres.BillShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBillShare", ctx, arg)
+	ret0, _ := ret[0].(postgres.BillShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBillShare indicates an expected call of CreateBillShare.
+func (mr *MockIRepositoryMockRecorder) CreateBillShare(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBillShare", reflect.TypeOf((*MockIRepository)(nil).CreateBillShare), ctx, arg)
+}
+
+// CreateExchange mocks base method.
+func (m *MockIRepository) CreateExchange(ctx context.Context, arg postgres.CreateExchangeParams) (postgres.Exchange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExchange", ctx, arg)
+	ret0, _ := ret[0].(postgres.Exchange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExchange indicates an expected call of CreateExchange.
+func (mr *MockIRepositoryMockRecorder) CreateExchange(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExchange", reflect.TypeOf((*MockIRepository)(nil).CreateExchange), ctx, arg)
+}
+
+// CreateFeeRule mocks base method.
+func (m *MockIRepository) CreateFeeRule(ctx context.Context, arg postgres.CreateFeeRuleParams) (postgres.FeeRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFeeRule", ctx, arg)
+	ret0, _ := ret[0].(postgres.FeeRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFeeRule indicates an expected call of CreateFeeRule.
+func (mr *MockIRepositoryMockRecorder) CreateFeeRule(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFeeRule", reflect.TypeOf((*MockIRepository)(nil).CreateFeeRule), ctx, arg)
+}
+
+// CreateMerchant mocks base method.
+func (m *MockIRepository) CreateMerchant(ctx context.Context, arg postgres.CreateMerchantParams) (postgres.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMerchant", ctx, arg)
+	ret0, _ := ret[0].(postgres.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMerchant indicates an expected call of CreateMerchant.
+func (mr *MockIRepositoryMockRecorder) CreateMerchant(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMerchant", reflect.TypeOf((*MockIRepository)(nil).CreateMerchant), ctx, arg)
+}
+
+// CreateMerchantApiKey mocks base method.
+func (m *MockIRepository) CreateMerchantApiKey(ctx context.Context, arg postgres.CreateMerchantApiKeyParams) (postgres.MerchantApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMerchantApiKey", ctx, arg)
+	ret0, _ := ret[0].(postgres.MerchantApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMerchantApiKey indicates an expected call of CreateMerchantApiKey.
+func (mr *MockIRepositoryMockRecorder) CreateMerchantApiKey(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMerchantApiKey", reflect.TypeOf((*MockIRepository)(nil).CreateMerchantApiKey), ctx, arg)
+}
+
+// CreatePayment mocks base method.
+func (m *MockIRepository) CreatePayment(ctx context.Context, arg postgres.CreatePaymentParams) (postgres.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePayment", ctx, arg)
+	ret0, _ := ret[0].(postgres.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePayment indicates an expected call of CreatePayment.
+func (mr *MockIRepositoryMockRecorder) CreatePayment(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayment", reflect.TypeOf((*MockIRepository)(nil).CreatePayment), ctx, arg)
+}
+
+// CreatePaymentRequest mocks base method.
+func (m *MockIRepository) CreatePaymentRequest(ctx context.Context, arg postgres.CreatePaymentRequestParams) (postgres.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePaymentRequest", ctx, arg)
+	ret0, _ := ret[0].(postgres.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePaymentRequest indicates an expected call of CreatePaymentRequest.
+func (mr *MockIRepositoryMockRecorder) CreatePaymentRequest(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentRequest", reflect.TypeOf((*MockIRepository)(nil).CreatePaymentRequest), ctx, arg)
+}
+
+// CreatePocket mocks base method.
+func (m *MockIRepository) CreatePocket(ctx context.Context, arg postgres.CreatePocketParams) (postgres.Pocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePocket", ctx, arg)
+	ret0, _ := ret[0].(postgres.Pocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePocket indicates an expected call of CreatePocket.
+func (mr *MockIRepositoryMockRecorder) CreatePocket(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePocket", reflect.TypeOf((*MockIRepository)(nil).CreatePocket), ctx, arg)
+}
+
+// CreatePocketEntry mocks base method.
+func (m *MockIRepository) CreatePocketEntry(ctx context.Context, arg postgres.CreatePocketEntryParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePocketEntry", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePocketEntry indicates an expected call of CreatePocketEntry.
+func (mr *MockIRepositoryMockRecorder) CreatePocketEntry(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePocketEntry", reflect.TypeOf((*MockIRepository)(nil).CreatePocketEntry), ctx, arg)
+}
+
+// CreateQuoteRedemption mocks base method.
+func (m *MockIRepository) CreateQuoteRedemption(ctx context.Context, arg postgres.CreateQuoteRedemptionParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateQuoteRedemption", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateQuoteRedemption indicates an expected call of CreateQuoteRedemption.
+func (mr *MockIRepositoryMockRecorder) CreateQuoteRedemption(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQuoteRedemption", reflect.TypeOf((*MockIRepository)(nil).CreateQuoteRedemption), ctx, arg)
+}
+
+// CreateSavingsGoal mocks base method.
+func (m *MockIRepository) CreateSavingsGoal(ctx context.Context, arg postgres.CreateSavingsGoalParams) (postgres.SavingsGoal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSavingsGoal", ctx, arg)
+	ret0, _ := ret[0].(postgres.SavingsGoal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSavingsGoal indicates an expected call of CreateSavingsGoal.
+func (mr *MockIRepositoryMockRecorder) CreateSavingsGoal(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSavingsGoal", reflect.TypeOf((*MockIRepository)(nil).CreateSavingsGoal), ctx, arg)
+}
+
+// CreateScheduledTransfer mocks base method.
+func (m *MockIRepository) CreateScheduledTransfer(ctx context.Context, arg postgres.CreateScheduledTransferParams) (postgres.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScheduledTransfer", ctx, arg)
+	ret0, _ := ret[0].(postgres.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateScheduledTransfer indicates an expected call of CreateScheduledTransfer.
+func (mr *MockIRepositoryMockRecorder) CreateScheduledTransfer(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScheduledTransfer", reflect.TypeOf((*MockIRepository)(nil).CreateScheduledTransfer), ctx, arg)
+}
+
+// CreateScheduledTransferRun mocks base method.
+func (m *MockIRepository) CreateScheduledTransferRun(ctx context.Context, arg postgres.CreateScheduledTransferRunParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScheduledTransferRun", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateScheduledTransferRun indicates an expected call of CreateScheduledTransferRun.
+func (mr *MockIRepositoryMockRecorder) CreateScheduledTransferRun(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScheduledTransferRun", reflect.TypeOf((*MockIRepository)(nil).CreateScheduledTransferRun), ctx, arg)
+}
+
+// CreateSettlement mocks base method.
+func (m *MockIRepository) CreateSettlement(ctx context.Context, arg postgres.CreateSettlementParams) (postgres.Settlement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSettlement", ctx, arg)
+	ret0, _ := ret[0].(postgres.Settlement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSettlement indicates an expected call of CreateSettlement.
+func (mr *MockIRepositoryMockRecorder) CreateSettlement(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSettlement", reflect.TypeOf((*MockIRepository)(nil).CreateSettlement), ctx, arg)
+}
+
 // CreateTransaction mocks base method.
 func (m *MockIRepository) CreateTransaction(ctx context.Context, arg postgres.CreateTransactionParams) (int32, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTransaction", ctx, arg)
-	ret0, _ := ret[0].(int32)
+	ret := m.ctrl.Call(m, "CreateTransaction", ctx, arg)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransaction indicates an expected call of CreateTransaction.
+func (mr *MockIRepositoryMockRecorder) CreateTransaction(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransaction", reflect.TypeOf((*MockIRepository)(nil).CreateTransaction), ctx, arg)
+}
+
+// CreateTransactionFee mocks base method.
+func (m *MockIRepository) CreateTransactionFee(ctx context.Context, arg postgres.CreateTransactionFeeParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransactionFee", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTransactionFee indicates an expected call of CreateTransactionFee.
+func (mr *MockIRepositoryMockRecorder) CreateTransactionFee(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransactionFee", reflect.TypeOf((*MockIRepository)(nil).CreateTransactionFee), ctx, arg)
+}
+
+// CreateTransfer mocks base method.
+func (m *MockIRepository) CreateTransfer(ctx context.Context, arg postgres.CreateTransferParams) (postgres.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransfer", ctx, arg)
+	ret0, _ := ret[0].(postgres.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransfer indicates an expected call of CreateTransfer.
+func (mr *MockIRepositoryMockRecorder) CreateTransfer(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockIRepository)(nil).CreateTransfer), ctx, arg)
+}
+
+// CreateUser mocks base method.
+func (m *MockIRepository) CreateUser(ctx context.Context, arg postgres.CreateUserParams) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", ctx, arg)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockIRepositoryMockRecorder) CreateUser(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockIRepository)(nil).CreateUser), ctx, arg)
+}
+
+// CreateWallet mocks base method.
+func (m *MockIRepository) CreateWallet(ctx context.Context, arg postgres.CreateWalletParams) (postgres.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWallet", ctx, arg)
+	ret0, _ := ret[0].(postgres.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWallet indicates an expected call of CreateWallet.
+func (mr *MockIRepositoryMockRecorder) CreateWallet(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWallet", reflect.TypeOf((*MockIRepository)(nil).CreateWallet), ctx, arg)
+}
+
+// CreateWebhookDeliveries mocks base method.
+func (m *MockIRepository) CreateWebhookDeliveries(ctx context.Context, arg postgres.CreateWebhookDeliveriesParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookDeliveries", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhookDeliveries indicates an expected call of CreateWebhookDeliveries.
+func (mr *MockIRepositoryMockRecorder) CreateWebhookDeliveries(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookDeliveries", reflect.TypeOf((*MockIRepository)(nil).CreateWebhookDeliveries), ctx, arg)
+}
+
+// CreateWebhookSubscription mocks base method.
+func (m *MockIRepository) CreateWebhookSubscription(ctx context.Context, arg postgres.CreateWebhookSubscriptionParams) (postgres.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookSubscription", ctx, arg)
+	ret0, _ := ret[0].(postgres.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhookSubscription indicates an expected call of CreateWebhookSubscription.
+func (mr *MockIRepositoryMockRecorder) CreateWebhookSubscription(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookSubscription", reflect.TypeOf((*MockIRepository)(nil).CreateWebhookSubscription), ctx, arg)
+}
+
+// DeactivateFeeRule mocks base method.
+func (m *MockIRepository) DeactivateFeeRule(ctx context.Context, id int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateFeeRule", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateFeeRule indicates an expected call of DeactivateFeeRule.
+func (mr *MockIRepositoryMockRecorder) DeactivateFeeRule(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateFeeRule", reflect.TypeOf((*MockIRepository)(nil).DeactivateFeeRule), ctx, id)
+}
+
+// DeactivateFeeRulesByKey mocks base method.
+func (m *MockIRepository) DeactivateFeeRulesByKey(ctx context.Context, arg postgres.DeactivateFeeRulesByKeyParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateFeeRulesByKey", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeactivateFeeRulesByKey indicates an expected call of DeactivateFeeRulesByKey.
+func (mr *MockIRepositoryMockRecorder) DeactivateFeeRulesByKey(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateFeeRulesByKey", reflect.TypeOf((*MockIRepository)(nil).DeactivateFeeRulesByKey), ctx, arg)
+}
+
+// DeclinePaymentRequest mocks base method.
+func (m *MockIRepository) DeclinePaymentRequest(ctx context.Context, arg postgres.DeclinePaymentRequestParams) (postgres.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclinePaymentRequest", ctx, arg)
+	ret0, _ := ret[0].(postgres.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeclinePaymentRequest indicates an expected call of DeclinePaymentRequest.
+func (mr *MockIRepositoryMockRecorder) DeclinePaymentRequest(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclinePaymentRequest", reflect.TypeOf((*MockIRepository)(nil).DeclinePaymentRequest), ctx, arg)
+}
+
+// DeleteWebhookSubscription mocks base method.
+func (m *MockIRepository) DeleteWebhookSubscription(ctx context.Context, arg postgres.DeleteWebhookSubscriptionParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhookSubscription", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWebhookSubscription indicates an expected call of DeleteWebhookSubscription.
+func (mr *MockIRepositoryMockRecorder) DeleteWebhookSubscription(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhookSubscription", reflect.TypeOf((*MockIRepository)(nil).DeleteWebhookSubscription), ctx, arg)
+}
+
+// ExpireMerchantApiKey mocks base method.
+func (m *MockIRepository) ExpireMerchantApiKey(ctx context.Context, arg postgres.ExpireMerchantApiKeyParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireMerchantApiKey", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireMerchantApiKey indicates an expected call of ExpireMerchantApiKey.
+func (mr *MockIRepositoryMockRecorder) ExpireMerchantApiKey(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireMerchantApiKey", reflect.TypeOf((*MockIRepository)(nil).ExpireMerchantApiKey), ctx, arg)
+}
+
+// ExpireMerchantApiKeys mocks base method.
+func (m *MockIRepository) ExpireMerchantApiKeys(ctx context.Context, arg postgres.ExpireMerchantApiKeysParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireMerchantApiKeys", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireMerchantApiKeys indicates an expected call of ExpireMerchantApiKeys.
+func (mr *MockIRepositoryMockRecorder) ExpireMerchantApiKeys(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireMerchantApiKeys", reflect.TypeOf((*MockIRepository)(nil).ExpireMerchantApiKeys), ctx, arg)
+}
+
+// ExpirePaymentRequests mocks base method.
+func (m *MockIRepository) ExpirePaymentRequests(ctx context.Context, now time.Time) ([]postgres.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpirePaymentRequests", ctx, now)
+	ret0, _ := ret[0].([]postgres.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpirePaymentRequests indicates an expected call of ExpirePaymentRequests.
+func (mr *MockIRepositoryMockRecorder) ExpirePaymentRequests(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpirePaymentRequests", reflect.TypeOf((*MockIRepository)(nil).ExpirePaymentRequests), ctx, now)
+}
+
+// GetApplicableFeeRule mocks base method.
+func (m *MockIRepository) GetApplicableFeeRule(ctx context.Context, arg postgres.GetApplicableFeeRuleParams) (postgres.FeeRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApplicableFeeRule", ctx, arg)
+	ret0, _ := ret[0].(postgres.FeeRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApplicableFeeRule indicates an expected call of GetApplicableFeeRule.
+func (mr *MockIRepositoryMockRecorder) GetApplicableFeeRule(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicableFeeRule", reflect.TypeOf((*MockIRepository)(nil).GetApplicableFeeRule), ctx, arg)
+}
+
+// GetBill mocks base method.
+func (m *MockIRepository) GetBill(ctx context.Context, arg postgres.GetBillParams) (postgres.Bill, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBill", ctx, arg)
+	ret0, _ := ret[0].(postgres.Bill)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBill indicates an expected call of GetBill.
+func (mr *MockIRepositoryMockRecorder) GetBill(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBill", reflect.TypeOf((*MockIRepository)(nil).GetBill), ctx, arg)
+}
+
+// GetCurrency mocks base method.
+func (m *MockIRepository) GetCurrency(ctx context.Context, code string) (postgres.Currency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrency", ctx, code)
+	ret0, _ := ret[0].(postgres.Currency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrency indicates an expected call of GetCurrency.
+func (mr *MockIRepositoryMockRecorder) GetCurrency(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrency", reflect.TypeOf((*MockIRepository)(nil).GetCurrency), ctx, code)
+}
+
+// GetDefaultPocketLock mocks base method.
+func (m *MockIRepository) GetDefaultPocketLock(ctx context.Context, arg postgres.GetDefaultPocketLockParams) (postgres.Pocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefaultPocketLock", ctx, arg)
+	ret0, _ := ret[0].(postgres.Pocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDefaultPocketLock indicates an expected call of GetDefaultPocketLock.
+func (mr *MockIRepositoryMockRecorder) GetDefaultPocketLock(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultPocketLock", reflect.TypeOf((*MockIRepository)(nil).GetDefaultPocketLock), ctx, arg)
+}
+
+// GetDueSavingsGoalLock mocks base method.
+func (m *MockIRepository) GetDueSavingsGoalLock(ctx context.Context, nextSweepAt sql.NullTime) (postgres.SavingsGoal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueSavingsGoalLock", ctx, nextSweepAt)
+	ret0, _ := ret[0].(postgres.SavingsGoal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueSavingsGoalLock indicates an expected call of GetDueSavingsGoalLock.
+func (mr *MockIRepositoryMockRecorder) GetDueSavingsGoalLock(ctx, nextSweepAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueSavingsGoalLock", reflect.TypeOf((*MockIRepository)(nil).GetDueSavingsGoalLock), ctx, nextSweepAt)
+}
+
+// GetLastAuditEvent mocks base method.
+func (m *MockIRepository) GetLastAuditEvent(ctx context.Context, subjectID int32) (postgres.GetLastAuditEventRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastAuditEvent", ctx, subjectID)
+	ret0, _ := ret[0].(postgres.GetLastAuditEventRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastAuditEvent indicates an expected call of GetLastAuditEvent.
+func (mr *MockIRepositoryMockRecorder) GetLastAuditEvent(ctx, subjectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastAuditEvent", reflect.TypeOf((*MockIRepository)(nil).GetLastAuditEvent), ctx, subjectID)
+}
+
+// GetMerchantApiKeyByKeyID mocks base method.
+func (m *MockIRepository) GetMerchantApiKeyByKeyID(ctx context.Context, keyID string) (postgres.MerchantApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerchantApiKeyByKeyID", ctx, keyID)
+	ret0, _ := ret[0].(postgres.MerchantApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMerchantApiKeyByKeyID indicates an expected call of GetMerchantApiKeyByKeyID.
+func (mr *MockIRepositoryMockRecorder) GetMerchantApiKeyByKeyID(ctx, keyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchantApiKeyByKeyID", reflect.TypeOf((*MockIRepository)(nil).GetMerchantApiKeyByKeyID), ctx, keyID)
+}
+
+// GetMerchantByID mocks base method.
+func (m *MockIRepository) GetMerchantByID(ctx context.Context, id int32) (postgres.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerchantByID", ctx, id)
+	ret0, _ := ret[0].(postgres.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMerchantByID indicates an expected call of GetMerchantByID.
+func (mr *MockIRepositoryMockRecorder) GetMerchantByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchantByID", reflect.TypeOf((*MockIRepository)(nil).GetMerchantByID), ctx, id)
+}
+
+// GetOldestDueWebhookDelivery mocks base method.
+func (m *MockIRepository) GetOldestDueWebhookDelivery(ctx context.Context) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOldestDueWebhookDelivery", ctx)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOldestDueWebhookDelivery indicates an expected call of GetOldestDueWebhookDelivery.
+func (mr *MockIRepositoryMockRecorder) GetOldestDueWebhookDelivery(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOldestDueWebhookDelivery", reflect.TypeOf((*MockIRepository)(nil).GetOldestDueWebhookDelivery), ctx)
+}
+
+// GetPaymentRequest mocks base method.
+func (m *MockIRepository) GetPaymentRequest(ctx context.Context, arg postgres.GetPaymentRequestParams) (postgres.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentRequest", ctx, arg)
+	ret0, _ := ret[0].(postgres.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentRequest indicates an expected call of GetPaymentRequest.
+func (mr *MockIRepositoryMockRecorder) GetPaymentRequest(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentRequest", reflect.TypeOf((*MockIRepository)(nil).GetPaymentRequest), ctx, arg)
+}
+
+// GetPocket mocks base method.
+func (m *MockIRepository) GetPocket(ctx context.Context, arg postgres.GetPocketParams) (postgres.Pocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPocket", ctx, arg)
+	ret0, _ := ret[0].(postgres.Pocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPocket indicates an expected call of GetPocket.
+func (mr *MockIRepositoryMockRecorder) GetPocket(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPocket", reflect.TypeOf((*MockIRepository)(nil).GetPocket), ctx, arg)
+}
+
+// GetPocketLock mocks base method.
+func (m *MockIRepository) GetPocketLock(ctx context.Context, arg postgres.GetPocketLockParams) (postgres.Pocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPocketLock", ctx, arg)
+	ret0, _ := ret[0].(postgres.Pocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPocketLock indicates an expected call of GetPocketLock.
+func (mr *MockIRepositoryMockRecorder) GetPocketLock(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPocketLock", reflect.TypeOf((*MockIRepository)(nil).GetPocketLock), ctx, arg)
+}
+
+// GetSavingsGoal mocks base method.
+func (m *MockIRepository) GetSavingsGoal(ctx context.Context, arg postgres.GetSavingsGoalParams) (postgres.SavingsGoal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSavingsGoal", ctx, arg)
+	ret0, _ := ret[0].(postgres.SavingsGoal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSavingsGoal indicates an expected call of GetSavingsGoal.
+func (mr *MockIRepositoryMockRecorder) GetSavingsGoal(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSavingsGoal", reflect.TypeOf((*MockIRepository)(nil).GetSavingsGoal), ctx, arg)
+}
+
+// GetScheduledTransfer mocks base method.
+func (m *MockIRepository) GetScheduledTransfer(ctx context.Context, arg postgres.GetScheduledTransferParams) (postgres.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduledTransfer", ctx, arg)
+	ret0, _ := ret[0].(postgres.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduledTransfer indicates an expected call of GetScheduledTransfer.
+func (mr *MockIRepositoryMockRecorder) GetScheduledTransfer(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledTransfer", reflect.TypeOf((*MockIRepository)(nil).GetScheduledTransfer), ctx, arg)
+}
+
+// GetSettlement mocks base method.
+func (m *MockIRepository) GetSettlement(ctx context.Context, arg postgres.GetSettlementParams) (postgres.Settlement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSettlement", ctx, arg)
+	ret0, _ := ret[0].(postgres.Settlement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSettlement indicates an expected call of GetSettlement.
+func (mr *MockIRepositoryMockRecorder) GetSettlement(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettlement", reflect.TypeOf((*MockIRepository)(nil).GetSettlement), ctx, arg)
+}
+
+// GetTransferByIdempotencyKey mocks base method.
+func (m *MockIRepository) GetTransferByIdempotencyKey(ctx context.Context, idempotencyKey sql.NullString) (postgres.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferByIdempotencyKey", ctx, idempotencyKey)
+	ret0, _ := ret[0].(postgres.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferByIdempotencyKey indicates an expected call of GetTransferByIdempotencyKey.
+func (mr *MockIRepositoryMockRecorder) GetTransferByIdempotencyKey(ctx, idempotencyKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferByIdempotencyKey", reflect.TypeOf((*MockIRepository)(nil).GetTransferByIdempotencyKey), ctx, idempotencyKey)
+}
+
+// GetUserByID mocks base method.
+func (m *MockIRepository) GetUserByID(ctx context.Context, id int32) (postgres.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
+	ret0, _ := ret[0].(postgres.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockIRepositoryMockRecorder) GetUserByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockIRepository)(nil).GetUserByID), ctx, id)
+}
+
+// GetUserByIDLock mocks base method.
+func (m *MockIRepository) GetUserByIDLock(ctx context.Context, id int32) (postgres.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByIDLock", ctx, id)
+	ret0, _ := ret[0].(postgres.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByIDLock indicates an expected call of GetUserByIDLock.
+func (mr *MockIRepositoryMockRecorder) GetUserByIDLock(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByIDLock", reflect.TypeOf((*MockIRepository)(nil).GetUserByIDLock), ctx, id)
+}
+
+// GetUserByUsername mocks base method.
+func (m *MockIRepository) GetUserByUsername(ctx context.Context, username string) (postgres.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByUsername", ctx, username)
+	ret0, _ := ret[0].(postgres.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByUsername indicates an expected call of GetUserByUsername.
+func (mr *MockIRepositoryMockRecorder) GetUserByUsername(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockIRepository)(nil).GetUserByUsername), ctx, username)
+}
+
+// GetWallet mocks base method.
+func (m *MockIRepository) GetWallet(ctx context.Context, arg postgres.GetWalletParams) (postgres.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWallet", ctx, arg)
+	ret0, _ := ret[0].(postgres.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWallet indicates an expected call of GetWallet.
+func (mr *MockIRepositoryMockRecorder) GetWallet(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWallet", reflect.TypeOf((*MockIRepository)(nil).GetWallet), ctx, arg)
+}
+
+// GetWalletLock mocks base method.
+func (m *MockIRepository) GetWalletLock(ctx context.Context, arg postgres.GetWalletLockParams) (postgres.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWalletLock", ctx, arg)
+	ret0, _ := ret[0].(postgres.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWalletLock indicates an expected call of GetWalletLock.
+func (mr *MockIRepositoryMockRecorder) GetWalletLock(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletLock", reflect.TypeOf((*MockIRepository)(nil).GetWalletLock), ctx, arg)
+}
+
+// GetWebhookDelivery mocks base method.
+func (m *MockIRepository) GetWebhookDelivery(ctx context.Context, arg postgres.GetWebhookDeliveryParams) (postgres.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDelivery", ctx, arg)
+	ret0, _ := ret[0].(postgres.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDelivery indicates an expected call of GetWebhookDelivery.
+func (mr *MockIRepositoryMockRecorder) GetWebhookDelivery(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDelivery", reflect.TypeOf((*MockIRepository)(nil).GetWebhookDelivery), ctx, arg)
+}
+
+// GetWebhookSubscriptionByID mocks base method.
+func (m *MockIRepository) GetWebhookSubscriptionByID(ctx context.Context, id int32) (postgres.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookSubscriptionByID", ctx, id)
+	ret0, _ := ret[0].(postgres.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookSubscriptionByID indicates an expected call of GetWebhookSubscriptionByID.
+func (mr *MockIRepositoryMockRecorder) GetWebhookSubscriptionByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookSubscriptionByID", reflect.TypeOf((*MockIRepository)(nil).GetWebhookSubscriptionByID), ctx, id)
+}
+
+// IncrementWalletBalance mocks base method.
+func (m *MockIRepository) IncrementWalletBalance(ctx context.Context, arg postgres.IncrementWalletBalanceParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementWalletBalance", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementWalletBalance indicates an expected call of IncrementWalletBalance.
+func (mr *MockIRepositoryMockRecorder) IncrementWalletBalance(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementWalletBalance", reflect.TypeOf((*MockIRepository)(nil).IncrementWalletBalance), ctx, arg)
+}
+
+// ListAuditEventsAfterID mocks base method.
+func (m *MockIRepository) ListAuditEventsAfterID(ctx context.Context, arg postgres.ListAuditEventsAfterIDParams) ([]postgres.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditEventsAfterID", ctx, arg)
+	ret0, _ := ret[0].([]postgres.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditEventsAfterID indicates an expected call of ListAuditEventsAfterID.
+func (mr *MockIRepositoryMockRecorder) ListAuditEventsAfterID(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditEventsAfterID", reflect.TypeOf((*MockIRepository)(nil).ListAuditEventsAfterID), ctx, arg)
+}
+
+// ListBillShares mocks base method.
+func (m *MockIRepository) ListBillShares(ctx context.Context, billID int32) ([]postgres.BillShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBillShares", ctx, billID)
+	ret0, _ := ret[0].([]postgres.BillShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBillShares indicates an expected call of ListBillShares.
+func (mr *MockIRepositoryMockRecorder) ListBillShares(ctx, billID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBillShares", reflect.TypeOf((*MockIRepository)(nil).ListBillShares), ctx, billID)
+}
+
+// ListBillSharesByUserID mocks base method.
+func (m *MockIRepository) ListBillSharesByUserID(ctx context.Context, arg postgres.ListBillSharesByUserIDParams) ([]postgres.ListBillSharesByUserIDRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBillSharesByUserID", ctx, arg)
+	ret0, _ := ret[0].([]postgres.ListBillSharesByUserIDRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBillSharesByUserID indicates an expected call of ListBillSharesByUserID.
+func (mr *MockIRepositoryMockRecorder) ListBillSharesByUserID(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBillSharesByUserID", reflect.TypeOf((*MockIRepository)(nil).ListBillSharesByUserID), ctx, arg)
+}
+
+// ListBillsByCreatorID mocks base method.
+func (m *MockIRepository) ListBillsByCreatorID(ctx context.Context, arg postgres.ListBillsByCreatorIDParams) ([]postgres.Bill, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBillsByCreatorID", ctx, arg)
+	ret0, _ := ret[0].([]postgres.Bill)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBillsByCreatorID indicates an expected call of ListBillsByCreatorID.
+func (mr *MockIRepositoryMockRecorder) ListBillsByCreatorID(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBillsByCreatorID", reflect.TypeOf((*MockIRepository)(nil).ListBillsByCreatorID), ctx, arg)
+}
+
+// ListCapturedPaymentBatches mocks base method.
+func (m *MockIRepository) ListCapturedPaymentBatches(ctx context.Context, capturedBefore time.Time) ([]postgres.ListCapturedPaymentBatchesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCapturedPaymentBatches", ctx, capturedBefore)
+	ret0, _ := ret[0].([]postgres.ListCapturedPaymentBatchesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCapturedPaymentBatches indicates an expected call of ListCapturedPaymentBatches.
+func (mr *MockIRepositoryMockRecorder) ListCapturedPaymentBatches(ctx, capturedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCapturedPaymentBatches", reflect.TypeOf((*MockIRepository)(nil).ListCapturedPaymentBatches), ctx, capturedBefore)
+}
+
+// ListCurrencies mocks base method.
+func (m *MockIRepository) ListCurrencies(ctx context.Context) ([]postgres.Currency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCurrencies", ctx)
+	ret0, _ := ret[0].([]postgres.Currency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCurrencies indicates an expected call of ListCurrencies.
+func (mr *MockIRepositoryMockRecorder) ListCurrencies(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCurrencies", reflect.TypeOf((*MockIRepository)(nil).ListCurrencies), ctx)
+}
+
+// ListFeeRules mocks base method.
+func (m *MockIRepository) ListFeeRules(ctx context.Context, activeOnly bool) ([]postgres.FeeRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFeeRules", ctx, activeOnly)
+	ret0, _ := ret[0].([]postgres.FeeRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFeeRules indicates an expected call of ListFeeRules.
+func (mr *MockIRepositoryMockRecorder) ListFeeRules(ctx, activeOnly interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFeeRules", reflect.TypeOf((*MockIRepository)(nil).ListFeeRules), ctx, activeOnly)
+}
+
+// ListMerchantApiKeys mocks base method.
+func (m *MockIRepository) ListMerchantApiKeys(ctx context.Context, merchantID int32) ([]postgres.MerchantApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMerchantApiKeys", ctx, merchantID)
+	ret0, _ := ret[0].([]postgres.MerchantApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMerchantApiKeys indicates an expected call of ListMerchantApiKeys.
+func (mr *MockIRepositoryMockRecorder) ListMerchantApiKeys(ctx, merchantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMerchantApiKeys", reflect.TypeOf((*MockIRepository)(nil).ListMerchantApiKeys), ctx, merchantID)
+}
+
+// ListPaymentRequests mocks base method.
+func (m *MockIRepository) ListPaymentRequests(ctx context.Context, arg postgres.ListPaymentRequestsParams) ([]postgres.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPaymentRequests", ctx, arg)
+	ret0, _ := ret[0].([]postgres.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPaymentRequests indicates an expected call of ListPaymentRequests.
+func (mr *MockIRepositoryMockRecorder) ListPaymentRequests(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaymentRequests", reflect.TypeOf((*MockIRepository)(nil).ListPaymentRequests), ctx, arg)
+}
+
+// ListPaymentsBySettlementID mocks base method.
+func (m *MockIRepository) ListPaymentsBySettlementID(ctx context.Context, settlementID sql.NullInt32) ([]postgres.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPaymentsBySettlementID", ctx, settlementID)
+	ret0, _ := ret[0].([]postgres.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPaymentsBySettlementID indicates an expected call of ListPaymentsBySettlementID.
+func (mr *MockIRepositoryMockRecorder) ListPaymentsBySettlementID(ctx, settlementID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaymentsBySettlementID", reflect.TypeOf((*MockIRepository)(nil).ListPaymentsBySettlementID), ctx, settlementID)
+}
+
+// ListPocketEntries mocks base method.
+func (m *MockIRepository) ListPocketEntries(ctx context.Context, arg postgres.ListPocketEntriesParams) ([]postgres.PocketEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPocketEntries", ctx, arg)
+	ret0, _ := ret[0].([]postgres.PocketEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPocketEntries indicates an expected call of ListPocketEntries.
+func (mr *MockIRepositoryMockRecorder) ListPocketEntries(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPocketEntries", reflect.TypeOf((*MockIRepository)(nil).ListPocketEntries), ctx, arg)
+}
+
+// ListPocketsByUserID mocks base method.
+func (m *MockIRepository) ListPocketsByUserID(ctx context.Context, userID int32) ([]postgres.Pocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPocketsByUserID", ctx, userID)
+	ret0, _ := ret[0].([]postgres.Pocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPocketsByUserID indicates an expected call of ListPocketsByUserID.
+func (mr *MockIRepositoryMockRecorder) ListPocketsByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPocketsByUserID", reflect.TypeOf((*MockIRepository)(nil).ListPocketsByUserID), ctx, userID)
+}
+
+// ListRoundUpSavingsGoals mocks base method.
+func (m *MockIRepository) ListRoundUpSavingsGoals(ctx context.Context, arg postgres.ListRoundUpSavingsGoalsParams) ([]postgres.SavingsGoal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoundUpSavingsGoals", ctx, arg)
+	ret0, _ := ret[0].([]postgres.SavingsGoal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoundUpSavingsGoals indicates an expected call of ListRoundUpSavingsGoals.
+func (mr *MockIRepositoryMockRecorder) ListRoundUpSavingsGoals(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoundUpSavingsGoals", reflect.TypeOf((*MockIRepository)(nil).ListRoundUpSavingsGoals), ctx, arg)
+}
+
+// ListSavingsGoalsByUserID mocks base method.
+func (m *MockIRepository) ListSavingsGoalsByUserID(ctx context.Context, userID int32) ([]postgres.SavingsGoal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSavingsGoalsByUserID", ctx, userID)
+	ret0, _ := ret[0].([]postgres.SavingsGoal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSavingsGoalsByUserID indicates an expected call of ListSavingsGoalsByUserID.
+func (mr *MockIRepositoryMockRecorder) ListSavingsGoalsByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSavingsGoalsByUserID", reflect.TypeOf((*MockIRepository)(nil).ListSavingsGoalsByUserID), ctx, userID)
+}
+
+// ListScheduledTransferRuns mocks base method.
+func (m *MockIRepository) ListScheduledTransferRuns(ctx context.Context, arg postgres.ListScheduledTransferRunsParams) ([]postgres.ScheduledTransferRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScheduledTransferRuns", ctx, arg)
+	ret0, _ := ret[0].([]postgres.ScheduledTransferRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScheduledTransferRuns indicates an expected call of ListScheduledTransferRuns.
+func (mr *MockIRepositoryMockRecorder) ListScheduledTransferRuns(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScheduledTransferRuns", reflect.TypeOf((*MockIRepository)(nil).ListScheduledTransferRuns), ctx, arg)
+}
+
+// ListScheduledTransfersByUserID mocks base method.
+func (m *MockIRepository) ListScheduledTransfersByUserID(ctx context.Context, userID int32) ([]postgres.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScheduledTransfersByUserID", ctx, userID)
+	ret0, _ := ret[0].([]postgres.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScheduledTransfersByUserID indicates an expected call of ListScheduledTransfersByUserID.
+func (mr *MockIRepositoryMockRecorder) ListScheduledTransfersByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScheduledTransfersByUserID", reflect.TypeOf((*MockIRepository)(nil).ListScheduledTransfersByUserID), ctx, userID)
+}
+
+// ListSettlementsByMerchantID mocks base method.
+func (m *MockIRepository) ListSettlementsByMerchantID(ctx context.Context, arg postgres.ListSettlementsByMerchantIDParams) ([]postgres.Settlement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSettlementsByMerchantID", ctx, arg)
+	ret0, _ := ret[0].([]postgres.Settlement)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateTransaction indicates an expected call of CreateTransaction.
-func (mr *MockIRepositoryMockRecorder) CreateTransaction(ctx, arg interface{}) *gomock.Call {
+// ListSettlementsByMerchantID indicates an expected call of ListSettlementsByMerchantID.
+func (mr *MockIRepositoryMockRecorder) ListSettlementsByMerchantID(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransaction", reflect.TypeOf((*MockIRepository)(nil).CreateTransaction), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSettlementsByMerchantID", reflect.TypeOf((*MockIRepository)(nil).ListSettlementsByMerchantID), ctx, arg)
 }
 
-// CreateUser mocks base method.
-func (m *MockIRepository) CreateUser(ctx context.Context, arg postgres.CreateUserParams) (int32, error) {
+// ListWalletsByUserID mocks base method.
+func (m *MockIRepository) ListWalletsByUserID(ctx context.Context, userID int32) ([]postgres.Wallet, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", ctx, arg)
-	ret0, _ := ret[0].(int32)
+	ret := m.ctrl.Call(m, "ListWalletsByUserID", ctx, userID)
+	ret0, _ := ret[0].([]postgres.Wallet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockIRepositoryMockRecorder) CreateUser(ctx, arg interface{}) *gomock.Call {
+// ListWalletsByUserID indicates an expected call of ListWalletsByUserID.
+func (mr *MockIRepositoryMockRecorder) ListWalletsByUserID(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockIRepository)(nil).CreateUser), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWalletsByUserID", reflect.TypeOf((*MockIRepository)(nil).ListWalletsByUserID), ctx, userID)
 }
 
-// GetUserByIDLock mocks base method.
-func (m *MockIRepository) GetUserByIDLock(ctx context.Context, id int32) (postgres.User, error) {
+// ListWebhookDeliveries mocks base method.
+func (m *MockIRepository) ListWebhookDeliveries(ctx context.Context, arg postgres.ListWebhookDeliveriesParams) ([]postgres.WebhookDelivery, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByIDLock", ctx, id)
-	ret0, _ := ret[0].(postgres.User)
+	ret := m.ctrl.Call(m, "ListWebhookDeliveries", ctx, arg)
+	ret0, _ := ret[0].([]postgres.WebhookDelivery)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserByIDLock indicates an expected call of GetUserByIDLock.
-func (mr *MockIRepositoryMockRecorder) GetUserByIDLock(ctx, id interface{}) *gomock.Call {
+// ListWebhookDeliveries indicates an expected call of ListWebhookDeliveries.
+func (mr *MockIRepositoryMockRecorder) ListWebhookDeliveries(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByIDLock", reflect.TypeOf((*MockIRepository)(nil).GetUserByIDLock), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveries", reflect.TypeOf((*MockIRepository)(nil).ListWebhookDeliveries), ctx, arg)
 }
 
-// GetUserByUsername mocks base method.
-func (m *MockIRepository) GetUserByUsername(ctx context.Context, username string) (postgres.User, error) {
+// ListWebhookSubscriptionsByUserID mocks base method.
+func (m *MockIRepository) ListWebhookSubscriptionsByUserID(ctx context.Context, userID int32) ([]postgres.WebhookSubscription, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByUsername", ctx, username)
-	ret0, _ := ret[0].(postgres.User)
+	ret := m.ctrl.Call(m, "ListWebhookSubscriptionsByUserID", ctx, userID)
+	ret0, _ := ret[0].([]postgres.WebhookSubscription)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserByUsername indicates an expected call of GetUserByUsername.
-func (mr *MockIRepositoryMockRecorder) GetUserByUsername(ctx, username interface{}) *gomock.Call {
+// ListWebhookSubscriptionsByUserID indicates an expected call of ListWebhookSubscriptionsByUserID.
+func (mr *MockIRepositoryMockRecorder) ListWebhookSubscriptionsByUserID(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockIRepository)(nil).GetUserByUsername), ctx, username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookSubscriptionsByUserID", reflect.TypeOf((*MockIRepository)(nil).ListWebhookSubscriptionsByUserID), ctx, userID)
+}
+
+// LockAuditChain mocks base method.
+func (m *MockIRepository) LockAuditChain(ctx context.Context, pgAdvisoryXactLock int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockAuditChain", ctx, pgAdvisoryXactLock)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockAuditChain indicates an expected call of LockAuditChain.
+func (mr *MockIRepositoryMockRecorder) LockAuditChain(ctx, pgAdvisoryXactLock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockAuditChain", reflect.TypeOf((*MockIRepository)(nil).LockAuditChain), ctx, pgAdvisoryXactLock)
+}
+
+// PayBillShare mocks base method.
+func (m *MockIRepository) PayBillShare(ctx context.Context, arg postgres.PayBillShareParams) (postgres.BillShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PayBillShare", ctx, arg)
+	ret0, _ := ret[0].(postgres.BillShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PayBillShare indicates an expected call of PayBillShare.
+func (mr *MockIRepositoryMockRecorder) PayBillShare(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayBillShare", reflect.TypeOf((*MockIRepository)(nil).PayBillShare), ctx, arg)
+}
+
+// PayPaymentRequest mocks base method.
+func (m *MockIRepository) PayPaymentRequest(ctx context.Context, arg postgres.PayPaymentRequestParams) (postgres.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PayPaymentRequest", ctx, arg)
+	ret0, _ := ret[0].(postgres.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PayPaymentRequest indicates an expected call of PayPaymentRequest.
+func (mr *MockIRepositoryMockRecorder) PayPaymentRequest(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayPaymentRequest", reflect.TypeOf((*MockIRepository)(nil).PayPaymentRequest), ctx, arg)
+}
+
+// RecordBillPayment mocks base method.
+func (m *MockIRepository) RecordBillPayment(ctx context.Context, arg postgres.RecordBillPaymentParams) (postgres.Bill, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordBillPayment", ctx, arg)
+	ret0, _ := ret[0].(postgres.Bill)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordBillPayment indicates an expected call of RecordBillPayment.
+func (mr *MockIRepositoryMockRecorder) RecordBillPayment(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordBillPayment", reflect.TypeOf((*MockIRepository)(nil).RecordBillPayment), ctx, arg)
+}
+
+// RemindBillShares mocks base method.
+func (m *MockIRepository) RemindBillShares(ctx context.Context, arg postgres.RemindBillSharesParams) ([]postgres.BillShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemindBillShares", ctx, arg)
+	ret0, _ := ret[0].([]postgres.BillShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemindBillShares indicates an expected call of RemindBillShares.
+func (mr *MockIRepositoryMockRecorder) RemindBillShares(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemindBillShares", reflect.TypeOf((*MockIRepository)(nil).RemindBillShares), ctx, arg)
+}
+
+// SetDefaultPocket mocks base method.
+func (m *MockIRepository) SetDefaultPocket(ctx context.Context, id int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDefaultPocket", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDefaultPocket indicates an expected call of SetDefaultPocket.
+func (mr *MockIRepositoryMockRecorder) SetDefaultPocket(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDefaultPocket", reflect.TypeOf((*MockIRepository)(nil).SetDefaultPocket), ctx, id)
+}
+
+// SettlePayments mocks base method.
+func (m *MockIRepository) SettlePayments(ctx context.Context, arg postgres.SettlePaymentsParams) (postgres.SettlePaymentsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SettlePayments", ctx, arg)
+	ret0, _ := ret[0].(postgres.SettlePaymentsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SettlePayments indicates an expected call of SettlePayments.
+func (mr *MockIRepositoryMockRecorder) SettlePayments(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SettlePayments", reflect.TypeOf((*MockIRepository)(nil).SettlePayments), ctx, arg)
+}
+
+// SumPocketBalances mocks base method.
+func (m *MockIRepository) SumPocketBalances(ctx context.Context, arg postgres.SumPocketBalancesParams) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumPocketBalances", ctx, arg)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumPocketBalances indicates an expected call of SumPocketBalances.
+func (mr *MockIRepositoryMockRecorder) SumPocketBalances(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumPocketBalances", reflect.TypeOf((*MockIRepository)(nil).SumPocketBalances), ctx, arg)
+}
+
+// UpdatePocketBalance mocks base method.
+func (m *MockIRepository) UpdatePocketBalance(ctx context.Context, arg postgres.UpdatePocketBalanceParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePocketBalance", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePocketBalance indicates an expected call of UpdatePocketBalance.
+func (mr *MockIRepositoryMockRecorder) UpdatePocketBalance(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePocketBalance", reflect.TypeOf((*MockIRepository)(nil).UpdatePocketBalance), ctx, arg)
+}
+
+// UpdateSavingsGoalNextSweep mocks base method.
+func (m *MockIRepository) UpdateSavingsGoalNextSweep(ctx context.Context, arg postgres.UpdateSavingsGoalNextSweepParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSavingsGoalNextSweep", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSavingsGoalNextSweep indicates an expected call of UpdateSavingsGoalNextSweep.
+func (mr *MockIRepositoryMockRecorder) UpdateSavingsGoalNextSweep(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSavingsGoalNextSweep", reflect.TypeOf((*MockIRepository)(nil).UpdateSavingsGoalNextSweep), ctx, arg)
+}
+
+// UpdateScheduledTransferState mocks base method.
+func (m *MockIRepository) UpdateScheduledTransferState(ctx context.Context, arg postgres.UpdateScheduledTransferStateParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScheduledTransferState", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateScheduledTransferState indicates an expected call of UpdateScheduledTransferState.
+func (mr *MockIRepositoryMockRecorder) UpdateScheduledTransferState(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScheduledTransferState", reflect.TypeOf((*MockIRepository)(nil).UpdateScheduledTransferState), ctx, arg)
+}
+
+// UpdateSettlementTotals mocks base method.
+func (m *MockIRepository) UpdateSettlementTotals(ctx context.Context, arg postgres.UpdateSettlementTotalsParams) (postgres.Settlement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSettlementTotals", ctx, arg)
+	ret0, _ := ret[0].(postgres.Settlement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSettlementTotals indicates an expected call of UpdateSettlementTotals.
+func (mr *MockIRepositoryMockRecorder) UpdateSettlementTotals(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSettlementTotals", reflect.TypeOf((*MockIRepository)(nil).UpdateSettlementTotals), ctx, arg)
+}
+
+// UpdateWalletBalanceByID mocks base method.
+func (m *MockIRepository) UpdateWalletBalanceByID(ctx context.Context, arg postgres.UpdateWalletBalanceByIDParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWalletBalanceByID", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWalletBalanceByID indicates an expected call of UpdateWalletBalanceByID.
+func (mr *MockIRepositoryMockRecorder) UpdateWalletBalanceByID(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWalletBalanceByID", reflect.TypeOf((*MockIRepository)(nil).UpdateWalletBalanceByID), ctx, arg)
 }
 
-// UpdateUserBalanceByID mocks base method.
-func (m *MockIRepository) UpdateUserBalanceByID(ctx context.Context, arg postgres.UpdateUserBalanceByIDParams) error {
+// UpdateWebhookDeliveryAttempt mocks base method.
+func (m *MockIRepository) UpdateWebhookDeliveryAttempt(ctx context.Context, arg postgres.UpdateWebhookDeliveryAttemptParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUserBalanceByID", ctx, arg)
+	ret := m.ctrl.Call(m, "UpdateWebhookDeliveryAttempt", ctx, arg)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateUserBalanceByID indicates an expected call of UpdateUserBalanceByID.
-func (mr *MockIRepositoryMockRecorder) UpdateUserBalanceByID(ctx, arg interface{}) *gomock.Call {
+// UpdateWebhookDeliveryAttempt indicates an expected call of UpdateWebhookDeliveryAttempt.
+func (mr *MockIRepositoryMockRecorder) UpdateWebhookDeliveryAttempt(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserBalanceByID", reflect.TypeOf((*MockIRepository)(nil).UpdateUserBalanceByID), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhookDeliveryAttempt", reflect.TypeOf((*MockIRepository)(nil).UpdateWebhookDeliveryAttempt), ctx, arg)
 }
 
 // WithTx mocks base method.
@@ -123,3 +1538,247 @@ func (mr *MockIRepositoryMockRecorder) WithTx(tx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockIRepository)(nil).WithTx), tx)
 }
+
+// MockIUserCache is a mock of IUserCache interface.
+type MockIUserCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserCacheMockRecorder
+}
+
+// MockIUserCacheMockRecorder is the mock recorder for MockIUserCache.
+type MockIUserCacheMockRecorder struct {
+	mock *MockIUserCache
+}
+
+// NewMockIUserCache creates a new mock instance.
+func NewMockIUserCache(ctrl *gomock.Controller) *MockIUserCache {
+	mock := &MockIUserCache{ctrl: ctrl}
+	mock.recorder = &MockIUserCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserCache) EXPECT() *MockIUserCacheMockRecorder {
+	return m.recorder
+}
+
+// DeleteBalances mocks base method.
+func (m *MockIUserCache) DeleteBalances(ctx context.Context, userIDs ...int32) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range userIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteBalances", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBalances indicates an expected call of DeleteBalances.
+func (mr *MockIUserCacheMockRecorder) DeleteBalances(ctx interface{}, userIDs ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, userIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBalances", reflect.TypeOf((*MockIUserCache)(nil).DeleteBalances), varargs...)
+}
+
+// GetBalances mocks base method.
+func (m *MockIUserCache) GetBalances(ctx context.Context, userID int32) ([]postgres.Wallet, []postgres.Pocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalances", ctx, userID)
+	ret0, _ := ret[0].([]postgres.Wallet)
+	ret1, _ := ret[1].([]postgres.Pocket)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetBalances indicates an expected call of GetBalances.
+func (mr *MockIUserCacheMockRecorder) GetBalances(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalances", reflect.TypeOf((*MockIUserCache)(nil).GetBalances), ctx, userID)
+}
+
+// GetUser mocks base method.
+func (m *MockIUserCache) GetUser(ctx context.Context, id int32) (*postgres.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, id)
+	ret0, _ := ret[0].(*postgres.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockIUserCacheMockRecorder) GetUser(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockIUserCache)(nil).GetUser), ctx, id)
+}
+
+// SetBalances mocks base method.
+func (m *MockIUserCache) SetBalances(ctx context.Context, userID int32, wallets []postgres.Wallet, pockets []postgres.Pocket) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBalances", ctx, userID, wallets, pockets)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBalances indicates an expected call of SetBalances.
+func (mr *MockIUserCacheMockRecorder) SetBalances(ctx, userID, wallets, pockets interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBalances", reflect.TypeOf((*MockIUserCache)(nil).SetBalances), ctx, userID, wallets, pockets)
+}
+
+// SetUser mocks base method.
+func (m *MockIUserCache) SetUser(ctx context.Context, user postgres.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUser", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUser indicates an expected call of SetUser.
+func (mr *MockIUserCacheMockRecorder) SetUser(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUser", reflect.TypeOf((*MockIUserCache)(nil).SetUser), ctx, user)
+}
+
+// MockIEventStream is a mock of IEventStream interface.
+type MockIEventStream struct {
+	ctrl     *gomock.Controller
+	recorder *MockIEventStreamMockRecorder
+}
+
+// MockIEventStreamMockRecorder is the mock recorder for MockIEventStream.
+type MockIEventStreamMockRecorder struct {
+	mock *MockIEventStream
+}
+
+// NewMockIEventStream creates a new mock instance.
+func NewMockIEventStream(ctrl *gomock.Controller) *MockIEventStream {
+	mock := &MockIEventStream{ctrl: ctrl}
+	mock.recorder = &MockIEventStreamMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIEventStream) EXPECT() *MockIEventStreamMockRecorder {
+	return m.recorder
+}
+
+// Listen mocks base method.
+func (m *MockIEventStream) Listen(ctx context.Context, handle func(stream.Event)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Listen", ctx, handle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Listen indicates an expected call of Listen.
+func (mr *MockIEventStreamMockRecorder) Listen(ctx, handle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Listen", reflect.TypeOf((*MockIEventStream)(nil).Listen), ctx, handle)
+}
+
+// Publish mocks base method.
+func (m *MockIEventStream) Publish(ctx context.Context, event stream.Event) (stream.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, event)
+	ret0, _ := ret[0].(stream.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockIEventStreamMockRecorder) Publish(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockIEventStream)(nil).Publish), ctx, event)
+}
+
+// Since mocks base method.
+func (m *MockIEventStream) Since(ctx context.Context, userID int32, lastEventID string) ([]stream.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Since", ctx, userID, lastEventID)
+	ret0, _ := ret[0].([]stream.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Since indicates an expected call of Since.
+func (mr *MockIEventStreamMockRecorder) Since(ctx, userID, lastEventID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Since", reflect.TypeOf((*MockIEventStream)(nil).Since), ctx, userID, lastEventID)
+}
+
+// MockINonceCache is a mock of INonceCache interface.
+type MockINonceCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockINonceCacheMockRecorder
+}
+
+// MockINonceCacheMockRecorder is the mock recorder for MockINonceCache.
+type MockINonceCacheMockRecorder struct {
+	mock *MockINonceCache
+}
+
+// NewMockINonceCache creates a new mock instance.
+func NewMockINonceCache(ctrl *gomock.Controller) *MockINonceCache {
+	mock := &MockINonceCache{ctrl: ctrl}
+	mock.recorder = &MockINonceCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockINonceCache) EXPECT() *MockINonceCacheMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockINonceCache) Claim(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, key, ttl)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockINonceCacheMockRecorder) Claim(ctx, key, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockINonceCache)(nil).Claim), ctx, key, ttl)
+}
+
+// MockIRateSource is a mock of IRateSource interface.
+type MockIRateSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRateSourceMockRecorder
+}
+
+// MockIRateSourceMockRecorder is the mock recorder for MockIRateSource.
+type MockIRateSourceMockRecorder struct {
+	mock *MockIRateSource
+}
+
+// NewMockIRateSource creates a new mock instance.
+func NewMockIRateSource(ctrl *gomock.Controller) *MockIRateSource {
+	mock := &MockIRateSource{ctrl: ctrl}
+	mock.recorder = &MockIRateSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRateSource) EXPECT() *MockIRateSourceMockRecorder {
+	return m.recorder
+}
+
+// GetRate mocks base method.
+func (m *MockIRateSource) GetRate(ctx context.Context, from, to string) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRate", ctx, from, to)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRate indicates an expected call of GetRate.
+func (mr *MockIRateSourceMockRecorder) GetRate(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRate", reflect.TypeOf((*MockIRateSource)(nil).GetRate), ctx, from, to)
+}
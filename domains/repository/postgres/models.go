@@ -6,21 +6,289 @@ package postgres
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
+
+	"github.com/google/uuid"
 )
 
+type AuditEvent struct {
+	ID          int64
+	EventType   string
+	ActorID     sql.NullInt32
+	ActorRole   string
+	SubjectID   sql.NullInt32
+	Ip          string
+	UserAgent   string
+	RequestID   string
+	BeforeValue json.RawMessage
+	AfterValue  json.RawMessage
+	Metadata    json.RawMessage
+	PrevHash    string
+	Hash        string
+	CreatedAt   time.Time
+}
+
+type Bill struct {
+	ID                int32
+	CreatorID         int32
+	Title             string
+	Currency          string
+	Total             float64
+	Split             string
+	PaidAmount        float64
+	OutstandingAmount float64
+	Status            string
+	SettledAt         sql.NullTime
+	CreatedAt         time.Time
+}
+
+type BillShare struct {
+	ID         int32
+	BillID     int32
+	UserID     int32
+	Amount     float64
+	Status     string
+	TransferID sql.NullInt32
+	PaidAt     sql.NullTime
+	RemindedAt sql.NullTime
+	CreatedAt  time.Time
+}
+
+type Currency struct {
+	Code       string
+	MinorUnits int16
+}
+
+type Exchange struct {
+	ID                  int32
+	UserID              int32
+	QuoteNonce          string
+	FromCurrency        string
+	ToCurrency          string
+	FromAmount          float64
+	ToAmount            float64
+	Rate                float64
+	MidRate             float64
+	SpreadPercent       float64
+	DebitTransactionID  int32
+	CreditTransactionID int32
+	CreatedAt           time.Time
+}
+
+type FeeRule struct {
+	ID              int32
+	TransactionType string
+	Channel         sql.NullString
+	UserTier        sql.NullString
+	Kind            string
+	FlatAmount      float64
+	Percent         float64
+	Tiers           json.RawMessage
+	MinFee          sql.NullFloat64
+	MaxFee          sql.NullFloat64
+	Active          bool
+	CreatedAt       time.Time
+	Currency        string
+}
+
+type Merchant struct {
+	ID        int32
+	Name      string
+	CreatedAt time.Time
+	UserID    sql.NullInt32
+}
+
+type MerchantApiKey struct {
+	ID               int32
+	MerchantID       int32
+	KeyID            string
+	SecretCiphertext string
+	ExpiresAt        sql.NullTime
+	CreatedAt        time.Time
+}
+
+type Payment struct {
+	ID             int32
+	MerchantID     int32
+	UserID         int32
+	Currency       string
+	Amount         float64
+	OrderReference string
+	Metadata       json.RawMessage
+	Status         string
+	TransactionID  int32
+	SettlementID   sql.NullInt32
+	CreatedAt      time.Time
+}
+
+type PaymentRequest struct {
+	ID          int32
+	RequesterID int32
+	PayerID     int32
+	Currency    string
+	Amount      float64
+	Note        string
+	Status      string
+	ExpiresAt   time.Time
+	TransferID  sql.NullInt32
+	ResolvedAt  sql.NullTime
+	CreatedAt   time.Time
+}
+
+type Pocket struct {
+	ID        int32
+	UserID    int32
+	Currency  string
+	Name      string
+	Balance   float64
+	IsDefault bool
+	CreatedAt time.Time
+}
+
+type PocketEntry struct {
+	ID                  int32
+	PocketID            int32
+	Type                string
+	Amount              float64
+	Balance             float64
+	TransactionID       sql.NullInt32
+	CounterpartPocketID sql.NullInt32
+	CreatedAt           time.Time
+}
+
+type QuoteRedemption struct {
+	Nonce     string
+	UserID    int32
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+type SavingsGoal struct {
+	ID             int32
+	UserID         int32
+	PocketID       int32
+	TargetAmount   float64
+	TargetDate     time.Time
+	RoundUpTo      sql.NullFloat64
+	SweepAmount    sql.NullFloat64
+	SweepFrequency sql.NullString
+	NextSweepAt    sql.NullTime
+	CreatedAt      time.Time
+}
+
+type ScheduledTransfer struct {
+	ID                  int32
+	UserID              int32
+	ToUserID            int32
+	Currency            string
+	Amount              float64
+	Note                string
+	Recurrence          sql.NullString
+	StartAt             time.Time
+	EndAt               sql.NullTime
+	MaxOccurrences      sql.NullInt32
+	OnInsufficientFunds string
+	Status              string
+	OccurrenceAt        sql.NullTime
+	OccurrenceCount     int32
+	Attempts            int32
+	NextRunAt           sql.NullTime
+	CreatedAt           time.Time
+}
+
+type ScheduledTransferRun struct {
+	ID                  int32
+	ScheduledTransferID int32
+	OccurrenceAt        time.Time
+	Attempt             int32
+	Status              string
+	TransferID          sql.NullInt32
+	Error               string
+	CreatedAt           time.Time
+}
+
+type Settlement struct {
+	ID             int32
+	MerchantID     int32
+	SettlementDate time.Time
+	Currency       string
+	PaymentCount   int32
+	GrossAmount    float64
+	FeeAmount      float64
+	NetAmount      float64
+	TransactionID  sql.NullInt32
+	CreatedAt      time.Time
+}
+
 type Transaction struct {
 	ID        int32
 	UserID    sql.NullInt32
 	Amount    float64
 	Type      string
 	CreatedAt time.Time
+	Currency  string
+}
+
+type TransactionFee struct {
+	ID                   int32
+	TransactionID        int32
+	FeeRuleID            sql.NullInt32
+	Amount               float64
+	ChargeTransactionID  int32
+	RevenueTransactionID int32
+	CreatedAt            time.Time
+}
+
+type Transfer struct {
+	ID                  int32
+	FromUserID          int32
+	ToUserID            int32
+	Currency            string
+	Amount              float64
+	Note                string
+	IdempotencyKey      sql.NullString
+	DebitTransactionID  int32
+	CreditTransactionID int32
+	CreatedAt           time.Time
 }
 
 type User struct {
 	ID        int32
 	Username  string
 	Password  string
+	CreatedAt time.Time
+	Tier      string
+}
+
+type Wallet struct {
+	ID        int32
+	UserID    int32
+	Currency  string
 	Balance   float64
 	CreatedAt time.Time
 }
+
+type WebhookDelivery struct {
+	ID             int64
+	SubscriptionID int32
+	EventID        uuid.UUID
+	EventType      string
+	Payload        json.RawMessage
+	Status         string
+	Attempts       int32
+	NextAttemptAt  time.Time
+	LastAttemptAt  sql.NullTime
+	ResponseStatus sql.NullInt32
+	LastError      string
+	CreatedAt      time.Time
+}
+
+type WebhookSubscription struct {
+	ID         int32
+	UserID     int32
+	Url        string
+	Secret     string
+	EventTypes []string
+	CreatedAt  time.Time
+}
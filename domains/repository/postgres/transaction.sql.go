@@ -8,23 +8,46 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
 const createTransaction = `-- name: CreateTransaction :one
-INSERT INTO transactions (user_id, amount, type, created_at)
-VALUES ($1, $2, $3, NOW())
+INSERT INTO transactions (user_id, amount, type, currency, created_at)
+VALUES ($1, $2, $3, $4, NOW())
 RETURNING id
 `
 
 type CreateTransactionParams struct {
-	UserID sql.NullInt32
-	Amount float64
-	Type   string
+	UserID   sql.NullInt32
+	Amount   float64
+	Type     string
+	Currency string
 }
 
 func (q *Queries) CreateTransaction(ctx context.Context, arg CreateTransactionParams) (int32, error) {
-	row := q.db.QueryRowContext(ctx, createTransaction, arg.UserID, arg.Amount, arg.Type)
+	row := q.db.QueryRowContext(ctx, createTransaction,
+		arg.UserID,
+		arg.Amount,
+		arg.Type,
+		arg.Currency,
+	)
 	var id int32
 	err := row.Scan(&id)
 	return id, err
 }
+
+const createQuoteRedemption = `-- name: CreateQuoteRedemption :exec
+INSERT INTO quote_redemptions (nonce, user_id, expires_at, created_at)
+VALUES ($1, $2, $3, NOW())
+`
+
+type CreateQuoteRedemptionParams struct {
+	Nonce     string
+	UserID    int32
+	ExpiresAt time.Time
+}
+
+func (q *Queries) CreateQuoteRedemption(ctx context.Context, arg CreateQuoteRedemptionParams) error {
+	_, err := q.db.ExecContext(ctx, createQuoteRedemption, arg.Nonce, arg.UserID, arg.ExpiresAt)
+	return err
+}
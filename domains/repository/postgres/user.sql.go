@@ -27,8 +27,27 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (int32,
 	return id, err
 }
 
+const getUserByID = `-- name: GetUserByID :one
+SELECT id, username, password, created_at, tier
+FROM users
+WHERE id = $1
+`
+
+func (q *Queries) GetUserByID(ctx context.Context, id int32) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByID, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Password,
+		&i.CreatedAt,
+		&i.Tier,
+	)
+	return i, err
+}
+
 const getUserByIDLock = `-- name: GetUserByIDLock :one
-SELECT id, username, password, balance, created_at
+SELECT id, username, password, created_at, tier
 FROM users
 WHERE id = $1
 FOR UPDATE
@@ -41,14 +60,14 @@ func (q *Queries) GetUserByIDLock(ctx context.Context, id int32) (User, error) {
 		&i.ID,
 		&i.Username,
 		&i.Password,
-		&i.Balance,
 		&i.CreatedAt,
+		&i.Tier,
 	)
 	return i, err
 }
 
 const getUserByUsername = `-- name: GetUserByUsername :one
-SELECT id, username, password, balance, created_at
+SELECT id, username, password, created_at, tier
 FROM users
 WHERE username = $1
 `
@@ -60,24 +79,8 @@ func (q *Queries) GetUserByUsername(ctx context.Context, username string) (User,
 		&i.ID,
 		&i.Username,
 		&i.Password,
-		&i.Balance,
 		&i.CreatedAt,
+		&i.Tier,
 	)
 	return i, err
 }
-
-const updateUserBalanceByID = `-- name: UpdateUserBalanceByID :exec
-UPDATE users
-SET balance = $2
-WHERE id = $1
-`
-
-type UpdateUserBalanceByIDParams struct {
-	ID      int32
-	Balance float64
-}
-
-func (q *Queries) UpdateUserBalanceByID(ctx context.Context, arg UpdateUserBalanceByIDParams) error {
-	_, err := q.db.ExecContext(ctx, updateUserBalanceByID, arg.ID, arg.Balance)
-	return err
-}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/repository/stream"
+	"time"
 )
 
 //go:generate mockgen -destination=mocks/mock_repository.go -source=repository.go IRepository,INats,IInternalService
@@ -13,10 +15,164 @@ type IRepository interface {
 
 	// User
 	CreateUser(ctx context.Context, arg postgres.CreateUserParams) (int32, error)
+	GetUserByID(ctx context.Context, id int32) (postgres.User, error)
 	GetUserByIDLock(ctx context.Context, id int32) (postgres.User, error)
 	GetUserByUsername(ctx context.Context, username string) (postgres.User, error)
-	UpdateUserBalanceByID(ctx context.Context, arg postgres.UpdateUserBalanceByIDParams) error
+
+	// Wallet
+	GetCurrency(ctx context.Context, code string) (postgres.Currency, error)
+	ListCurrencies(ctx context.Context) ([]postgres.Currency, error)
+	CreateWallet(ctx context.Context, arg postgres.CreateWalletParams) (postgres.Wallet, error)
+	GetWallet(ctx context.Context, arg postgres.GetWalletParams) (postgres.Wallet, error)
+	GetWalletLock(ctx context.Context, arg postgres.GetWalletLockParams) (postgres.Wallet, error)
+	ListWalletsByUserID(ctx context.Context, userID int32) ([]postgres.Wallet, error)
+	UpdateWalletBalanceByID(ctx context.Context, arg postgres.UpdateWalletBalanceByIDParams) error
+	IncrementWalletBalance(ctx context.Context, arg postgres.IncrementWalletBalanceParams) (int64, error)
 
 	// Transaction
 	CreateTransaction(ctx context.Context, arg postgres.CreateTransactionParams) (int32, error)
+	CreateQuoteRedemption(ctx context.Context, arg postgres.CreateQuoteRedemptionParams) error
+
+	// Audit
+	LockAuditChain(ctx context.Context, pgAdvisoryXactLock int64) error
+	GetLastAuditEvent(ctx context.Context, subjectID int32) (postgres.GetLastAuditEventRow, error)
+	CreateAuditEvent(ctx context.Context, arg postgres.CreateAuditEventParams) (int64, error)
+	ListAuditEventsAfterID(ctx context.Context, arg postgres.ListAuditEventsAfterIDParams) ([]postgres.AuditEvent, error)
+
+	// Webhook
+	CreateWebhookSubscription(ctx context.Context, arg postgres.CreateWebhookSubscriptionParams) (postgres.WebhookSubscription, error)
+	GetWebhookSubscriptionByID(ctx context.Context, id int32) (postgres.WebhookSubscription, error)
+	ListWebhookSubscriptionsByUserID(ctx context.Context, userID int32) ([]postgres.WebhookSubscription, error)
+	DeleteWebhookSubscription(ctx context.Context, arg postgres.DeleteWebhookSubscriptionParams) (int64, error)
+	CreateWebhookDeliveries(ctx context.Context, arg postgres.CreateWebhookDeliveriesParams) (int64, error)
+	ClaimWebhookDeliveries(ctx context.Context, arg postgres.ClaimWebhookDeliveriesParams) ([]postgres.WebhookDelivery, error)
+	GetOldestDueWebhookDelivery(ctx context.Context) (time.Time, error)
+	UpdateWebhookDeliveryAttempt(ctx context.Context, arg postgres.UpdateWebhookDeliveryAttemptParams) error
+	GetWebhookDelivery(ctx context.Context, arg postgres.GetWebhookDeliveryParams) (postgres.WebhookDelivery, error)
+	ListWebhookDeliveries(ctx context.Context, arg postgres.ListWebhookDeliveriesParams) ([]postgres.WebhookDelivery, error)
+	CountWebhookDeliveries(ctx context.Context, arg postgres.CountWebhookDeliveriesParams) (int64, error)
+
+	// Merchant
+	CreateMerchant(ctx context.Context, arg postgres.CreateMerchantParams) (postgres.Merchant, error)
+	GetMerchantByID(ctx context.Context, id int32) (postgres.Merchant, error)
+	CreateMerchantApiKey(ctx context.Context, arg postgres.CreateMerchantApiKeyParams) (postgres.MerchantApiKey, error)
+	GetMerchantApiKeyByKeyID(ctx context.Context, keyID string) (postgres.MerchantApiKey, error)
+	ListMerchantApiKeys(ctx context.Context, merchantID int32) ([]postgres.MerchantApiKey, error)
+	ExpireMerchantApiKeys(ctx context.Context, arg postgres.ExpireMerchantApiKeysParams) (int64, error)
+	ExpireMerchantApiKey(ctx context.Context, arg postgres.ExpireMerchantApiKeyParams) (int64, error)
+
+	// Payment
+	CreatePayment(ctx context.Context, arg postgres.CreatePaymentParams) (postgres.Payment, error)
+	ListCapturedPaymentBatches(ctx context.Context, capturedBefore time.Time) ([]postgres.ListCapturedPaymentBatchesRow, error)
+	SettlePayments(ctx context.Context, arg postgres.SettlePaymentsParams) (postgres.SettlePaymentsRow, error)
+	ListPaymentsBySettlementID(ctx context.Context, settlementID sql.NullInt32) ([]postgres.Payment, error)
+
+	// Settlement
+	CreateSettlement(ctx context.Context, arg postgres.CreateSettlementParams) (postgres.Settlement, error)
+	UpdateSettlementTotals(ctx context.Context, arg postgres.UpdateSettlementTotalsParams) (postgres.Settlement, error)
+	GetSettlement(ctx context.Context, arg postgres.GetSettlementParams) (postgres.Settlement, error)
+	ListSettlementsByMerchantID(ctx context.Context, arg postgres.ListSettlementsByMerchantIDParams) ([]postgres.Settlement, error)
+	CountSettlementsByMerchantID(ctx context.Context, merchantID int32) (int64, error)
+
+	// Fee
+	CreateFeeRule(ctx context.Context, arg postgres.CreateFeeRuleParams) (postgres.FeeRule, error)
+	DeactivateFeeRule(ctx context.Context, id int32) (int64, error)
+	DeactivateFeeRulesByKey(ctx context.Context, arg postgres.DeactivateFeeRulesByKeyParams) error
+	GetApplicableFeeRule(ctx context.Context, arg postgres.GetApplicableFeeRuleParams) (postgres.FeeRule, error)
+	ListFeeRules(ctx context.Context, activeOnly bool) ([]postgres.FeeRule, error)
+	CreateTransactionFee(ctx context.Context, arg postgres.CreateTransactionFeeParams) error
+
+	// Exchange
+	CreateExchange(ctx context.Context, arg postgres.CreateExchangeParams) (postgres.Exchange, error)
+
+	// Pocket
+	CreatePocket(ctx context.Context, arg postgres.CreatePocketParams) (postgres.Pocket, error)
+	GetPocket(ctx context.Context, arg postgres.GetPocketParams) (postgres.Pocket, error)
+	GetPocketLock(ctx context.Context, arg postgres.GetPocketLockParams) (postgres.Pocket, error)
+	GetDefaultPocketLock(ctx context.Context, arg postgres.GetDefaultPocketLockParams) (postgres.Pocket, error)
+	ListPocketsByUserID(ctx context.Context, userID int32) ([]postgres.Pocket, error)
+	SumPocketBalances(ctx context.Context, arg postgres.SumPocketBalancesParams) (float64, error)
+	UpdatePocketBalance(ctx context.Context, arg postgres.UpdatePocketBalanceParams) error
+	ClearDefaultPocket(ctx context.Context, arg postgres.ClearDefaultPocketParams) error
+	SetDefaultPocket(ctx context.Context, id int32) error
+	CreatePocketEntry(ctx context.Context, arg postgres.CreatePocketEntryParams) error
+	ListPocketEntries(ctx context.Context, arg postgres.ListPocketEntriesParams) ([]postgres.PocketEntry, error)
+	CountPocketEntries(ctx context.Context, pocketID int32) (int64, error)
+
+	// Savings
+	CreateSavingsGoal(ctx context.Context, arg postgres.CreateSavingsGoalParams) (postgres.SavingsGoal, error)
+	GetSavingsGoal(ctx context.Context, arg postgres.GetSavingsGoalParams) (postgres.SavingsGoal, error)
+	ListSavingsGoalsByUserID(ctx context.Context, userID int32) ([]postgres.SavingsGoal, error)
+	ListRoundUpSavingsGoals(ctx context.Context, arg postgres.ListRoundUpSavingsGoalsParams) ([]postgres.SavingsGoal, error)
+	GetDueSavingsGoalLock(ctx context.Context, nextSweepAt sql.NullTime) (postgres.SavingsGoal, error)
+	UpdateSavingsGoalNextSweep(ctx context.Context, arg postgres.UpdateSavingsGoalNextSweepParams) error
+
+	// Transfer
+	CreateTransfer(ctx context.Context, arg postgres.CreateTransferParams) (postgres.Transfer, error)
+	GetTransferByIdempotencyKey(ctx context.Context, idempotencyKey sql.NullString) (postgres.Transfer, error)
+
+	// Schedule
+	CreateScheduledTransfer(ctx context.Context, arg postgres.CreateScheduledTransferParams) (postgres.ScheduledTransfer, error)
+	GetScheduledTransfer(ctx context.Context, arg postgres.GetScheduledTransferParams) (postgres.ScheduledTransfer, error)
+	ListScheduledTransfersByUserID(ctx context.Context, userID int32) ([]postgres.ScheduledTransfer, error)
+	CancelScheduledTransfer(ctx context.Context, arg postgres.CancelScheduledTransferParams) (postgres.ScheduledTransfer, error)
+	ClaimScheduledTransfers(ctx context.Context, arg postgres.ClaimScheduledTransfersParams) ([]postgres.ScheduledTransfer, error)
+	UpdateScheduledTransferState(ctx context.Context, arg postgres.UpdateScheduledTransferStateParams) error
+	CreateScheduledTransferRun(ctx context.Context, arg postgres.CreateScheduledTransferRunParams) error
+	ListScheduledTransferRuns(ctx context.Context, arg postgres.ListScheduledTransferRunsParams) ([]postgres.ScheduledTransferRun, error)
+	CountScheduledTransferRuns(ctx context.Context, scheduledTransferID int32) (int64, error)
+
+	// Payment request
+	CreatePaymentRequest(ctx context.Context, arg postgres.CreatePaymentRequestParams) (postgres.PaymentRequest, error)
+	GetPaymentRequest(ctx context.Context, arg postgres.GetPaymentRequestParams) (postgres.PaymentRequest, error)
+	ListPaymentRequests(ctx context.Context, arg postgres.ListPaymentRequestsParams) ([]postgres.PaymentRequest, error)
+	CountPaymentRequests(ctx context.Context, arg postgres.CountPaymentRequestsParams) (int64, error)
+	PayPaymentRequest(ctx context.Context, arg postgres.PayPaymentRequestParams) (postgres.PaymentRequest, error)
+	DeclinePaymentRequest(ctx context.Context, arg postgres.DeclinePaymentRequestParams) (postgres.PaymentRequest, error)
+	CancelPaymentRequest(ctx context.Context, arg postgres.CancelPaymentRequestParams) (postgres.PaymentRequest, error)
+	ExpirePaymentRequests(ctx context.Context, now time.Time) ([]postgres.PaymentRequest, error)
+
+	// Bill
+	CreateBill(ctx context.Context, arg postgres.CreateBillParams) (postgres.Bill, error)
+	CreateBillShare(ctx context.Context, arg postgres.CreateBillShareParams) (postgres.BillShare, error)
+	GetBill(ctx context.Context, arg postgres.GetBillParams) (postgres.Bill, error)
+	ListBillsByCreatorID(ctx context.Context, arg postgres.ListBillsByCreatorIDParams) ([]postgres.Bill, error)
+	CountBillsByCreatorID(ctx context.Context, creatorID int32) (int64, error)
+	ListBillShares(ctx context.Context, billID int32) ([]postgres.BillShare, error)
+	ListBillSharesByUserID(ctx context.Context, arg postgres.ListBillSharesByUserIDParams) ([]postgres.ListBillSharesByUserIDRow, error)
+	CountBillSharesByUserID(ctx context.Context, arg postgres.CountBillSharesByUserIDParams) (int64, error)
+	PayBillShare(ctx context.Context, arg postgres.PayBillShareParams) (postgres.BillShare, error)
+	RecordBillPayment(ctx context.Context, arg postgres.RecordBillPaymentParams) (postgres.Bill, error)
+	RemindBillShares(ctx context.Context, arg postgres.RemindBillSharesParams) ([]postgres.BillShare, error)
+}
+
+// IUserCache keeps a read-through copy of the user profile and balance, the wallets
+// and pockets of the user. Every writer of a balance drops it once committed.
+// Password hashes are never stored in the cache.
+type IUserCache interface {
+	GetUser(ctx context.Context, id int32) (*postgres.User, error)
+	SetUser(ctx context.Context, user postgres.User) error
+	GetBalances(ctx context.Context, userID int32) ([]postgres.Wallet, []postgres.Pocket, error)
+	SetBalances(ctx context.Context, userID int32, wallets []postgres.Wallet, pockets []postgres.Pocket) error
+	DeleteBalances(ctx context.Context, userIDs ...int32) error
+}
+
+// IEventStream fans user events out to every instance and keeps the
+// recent ones so a reconnecting client can resume from its Last-Event-ID
+type IEventStream interface {
+	Publish(ctx context.Context, event stream.Event) (stream.Event, error)
+	Since(ctx context.Context, userID int32, lastEventID string) ([]stream.Event, error)
+	Listen(ctx context.Context, handle func(stream.Event)) error
+}
+
+// INonceCache remembers the nonces of signed requests, Claim reports false
+// when the nonce was already seen within ttl
+type INonceCache interface {
+	Claim(ctx context.Context, key string, ttl time.Duration) (bool, error)
+}
+
+// IRateSource gives the mid-market rate of a currency pair, the units of to
+// bought by one unit of from
+type IRateSource interface {
+	GetRate(ctx context.Context, from, to string) (float64, error)
 }
@@ -0,0 +1,117 @@
+package stream
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventBalanceChanged     EventType = "BalanceChanged"
+	EventTransactionCreated EventType = "TransactionCreated"
+	EventBillReminder       EventType = "BillReminder"
+)
+
+// Event is pushed to the streams of UserID, ID is the redis stream id
+// so events of one user are ordered and resumable with Last-Event-ID
+type Event struct {
+	ID        string          `json:"id"`
+	Type      EventType       `json:"type"`
+	UserID    int32           `json:"user_id"`
+	Data      json.RawMessage `json:"data"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type BalanceChangedData struct {
+	Currency        string  `json:"currency"`
+	Balance         float64 `json:"balance"`
+	PreviousBalance float64 `json:"previous_balance"`
+	TransactionID   int32   `json:"transaction_id"`
+}
+
+type TransactionCreatedData struct {
+	TransactionID int32   `json:"transaction_id"`
+	Type          string  `json:"type"`
+	Currency      string  `json:"currency"`
+	Amount        float64 `json:"amount"`
+}
+
+// BillReminderData reminds a participant of its unpaid share of a bill
+type BillReminderData struct {
+	BillID    int32   `json:"bill_id"`
+	CreatorID int32   `json:"creator_id"`
+	Title     string  `json:"title"`
+	Currency  string  `json:"currency"`
+	Amount    float64 `json:"amount"`
+}
+
+// NewEvent builds an unpublished event, its ID is assigned by Publish
+func NewEvent(eventType EventType, userID int32, data interface{}) (Event, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, err
+	}
+
+	return Event{
+		Type:      eventType,
+		UserID:    userID,
+		Data:      raw,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// CompareIDs orders two stream ids ("<ms>-<seq>"), an invalid id sorts first
+func CompareIDs(a, b string) int {
+	aMs, aSeq, aOk := parseID(a)
+	bMs, bSeq, bOk := parseID(b)
+
+	switch {
+	case !aOk && !bOk:
+		return 0
+	case !aOk:
+		return -1
+	case !bOk:
+		return 1
+	case aMs != bMs:
+		return compareUint(aMs, bMs)
+	default:
+		return compareUint(aSeq, bSeq)
+	}
+}
+
+// ValidID reports whether id is a stream id, Last-Event-ID comes from the client
+func ValidID(id string) bool {
+	_, _, ok := parseID(id)
+	return ok
+}
+
+func parseID(id string) (ms, seq uint64, ok bool) {
+	msPart, seqPart, found := strings.Cut(id, "-")
+	if !found {
+		return 0, 0, false
+	}
+
+	ms, err := strconv.ParseUint(msPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	seq, err = strconv.ParseUint(seqPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return ms, seq, true
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,176 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const (
+	// userStreamKey keeps the recent events of a user for replay
+	userStreamKey = "user-events:%d"
+	// userChannel fans the events out to every instance
+	userChannel        = "user-events:%d"
+	userChannelPattern = "user-events:*"
+)
+
+type redisEventStream struct {
+	pool       *redis.Pool
+	replaySize int
+	tracer     trace.Tracer
+}
+
+// NewRedisEventStream stores events in a capped redis stream per user and
+// publishes them on a pub/sub channel per user
+func NewRedisEventStream(pool *redis.Pool, replaySize int, tracer trace.Tracer) *redisEventStream {
+	if tracer == nil {
+		tracer = noop.NewTracerProvider().Tracer("")
+	}
+
+	return &redisEventStream{
+		pool:       pool,
+		replaySize: replaySize,
+		tracer:     tracer,
+	}
+}
+
+// Publish appends the event to the user stream, which assigns its ID, then publishes it
+func (s *redisEventStream) Publish(ctx context.Context, event Event) (Event, error) {
+	key := fmt.Sprintf(userStreamKey, event.UserID)
+	_, span := s.startSpan(ctx, "XADD", key)
+	defer span.End()
+
+	conn, err := s.pool.GetContext(ctx)
+	if err != nil {
+		return event, s.fail(span, err)
+	}
+	defer conn.Close()
+
+	event.ID, err = redis.String(conn.Do("XADD", key, "MAXLEN", "~", s.replaySize, "*",
+		"type", string(event.Type),
+		"data", []byte(event.Data),
+		"created_at", event.CreatedAt.Format(time.RFC3339Nano),
+	))
+	if err != nil {
+		return event, s.fail(span, err)
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return event, s.fail(span, err)
+	}
+	if _, err := conn.Do("PUBLISH", fmt.Sprintf(userChannel, event.UserID), payload); err != nil {
+		return event, s.fail(span, err)
+	}
+
+	return event, nil
+}
+
+// Since returns the kept events of the user after lastEventID, oldest first
+func (s *redisEventStream) Since(ctx context.Context, userID int32, lastEventID string) ([]Event, error) {
+	key := fmt.Sprintf(userStreamKey, userID)
+	_, span := s.startSpan(ctx, "XRANGE", key)
+	defer span.End()
+
+	conn, err := s.pool.GetContext(ctx)
+	if err != nil {
+		return nil, s.fail(span, err)
+	}
+	defer conn.Close()
+
+	entries, err := redis.Values(conn.Do("XRANGE", key, "("+lastEventID, "+"))
+	if err != nil {
+		return nil, s.fail(span, err)
+	}
+
+	events := make([]Event, 0, len(entries))
+	for _, entry := range entries {
+		event, err := parseEntry(userID, entry)
+		if err != nil {
+			return nil, s.fail(span, err)
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// Listen calls handle for the events published by every instance until ctx is done
+// or the subscription connection fails, callers are expected to listen again
+func (s *redisEventStream) Listen(ctx context.Context, handle func(Event)) error {
+	conn, err := s.pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	psc := redis.PubSubConn{Conn: conn}
+	if err := psc.PSubscribe(userChannelPattern); err != nil {
+		return err
+	}
+	defer psc.PUnsubscribe()
+
+	for {
+		switch message := psc.ReceiveContext(ctx).(type) {
+		case redis.Message:
+			var event Event
+			if err := json.Unmarshal(message.Data, &event); err != nil {
+				continue
+			}
+			handle(event)
+		case error:
+			if ctx.Err() != nil {
+				return nil
+			}
+			return message
+		}
+	}
+}
+
+func parseEntry(userID int32, entry interface{}) (Event, error) {
+	values, err := redis.Values(entry, nil)
+	if err != nil || len(values) != 2 {
+		return Event{}, fmt.Errorf("unexpected stream entry: %v", entry)
+	}
+
+	id, err := redis.String(values[0], nil)
+	if err != nil {
+		return Event{}, err
+	}
+	fields, err := redis.StringMap(values[1], nil)
+	if err != nil {
+		return Event{}, err
+	}
+
+	createdAt, _ := time.Parse(time.RFC3339Nano, fields["created_at"])
+	return Event{
+		ID:        id,
+		Type:      EventType(fields["type"]),
+		UserID:    userID,
+		Data:      json.RawMessage(fields["data"]),
+		CreatedAt: createdAt,
+	}, nil
+}
+
+func (s *redisEventStream) startSpan(ctx context.Context, command, key string) (context.Context, trace.Span) {
+	return s.tracer.Start(ctx, "redis."+command,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemNameRedis,
+			semconv.DBOperationName(command),
+			semconv.DBQueryText(command+" "+key),
+		),
+	)
+}
+
+func (s *redisEventStream) fail(span trace.Span, err error) error {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	return err
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"database/sql"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/internals/database"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type tracedRepository struct {
+	*postgres.Queries
+	tracer trace.Tracer
+}
+
+// NewTracedRepository returns the sqlc queries with every statement traced,
+// including the ones executed through WithTx
+func NewTracedRepository(db postgres.DBTX, tracer trace.Tracer) *tracedRepository {
+	return &tracedRepository{
+		Queries: postgres.New(database.NewTracedDBTX(db, tracer)),
+		tracer:  tracer,
+	}
+}
+
+func (r *tracedRepository) WithTx(tx *sql.Tx) *postgres.Queries {
+	return postgres.New(database.NewTracedDBTX(tx, r.tracer))
+}
@@ -0,0 +1,223 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	goerrors "errors"
+	"fmt"
+	"kc-ewallet/domains/repository"
+	"kc-ewallet/domains/repository/postgres"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// chainLockNamespace is the high half of the advisory lock serializing the appends to a
+// chain, the low half is the subject, so every event links to the one before it in its
+// chain and appends about different subjects don't wait for each other
+const chainLockNamespace int64 = 0x61756474 // "audt"
+
+// verifyBatchSize bounds the rows held in memory while walking the chain
+const verifyBatchSize int32 = 500
+
+type VerifyResult struct {
+	Valid bool `json:"valid"`
+	// Checked is the number of events walked before the first broken link
+	Checked int64 `json:"checked"`
+	// Heads are the last hash of every chain by subject id, 0 for the events without
+	// subject. Store them elsewhere to detect truncation
+	Heads      map[int32]string `json:"heads"`
+	BrokenAtID int64            `json:"broken_at_id,omitempty"`
+	Reason     string           `json:"reason,omitempty"`
+}
+
+type auditUsecase struct {
+	db         *sql.DB
+	repository repository.IRepository
+	trace      trace.Tracer
+}
+
+func NewAuditUsecase(db *sql.DB, repository repository.IRepository, tracer trace.Tracer) *auditUsecase {
+	if tracer == nil {
+		tracer = noop.NewTracerProvider().Tracer("")
+	}
+
+	return &auditUsecase{
+		db:         db,
+		repository: repository,
+		trace:      tracer,
+	}
+}
+
+// Record appends the event in its own database transaction
+func (a *auditUsecase) Record(ctx context.Context, event Event) (err error) {
+	if a.db == nil {
+		return a.RecordTx(ctx, nil, event)
+	}
+
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin audit transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	return a.RecordTx(ctx, tx, event)
+}
+
+// RecordTx appends the event to the chain of its subject inside the caller transaction,
+// so a money movement and its audit row commit or roll back together
+func (a *auditUsecase) RecordTx(ctx context.Context, tx *sql.Tx, event Event) error {
+	ctx, span := a.trace.Start(ctx, "auditUsecase.Record", trace.WithAttributes(
+		attribute.String("event_type", string(event.Type)),
+	))
+	defer span.End()
+
+	query := a.repository
+	if tx != nil {
+		query = a.repository.WithTx(tx)
+	}
+
+	params, err := newAuditEventParams(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	// held until the transaction ends, concurrent appends to the chain wait for its head
+	if err := query.LockAuditChain(ctx, chainLockKey(event.SubjectID)); err != nil {
+		return fmt.Errorf("failed to lock audit chain: %w", err)
+	}
+
+	last, err := query.GetLastAuditEvent(ctx, event.SubjectID)
+	switch {
+	case goerrors.Is(err, sql.ErrNoRows):
+		params.PrevHash = GenesisHash
+	case err != nil:
+		return fmt.Errorf("failed to get last audit event: %w", err)
+	default:
+		params.PrevHash = last.Hash
+	}
+
+	params.Hash, err = ComputeHash(postgres.AuditEvent{
+		EventType:   params.EventType,
+		ActorID:     params.ActorID,
+		ActorRole:   params.ActorRole,
+		SubjectID:   params.SubjectID,
+		Ip:          params.Ip,
+		UserAgent:   params.UserAgent,
+		RequestID:   params.RequestID,
+		BeforeValue: params.BeforeValue,
+		AfterValue:  params.AfterValue,
+		Metadata:    params.Metadata,
+		PrevHash:    params.PrevHash,
+		CreatedAt:   params.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to hash audit event: %w", err)
+	}
+
+	if _, err := query.CreateAuditEvent(ctx, params); err != nil {
+		return fmt.Errorf("failed to create audit event: %w", err)
+	}
+
+	return nil
+}
+
+// Verify walks every chain in id order and stops at the first event whose link or hash
+// doesn't match
+func (a *auditUsecase) Verify(ctx context.Context) (VerifyResult, error) {
+	ctx, span := a.trace.Start(ctx, "auditUsecase.Verify")
+	defer span.End()
+
+	result := VerifyResult{Valid: true, Heads: map[int32]string{}}
+
+	var afterID int64
+	for {
+		events, err := a.repository.ListAuditEventsAfterID(ctx, postgres.ListAuditEventsAfterIDParams{
+			ID:    afterID,
+			Limit: verifyBatchSize,
+		})
+		if err != nil {
+			return result, fmt.Errorf("failed to list audit events: %w", err)
+		}
+
+		for _, event := range events {
+			head, ok := result.Heads[event.SubjectID.Int32]
+			if !ok {
+				head = GenesisHash
+			}
+			if event.PrevHash != head {
+				return broken(result, event.ID, "prev_hash doesn't match the previous event of the subject, a row was removed or reordered"), nil
+			}
+
+			hash, err := ComputeHash(event)
+			if err != nil {
+				return broken(result, event.ID, fmt.Sprintf("failed to hash event: %v", err)), nil
+			}
+			if hash != event.Hash {
+				return broken(result, event.ID, "hash doesn't match the event content, the row was modified"), nil
+			}
+
+			result.Heads[event.SubjectID.Int32] = event.Hash
+			result.Checked++
+			afterID = event.ID
+		}
+
+		if int32(len(events)) < verifyBatchSize {
+			return result, nil
+		}
+	}
+}
+
+func chainLockKey(subjectID int32) int64 {
+	return chainLockNamespace<<32 | int64(uint32(subjectID))
+}
+
+func broken(result VerifyResult, id int64, reason string) VerifyResult {
+	result.Valid = false
+	result.BrokenAtID = id
+	result.Reason = reason
+	return result
+}
+
+func newAuditEventParams(ctx context.Context, event Event) (postgres.CreateAuditEventParams, error) {
+	var (
+		client = clientFromContext(ctx)
+		params = postgres.CreateAuditEventParams{
+			EventType: string(event.Type),
+			Ip:        client.IP,
+			UserAgent: client.UserAgent,
+			RequestID: requestIDFromContext(ctx),
+			// postgres TIMESTAMP keeps microseconds, truncate so the hash survives a round trip
+			CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+		}
+		err error
+	)
+
+	if actor, ok := actorFromContext(ctx); ok {
+		params.ActorID = sql.NullInt32{Int32: actor.UserID, Valid: true}
+		params.ActorRole = actor.Role
+	}
+	if event.SubjectID != 0 {
+		params.SubjectID = sql.NullInt32{Int32: event.SubjectID, Valid: true}
+	}
+
+	if params.BeforeValue, err = marshalValue(event.Before); err != nil {
+		return params, fmt.Errorf("failed to marshal audit before value: %w", err)
+	}
+	if params.AfterValue, err = marshalValue(event.After); err != nil {
+		return params, fmt.Errorf("failed to marshal audit after value: %w", err)
+	}
+	if params.Metadata, err = marshalValue(event.Metadata); err != nil {
+		return params, fmt.Errorf("failed to marshal audit metadata: %w", err)
+	}
+
+	return params, nil
+}
@@ -0,0 +1,216 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	mock_repository "kc-ewallet/domains/repository/mocks"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/internals/helpers/logging"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditUsecase_Record(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mock_repository.NewMockIRepository(ctrl)
+
+	usecase := NewAuditUsecase(nil, mockRepo, nil)
+
+	ctx := logging.WithRequestID(context.Background(), "request-1")
+	ctx = WithActor(ctx, Actor{UserID: 7, Role: "customer"})
+	ctx = WithClient(ctx, Client{IP: "10.0.0.1", UserAgent: "curl"})
+
+	var created postgres.CreateAuditEventParams
+	gomock.InOrder(
+		mockRepo.EXPECT().LockAuditChain(gomock.Any(), chainLockKey(7)).Return(nil),
+		mockRepo.EXPECT().GetLastAuditEvent(gomock.Any(), int32(7)).Return(postgres.GetLastAuditEventRow{ID: 1, Hash: "previous"}, nil),
+		mockRepo.EXPECT().CreateAuditEvent(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, arg postgres.CreateAuditEventParams) (int64, error) {
+				created = arg
+				return 2, nil
+			}),
+	)
+
+	err := usecase.Record(ctx, Event{
+		Type:      EventTransactionDebit,
+		SubjectID: 7,
+		Before:    map[string]interface{}{"balance": 100},
+		After:     map[string]interface{}{"balance": 40},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "previous", created.PrevHash)
+	assert.Equal(t, sql.NullInt32{Int32: 7, Valid: true}, created.ActorID)
+	assert.Equal(t, "customer", created.ActorRole)
+	assert.Equal(t, "10.0.0.1", created.Ip)
+	assert.Equal(t, "request-1", created.RequestID)
+	assert.JSONEq(t, `{}`, string(created.Metadata))
+
+	expectedHash, _ := ComputeHash(postgres.AuditEvent{
+		EventType:   created.EventType,
+		ActorID:     created.ActorID,
+		ActorRole:   created.ActorRole,
+		SubjectID:   created.SubjectID,
+		Ip:          created.Ip,
+		UserAgent:   created.UserAgent,
+		RequestID:   created.RequestID,
+		BeforeValue: created.BeforeValue,
+		AfterValue:  created.AfterValue,
+		Metadata:    created.Metadata,
+		PrevHash:    created.PrevHash,
+		CreatedAt:   created.CreatedAt,
+	})
+	assert.Equal(t, expectedHash, created.Hash)
+}
+
+func TestAuditUsecase_Record_Genesis(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mock_repository.NewMockIRepository(ctrl)
+
+	usecase := NewAuditUsecase(nil, mockRepo, nil)
+
+	mockRepo.EXPECT().LockAuditChain(gomock.Any(), chainLockKey(0)).Return(nil)
+	mockRepo.EXPECT().GetLastAuditEvent(gomock.Any(), int32(0)).Return(postgres.GetLastAuditEventRow{}, sql.ErrNoRows)
+	mockRepo.EXPECT().CreateAuditEvent(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg postgres.CreateAuditEventParams) (int64, error) {
+			assert.Equal(t, GenesisHash, arg.PrevHash)
+			assert.False(t, arg.ActorID.Valid)
+			return 1, nil
+		})
+
+	assert.NoError(t, usecase.Record(context.Background(), Event{Type: EventLoginFailure}))
+}
+
+func TestAuditUsecase_Verify(t *testing.T) {
+	chain := func() []postgres.AuditEvent {
+		prevHash := GenesisHash
+		events := make([]postgres.AuditEvent, 3)
+		for i := range events {
+			events[i] = postgres.AuditEvent{
+				ID:          int64(i + 1),
+				EventType:   string(EventTransactionCredit),
+				SubjectID:   sql.NullInt32{Int32: 7, Valid: true},
+				BeforeValue: json.RawMessage(`{"balance": 0}`),
+				AfterValue:  json.RawMessage(`{"balance": 10}`),
+				Metadata:    json.RawMessage(`{}`),
+				PrevHash:    prevHash,
+				CreatedAt:   time.Date(2026, 10, 19, 9, 0, i, 0, time.UTC),
+			}
+			events[i].Hash, _ = ComputeHash(events[i])
+			prevHash = events[i].Hash
+		}
+		return events
+	}
+
+	testCases := []struct {
+		name             string
+		tamper           func(events []postgres.AuditEvent) []postgres.AuditEvent
+		expectedValid    bool
+		expectedChecked  int64
+		expectedBrokenAt int64
+	}{
+		{
+			name:            "intact chain",
+			tamper:          func(events []postgres.AuditEvent) []postgres.AuditEvent { return events },
+			expectedValid:   true,
+			expectedChecked: 3,
+		},
+		{
+			name: "modified value",
+			tamper: func(events []postgres.AuditEvent) []postgres.AuditEvent {
+				events[1].AfterValue = json.RawMessage(`{"balance": 1000}`)
+				return events
+			},
+			expectedChecked:  1,
+			expectedBrokenAt: 2,
+		},
+		{
+			name: "removed row",
+			tamper: func(events []postgres.AuditEvent) []postgres.AuditEvent {
+				return append(events[:1], events[2:]...)
+			},
+			expectedChecked:  1,
+			expectedBrokenAt: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockIRepository(ctrl)
+
+			usecase := NewAuditUsecase(nil, mockRepo, nil)
+
+			mockRepo.EXPECT().ListAuditEventsAfterID(gomock.Any(), postgres.ListAuditEventsAfterIDParams{
+				ID:    0,
+				Limit: verifyBatchSize,
+			}).Return(tc.tamper(chain()), nil)
+
+			result, err := usecase.Verify(context.Background())
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValid, result.Valid)
+			assert.Equal(t, tc.expectedChecked, result.Checked)
+			assert.Equal(t, tc.expectedBrokenAt, result.BrokenAtID)
+		})
+	}
+}
+
+func TestAuditUsecase_Verify_Subjects(t *testing.T) {
+	// two subjects appending in turn, each event links to the previous one of its subject
+	heads := map[int32]string{}
+	events := make([]postgres.AuditEvent, 4)
+	for i := range events {
+		subjectID := int32(7 + i%2)
+		prevHash, ok := heads[subjectID]
+		if !ok {
+			prevHash = GenesisHash
+		}
+		events[i] = postgres.AuditEvent{
+			ID:          int64(i + 1),
+			EventType:   string(EventTransactionDebit),
+			SubjectID:   sql.NullInt32{Int32: subjectID, Valid: true},
+			BeforeValue: json.RawMessage(`{}`),
+			AfterValue:  json.RawMessage(`{}`),
+			Metadata:    json.RawMessage(`{}`),
+			PrevHash:    prevHash,
+			CreatedAt:   time.Date(2026, 10, 19, 9, 0, i, 0, time.UTC),
+		}
+		events[i].Hash, _ = ComputeHash(events[i])
+		heads[subjectID] = events[i].Hash
+	}
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mock_repository.NewMockIRepository(ctrl)
+	mockRepo.EXPECT().ListAuditEventsAfterID(gomock.Any(), gomock.Any()).Return(events, nil)
+
+	result, err := NewAuditUsecase(nil, mockRepo, nil).Verify(context.Background())
+
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Equal(t, int64(4), result.Checked)
+	assert.Equal(t, heads, result.Heads)
+}
+
+func TestComputeHash_JSONBRoundTrip(t *testing.T) {
+	event := postgres.AuditEvent{
+		EventType:   string(EventLoginSuccess),
+		BeforeValue: json.RawMessage(`{"b":1,"a":"x"}`),
+		PrevHash:    GenesisHash,
+		CreatedAt:   time.Date(2026, 10, 19, 9, 0, 0, 123456000, time.UTC),
+	}
+	written, err := ComputeHash(event)
+	assert.NoError(t, err)
+
+	// jsonb reorders keys and adds whitespace, lib/pq reads TIMESTAMP back in a fixed zone
+	event.BeforeValue = json.RawMessage(`{"a": "x", "b": 1}`)
+	event.CreatedAt = event.CreatedAt.In(time.FixedZone("", 0))
+	read, err := ComputeHash(event)
+	assert.NoError(t, err)
+
+	assert.Equal(t, written, read)
+}
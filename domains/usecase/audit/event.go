@@ -0,0 +1,96 @@
+package audit
+
+import (
+	"context"
+	"kc-ewallet/internals/helpers/logging"
+)
+
+type EventType string
+
+const (
+	EventLoginSuccess            EventType = "login.success"
+	EventLoginFailure            EventType = "login.failure"
+	EventPasswordChanged         EventType = "credential.password_changed"
+	EventPinChanged              EventType = "credential.pin_changed"
+	EventTwoFactorChanged        EventType = "credential.two_factor_changed"
+	EventTransactionCredit       EventType = "transaction.credit"
+	EventTransactionDebit        EventType = "transaction.debit"
+	EventAdminLogLevelChanged    EventType = "admin.log_level_changed"
+	EventRateLimitMarked         EventType = "rate_limit.marked"
+	EventWebhookCreated          EventType = "webhook.created"
+	EventWebhookDeleted          EventType = "webhook.deleted"
+	EventMerchantCreated         EventType = "merchant.created"
+	EventMerchantKeyRotated      EventType = "merchant.key_rotated"
+	EventMerchantKeyRevoked      EventType = "merchant.key_revoked"
+	EventPaymentCaptured         EventType = "payment.captured"
+	EventSettlementCreated       EventType = "settlement.created"
+	EventFeeRuleCreated          EventType = "fee.rule_created"
+	EventFeeRuleDeactivated      EventType = "fee.rule_deactivated"
+	EventWalletOpened            EventType = "wallet.opened"
+	EventTransactionExchange     EventType = "transaction.exchange"
+	EventTransactionTransfer     EventType = "transaction.transfer"
+	EventPocketCreated           EventType = "pocket.created"
+	EventPocketMoneyMoved        EventType = "pocket.money_moved"
+	EventPocketDefaultChanged    EventType = "pocket.default_changed"
+	EventSavingsGoalCreated      EventType = "savings.goal_created"
+	EventSavingsSwept            EventType = "savings.swept"
+	EventScheduleCreated         EventType = "schedule.created"
+	EventScheduleCancelled       EventType = "schedule.cancelled"
+	EventPaymentRequestCreated   EventType = "payment_request.created"
+	EventPaymentRequestDeclined  EventType = "payment_request.declined"
+	EventPaymentRequestCancelled EventType = "payment_request.cancelled"
+	EventPaymentRequestExpired   EventType = "payment_request.expired"
+	EventBillCreated             EventType = "bill.created"
+	EventBillReminded            EventType = "bill.reminded"
+)
+
+// Event is what callers record, actor, client and request id are read from the context
+type Event struct {
+	Type EventType
+	// SubjectID is the user the event is about, zero when unknown e.g. a failed login
+	SubjectID int32
+	Before    map[string]interface{}
+	After     map[string]interface{}
+	Metadata  map[string]interface{}
+}
+
+// Actor is the authenticated caller, copied from middleware.Actor.
+// For a merchant UserID is the merchant id, told apart by the role
+type Actor struct {
+	UserID int32
+	Role   string
+}
+
+// Client is where the request came from
+type Client struct {
+	IP        string
+	UserAgent string
+}
+
+type actorKey struct{}
+
+type clientKey struct{}
+
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func WithClient(ctx context.Context, client Client) context.Context {
+	return context.WithValue(ctx, clientKey{}, client)
+}
+
+func actorFromContext(ctx context.Context) (Actor, bool) {
+	actor, ok := ctx.Value(actorKey{}).(Actor)
+	return actor, ok
+}
+
+func clientFromContext(ctx context.Context) Client {
+	client, _ := ctx.Value(clientKey{}).(Client)
+	return client
+}
+
+// requestIDFromContext doesn't fall back to a random id, an empty one is more honest in an audit row
+func requestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(logging.RequestIDKey{}).(string)
+	return requestID
+}
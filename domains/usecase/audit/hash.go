@@ -0,0 +1,85 @@
+package audit
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"kc-ewallet/domains/repository/postgres"
+	"strings"
+	"time"
+)
+
+// GenesisHash is the prev_hash of the first event in the chain
+var GenesisHash = strings.Repeat("0", sha256.Size*2)
+
+// ComputeHash hashes the previous hash together with every column of the event
+// except id and hash, so changing any stored value breaks the chain from that row
+func ComputeHash(event postgres.AuditEvent) (string, error) {
+	var actorID, subjectID interface{}
+	if event.ActorID.Valid {
+		actorID = event.ActorID.Int32
+	}
+	if event.SubjectID.Valid {
+		subjectID = event.SubjectID.Int32
+	}
+
+	before, err := canonicalJSON(event.BeforeValue)
+	if err != nil {
+		return "", err
+	}
+	after, err := canonicalJSON(event.AfterValue)
+	if err != nil {
+		return "", err
+	}
+	metadata, err := canonicalJSON(event.Metadata)
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal([]interface{}{
+		event.PrevHash,
+		event.EventType,
+		actorID,
+		event.ActorRole,
+		subjectID,
+		event.Ip,
+		event.UserAgent,
+		event.RequestID,
+		before,
+		after,
+		metadata,
+		event.CreatedAt.UTC().Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// canonicalJSON re-encodes value with sorted keys and no whitespace,
+// postgres jsonb reformats documents so the stored bytes can't be hashed as is
+func canonicalJSON(value json.RawMessage) (json.RawMessage, error) {
+	if len(value) == 0 {
+		return json.RawMessage("{}"), nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.UseNumber()
+
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(decoded)
+}
+
+func marshalValue(value map[string]interface{}) (json.RawMessage, error) {
+	if value == nil {
+		return json.RawMessage("{}"), nil
+	}
+	return json.Marshal(value)
+}
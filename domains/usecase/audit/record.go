@@ -0,0 +1,25 @@
+package audit
+
+import (
+	"context"
+	"kc-ewallet/internals/helpers/logging"
+
+	"go.uber.org/zap"
+)
+
+// Recorder is the part of the audit usecase RecordOrLog needs
+type Recorder interface {
+	Record(ctx context.Context, event Event) error
+}
+
+// RecordOrLog never fails the request, a missing row is logged instead.
+// A nil recorder records nothing
+func RecordOrLog(ctx context.Context, recorder Recorder, event Event) {
+	if recorder == nil {
+		return
+	}
+
+	if err := recorder.Record(ctx, event); err != nil {
+		logging.NewFromContext(ctx).Error("failed to record audit event", zap.String("event_type", string(event.Type)), zap.Error(err))
+	}
+}
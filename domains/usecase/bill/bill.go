@@ -0,0 +1,421 @@
+package bill
+
+import (
+	"context"
+	"database/sql"
+	goerrors "errors"
+	"fmt"
+	"kc-ewallet/configurations"
+	"kc-ewallet/domains/repository"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/repository/stream"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/domains/usecase/audit"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/internals/helpers/logging"
+	"kc-ewallet/internals/helpers/money"
+	"kc-ewallet/protocols/http/request"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+const (
+	StatusOpen    = "open"
+	StatusSettled = "settled"
+)
+
+const (
+	SharePending = "pending"
+	SharePaid    = "paid"
+)
+
+const (
+	SplitEqual  = "equal"
+	SplitCustom = "custom"
+)
+
+type billUsecase struct {
+	db         *sql.DB
+	repository repository.IRepository
+	transfer   usecase.ITransferUsecase
+	events     repository.IEventStream
+	audit      usecase.IAuditUsecase
+	trace      trace.Tracer
+
+	maxParticipants  int
+	reminderInterval time.Duration
+}
+
+// NewBillUsecase keeps the bills and settles their shares through the transfer usecase,
+// reminders go out on the event streams of the participants
+func NewBillUsecase(
+	db *sql.DB,
+	repository repository.IRepository,
+	config configurations.IBillConfiguration,
+	transferUsecase usecase.ITransferUsecase,
+	eventStream repository.IEventStream,
+	auditUsecase usecase.IAuditUsecase,
+	trace trace.Tracer,
+) *billUsecase {
+	if trace == nil {
+		trace = noop.NewTracerProvider().Tracer("")
+	}
+
+	return &billUsecase{
+		db:               db,
+		repository:       repository,
+		transfer:         transferUsecase,
+		events:           eventStream,
+		audit:            auditUsecase,
+		trace:            trace,
+		maxParticipants:  config.GetMaxParticipants(),
+		reminderInterval: config.GetReminderInterval(),
+	}
+}
+
+// CreateBill splits the total into the shares the participants owe the user
+func (b *billUsecase) CreateBill(ctx context.Context, request request.CreateBillRequest) (usecase.BillDetail, error) {
+	ctx, span := b.trace.Start(ctx, "billUsecase.CreateBill", trace.WithAttributes(
+		attribute.Int("user_id", int(request.UserID)),
+		attribute.String("currency", request.Currency),
+		attribute.Float64("total", request.Total),
+		attribute.String("split", request.Split),
+		attribute.Int("participants", len(request.Participants)),
+	))
+	defer span.End()
+
+	if len(request.Participants) > b.maxParticipants {
+		return usecase.BillDetail{}, errors.BadRequest.NewWithUserMsg(nil, fmt.Sprintf("a bill can't have more than %d participants", b.maxParticipants))
+	}
+	seen := make(map[int32]bool, len(request.Participants))
+	for _, participant := range request.Participants {
+		if participant.UserID == request.UserID {
+			return usecase.BillDetail{}, errors.BadRequest.NewWithUserMsg(nil, "the creator can't be a participant")
+		}
+		if seen[participant.UserID] {
+			return usecase.BillDetail{}, errors.BadRequest.NewWithUserMsg(nil, "a participant is listed twice")
+		}
+		seen[participant.UserID] = true
+	}
+
+	currency, err := b.repository.GetCurrency(ctx, request.Currency)
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return usecase.BillDetail{}, errors.WithCode(errors.BadRequest.NewWithUserMsg(err, "currency is not supported"), errors.CodeUnsupportedCurrency)
+		}
+		logging.NewFromContext(ctx).Error("CreateBill failed to get currency", zap.Error(err))
+		return usecase.BillDetail{}, errors.InternalServer.NewWithUserMsg(err, "failed to create bill")
+	}
+	if !money.HasPrecision(request.Total, currency.MinorUnits) {
+		return usecase.BillDetail{}, errors.WithCode(errors.BadRequest.NewWithUserMsg(nil, "total has too many decimals"), errors.CodeInvalidAmountPrecision)
+	}
+
+	amounts, err := sharesOf(request, currency)
+	if err != nil {
+		return usecase.BillDetail{}, err
+	}
+
+	for _, participant := range request.Participants {
+		if _, err := b.repository.GetUserByID(ctx, participant.UserID); err != nil {
+			if goerrors.Is(err, sql.ErrNoRows) {
+				return usecase.BillDetail{}, errors.WithCode(errors.NotFound.NewWithUserMsg(err, "participant not found"), errors.CodeUserNotFound)
+			}
+			logging.NewFromContext(ctx).Error("CreateBill failed to get participant", zap.Error(err))
+			return usecase.BillDetail{}, errors.InternalServer.NewWithUserMsg(err, "failed to create bill")
+		}
+	}
+
+	var outstanding float64
+	for _, amount := range amounts {
+		outstanding += amount
+	}
+
+	var detail usecase.BillDetail
+	if err := usecase.InTx(ctx, b.db, b.repository, func(query repository.IRepository) error {
+		bill, err := query.CreateBill(ctx, postgres.CreateBillParams{
+			CreatorID:         request.UserID,
+			Title:             strings.TrimSpace(request.Title),
+			Currency:          currency.Code,
+			Total:             request.Total,
+			Split:             request.Split,
+			OutstandingAmount: money.Round(outstanding, currency.MinorUnits),
+		})
+		if err != nil {
+			return err
+		}
+
+		detail = usecase.BillDetail{Bill: bill, Shares: make([]postgres.BillShare, 0, len(amounts))}
+		for i, participant := range request.Participants {
+			share, err := query.CreateBillShare(ctx, postgres.CreateBillShareParams{
+				BillID: bill.ID,
+				UserID: participant.UserID,
+				Amount: amounts[i],
+			})
+			if err != nil {
+				return err
+			}
+			detail.Shares = append(detail.Shares, share)
+		}
+		return nil
+	}); err != nil {
+		logging.NewFromContext(ctx).Error("CreateBill failed to create bill", zap.Error(err))
+		return usecase.BillDetail{}, errors.InternalServer.NewWithUserMsg(err, "failed to create bill")
+	}
+
+	audit.RecordOrLog(ctx, b.audit, audit.Event{
+		Type:      audit.EventBillCreated,
+		SubjectID: request.UserID,
+		Metadata: map[string]interface{}{
+			"bill_id":      detail.Bill.ID,
+			"currency":     detail.Bill.Currency,
+			"total":        detail.Bill.Total,
+			"split":        detail.Bill.Split,
+			"participants": len(detail.Shares),
+		},
+	})
+
+	return detail, nil
+}
+
+// ListBills returns a page of the bills the user created, newest first
+func (b *billUsecase) ListBills(ctx context.Context, request request.ListBillsRequest) ([]postgres.Bill, int64, error) {
+	ctx, span := b.trace.Start(ctx, "billUsecase.ListBills", trace.WithAttributes(
+		attribute.Int("user_id", int(request.UserID)),
+	))
+	defer span.End()
+
+	total, err := b.repository.CountBillsByCreatorID(ctx, request.UserID)
+	if err != nil {
+		logging.NewFromContext(ctx).Error("ListBills failed to count bills", zap.Error(err))
+		return nil, 0, errors.InternalServer.NewWithUserMsg(err, "failed to list bills")
+	}
+
+	bills, err := b.repository.ListBillsByCreatorID(ctx, postgres.ListBillsByCreatorIDParams{
+		CreatorID: request.UserID,
+		RowLimit:  int32(request.Limit),
+		RowOffset: int32(request.Offset),
+	})
+	if err != nil {
+		logging.NewFromContext(ctx).Error("ListBills failed to list bills", zap.Error(err))
+		return nil, 0, errors.InternalServer.NewWithUserMsg(err, "failed to list bills")
+	}
+
+	return bills, total, nil
+}
+
+// ListOwedShares returns a page of the shares the user owes in the bills of others,
+// newest first
+func (b *billUsecase) ListOwedShares(ctx context.Context, request request.ListOwedBillSharesRequest) ([]postgres.ListBillSharesByUserIDRow, int64, error) {
+	ctx, span := b.trace.Start(ctx, "billUsecase.ListOwedShares", trace.WithAttributes(
+		attribute.Int("user_id", int(request.UserID)),
+	))
+	defer span.End()
+
+	status := sql.NullString{String: request.Status, Valid: request.Status != ""}
+	total, err := b.repository.CountBillSharesByUserID(ctx, postgres.CountBillSharesByUserIDParams{
+		UserID: request.UserID,
+		Status: status,
+	})
+	if err != nil {
+		logging.NewFromContext(ctx).Error("ListOwedShares failed to count bill shares", zap.Error(err))
+		return nil, 0, errors.InternalServer.NewWithUserMsg(err, "failed to list bill shares")
+	}
+
+	shares, err := b.repository.ListBillSharesByUserID(ctx, postgres.ListBillSharesByUserIDParams{
+		UserID:    request.UserID,
+		Status:    status,
+		RowLimit:  int32(request.Limit),
+		RowOffset: int32(request.Offset),
+	})
+	if err != nil {
+		logging.NewFromContext(ctx).Error("ListOwedShares failed to list bill shares", zap.Error(err))
+		return nil, 0, errors.InternalServer.NewWithUserMsg(err, "failed to list bill shares")
+	}
+
+	return shares, total, nil
+}
+
+// GetBill returns a bill the user created or takes part in, with every share
+func (b *billUsecase) GetBill(ctx context.Context, userID, billID int32) (usecase.BillDetail, error) {
+	ctx, span := b.trace.Start(ctx, "billUsecase.GetBill", trace.WithAttributes(
+		attribute.Int("user_id", int(userID)),
+		attribute.Int("bill_id", int(billID)),
+	))
+	defer span.End()
+
+	return b.getBill(ctx, userID, billID)
+}
+
+// PayShare transfers the share of the user to the creator of the bill, the share is
+// marked paid in the database transaction of the transfer
+func (b *billUsecase) PayShare(ctx context.Context, userID, billID int32) (usecase.TransferResult, error) {
+	ctx, span := b.trace.Start(ctx, "billUsecase.PayShare", trace.WithAttributes(
+		attribute.Int("user_id", int(userID)),
+		attribute.Int("bill_id", int(billID)),
+	))
+	defer span.End()
+
+	detail, err := b.getBill(ctx, userID, billID)
+	if err != nil {
+		return usecase.TransferResult{}, err
+	}
+
+	share, found := shareOf(detail.Shares, userID)
+	if !found {
+		return usecase.TransferResult{}, errors.Forbidden.NewWithUserMsg(nil, "only a participant can pay a share of the bill")
+	}
+	if share.Status != SharePending {
+		return usecase.TransferResult{}, errors.WithCode(errors.BadRequest.NewWithUserMsg(nil, "bill share is already paid"), errors.CodeBillSharePaid)
+	}
+
+	return b.transfer.Transfer(ctx, request.CreateTransferRequest{
+		UserID:         userID,
+		ToUserID:       detail.Bill.CreatorID,
+		Currency:       detail.Bill.Currency,
+		Amount:         share.Amount,
+		Note:           detail.Bill.Title,
+		IdempotencyKey: fmt.Sprintf("bill:%d:%d", detail.Bill.ID, userID),
+		BillID:         detail.Bill.ID,
+	})
+}
+
+// Remind pushes a reminder to the participants with a pending share, one reminded less
+// than the reminder interval ago is left alone. It returns the shares reminded
+func (b *billUsecase) Remind(ctx context.Context, userID, billID int32) ([]postgres.BillShare, error) {
+	ctx, span := b.trace.Start(ctx, "billUsecase.Remind", trace.WithAttributes(
+		attribute.Int("user_id", int(userID)),
+		attribute.Int("bill_id", int(billID)),
+	))
+	defer span.End()
+
+	detail, err := b.getBill(ctx, userID, billID)
+	if err != nil {
+		return nil, err
+	}
+	if detail.Bill.CreatorID != userID {
+		return nil, errors.Forbidden.NewWithUserMsg(nil, "only the creator can send reminders")
+	}
+
+	now := time.Now().UTC()
+	shares, err := b.repository.RemindBillShares(ctx, postgres.RemindBillSharesParams{
+		Now:          now,
+		BillID:       billID,
+		RemindBefore: now.Add(-b.reminderInterval),
+	})
+	if err != nil {
+		logging.NewFromContext(ctx).Error("Remind failed to remind bill shares", zap.Error(err))
+		return nil, errors.InternalServer.NewWithUserMsg(err, "failed to send reminders")
+	}
+
+	for _, share := range shares {
+		b.publishReminder(ctx, detail.Bill, share)
+	}
+	if len(shares) > 0 {
+		audit.RecordOrLog(ctx, b.audit, audit.Event{
+			Type:      audit.EventBillReminded,
+			SubjectID: userID,
+			Metadata: map[string]interface{}{
+				"bill_id":   billID,
+				"reminders": len(shares),
+			},
+		})
+	}
+
+	if shares == nil {
+		shares = []postgres.BillShare{}
+	}
+	return shares, nil
+}
+
+func (b *billUsecase) getBill(ctx context.Context, userID, billID int32) (usecase.BillDetail, error) {
+	bill, err := b.repository.GetBill(ctx, postgres.GetBillParams{ID: billID, UserID: userID})
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return usecase.BillDetail{}, errors.WithCode(errors.NotFound.NewWithUserMsg(err, "bill not found"), errors.CodeBillNotFound)
+		}
+		logging.NewFromContext(ctx).Error("failed to get bill", zap.Error(err))
+		return usecase.BillDetail{}, errors.InternalServer.NewWithUserMsg(err, "failed to get bill")
+	}
+
+	shares, err := b.repository.ListBillShares(ctx, bill.ID)
+	if err != nil {
+		logging.NewFromContext(ctx).Error("failed to list bill shares", zap.Error(err))
+		return usecase.BillDetail{}, errors.InternalServer.NewWithUserMsg(err, "failed to get bill")
+	}
+
+	return usecase.BillDetail{Bill: bill, Shares: shares}, nil
+}
+
+// publishReminder pushes the reminder to the event stream of the participant, a failure
+// only costs the reminder
+func (b *billUsecase) publishReminder(ctx context.Context, bill postgres.Bill, share postgres.BillShare) {
+	if b.events == nil {
+		return
+	}
+
+	event, err := stream.NewEvent(stream.EventBillReminder, share.UserID, stream.BillReminderData{
+		BillID:    bill.ID,
+		CreatorID: bill.CreatorID,
+		Title:     bill.Title,
+		Currency:  bill.Currency,
+		Amount:    share.Amount,
+	})
+	if err == nil {
+		_, err = b.events.Publish(ctx, event)
+	}
+	if err != nil {
+		logging.NewFromContext(ctx).Warn("Failed to publish bill reminder", zap.Int32("bill_id", bill.ID), zap.Error(err))
+	}
+}
+
+// sharesOf is what each participant owes, in the order of the participants. An equal
+// split counts the creator as one more participant, its part takes the minor units left
+// over. A custom split leaves the creator what the shares don't cover
+func sharesOf(request request.CreateBillRequest, currency postgres.Currency) ([]float64, error) {
+	if request.Split == SplitEqual {
+		for _, participant := range request.Participants {
+			if participant.Amount != 0 {
+				return nil, errors.BadRequest.NewWithUserMsg(nil, "amount is only set on a custom split")
+			}
+		}
+
+		parts := money.Split(request.Total, len(request.Participants)+1, currency.MinorUnits)
+		// the first part has the leftover minor units and goes to the creator
+		amounts := parts[1:]
+		if amounts[len(amounts)-1] <= 0 {
+			return nil, errors.BadRequest.NewWithUserMsg(nil, "total is too small to split")
+		}
+		return amounts, nil
+	}
+
+	amounts := make([]float64, 0, len(request.Participants))
+	var sum float64
+	for _, participant := range request.Participants {
+		if participant.Amount <= 0 {
+			return nil, errors.BadRequest.NewWithUserMsg(nil, "amount is required on a custom split")
+		}
+		if !money.HasPrecision(participant.Amount, currency.MinorUnits) {
+			return nil, errors.WithCode(errors.BadRequest.NewWithUserMsg(nil, "amount has too many decimals"), errors.CodeInvalidAmountPrecision)
+		}
+		amounts = append(amounts, participant.Amount)
+		sum += participant.Amount
+	}
+	if money.Round(sum, currency.MinorUnits) > request.Total {
+		return nil, errors.BadRequest.NewWithUserMsg(nil, "shares exceed the total")
+	}
+	return amounts, nil
+}
+
+func shareOf(shares []postgres.BillShare, userID int32) (postgres.BillShare, bool) {
+	for _, share := range shares {
+		if share.UserID == userID {
+			return share, true
+		}
+	}
+	return postgres.BillShare{}, false
+}
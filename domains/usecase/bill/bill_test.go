@@ -0,0 +1,233 @@
+package bill
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	mock_configuration "kc-ewallet/configurations/mocks"
+	"kc-ewallet/domains/repository"
+	mock_repository "kc-ewallet/domains/repository/mocks"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/repository/stream"
+	"kc-ewallet/domains/usecase"
+	mock_usecase "kc-ewallet/domains/usecase/mocks"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/protocols/http/request"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBillUsecase(ctrl *gomock.Controller, repo *mock_repository.MockIRepository, transfer usecase.ITransferUsecase, events repository.IEventStream) *billUsecase {
+	config := mock_configuration.NewMockIBillConfiguration(ctrl)
+	config.EXPECT().GetMaxParticipants().Return(3)
+	config.EXPECT().GetReminderInterval().Return(24 * time.Hour)
+	return NewBillUsecase(nil, repo, config, transfer, events, nil, nil)
+}
+
+func TestBillUsecase_CreateBill(t *testing.T) {
+	mockLookups := func(repo *mock_repository.MockIRepository, participants ...int32) {
+		repo.EXPECT().GetCurrency(gomock.Any(), "IDR").Return(postgres.Currency{Code: "IDR", MinorUnits: 2}, nil)
+		for _, participant := range participants {
+			repo.EXPECT().GetUserByID(gomock.Any(), participant).Return(postgres.User{ID: participant}, nil)
+		}
+	}
+	mockCreate := func(repo *mock_repository.MockIRepository, outstanding float64, amounts map[int32]float64) {
+		repo.EXPECT().CreateBill(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, params postgres.CreateBillParams) (postgres.Bill, error) {
+				assert.Equal(t, outstanding, params.OutstandingAmount)
+				return postgres.Bill{ID: 5, CreatorID: params.CreatorID, Total: params.Total, OutstandingAmount: params.OutstandingAmount, Status: StatusOpen}, nil
+			})
+		repo.EXPECT().CreateBillShare(gomock.Any(), gomock.Any()).Times(len(amounts)).DoAndReturn(
+			func(_ context.Context, params postgres.CreateBillShareParams) (postgres.BillShare, error) {
+				assert.Equal(t, amounts[params.UserID], params.Amount)
+				return postgres.BillShare{BillID: params.BillID, UserID: params.UserID, Amount: params.Amount, Status: SharePending}, nil
+			})
+	}
+
+	testCases := []struct {
+		name         string
+		request      request.CreateBillRequest
+		mock         func(repo *mock_repository.MockIRepository)
+		expectedCode errors.Code
+	}{
+		{
+			name: "should split equally with the creator keeping the leftover minor units",
+			request: request.CreateBillRequest{UserID: 1, Title: "dinner", Currency: "IDR", Total: 100, Split: SplitEqual,
+				Participants: []request.BillParticipant{{UserID: 2}, {UserID: 3}}},
+			mock: func(repo *mock_repository.MockIRepository) {
+				mockLookups(repo, 2, 3)
+				mockCreate(repo, 66.66, map[int32]float64{2: 33.33, 3: 33.33})
+			},
+		},
+		{
+			name: "should owe each participant its custom share",
+			request: request.CreateBillRequest{UserID: 1, Title: "rent", Currency: "IDR", Total: 3000, Split: SplitCustom,
+				Participants: []request.BillParticipant{{UserID: 2, Amount: 1200}, {UserID: 3, Amount: 800.5}}},
+			mock: func(repo *mock_repository.MockIRepository) {
+				mockLookups(repo, 2, 3)
+				mockCreate(repo, 2000.5, map[int32]float64{2: 1200, 3: 800.5})
+			},
+		},
+		{
+			name: "should reject custom shares above the total",
+			request: request.CreateBillRequest{UserID: 1, Title: "rent", Currency: "IDR", Total: 1000, Split: SplitCustom,
+				Participants: []request.BillParticipant{{UserID: 2, Amount: 600}, {UserID: 3, Amount: 500}}},
+			mock:         func(repo *mock_repository.MockIRepository) { mockLookups(repo) },
+			expectedCode: errors.CodeBadRequest,
+		},
+		{
+			name: "should reject an amount on an equal split",
+			request: request.CreateBillRequest{UserID: 1, Title: "dinner", Currency: "IDR", Total: 100, Split: SplitEqual,
+				Participants: []request.BillParticipant{{UserID: 2, Amount: 50}}},
+			mock:         func(repo *mock_repository.MockIRepository) { mockLookups(repo) },
+			expectedCode: errors.CodeBadRequest,
+		},
+		{
+			name: "should reject the creator as participant",
+			request: request.CreateBillRequest{UserID: 1, Title: "dinner", Currency: "IDR", Total: 100, Split: SplitEqual,
+				Participants: []request.BillParticipant{{UserID: 1}}},
+			mock:         func(repo *mock_repository.MockIRepository) {},
+			expectedCode: errors.CodeBadRequest,
+		},
+		{
+			name: "should reject more participants than allowed",
+			request: request.CreateBillRequest{UserID: 1, Title: "trip", Currency: "IDR", Total: 100, Split: SplitEqual,
+				Participants: []request.BillParticipant{{UserID: 2}, {UserID: 3}, {UserID: 4}, {UserID: 5}}},
+			mock:         func(repo *mock_repository.MockIRepository) {},
+			expectedCode: errors.CodeBadRequest,
+		},
+		{
+			name: "should reject an unknown participant",
+			request: request.CreateBillRequest{UserID: 1, Title: "dinner", Currency: "IDR", Total: 100, Split: SplitEqual,
+				Participants: []request.BillParticipant{{UserID: 2}}},
+			mock: func(repo *mock_repository.MockIRepository) {
+				mockLookups(repo)
+				repo.EXPECT().GetUserByID(gomock.Any(), int32(2)).Return(postgres.User{}, sql.ErrNoRows)
+			},
+			expectedCode: errors.CodeUserNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mock_repository.NewMockIRepository(ctrl)
+			tc.mock(repo)
+
+			detail, err := newBillUsecase(ctrl, repo, nil, nil).CreateBill(context.Background(), tc.request)
+			if tc.expectedCode != "" {
+				assert.Equal(t, tc.expectedCode, errors.CatalogEntryOf(err).Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int32(5), detail.Bill.ID)
+			assert.Len(t, detail.Shares, len(tc.request.Participants))
+		})
+	}
+}
+
+func TestBillUsecase_PayShare(t *testing.T) {
+	bill := postgres.Bill{ID: 5, CreatorID: 1, Title: "dinner", Currency: "IDR", Total: 100, Status: StatusOpen}
+	shares := []postgres.BillShare{
+		{ID: 7, BillID: 5, UserID: 2, Amount: 33.33, Status: SharePending},
+		{ID: 8, BillID: 5, UserID: 3, Amount: 33.33, Status: SharePaid},
+	}
+
+	testCases := []struct {
+		name         string
+		userID       int32
+		mock         func(transfer *mock_usecase.MockITransferUsecase)
+		expectedCode errors.Code
+	}{
+		{
+			name:   "should transfer the share to the creator",
+			userID: 2,
+			mock: func(transfer *mock_usecase.MockITransferUsecase) {
+				transfer.EXPECT().Transfer(gomock.Any(), request.CreateTransferRequest{
+					UserID:         2,
+					ToUserID:       1,
+					Currency:       "IDR",
+					Amount:         33.33,
+					Note:           "dinner",
+					IdempotencyKey: "bill:5:2",
+					BillID:         5,
+				}).Return(usecase.TransferResult{Transfer: postgres.Transfer{ID: 9}}, nil)
+			},
+		},
+		{
+			name:         "should reject a share already paid",
+			userID:       3,
+			mock:         func(transfer *mock_usecase.MockITransferUsecase) {},
+			expectedCode: errors.CodeBillSharePaid,
+		},
+		{
+			name:         "should not let the creator pay",
+			userID:       1,
+			mock:         func(transfer *mock_usecase.MockITransferUsecase) {},
+			expectedCode: errors.CodeForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mock_repository.NewMockIRepository(ctrl)
+			repo.EXPECT().GetBill(gomock.Any(), postgres.GetBillParams{ID: 5, UserID: tc.userID}).Return(bill, nil)
+			repo.EXPECT().ListBillShares(gomock.Any(), int32(5)).Return(shares, nil)
+			transfer := mock_usecase.NewMockITransferUsecase(ctrl)
+			tc.mock(transfer)
+
+			result, err := newBillUsecase(ctrl, repo, transfer, nil).PayShare(context.Background(), tc.userID, 5)
+			if tc.expectedCode != "" {
+				assert.Equal(t, tc.expectedCode, errors.CatalogEntryOf(err).Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int32(9), result.Transfer.ID)
+		})
+	}
+}
+
+func TestBillUsecase_Remind(t *testing.T) {
+	bill := postgres.Bill{ID: 5, CreatorID: 1, Title: "dinner", Currency: "IDR", Total: 100, Status: StatusOpen}
+
+	t.Run("should push a reminder to the participants with a pending share", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockIRepository(ctrl)
+		repo.EXPECT().GetBill(gomock.Any(), postgres.GetBillParams{ID: 5, UserID: 1}).Return(bill, nil)
+		repo.EXPECT().ListBillShares(gomock.Any(), int32(5)).Return(nil, nil)
+		repo.EXPECT().RemindBillShares(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, params postgres.RemindBillSharesParams) ([]postgres.BillShare, error) {
+				assert.Equal(t, 24*time.Hour, params.Now.Sub(params.RemindBefore))
+				return []postgres.BillShare{{ID: 7, BillID: 5, UserID: 2, Amount: 33.33, Status: SharePending}}, nil
+			})
+		events := mock_repository.NewMockIEventStream(ctrl)
+		events.EXPECT().Publish(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, event stream.Event) (stream.Event, error) {
+				assert.Equal(t, stream.EventBillReminder, event.Type)
+				assert.Equal(t, int32(2), event.UserID)
+				var data stream.BillReminderData
+				require.NoError(t, json.Unmarshal(event.Data, &data))
+				assert.Equal(t, stream.BillReminderData{BillID: 5, CreatorID: 1, Title: "dinner", Currency: "IDR", Amount: 33.33}, data)
+				return event, nil
+			})
+
+		shares, err := newBillUsecase(ctrl, repo, nil, events).Remind(context.Background(), 1, 5)
+		require.NoError(t, err)
+		assert.Len(t, shares, 1)
+	})
+
+	t.Run("should only let the creator remind", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockIRepository(ctrl)
+		repo.EXPECT().GetBill(gomock.Any(), postgres.GetBillParams{ID: 5, UserID: 2}).Return(bill, nil)
+		repo.EXPECT().ListBillShares(gomock.Any(), int32(5)).Return(nil, nil)
+
+		_, err := newBillUsecase(ctrl, repo, nil, nil).Remind(context.Background(), 2, 5)
+		assert.Equal(t, errors.CodeForbidden, errors.CatalogEntryOf(err).Code)
+	})
+}
@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+	"kc-ewallet/domains/repository"
+	"kc-ewallet/internals/helpers/logging"
+
+	"go.uber.org/zap"
+)
+
+// InvalidateBalances drops the cached balances of the users once their change is
+// committed, a failure only leaves them stale until the cache expires. A nil cache
+// caches nothing
+func InvalidateBalances(ctx context.Context, cache repository.IUserCache, userIDs ...int32) {
+	if cache == nil || len(userIDs) == 0 {
+		return
+	}
+
+	if err := cache.DeleteBalances(ctx, userIDs...); err != nil {
+		logging.NewFromContext(ctx).Warn("Failed to invalidate cached balances", zap.Error(err))
+	}
+}
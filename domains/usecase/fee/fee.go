@@ -0,0 +1,269 @@
+package fee
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	goerrors "errors"
+	"kc-ewallet/configurations"
+	"kc-ewallet/constants"
+	"kc-ewallet/domains/repository"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/domains/usecase/audit"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/internals/helpers/logging"
+	"kc-ewallet/protocols/http/request"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+type feeUsecase struct {
+	db            *sql.DB
+	repository    repository.IRepository
+	audit         usecase.IAuditUsecase
+	trace         trace.Tracer
+	revenueUserID int32
+}
+
+func NewFeeUsecase(
+	db *sql.DB,
+	repository repository.IRepository,
+	config configurations.IFeeConfiguration,
+	auditUsecase usecase.IAuditUsecase,
+	trace trace.Tracer,
+) *feeUsecase {
+	if trace == nil {
+		trace = noop.NewTracerProvider().Tracer("")
+	}
+
+	return &feeUsecase{
+		db:            db,
+		repository:    repository,
+		audit:         auditUsecase,
+		trace:         trace,
+		revenueUserID: config.GetRevenueUserID(),
+	}
+}
+
+// Calculate computes the fee of the most specific active rule, no rule means no fee
+func (f *feeUsecase) Calculate(ctx context.Context, input usecase.FeeInput) (usecase.Fee, error) {
+	ctx, span := f.trace.Start(ctx, "feeUsecase.Calculate", trace.WithAttributes(
+		attribute.String("transaction_type", input.TransactionType),
+		attribute.String("currency", input.Currency),
+		attribute.String("channel", input.Channel),
+		attribute.String("user_tier", input.UserTier),
+	))
+	defer span.End()
+
+	rule, err := f.repository.GetApplicableFeeRule(ctx, postgres.GetApplicableFeeRuleParams{
+		TransactionType: input.TransactionType,
+		Currency:        input.Currency,
+		Channel:         sql.NullString{String: input.Channel, Valid: true},
+		UserTier:        sql.NullString{String: input.UserTier, Valid: true},
+	})
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return usecase.Fee{}, nil
+		}
+		logging.NewFromContext(ctx).Error("Calculate failed to get fee rule", zap.Error(err))
+		return usecase.Fee{}, errors.InternalServer.NewWithUserMsg(err, "failed to calculate fee")
+	}
+
+	amount, err := Calculate(rule, input.Amount, input.MinorUnits)
+	if err != nil {
+		logging.NewFromContext(ctx).Error("Calculate failed to apply fee rule", zap.Int32("fee_rule_id", rule.ID), zap.Error(err))
+		return usecase.Fee{}, errors.InternalServer.NewWithUserMsg(err, "failed to calculate fee")
+	}
+
+	return usecase.Fee{RuleID: rule.ID, Amount: amount}, nil
+}
+
+// PostTx writes the fee row of the user and credits the revenue wallet of the fee currency
+// in the caller transaction. The revenue wallet row stays locked until the caller commits
+func (f *feeUsecase) PostTx(ctx context.Context, tx *sql.Tx, posting usecase.FeePosting) error {
+	if posting.Fee.Amount == 0 {
+		return nil
+	}
+	if f.revenueUserID == 0 {
+		return errors.InternalServer.New("fee revenue account is not configured")
+	}
+
+	query := f.repository
+	if tx != nil {
+		query = f.repository.WithTx(tx)
+	}
+
+	chargeTransactionID, err := query.CreateTransaction(ctx, postgres.CreateTransactionParams{
+		UserID:   sql.NullInt32{Int32: posting.UserID, Valid: true},
+		Amount:   posting.Fee.Amount,
+		Type:     constants.TransactionTypeFee,
+		Currency: posting.Currency,
+	})
+	if err != nil {
+		return err
+	}
+
+	credited, err := query.IncrementWalletBalance(ctx, postgres.IncrementWalletBalanceParams{
+		Amount:   posting.Fee.Amount,
+		UserID:   f.revenueUserID,
+		Currency: posting.Currency,
+	})
+	if err != nil {
+		return err
+	}
+	if credited == 0 {
+		return errors.InternalServer.New("fee revenue account %d has no %s wallet", f.revenueUserID, posting.Currency)
+	}
+
+	revenueTransactionID, err := query.CreateTransaction(ctx, postgres.CreateTransactionParams{
+		UserID:   sql.NullInt32{Int32: f.revenueUserID, Valid: true},
+		Amount:   posting.Fee.Amount,
+		Type:     constants.TransactionTypeFeeRevenue,
+		Currency: posting.Currency,
+	})
+	if err != nil {
+		return err
+	}
+
+	return query.CreateTransactionFee(ctx, postgres.CreateTransactionFeeParams{
+		TransactionID:        posting.TransactionID,
+		FeeRuleID:            sql.NullInt32{Int32: posting.Fee.RuleID, Valid: posting.Fee.RuleID != 0},
+		Amount:               posting.Fee.Amount,
+		ChargeTransactionID:  chargeTransactionID,
+		RevenueTransactionID: revenueTransactionID,
+	})
+}
+
+// CreateRule replaces the active rule of the same transaction type, channel and tier
+func (f *feeUsecase) CreateRule(ctx context.Context, request request.CreateFeeRuleRequest) (postgres.FeeRule, error) {
+	ctx, span := f.trace.Start(ctx, "feeUsecase.CreateRule", trace.WithAttributes(
+		attribute.String("transaction_type", request.TransactionType),
+		attribute.String("currency", request.Currency),
+		attribute.String("kind", request.Kind),
+	))
+	defer span.End()
+
+	tiers := make([]Tier, 0, len(request.Tiers))
+	for _, tier := range request.Tiers {
+		tiers = append(tiers, Tier{UpTo: tier.UpTo, Flat: tier.Flat, Percent: tier.Percent})
+	}
+	if request.Kind == KindTiered {
+		if err := ValidateTiers(tiers); err != nil {
+			return postgres.FeeRule{}, errors.BadRequest.NewWithUserMsg(err, err.Error())
+		}
+	}
+	if request.MinFee != nil && request.MaxFee != nil && *request.MinFee > *request.MaxFee {
+		return postgres.FeeRule{}, errors.BadRequest.NewWithUserMsg(nil, "min_fee is greater than max_fee")
+	}
+
+	encodedTiers, err := json.Marshal(tiers)
+	if err != nil {
+		return postgres.FeeRule{}, errors.InternalServer.NewWithUserMsg(err, "failed to create fee rule")
+	}
+
+	channel := sql.NullString{String: request.Channel, Valid: request.Channel != ""}
+	userTier := sql.NullString{String: request.UserTier, Valid: request.UserTier != ""}
+
+	var rule postgres.FeeRule
+	err = usecase.InTx(ctx, f.db, f.repository, func(query repository.IRepository) error {
+		if err := query.DeactivateFeeRulesByKey(ctx, postgres.DeactivateFeeRulesByKeyParams{
+			TransactionType: request.TransactionType,
+			Currency:        request.Currency,
+			Channel:         channel,
+			UserTier:        userTier,
+		}); err != nil {
+			logging.NewFromContext(ctx).Error("CreateRule failed to replace previous rule", zap.Error(err))
+			return errors.InternalServer.NewWithUserMsg(err, "failed to create fee rule")
+		}
+
+		var err error
+		rule, err = query.CreateFeeRule(ctx, postgres.CreateFeeRuleParams{
+			TransactionType: request.TransactionType,
+			Currency:        request.Currency,
+			Channel:         channel,
+			UserTier:        userTier,
+			Kind:            request.Kind,
+			FlatAmount:      request.FlatAmount,
+			Percent:         request.Percent,
+			Tiers:           encodedTiers,
+			MinFee:          nullFloat(request.MinFee),
+			MaxFee:          nullFloat(request.MaxFee),
+		})
+		if err != nil {
+			logging.NewFromContext(ctx).Error("CreateRule failed to create rule", zap.Error(err))
+			return errors.InternalServer.NewWithUserMsg(err, "failed to create fee rule")
+		}
+		return nil
+	})
+	if err != nil {
+		return postgres.FeeRule{}, err
+	}
+
+	audit.RecordOrLog(ctx, f.audit, audit.Event{
+		Type: audit.EventFeeRuleCreated,
+		After: map[string]interface{}{
+			"transaction_type": rule.TransactionType,
+			"currency":         rule.Currency,
+			"channel":          request.Channel,
+			"user_tier":        request.UserTier,
+			"kind":             rule.Kind,
+			"flat_amount":      rule.FlatAmount,
+			"percent":          rule.Percent,
+			"tiers":            tiers,
+			"min_fee":          request.MinFee,
+			"max_fee":          request.MaxFee,
+		},
+		Metadata: map[string]interface{}{"fee_rule_id": rule.ID},
+	})
+
+	return rule, nil
+}
+
+// ListRules returns the active rules, or every rule ever created, newest first
+func (f *feeUsecase) ListRules(ctx context.Context, activeOnly bool) ([]postgres.FeeRule, error) {
+	ctx, span := f.trace.Start(ctx, "feeUsecase.ListRules")
+	defer span.End()
+
+	rules, err := f.repository.ListFeeRules(ctx, activeOnly)
+	if err != nil {
+		logging.NewFromContext(ctx).Error("ListRules failed to list rules", zap.Error(err))
+		return nil, errors.InternalServer.NewWithUserMsg(err, "failed to list fee rules")
+	}
+
+	return rules, nil
+}
+
+// DeactivateRule stops charging the rule, transactions fall back to a broader rule or no fee
+func (f *feeUsecase) DeactivateRule(ctx context.Context, ruleID int32) error {
+	ctx, span := f.trace.Start(ctx, "feeUsecase.DeactivateRule", trace.WithAttributes(
+		attribute.Int("fee_rule_id", int(ruleID)),
+	))
+	defer span.End()
+
+	deactivated, err := f.repository.DeactivateFeeRule(ctx, ruleID)
+	if err != nil {
+		logging.NewFromContext(ctx).Error("DeactivateRule failed to deactivate rule", zap.Error(err))
+		return errors.InternalServer.NewWithUserMsg(err, "failed to deactivate fee rule")
+	}
+	if deactivated == 0 {
+		return errors.NotFound.New("fee rule not found or already inactive")
+	}
+
+	audit.RecordOrLog(ctx, f.audit, audit.Event{
+		Type:     audit.EventFeeRuleDeactivated,
+		Metadata: map[string]interface{}{"fee_rule_id": ruleID},
+	})
+
+	return nil
+}
+
+func nullFloat(value *float64) sql.NullFloat64 {
+	if value == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *value, Valid: true}
+}
@@ -0,0 +1,90 @@
+package fee
+
+import (
+	"encoding/json"
+	"fmt"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/internals/helpers/money"
+	"math"
+)
+
+const (
+	KindFlat       = "flat"
+	KindPercentage = "percentage"
+	KindTiered     = "tiered"
+)
+
+// Tier applies to the amounts up to UpTo, a nil UpTo is unbounded
+type Tier struct {
+	UpTo    *float64 `json:"up_to"`
+	Flat    float64  `json:"flat"`
+	Percent float64  `json:"percent"`
+}
+
+// Calculate computes the fee of the amount under the rule:
+//   - flat charges the flat amount
+//   - percentage charges the percent of the amount on top of the flat amount
+//   - tiered charges the flat and percent of the first tier the amount fits
+//
+// the result is clamped by the min and max fee of the rule and rounded to the minor
+// units of the currency
+func Calculate(rule postgres.FeeRule, amount float64, minorUnits int16) (float64, error) {
+	var fee float64
+	switch rule.Kind {
+	case KindFlat:
+		fee = rule.FlatAmount
+	case KindPercentage:
+		fee = rule.FlatAmount + amount*rule.Percent/100
+	case KindTiered:
+		var tiers []Tier
+		if err := json.Unmarshal(rule.Tiers, &tiers); err != nil {
+			return 0, fmt.Errorf("fee rule %d has invalid tiers: %w", rule.ID, err)
+		}
+		tier, ok := tierOf(tiers, amount)
+		if !ok {
+			return 0, fmt.Errorf("fee rule %d has no tier for %.2f", rule.ID, amount)
+		}
+		fee = tier.Flat + amount*tier.Percent/100
+	default:
+		return 0, fmt.Errorf("fee rule %d has unknown kind %q", rule.ID, rule.Kind)
+	}
+
+	if rule.MinFee.Valid && fee < rule.MinFee.Float64 {
+		fee = rule.MinFee.Float64
+	}
+	if rule.MaxFee.Valid && fee > rule.MaxFee.Float64 {
+		fee = rule.MaxFee.Float64
+	}
+
+	return math.Max(0, money.Round(fee, minorUnits)), nil
+}
+
+// ValidateTiers checks the tiers are ordered by their bound and only the last one is unbounded
+func ValidateTiers(tiers []Tier) error {
+	if len(tiers) == 0 {
+		return fmt.Errorf("a tiered fee needs at least one tier")
+	}
+
+	for i, tier := range tiers {
+		if tier.UpTo == nil {
+			if i != len(tiers)-1 {
+				return fmt.Errorf("only the last tier can be unbounded")
+			}
+			continue
+		}
+		if i > 0 && tiers[i-1].UpTo != nil && *tier.UpTo <= *tiers[i-1].UpTo {
+			return fmt.Errorf("tiers must be ordered by up_to")
+		}
+	}
+
+	return nil
+}
+
+func tierOf(tiers []Tier, amount float64) (Tier, bool) {
+	for _, tier := range tiers {
+		if tier.UpTo == nil || amount <= *tier.UpTo {
+			return tier, true
+		}
+	}
+	return Tier{}, false
+}
@@ -0,0 +1,75 @@
+package fee
+
+import (
+	"database/sql"
+	"encoding/json"
+	"kc-ewallet/domains/repository/postgres"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculate(t *testing.T) {
+	upTo := func(value float64) *float64 { return &value }
+	tiers, err := json.Marshal([]Tier{
+		{UpTo: upTo(100), Flat: 1},
+		{UpTo: upTo(1000), Flat: 1, Percent: 0.5},
+		{Percent: 0.25},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name        string
+		rule        postgres.FeeRule
+		amount      float64
+		minorUnits  int16
+		expectedFee float64
+		expectedErr bool
+	}{
+		{name: "should charge the flat amount", rule: postgres.FeeRule{Kind: KindFlat, FlatAmount: 2.5}, amount: 40, minorUnits: 2, expectedFee: 2.5},
+		{name: "should charge the percent on top of the flat amount", rule: postgres.FeeRule{Kind: KindPercentage, FlatAmount: 1, Percent: 1.5}, amount: 200, minorUnits: 2, expectedFee: 4},
+		{name: "should round to cents", rule: postgres.FeeRule{Kind: KindPercentage, Percent: 0.7}, amount: 33.33, minorUnits: 2, expectedFee: 0.23},
+		{name: "should round to the minor units", rule: postgres.FeeRule{Kind: KindPercentage, Percent: 0.7}, amount: 3333, minorUnits: 0, expectedFee: 23},
+		{
+			name:        "should raise to the min fee",
+			rule:        postgres.FeeRule{Kind: KindPercentage, Percent: 1, MinFee: sql.NullFloat64{Float64: 0.5, Valid: true}},
+			amount:      10,
+			minorUnits:  2,
+			expectedFee: 0.5,
+		},
+		{
+			name:        "should cap at the max fee",
+			rule:        postgres.FeeRule{Kind: KindPercentage, Percent: 1, MaxFee: sql.NullFloat64{Float64: 5, Valid: true}},
+			amount:      10000,
+			minorUnits:  2,
+			expectedFee: 5,
+		},
+		{name: "should use the first tier", rule: postgres.FeeRule{Kind: KindTiered, Tiers: tiers}, amount: 100, minorUnits: 2, expectedFee: 1},
+		{name: "should use the middle tier", rule: postgres.FeeRule{Kind: KindTiered, Tiers: tiers}, amount: 500, minorUnits: 2, expectedFee: 3.5},
+		{name: "should use the unbounded tier", rule: postgres.FeeRule{Kind: KindTiered, Tiers: tiers}, amount: 4000, minorUnits: 2, expectedFee: 10},
+		{name: "should reject an unknown kind", rule: postgres.FeeRule{Kind: "free"}, amount: 10, expectedErr: true},
+		{name: "should reject invalid tiers", rule: postgres.FeeRule{Kind: KindTiered, Tiers: json.RawMessage(`{}`)}, amount: 10, expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fee, err := Calculate(tc.rule, tc.amount, tc.minorUnits)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedFee, fee)
+		})
+	}
+}
+
+func TestValidateTiers(t *testing.T) {
+	upTo := func(value float64) *float64 { return &value }
+
+	assert.NoError(t, ValidateTiers([]Tier{{UpTo: upTo(100), Flat: 1}, {Percent: 1}}))
+	assert.Error(t, ValidateTiers(nil))
+	assert.Error(t, ValidateTiers([]Tier{{Percent: 1}, {UpTo: upTo(100), Flat: 1}}))
+	assert.Error(t, ValidateTiers([]Tier{{UpTo: upTo(100)}, {UpTo: upTo(50)}}))
+}
@@ -0,0 +1,363 @@
+package merchant
+
+import (
+	"context"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	goerrors "errors"
+	"fmt"
+	"kc-ewallet/configurations"
+	"kc-ewallet/domains/repository"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/domains/usecase/audit"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/internals/helpers/logging"
+	"kc-ewallet/protocols/http/request"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+const (
+	// keyIDPrefix and secretPrefix make a leaked key recognizable in logs and secret scanners
+	keyIDPrefix  = "mk_"
+	secretPrefix = "msk_"
+
+	nonceKey       = "merchant-nonce:%s:%s"
+	minNonceLength = 16
+	maxNonceLength = 64
+)
+
+type merchantUsecase struct {
+	db         *sql.DB
+	repository repository.IRepository
+	nonces     repository.INonceCache
+	audit      usecase.IAuditUsecase
+	trace      trace.Tracer
+
+	// secrets encrypts the api key secrets at rest, nil while secretsErr says why
+	// the configured key is unusable
+	secrets    cipher.AEAD
+	secretsErr error
+
+	keyRotationOverlap time.Duration
+	signatureTolerance time.Duration
+}
+
+func NewMerchantUsecase(
+	db *sql.DB,
+	repository repository.IRepository,
+	nonces repository.INonceCache,
+	config configurations.IMerchantConfiguration,
+	auditUsecase usecase.IAuditUsecase,
+	trace trace.Tracer,
+) *merchantUsecase {
+	if trace == nil {
+		trace = noop.NewTracerProvider().Tracer("")
+	}
+
+	// keys can't be issued or verified until the encryption key is fixed, like a fee
+	// can't be booked without its revenue account
+	secrets, secretsErr := newSecretCipher(config.GetSecretEncryptionKey())
+
+	return &merchantUsecase{
+		db:                 db,
+		repository:         repository,
+		nonces:             nonces,
+		audit:              auditUsecase,
+		trace:              trace,
+		secrets:            secrets,
+		secretsErr:         secretsErr,
+		keyRotationOverlap: config.GetKeyRotationOverlap(),
+		signatureTolerance: config.GetSignatureTolerance(),
+	}
+}
+
+// CreateMerchant creates the merchant linked to the wallet account its payments
+// are settled to, with its first api key
+func (m *merchantUsecase) CreateMerchant(ctx context.Context, request request.CreateMerchantRequest) (postgres.Merchant, usecase.IssuedMerchantKey, error) {
+	ctx, span := m.trace.Start(ctx, "merchantUsecase.CreateMerchant")
+	defer span.End()
+
+	var (
+		merchant postgres.Merchant
+		key      usecase.IssuedMerchantKey
+	)
+	err := usecase.InTx(ctx, m.db, m.repository, func(query repository.IRepository) error {
+		if _, err := query.GetUserByID(ctx, request.UserID); err != nil {
+			if goerrors.Is(err, sql.ErrNoRows) {
+				return errors.WithCode(errors.NotFound.NewWithUserMsg(err, "user not found"), errors.CodeUserNotFound)
+			}
+			logging.NewFromContext(ctx).Error("CreateMerchant failed to get user", zap.Error(err))
+			return errors.InternalServer.NewWithUserMsg(err, "failed to create merchant")
+		}
+
+		var err error
+		merchant, err = query.CreateMerchant(ctx, postgres.CreateMerchantParams{
+			Name:   request.Name,
+			UserID: sql.NullInt32{Int32: request.UserID, Valid: true},
+		})
+		if err != nil {
+			logging.NewFromContext(ctx).Error("CreateMerchant failed to create merchant", zap.Error(err))
+			if pqErr, ok := err.(*pq.Error); ok {
+				if pqErr.Code == "23505" {
+					return errors.BadRequest.NewWithUserMsg(err, "wallet account already belongs to a merchant")
+				}
+			}
+			return errors.InternalServer.NewWithUserMsg(err, "failed to create merchant")
+		}
+
+		key, err = m.issueKey(ctx, query, merchant.ID)
+		return err
+	})
+	if err != nil {
+		return postgres.Merchant{}, usecase.IssuedMerchantKey{}, err
+	}
+
+	audit.RecordOrLog(ctx, m.audit, audit.Event{
+		Type:     audit.EventMerchantCreated,
+		After:    map[string]interface{}{"name": merchant.Name, "user_id": request.UserID},
+		Metadata: map[string]interface{}{"merchant_id": merchant.ID, "key_id": key.KeyID},
+	})
+
+	return merchant, key, nil
+}
+
+func (m *merchantUsecase) GetMerchant(ctx context.Context, merchantID int32) (postgres.Merchant, error) {
+	ctx, span := m.trace.Start(ctx, "merchantUsecase.GetMerchant", trace.WithAttributes(
+		attribute.Int("merchant_id", int(merchantID)),
+	))
+	defer span.End()
+
+	return m.getMerchant(ctx, m.repository, merchantID)
+}
+
+// ListKeys returns every key of the merchant including the expired ones, without secrets
+func (m *merchantUsecase) ListKeys(ctx context.Context, merchantID int32) ([]postgres.MerchantApiKey, error) {
+	ctx, span := m.trace.Start(ctx, "merchantUsecase.ListKeys", trace.WithAttributes(
+		attribute.Int("merchant_id", int(merchantID)),
+	))
+	defer span.End()
+
+	if _, err := m.getMerchant(ctx, m.repository, merchantID); err != nil {
+		return nil, err
+	}
+
+	keys, err := m.repository.ListMerchantApiKeys(ctx, merchantID)
+	if err != nil {
+		logging.NewFromContext(ctx).Error("ListKeys failed to list api keys", zap.Error(err))
+		return nil, errors.InternalServer.NewWithUserMsg(err, "failed to list api keys")
+	}
+
+	return keys, nil
+}
+
+// RotateKey issues a new key, the keys still valid keep working for the rotation
+// overlap so the merchant can deploy the new one without failing requests
+func (m *merchantUsecase) RotateKey(ctx context.Context, merchantID int32) (usecase.IssuedMerchantKey, error) {
+	ctx, span := m.trace.Start(ctx, "merchantUsecase.RotateKey", trace.WithAttributes(
+		attribute.Int("merchant_id", int(merchantID)),
+	))
+	defer span.End()
+
+	previousExpireAt := time.Now().UTC().Add(m.keyRotationOverlap)
+
+	var key usecase.IssuedMerchantKey
+	err := usecase.InTx(ctx, m.db, m.repository, func(query repository.IRepository) error {
+		if _, err := m.getMerchant(ctx, query, merchantID); err != nil {
+			return err
+		}
+
+		// a key already expiring sooner keeps its earlier expiry
+		if _, err := query.ExpireMerchantApiKeys(ctx, postgres.ExpireMerchantApiKeysParams{
+			ExpiresAt:  previousExpireAt,
+			MerchantID: merchantID,
+		}); err != nil {
+			logging.NewFromContext(ctx).Error("RotateKey failed to expire previous keys", zap.Error(err))
+			return errors.InternalServer.NewWithUserMsg(err, "failed to rotate api key")
+		}
+
+		var err error
+		key, err = m.issueKey(ctx, query, merchantID)
+		return err
+	})
+	if err != nil {
+		return usecase.IssuedMerchantKey{}, err
+	}
+
+	audit.RecordOrLog(ctx, m.audit, audit.Event{
+		Type: audit.EventMerchantKeyRotated,
+		Metadata: map[string]interface{}{
+			"merchant_id":             merchantID,
+			"key_id":                  key.KeyID,
+			"previous_keys_expire_at": previousExpireAt,
+		},
+	})
+
+	return key, nil
+}
+
+// RevokeKey expires the key immediately, e.g. when its secret leaked
+func (m *merchantUsecase) RevokeKey(ctx context.Context, merchantID int32, keyID string) error {
+	ctx, span := m.trace.Start(ctx, "merchantUsecase.RevokeKey", trace.WithAttributes(
+		attribute.Int("merchant_id", int(merchantID)),
+		attribute.String("key_id", keyID),
+	))
+	defer span.End()
+
+	revoked, err := m.repository.ExpireMerchantApiKey(ctx, postgres.ExpireMerchantApiKeyParams{
+		ExpiresAt:  time.Now().UTC(),
+		MerchantID: merchantID,
+		KeyID:      keyID,
+	})
+	if err != nil {
+		logging.NewFromContext(ctx).Error("RevokeKey failed to expire key", zap.Error(err))
+		return errors.InternalServer.NewWithUserMsg(err, "failed to revoke api key")
+	}
+	if revoked == 0 {
+		return errors.NotFound.New("api key not found or already expired")
+	}
+
+	audit.RecordOrLog(ctx, m.audit, audit.Event{
+		Type:     audit.EventMerchantKeyRevoked,
+		Metadata: map[string]interface{}{"merchant_id": merchantID, "key_id": keyID},
+	})
+
+	return nil
+}
+
+// Authenticate verifies a signed merchant request and returns its key. The signed
+// timestamp must be within the tolerance and each nonce is accepted only once
+func (m *merchantUsecase) Authenticate(ctx context.Context, signature request.MerchantSignature) (postgres.MerchantApiKey, error) {
+	ctx, span := m.trace.Start(ctx, "merchantUsecase.Authenticate", trace.WithAttributes(
+		attribute.String("key_id", signature.KeyID),
+	))
+	defer span.End()
+
+	if signature.KeyID == "" || signature.Signature == "" || signature.Timestamp == "" {
+		return postgres.MerchantApiKey{}, invalidSignature("signature headers are missing")
+	}
+	if len(signature.Nonce) < minNonceLength || len(signature.Nonce) > maxNonceLength {
+		return postgres.MerchantApiKey{}, invalidSignature("nonce must be %d to %d characters", minNonceLength, maxNonceLength)
+	}
+
+	timestamp, err := strconv.ParseInt(signature.Timestamp, 10, 64)
+	if err != nil {
+		return postgres.MerchantApiKey{}, invalidSignature("timestamp is not a unix time")
+	}
+	now := time.Now()
+	if age := now.Sub(time.Unix(timestamp, 0)); age > m.signatureTolerance || age < -m.signatureTolerance {
+		return postgres.MerchantApiKey{}, invalidSignature("timestamp is outside the tolerance")
+	}
+
+	key, err := m.repository.GetMerchantApiKeyByKeyID(ctx, signature.KeyID)
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return postgres.MerchantApiKey{}, invalidSignature("unknown api key")
+		}
+		logging.NewFromContext(ctx).Error("Authenticate failed to get api key", zap.Error(err))
+		return postgres.MerchantApiKey{}, errors.InternalServer.NewWithUserMsg(err, "failed to verify signature")
+	}
+	if key.ExpiresAt.Valid && !now.Before(key.ExpiresAt.Time) {
+		return postgres.MerchantApiKey{}, invalidSignature("api key is expired")
+	}
+
+	secret, err := m.openSecret(key)
+	if err != nil {
+		logging.NewFromContext(ctx).Error("Authenticate failed to decrypt api key secret", zap.Error(err))
+		return postgres.MerchantApiKey{}, errors.InternalServer.NewWithUserMsg(err, "failed to verify signature")
+	}
+
+	expected := Sign(secret, signature.Method, signature.Path, timestamp, signature.Nonce, signature.Body)
+	if !hmac.Equal([]byte(signature.Signature), []byte(expected)) {
+		return postgres.MerchantApiKey{}, invalidSignature("signature doesn't match")
+	}
+
+	// claimed only once the signature is valid, so nobody else can burn the merchant nonces.
+	// Kept for both sides of the tolerance, an older timestamp is rejected before this
+	claimed, err := m.nonces.Claim(ctx, fmt.Sprintf(nonceKey, key.KeyID, signature.Nonce), 2*m.signatureTolerance)
+	if err != nil {
+		logging.NewFromContext(ctx).Error("Authenticate failed to claim nonce", zap.Error(err))
+		return postgres.MerchantApiKey{}, errors.InternalServer.NewWithUserMsg(err, "failed to verify signature")
+	}
+	if !claimed {
+		return postgres.MerchantApiKey{}, invalidSignature("nonce was already used")
+	}
+
+	return key, nil
+}
+
+func (m *merchantUsecase) issueKey(ctx context.Context, query repository.IRepository, merchantID int32) (usecase.IssuedMerchantKey, error) {
+	keyID, err := randomToken(keyIDPrefix, 16)
+	if err != nil {
+		return usecase.IssuedMerchantKey{}, errors.InternalServer.NewWithUserMsg(err, "failed to generate api key")
+	}
+	secret, err := randomToken(secretPrefix, 32)
+	if err != nil {
+		return usecase.IssuedMerchantKey{}, errors.InternalServer.NewWithUserMsg(err, "failed to generate api key")
+	}
+
+	if m.secrets == nil {
+		logging.NewFromContext(ctx).Error("failed to encrypt api key secret", zap.Error(m.secretsErr))
+		return usecase.IssuedMerchantKey{}, errors.InternalServer.NewWithUserMsg(m.secretsErr, "failed to generate api key")
+	}
+	sealed, err := sealSecret(m.secrets, keyID, secret)
+	if err != nil {
+		return usecase.IssuedMerchantKey{}, errors.InternalServer.NewWithUserMsg(err, "failed to generate api key")
+	}
+
+	key, err := query.CreateMerchantApiKey(ctx, postgres.CreateMerchantApiKeyParams{
+		MerchantID:       merchantID,
+		KeyID:            keyID,
+		SecretCiphertext: sealed,
+	})
+	if err != nil {
+		logging.NewFromContext(ctx).Error("failed to create api key", zap.Error(err))
+		return usecase.IssuedMerchantKey{}, errors.InternalServer.NewWithUserMsg(err, "failed to create api key")
+	}
+
+	return usecase.IssuedMerchantKey{MerchantApiKey: key, Secret: secret}, nil
+}
+
+// openSecret decrypts the secret of key, the HMAC key of its signatures
+func (m *merchantUsecase) openSecret(key postgres.MerchantApiKey) (string, error) {
+	if m.secrets == nil {
+		return "", m.secretsErr
+	}
+	return openSecret(m.secrets, key.KeyID, key.SecretCiphertext)
+}
+
+func (m *merchantUsecase) getMerchant(ctx context.Context, query repository.IRepository, merchantID int32) (postgres.Merchant, error) {
+	merchant, err := query.GetMerchantByID(ctx, merchantID)
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return merchant, errors.NotFound.NewWithUserMsg(err, "merchant not found")
+		}
+		logging.NewFromContext(ctx).Error("failed to get merchant", zap.Error(err))
+		return merchant, errors.InternalServer.NewWithUserMsg(err, "failed to get merchant")
+	}
+
+	return merchant, nil
+}
+
+// invalidSignature doesn't tell the caller which check failed, the reason is only logged
+func invalidSignature(format string, args ...interface{}) error {
+	return errors.WithCode(errors.Unauthorized.New(format, args...), errors.CodeInvalidSignature)
+}
+
+func randomToken(prefix string, size int) (string, error) {
+	token := make([]byte, size)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return prefix + hex.EncodeToString(token), nil
+}
@@ -0,0 +1,261 @@
+package merchant
+
+import (
+	"context"
+	"database/sql"
+	mock_configuration "kc-ewallet/configurations/mocks"
+	mock_repository "kc-ewallet/domains/repository/mocks"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/protocols/http/request"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testKeyID  = "mk_test"
+	testSecret = "msk_test"
+	testNonce  = "0123456789abcdef"
+
+	testEncryptionKey = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+)
+
+func newTestUsecase(t *testing.T) (*merchantUsecase, *mock_repository.MockIRepository, *mock_repository.MockINonceCache) {
+	return newTestUsecaseWithKey(t, testEncryptionKey)
+}
+
+func newTestUsecaseWithKey(t *testing.T, encryptionKey string) (*merchantUsecase, *mock_repository.MockIRepository, *mock_repository.MockINonceCache) {
+	ctrl := gomock.NewController(t)
+
+	config := mock_configuration.NewMockIMerchantConfiguration(ctrl)
+	config.EXPECT().GetKeyRotationOverlap().Return(24 * time.Hour).AnyTimes()
+	config.EXPECT().GetSignatureTolerance().Return(5 * time.Minute).AnyTimes()
+	config.EXPECT().GetSecretEncryptionKey().Return(encryptionKey).AnyTimes()
+
+	repo := mock_repository.NewMockIRepository(ctrl)
+	nonces := mock_repository.NewMockINonceCache(ctrl)
+	return NewMerchantUsecase(nil, repo, nonces, config, nil, nil), repo, nonces
+}
+
+func signedRequest(secret string, timestamp time.Time, nonce string, body string) request.MerchantSignature {
+	return request.MerchantSignature{
+		KeyID:     testKeyID,
+		Timestamp: strconv.FormatInt(timestamp.Unix(), 10),
+		Nonce:     nonce,
+		Signature: Sign(secret, http.MethodPost, "/api/merchant/payments?ref=1", timestamp.Unix(), nonce, []byte(body)),
+		Method:    http.MethodPost,
+		Path:      "/api/merchant/payments?ref=1",
+		Body:      []byte(body),
+	}
+}
+
+// sealedFor encrypts testSecret the way it is stored for keyID
+func sealedFor(t *testing.T, keyID string) string {
+	aead, err := newSecretCipher(testEncryptionKey)
+	require.NoError(t, err)
+	sealed, err := sealSecret(aead, keyID, testSecret)
+	require.NoError(t, err)
+	return sealed
+}
+
+func TestMerchantUsecase_Authenticate(t *testing.T) {
+	now := time.Now()
+	key := postgres.MerchantApiKey{ID: 1, MerchantID: 7, KeyID: testKeyID, SecretCiphertext: sealedFor(t, testKeyID)}
+
+	testCases := []struct {
+		name        string
+		signature   func() request.MerchantSignature
+		key         postgres.MerchantApiKey
+		keyErr      error
+		getsKey     bool
+		claims      bool
+		claimed     bool
+		expectedErr errors.ErrorType
+	}{
+		{
+			name:      "should accept a valid signature",
+			signature: func() request.MerchantSignature { return signedRequest(testSecret, now, testNonce, `{"amount":10}`) },
+			key:       key,
+			getsKey:   true,
+			claims:    true,
+			claimed:   true,
+		},
+		{
+			name: "should accept a key inside its rotation overlap",
+			signature: func() request.MerchantSignature {
+				return signedRequest(testSecret, now, testNonce, `{"amount":10}`)
+			},
+			key: func() postgres.MerchantApiKey {
+				rotated := key
+				rotated.ExpiresAt = sql.NullTime{Time: now.Add(time.Hour), Valid: true}
+				return rotated
+			}(),
+			getsKey: true,
+			claims:  true,
+			claimed: true,
+		},
+		{
+			name: "should reject a tampered body",
+			signature: func() request.MerchantSignature {
+				signature := signedRequest(testSecret, now, testNonce, `{"amount":10}`)
+				signature.Body = []byte(`{"amount":1000}`)
+				return signature
+			},
+			key:         key,
+			getsKey:     true,
+			expectedErr: errors.Unauthorized,
+		},
+		{
+			name: "should reject another path",
+			signature: func() request.MerchantSignature {
+				signature := signedRequest(testSecret, now, testNonce, `{"amount":10}`)
+				signature.Path = "/api/merchant/refunds"
+				return signature
+			},
+			key:         key,
+			getsKey:     true,
+			expectedErr: errors.Unauthorized,
+		},
+		{
+			name:        "should reject another secret",
+			signature:   func() request.MerchantSignature { return signedRequest("msk_other", now, testNonce, "") },
+			key:         key,
+			getsKey:     true,
+			expectedErr: errors.Unauthorized,
+		},
+		{
+			name:      "should fail on a secret sealed for another key",
+			signature: func() request.MerchantSignature { return signedRequest(testSecret, now, testNonce, "") },
+			key: func() postgres.MerchantApiKey {
+				swapped := key
+				swapped.SecretCiphertext = sealedFor(t, "mk_other")
+				return swapped
+			}(),
+			getsKey:     true,
+			expectedErr: errors.InternalServer,
+		},
+		{
+			name:        "should reject a replayed nonce",
+			signature:   func() request.MerchantSignature { return signedRequest(testSecret, now, testNonce, "") },
+			key:         key,
+			getsKey:     true,
+			claims:      true,
+			claimed:     false,
+			expectedErr: errors.Unauthorized,
+		},
+		{
+			name: "should reject a timestamp outside the tolerance",
+			signature: func() request.MerchantSignature {
+				return signedRequest(testSecret, now.Add(-10*time.Minute), testNonce, "")
+			},
+			expectedErr: errors.Unauthorized,
+		},
+		{
+			name:        "should reject a short nonce",
+			signature:   func() request.MerchantSignature { return signedRequest(testSecret, now, "abc", "") },
+			expectedErr: errors.Unauthorized,
+		},
+		{
+			name:        "should reject an unknown key",
+			signature:   func() request.MerchantSignature { return signedRequest(testSecret, now, testNonce, "") },
+			keyErr:      sql.ErrNoRows,
+			getsKey:     true,
+			expectedErr: errors.Unauthorized,
+		},
+		{
+			name:      "should reject an expired key",
+			signature: func() request.MerchantSignature { return signedRequest(testSecret, now, testNonce, "") },
+			key: func() postgres.MerchantApiKey {
+				revoked := key
+				revoked.ExpiresAt = sql.NullTime{Time: now.Add(-time.Second), Valid: true}
+				return revoked
+			}(),
+			getsKey:     true,
+			expectedErr: errors.Unauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			usecase, repo, nonces := newTestUsecase(t)
+
+			if tc.getsKey {
+				repo.EXPECT().GetMerchantApiKeyByKeyID(gomock.Any(), testKeyID).Return(tc.key, tc.keyErr)
+			}
+			if tc.claims {
+				nonces.EXPECT().Claim(gomock.Any(), "merchant-nonce:"+testKeyID+":"+testNonce, 10*time.Minute).Return(tc.claimed, nil)
+			}
+
+			authenticated, err := usecase.Authenticate(context.Background(), tc.signature())
+			if tc.expectedErr != 0 {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr, errors.GetType(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int32(7), authenticated.MerchantID)
+		})
+	}
+}
+
+func TestMerchantUsecase_RotateKey(t *testing.T) {
+	usecase, repo, _ := newTestUsecase(t)
+	before := time.Now().UTC()
+
+	repo.EXPECT().GetMerchantByID(gomock.Any(), int32(7)).Return(postgres.Merchant{ID: 7}, nil)
+	repo.EXPECT().ExpireMerchantApiKeys(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, arg postgres.ExpireMerchantApiKeysParams) (int64, error) {
+			assert.Equal(t, int32(7), arg.MerchantID)
+			// the previous keys keep working for the overlap
+			assert.WithinDuration(t, before.Add(24*time.Hour), arg.ExpiresAt, time.Minute)
+			return 1, nil
+		})
+	repo.EXPECT().CreateMerchantApiKey(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, arg postgres.CreateMerchantApiKeyParams) (postgres.MerchantApiKey, error) {
+			return postgres.MerchantApiKey{ID: 2, MerchantID: arg.MerchantID, KeyID: arg.KeyID, SecretCiphertext: arg.SecretCiphertext}, nil
+		})
+
+	key, err := usecase.RotateKey(context.Background(), 7)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(key.KeyID, keyIDPrefix))
+	assert.True(t, strings.HasPrefix(key.Secret, secretPrefix))
+	// only the ciphertext is stored, it opens to the secret under the new key id only
+	assert.NotContains(t, key.SecretCiphertext, key.Secret)
+	secret, err := usecase.openSecret(key.MerchantApiKey)
+	require.NoError(t, err)
+	assert.Equal(t, key.Secret, secret)
+}
+
+func TestMerchantUsecase_RotateKey_WithoutEncryptionKey(t *testing.T) {
+	usecase, repo, _ := newTestUsecaseWithKey(t, "")
+
+	repo.EXPECT().GetMerchantByID(gomock.Any(), int32(7)).Return(postgres.Merchant{ID: 7}, nil)
+	repo.EXPECT().ExpireMerchantApiKeys(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+
+	_, err := usecase.RotateKey(context.Background(), 7)
+	assert.Equal(t, errors.InternalServer, errors.GetType(err))
+}
+
+func TestMerchantUsecase_RevokeKey(t *testing.T) {
+	usecase, repo, _ := newTestUsecase(t)
+
+	repo.EXPECT().ExpireMerchantApiKey(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+
+	err := usecase.RevokeKey(context.Background(), 7, "mk_missing")
+	assert.Equal(t, errors.NotFound, errors.GetType(err))
+}
+
+func TestCanonicalRequest(t *testing.T) {
+	assert.Equal(t,
+		"POST\n/api/merchant/profile?a=1\n1700000000\n"+testNonce+"\ne3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		CanonicalRequest("post", "/api/merchant/profile?a=1", 1_700_000_000, testNonce, nil),
+	)
+}
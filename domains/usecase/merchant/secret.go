@@ -0,0 +1,62 @@
+package merchant
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	goerrors "errors"
+	"fmt"
+)
+
+const secretKeySize = 32
+
+var errMalformedSecret = goerrors.New("stored secret is malformed")
+
+// newSecretCipher is the AES-256-GCM cipher of the hex encoded key the secrets are
+// encrypted with at rest
+func newSecretCipher(hexKey string) (cipher.AEAD, error) {
+	key, err := hex.DecodeString(hexKey)
+	if err != nil {
+		return nil, fmt.Errorf("MERCHANT_SECRET_ENCRYPTION_KEY is not hex: %w", err)
+	}
+	if len(key) != secretKeySize {
+		return nil, fmt.Errorf("MERCHANT_SECRET_ENCRYPTION_KEY must be %d bytes, got %d", secretKeySize, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// sealSecret encrypts a secret for storage with a random nonce put in front of the
+// ciphertext. The key id is the additional data, so a stored secret copied onto
+// another key doesn't open
+func sealSecret(aead cipher.AEAD, keyID, secret string) (string, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, []byte(secret), []byte(keyID))), nil
+}
+
+// openSecret decrypts a secret stored by sealSecret for keyID
+func openSecret(aead cipher.AEAD, keyID, sealed string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil {
+		return "", errMalformedSecret
+	}
+	if len(data) < aead.NonceSize() {
+		return "", errMalformedSecret
+	}
+
+	secret, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], []byte(keyID))
+	if err != nil {
+		return "", err
+	}
+	return string(secret), nil
+}
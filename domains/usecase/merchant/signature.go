@@ -0,0 +1,39 @@
+package merchant
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+const (
+	KeyIDHeader     = "X-Merchant-Key"
+	TimestampHeader = "X-Merchant-Timestamp"
+	NonceHeader     = "X-Merchant-Nonce"
+	SignatureHeader = "X-Merchant-Signature"
+)
+
+const signatureVersion = "v1"
+
+// CanonicalRequest is the signed string, one line each for the method, path with
+// query, unix timestamp, nonce and the hex SHA-256 of the body
+func CanonicalRequest(method, path string, timestamp int64, nonce string, body []byte) string {
+	bodyHash := sha256.Sum256(body)
+	return strings.Join([]string{
+		strings.ToUpper(method),
+		path,
+		strconv.FormatInt(timestamp, 10),
+		nonce,
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")
+}
+
+// Sign returns the signature header value, an HMAC-SHA256 of the canonical request
+// keyed with the secret
+func Sign(secret, method, path string, timestamp int64, nonce string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(CanonicalRequest(method, path, timestamp, nonce, body)))
+	return signatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
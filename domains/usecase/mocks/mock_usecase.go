@@ -6,7 +6,12 @@ package mock_usecase
 
 import (
 	context "context"
+	sql "database/sql"
 	postgres "kc-ewallet/domains/repository/postgres"
+	stream "kc-ewallet/domains/repository/stream"
+	usecase "kc-ewallet/domains/usecase"
+	audit "kc-ewallet/domains/usecase/audit"
+	realtime "kc-ewallet/domains/usecase/realtime"
 	request "kc-ewallet/protocols/http/request"
 	reflect "reflect"
 
@@ -135,3 +140,1230 @@ func (mr *MockITransactionUsecaseMockRecorder) CreateDebitTransaction(ctx, reque
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDebitTransaction", reflect.TypeOf((*MockITransactionUsecase)(nil).CreateDebitTransaction), ctx, request)
 }
+
+// CreatePayment mocks base method.
+func (m *MockITransactionUsecase) CreatePayment(ctx context.Context, request request.CreatePaymentRequest) (postgres.Payment, float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePayment", ctx, request)
+	ret0, _ := ret[0].(postgres.Payment)
+	ret1, _ := ret[1].(float64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreatePayment indicates an expected call of CreatePayment.
+func (mr *MockITransactionUsecaseMockRecorder) CreatePayment(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayment", reflect.TypeOf((*MockITransactionUsecase)(nil).CreatePayment), ctx, request)
+}
+
+// QuoteTransaction mocks base method.
+func (m *MockITransactionUsecase) QuoteTransaction(ctx context.Context, request request.CreateTransactionQuoteRequest) (usecase.TransactionQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuoteTransaction", ctx, request)
+	ret0, _ := ret[0].(usecase.TransactionQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuoteTransaction indicates an expected call of QuoteTransaction.
+func (mr *MockITransactionUsecaseMockRecorder) QuoteTransaction(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuoteTransaction", reflect.TypeOf((*MockITransactionUsecase)(nil).QuoteTransaction), ctx, request)
+}
+
+// MockIAuditUsecase is a mock of IAuditUsecase interface.
+type MockIAuditUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuditUsecaseMockRecorder
+}
+
+// MockIAuditUsecaseMockRecorder is the mock recorder for MockIAuditUsecase.
+type MockIAuditUsecaseMockRecorder struct {
+	mock *MockIAuditUsecase
+}
+
+// NewMockIAuditUsecase creates a new mock instance.
+func NewMockIAuditUsecase(ctrl *gomock.Controller) *MockIAuditUsecase {
+	mock := &MockIAuditUsecase{ctrl: ctrl}
+	mock.recorder = &MockIAuditUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuditUsecase) EXPECT() *MockIAuditUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockIAuditUsecase) Record(ctx context.Context, event audit.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockIAuditUsecaseMockRecorder) Record(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockIAuditUsecase)(nil).Record), ctx, event)
+}
+
+// RecordTx mocks base method.
+func (m *MockIAuditUsecase) RecordTx(ctx context.Context, tx *sql.Tx, event audit.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordTx", ctx, tx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordTx indicates an expected call of RecordTx.
+func (mr *MockIAuditUsecaseMockRecorder) RecordTx(ctx, tx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordTx", reflect.TypeOf((*MockIAuditUsecase)(nil).RecordTx), ctx, tx, event)
+}
+
+// Verify mocks base method.
+func (m *MockIAuditUsecase) Verify(ctx context.Context) (audit.VerifyResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx)
+	ret0, _ := ret[0].(audit.VerifyResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockIAuditUsecaseMockRecorder) Verify(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockIAuditUsecase)(nil).Verify), ctx)
+}
+
+// MockIRealtimeUsecase is a mock of IRealtimeUsecase interface.
+type MockIRealtimeUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRealtimeUsecaseMockRecorder
+}
+
+// MockIRealtimeUsecaseMockRecorder is the mock recorder for MockIRealtimeUsecase.
+type MockIRealtimeUsecaseMockRecorder struct {
+	mock *MockIRealtimeUsecase
+}
+
+// NewMockIRealtimeUsecase creates a new mock instance.
+func NewMockIRealtimeUsecase(ctrl *gomock.Controller) *MockIRealtimeUsecase {
+	mock := &MockIRealtimeUsecase{ctrl: ctrl}
+	mock.recorder = &MockIRealtimeUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRealtimeUsecase) EXPECT() *MockIRealtimeUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Subscribe mocks base method.
+func (m *MockIRealtimeUsecase) Subscribe(ctx context.Context, userID int32, lastEventID string) (*realtime.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, userID, lastEventID)
+	ret0, _ := ret[0].(*realtime.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockIRealtimeUsecaseMockRecorder) Subscribe(ctx, userID, lastEventID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockIRealtimeUsecase)(nil).Subscribe), ctx, userID, lastEventID)
+}
+
+// MockIWebhookUsecase is a mock of IWebhookUsecase interface.
+type MockIWebhookUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWebhookUsecaseMockRecorder
+}
+
+// MockIWebhookUsecaseMockRecorder is the mock recorder for MockIWebhookUsecase.
+type MockIWebhookUsecaseMockRecorder struct {
+	mock *MockIWebhookUsecase
+}
+
+// NewMockIWebhookUsecase creates a new mock instance.
+func NewMockIWebhookUsecase(ctrl *gomock.Controller) *MockIWebhookUsecase {
+	mock := &MockIWebhookUsecase{ctrl: ctrl}
+	mock.recorder = &MockIWebhookUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWebhookUsecase) EXPECT() *MockIWebhookUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateSubscription mocks base method.
+func (m *MockIWebhookUsecase) CreateSubscription(ctx context.Context, request request.CreateWebhookRequest) (postgres.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubscription", ctx, request)
+	ret0, _ := ret[0].(postgres.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubscription indicates an expected call of CreateSubscription.
+func (mr *MockIWebhookUsecaseMockRecorder) CreateSubscription(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockIWebhookUsecase)(nil).CreateSubscription), ctx, request)
+}
+
+// DeleteSubscription mocks base method.
+func (m *MockIWebhookUsecase) DeleteSubscription(ctx context.Context, userID, subscriptionID int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscription", ctx, userID, subscriptionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscription indicates an expected call of DeleteSubscription.
+func (mr *MockIWebhookUsecaseMockRecorder) DeleteSubscription(ctx, userID, subscriptionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockIWebhookUsecase)(nil).DeleteSubscription), ctx, userID, subscriptionID)
+}
+
+// EnqueueTx mocks base method.
+func (m *MockIWebhookUsecase) EnqueueTx(ctx context.Context, tx *sql.Tx, events []stream.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueTx", ctx, tx, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnqueueTx indicates an expected call of EnqueueTx.
+func (mr *MockIWebhookUsecaseMockRecorder) EnqueueTx(ctx, tx, events interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueTx", reflect.TypeOf((*MockIWebhookUsecase)(nil).EnqueueTx), ctx, tx, events)
+}
+
+// ListDeliveries mocks base method.
+func (m *MockIWebhookUsecase) ListDeliveries(ctx context.Context, request request.ListWebhookDeliveriesRequest) ([]postgres.WebhookDelivery, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveries", ctx, request)
+	ret0, _ := ret[0].([]postgres.WebhookDelivery)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListDeliveries indicates an expected call of ListDeliveries.
+func (mr *MockIWebhookUsecaseMockRecorder) ListDeliveries(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*MockIWebhookUsecase)(nil).ListDeliveries), ctx, request)
+}
+
+// ListSubscriptions mocks base method.
+func (m *MockIWebhookUsecase) ListSubscriptions(ctx context.Context, userID int32) ([]postgres.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubscriptions", ctx, userID)
+	ret0, _ := ret[0].([]postgres.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubscriptions indicates an expected call of ListSubscriptions.
+func (mr *MockIWebhookUsecaseMockRecorder) ListSubscriptions(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscriptions", reflect.TypeOf((*MockIWebhookUsecase)(nil).ListSubscriptions), ctx, userID)
+}
+
+// Redeliver mocks base method.
+func (m *MockIWebhookUsecase) Redeliver(ctx context.Context, userID, subscriptionID int32, deliveryID int64) (postgres.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeliver", ctx, userID, subscriptionID, deliveryID)
+	ret0, _ := ret[0].(postgres.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeliver indicates an expected call of Redeliver.
+func (mr *MockIWebhookUsecaseMockRecorder) Redeliver(ctx, userID, subscriptionID, deliveryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeliver", reflect.TypeOf((*MockIWebhookUsecase)(nil).Redeliver), ctx, userID, subscriptionID, deliveryID)
+}
+
+// MockIMerchantUsecase is a mock of IMerchantUsecase interface.
+type MockIMerchantUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMerchantUsecaseMockRecorder
+}
+
+// MockIMerchantUsecaseMockRecorder is the mock recorder for MockIMerchantUsecase.
+type MockIMerchantUsecaseMockRecorder struct {
+	mock *MockIMerchantUsecase
+}
+
+// NewMockIMerchantUsecase creates a new mock instance.
+func NewMockIMerchantUsecase(ctrl *gomock.Controller) *MockIMerchantUsecase {
+	mock := &MockIMerchantUsecase{ctrl: ctrl}
+	mock.recorder = &MockIMerchantUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMerchantUsecase) EXPECT() *MockIMerchantUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockIMerchantUsecase) Authenticate(ctx context.Context, signature request.MerchantSignature) (postgres.MerchantApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, signature)
+	ret0, _ := ret[0].(postgres.MerchantApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockIMerchantUsecaseMockRecorder) Authenticate(ctx, signature interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockIMerchantUsecase)(nil).Authenticate), ctx, signature)
+}
+
+// CreateMerchant mocks base method.
+func (m *MockIMerchantUsecase) CreateMerchant(ctx context.Context, request request.CreateMerchantRequest) (postgres.Merchant, usecase.IssuedMerchantKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMerchant", ctx, request)
+	ret0, _ := ret[0].(postgres.Merchant)
+	ret1, _ := ret[1].(usecase.IssuedMerchantKey)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateMerchant indicates an expected call of CreateMerchant.
+func (mr *MockIMerchantUsecaseMockRecorder) CreateMerchant(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMerchant", reflect.TypeOf((*MockIMerchantUsecase)(nil).CreateMerchant), ctx, request)
+}
+
+// GetMerchant mocks base method.
+func (m *MockIMerchantUsecase) GetMerchant(ctx context.Context, merchantID int32) (postgres.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerchant", ctx, merchantID)
+	ret0, _ := ret[0].(postgres.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMerchant indicates an expected call of GetMerchant.
+func (mr *MockIMerchantUsecaseMockRecorder) GetMerchant(ctx, merchantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchant", reflect.TypeOf((*MockIMerchantUsecase)(nil).GetMerchant), ctx, merchantID)
+}
+
+// ListKeys mocks base method.
+func (m *MockIMerchantUsecase) ListKeys(ctx context.Context, merchantID int32) ([]postgres.MerchantApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKeys", ctx, merchantID)
+	ret0, _ := ret[0].([]postgres.MerchantApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListKeys indicates an expected call of ListKeys.
+func (mr *MockIMerchantUsecaseMockRecorder) ListKeys(ctx, merchantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKeys", reflect.TypeOf((*MockIMerchantUsecase)(nil).ListKeys), ctx, merchantID)
+}
+
+// RevokeKey mocks base method.
+func (m *MockIMerchantUsecase) RevokeKey(ctx context.Context, merchantID int32, keyID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeKey", ctx, merchantID, keyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeKey indicates an expected call of RevokeKey.
+func (mr *MockIMerchantUsecaseMockRecorder) RevokeKey(ctx, merchantID, keyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeKey", reflect.TypeOf((*MockIMerchantUsecase)(nil).RevokeKey), ctx, merchantID, keyID)
+}
+
+// RotateKey mocks base method.
+func (m *MockIMerchantUsecase) RotateKey(ctx context.Context, merchantID int32) (usecase.IssuedMerchantKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateKey", ctx, merchantID)
+	ret0, _ := ret[0].(usecase.IssuedMerchantKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateKey indicates an expected call of RotateKey.
+func (mr *MockIMerchantUsecaseMockRecorder) RotateKey(ctx, merchantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateKey", reflect.TypeOf((*MockIMerchantUsecase)(nil).RotateKey), ctx, merchantID)
+}
+
+// MockISettlementUsecase is a mock of ISettlementUsecase interface.
+type MockISettlementUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockISettlementUsecaseMockRecorder
+}
+
+// MockISettlementUsecaseMockRecorder is the mock recorder for MockISettlementUsecase.
+type MockISettlementUsecaseMockRecorder struct {
+	mock *MockISettlementUsecase
+}
+
+// NewMockISettlementUsecase creates a new mock instance.
+func NewMockISettlementUsecase(ctrl *gomock.Controller) *MockISettlementUsecase {
+	mock := &MockISettlementUsecase{ctrl: ctrl}
+	mock.recorder = &MockISettlementUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISettlementUsecase) EXPECT() *MockISettlementUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetSettlementReport mocks base method.
+func (m *MockISettlementUsecase) GetSettlementReport(ctx context.Context, merchantID, settlementID int32) (postgres.Settlement, []postgres.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSettlementReport", ctx, merchantID, settlementID)
+	ret0, _ := ret[0].(postgres.Settlement)
+	ret1, _ := ret[1].([]postgres.Payment)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSettlementReport indicates an expected call of GetSettlementReport.
+func (mr *MockISettlementUsecaseMockRecorder) GetSettlementReport(ctx, merchantID, settlementID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettlementReport", reflect.TypeOf((*MockISettlementUsecase)(nil).GetSettlementReport), ctx, merchantID, settlementID)
+}
+
+// ListSettlements mocks base method.
+func (m *MockISettlementUsecase) ListSettlements(ctx context.Context, request request.ListSettlementsRequest) ([]postgres.Settlement, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSettlements", ctx, request)
+	ret0, _ := ret[0].([]postgres.Settlement)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListSettlements indicates an expected call of ListSettlements.
+func (mr *MockISettlementUsecaseMockRecorder) ListSettlements(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSettlements", reflect.TypeOf((*MockISettlementUsecase)(nil).ListSettlements), ctx, request)
+}
+
+// Run mocks base method.
+func (m *MockISettlementUsecase) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockISettlementUsecaseMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockISettlementUsecase)(nil).Run), ctx)
+}
+
+// MockIFeeUsecase is a mock of IFeeUsecase interface.
+type MockIFeeUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIFeeUsecaseMockRecorder
+}
+
+// MockIFeeUsecaseMockRecorder is the mock recorder for MockIFeeUsecase.
+type MockIFeeUsecaseMockRecorder struct {
+	mock *MockIFeeUsecase
+}
+
+// NewMockIFeeUsecase creates a new mock instance.
+func NewMockIFeeUsecase(ctrl *gomock.Controller) *MockIFeeUsecase {
+	mock := &MockIFeeUsecase{ctrl: ctrl}
+	mock.recorder = &MockIFeeUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIFeeUsecase) EXPECT() *MockIFeeUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Calculate mocks base method.
+func (m *MockIFeeUsecase) Calculate(ctx context.Context, input usecase.FeeInput) (usecase.Fee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Calculate", ctx, input)
+	ret0, _ := ret[0].(usecase.Fee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Calculate indicates an expected call of Calculate.
+func (mr *MockIFeeUsecaseMockRecorder) Calculate(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Calculate", reflect.TypeOf((*MockIFeeUsecase)(nil).Calculate), ctx, input)
+}
+
+// CreateRule mocks base method.
+func (m *MockIFeeUsecase) CreateRule(ctx context.Context, request request.CreateFeeRuleRequest) (postgres.FeeRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRule", ctx, request)
+	ret0, _ := ret[0].(postgres.FeeRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRule indicates an expected call of CreateRule.
+func (mr *MockIFeeUsecaseMockRecorder) CreateRule(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRule", reflect.TypeOf((*MockIFeeUsecase)(nil).CreateRule), ctx, request)
+}
+
+// DeactivateRule mocks base method.
+func (m *MockIFeeUsecase) DeactivateRule(ctx context.Context, ruleID int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateRule", ctx, ruleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeactivateRule indicates an expected call of DeactivateRule.
+func (mr *MockIFeeUsecaseMockRecorder) DeactivateRule(ctx, ruleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateRule", reflect.TypeOf((*MockIFeeUsecase)(nil).DeactivateRule), ctx, ruleID)
+}
+
+// ListRules mocks base method.
+func (m *MockIFeeUsecase) ListRules(ctx context.Context, activeOnly bool) ([]postgres.FeeRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRules", ctx, activeOnly)
+	ret0, _ := ret[0].([]postgres.FeeRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRules indicates an expected call of ListRules.
+func (mr *MockIFeeUsecaseMockRecorder) ListRules(ctx, activeOnly interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRules", reflect.TypeOf((*MockIFeeUsecase)(nil).ListRules), ctx, activeOnly)
+}
+
+// PostTx mocks base method.
+func (m *MockIFeeUsecase) PostTx(ctx context.Context, tx *sql.Tx, posting usecase.FeePosting) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostTx", ctx, tx, posting)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PostTx indicates an expected call of PostTx.
+func (mr *MockIFeeUsecaseMockRecorder) PostTx(ctx, tx, posting interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostTx", reflect.TypeOf((*MockIFeeUsecase)(nil).PostTx), ctx, tx, posting)
+}
+
+// MockIWalletUsecase is a mock of IWalletUsecase interface.
+type MockIWalletUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWalletUsecaseMockRecorder
+}
+
+// MockIWalletUsecaseMockRecorder is the mock recorder for MockIWalletUsecase.
+type MockIWalletUsecaseMockRecorder struct {
+	mock *MockIWalletUsecase
+}
+
+// NewMockIWalletUsecase creates a new mock instance.
+func NewMockIWalletUsecase(ctrl *gomock.Controller) *MockIWalletUsecase {
+	mock := &MockIWalletUsecase{ctrl: ctrl}
+	mock.recorder = &MockIWalletUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWalletUsecase) EXPECT() *MockIWalletUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ListCurrencies mocks base method.
+func (m *MockIWalletUsecase) ListCurrencies(ctx context.Context) ([]postgres.Currency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCurrencies", ctx)
+	ret0, _ := ret[0].([]postgres.Currency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCurrencies indicates an expected call of ListCurrencies.
+func (mr *MockIWalletUsecaseMockRecorder) ListCurrencies(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCurrencies", reflect.TypeOf((*MockIWalletUsecase)(nil).ListCurrencies), ctx)
+}
+
+// ListWallets mocks base method.
+func (m *MockIWalletUsecase) ListWallets(ctx context.Context, userID int32) ([]postgres.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWallets", ctx, userID)
+	ret0, _ := ret[0].([]postgres.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWallets indicates an expected call of ListWallets.
+func (mr *MockIWalletUsecaseMockRecorder) ListWallets(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWallets", reflect.TypeOf((*MockIWalletUsecase)(nil).ListWallets), ctx, userID)
+}
+
+// OpenWallet mocks base method.
+func (m *MockIWalletUsecase) OpenWallet(ctx context.Context, request request.OpenWalletRequest) (postgres.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenWallet", ctx, request)
+	ret0, _ := ret[0].(postgres.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenWallet indicates an expected call of OpenWallet.
+func (mr *MockIWalletUsecaseMockRecorder) OpenWallet(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenWallet", reflect.TypeOf((*MockIWalletUsecase)(nil).OpenWallet), ctx, request)
+}
+
+// MockIExchangeUsecase is a mock of IExchangeUsecase interface.
+type MockIExchangeUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIExchangeUsecaseMockRecorder
+}
+
+// MockIExchangeUsecaseMockRecorder is the mock recorder for MockIExchangeUsecase.
+type MockIExchangeUsecaseMockRecorder struct {
+	mock *MockIExchangeUsecase
+}
+
+// NewMockIExchangeUsecase creates a new mock instance.
+func NewMockIExchangeUsecase(ctrl *gomock.Controller) *MockIExchangeUsecase {
+	mock := &MockIExchangeUsecase{ctrl: ctrl}
+	mock.recorder = &MockIExchangeUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIExchangeUsecase) EXPECT() *MockIExchangeUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Exchange mocks base method.
+func (m *MockIExchangeUsecase) Exchange(ctx context.Context, request request.CreateExchangeRequest) (usecase.ExchangeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exchange", ctx, request)
+	ret0, _ := ret[0].(usecase.ExchangeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exchange indicates an expected call of Exchange.
+func (mr *MockIExchangeUsecaseMockRecorder) Exchange(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exchange", reflect.TypeOf((*MockIExchangeUsecase)(nil).Exchange), ctx, request)
+}
+
+// QuoteExchange mocks base method.
+func (m *MockIExchangeUsecase) QuoteExchange(ctx context.Context, request request.CreateExchangeQuoteRequest) (usecase.ExchangeQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuoteExchange", ctx, request)
+	ret0, _ := ret[0].(usecase.ExchangeQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuoteExchange indicates an expected call of QuoteExchange.
+func (mr *MockIExchangeUsecaseMockRecorder) QuoteExchange(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuoteExchange", reflect.TypeOf((*MockIExchangeUsecase)(nil).QuoteExchange), ctx, request)
+}
+
+// MockIPocketUsecase is a mock of IPocketUsecase interface.
+type MockIPocketUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPocketUsecaseMockRecorder
+}
+
+// MockIPocketUsecaseMockRecorder is the mock recorder for MockIPocketUsecase.
+type MockIPocketUsecaseMockRecorder struct {
+	mock *MockIPocketUsecase
+}
+
+// NewMockIPocketUsecase creates a new mock instance.
+func NewMockIPocketUsecase(ctrl *gomock.Controller) *MockIPocketUsecase {
+	mock := &MockIPocketUsecase{ctrl: ctrl}
+	mock.recorder = &MockIPocketUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPocketUsecase) EXPECT() *MockIPocketUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreatePocket mocks base method.
+func (m *MockIPocketUsecase) CreatePocket(ctx context.Context, request request.CreatePocketRequest) (postgres.Pocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePocket", ctx, request)
+	ret0, _ := ret[0].(postgres.Pocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePocket indicates an expected call of CreatePocket.
+func (mr *MockIPocketUsecaseMockRecorder) CreatePocket(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePocket", reflect.TypeOf((*MockIPocketUsecase)(nil).CreatePocket), ctx, request)
+}
+
+// ListBalances mocks base method.
+func (m *MockIPocketUsecase) ListBalances(ctx context.Context, userID int32) ([]usecase.WalletBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBalances", ctx, userID)
+	ret0, _ := ret[0].([]usecase.WalletBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBalances indicates an expected call of ListBalances.
+func (mr *MockIPocketUsecaseMockRecorder) ListBalances(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBalances", reflect.TypeOf((*MockIPocketUsecase)(nil).ListBalances), ctx, userID)
+}
+
+// ListEntries mocks base method.
+func (m *MockIPocketUsecase) ListEntries(ctx context.Context, request request.ListPocketEntriesRequest) ([]postgres.PocketEntry, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntries", ctx, request)
+	ret0, _ := ret[0].([]postgres.PocketEntry)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListEntries indicates an expected call of ListEntries.
+func (mr *MockIPocketUsecaseMockRecorder) ListEntries(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntries", reflect.TypeOf((*MockIPocketUsecase)(nil).ListEntries), ctx, request)
+}
+
+// MoveMoney mocks base method.
+func (m *MockIPocketUsecase) MoveMoney(ctx context.Context, request request.MovePocketMoneyRequest) (usecase.WalletBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveMoney", ctx, request)
+	ret0, _ := ret[0].(usecase.WalletBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveMoney indicates an expected call of MoveMoney.
+func (mr *MockIPocketUsecaseMockRecorder) MoveMoney(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveMoney", reflect.TypeOf((*MockIPocketUsecase)(nil).MoveMoney), ctx, request)
+}
+
+// SetDefaultPocket mocks base method.
+func (m *MockIPocketUsecase) SetDefaultPocket(ctx context.Context, userID, pocketID int32, isDefault bool) (postgres.Pocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDefaultPocket", ctx, userID, pocketID, isDefault)
+	ret0, _ := ret[0].(postgres.Pocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetDefaultPocket indicates an expected call of SetDefaultPocket.
+func (mr *MockIPocketUsecaseMockRecorder) SetDefaultPocket(ctx, userID, pocketID, isDefault interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDefaultPocket", reflect.TypeOf((*MockIPocketUsecase)(nil).SetDefaultPocket), ctx, userID, pocketID, isDefault)
+}
+
+// MockISavingsUsecase is a mock of ISavingsUsecase interface.
+type MockISavingsUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockISavingsUsecaseMockRecorder
+}
+
+// MockISavingsUsecaseMockRecorder is the mock recorder for MockISavingsUsecase.
+type MockISavingsUsecaseMockRecorder struct {
+	mock *MockISavingsUsecase
+}
+
+// NewMockISavingsUsecase creates a new mock instance.
+func NewMockISavingsUsecase(ctrl *gomock.Controller) *MockISavingsUsecase {
+	mock := &MockISavingsUsecase{ctrl: ctrl}
+	mock.recorder = &MockISavingsUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISavingsUsecase) EXPECT() *MockISavingsUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ApplyDebitTx mocks base method.
+func (m *MockISavingsUsecase) ApplyDebitTx(ctx context.Context, tx *sql.Tx, debit usecase.SavingsDebit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyDebitTx", ctx, tx, debit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyDebitTx indicates an expected call of ApplyDebitTx.
+func (mr *MockISavingsUsecaseMockRecorder) ApplyDebitTx(ctx, tx, debit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyDebitTx", reflect.TypeOf((*MockISavingsUsecase)(nil).ApplyDebitTx), ctx, tx, debit)
+}
+
+// CreateGoal mocks base method.
+func (m *MockISavingsUsecase) CreateGoal(ctx context.Context, request request.CreateSavingsGoalRequest) (usecase.SavingsProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGoal", ctx, request)
+	ret0, _ := ret[0].(usecase.SavingsProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGoal indicates an expected call of CreateGoal.
+func (mr *MockISavingsUsecaseMockRecorder) CreateGoal(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGoal", reflect.TypeOf((*MockISavingsUsecase)(nil).CreateGoal), ctx, request)
+}
+
+// GetGoal mocks base method.
+func (m *MockISavingsUsecase) GetGoal(ctx context.Context, userID, goalID int32) (usecase.SavingsProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoal", ctx, userID, goalID)
+	ret0, _ := ret[0].(usecase.SavingsProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoal indicates an expected call of GetGoal.
+func (mr *MockISavingsUsecaseMockRecorder) GetGoal(ctx, userID, goalID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoal", reflect.TypeOf((*MockISavingsUsecase)(nil).GetGoal), ctx, userID, goalID)
+}
+
+// ListGoals mocks base method.
+func (m *MockISavingsUsecase) ListGoals(ctx context.Context, userID int32) ([]usecase.SavingsProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGoals", ctx, userID)
+	ret0, _ := ret[0].([]usecase.SavingsProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGoals indicates an expected call of ListGoals.
+func (mr *MockISavingsUsecaseMockRecorder) ListGoals(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGoals", reflect.TypeOf((*MockISavingsUsecase)(nil).ListGoals), ctx, userID)
+}
+
+// Run mocks base method.
+func (m *MockISavingsUsecase) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockISavingsUsecaseMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockISavingsUsecase)(nil).Run), ctx)
+}
+
+// MockITransferUsecase is a mock of ITransferUsecase interface.
+type MockITransferUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockITransferUsecaseMockRecorder
+}
+
+// MockITransferUsecaseMockRecorder is the mock recorder for MockITransferUsecase.
+type MockITransferUsecaseMockRecorder struct {
+	mock *MockITransferUsecase
+}
+
+// NewMockITransferUsecase creates a new mock instance.
+func NewMockITransferUsecase(ctrl *gomock.Controller) *MockITransferUsecase {
+	mock := &MockITransferUsecase{ctrl: ctrl}
+	mock.recorder = &MockITransferUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITransferUsecase) EXPECT() *MockITransferUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Transfer mocks base method.
+func (m *MockITransferUsecase) Transfer(ctx context.Context, request request.CreateTransferRequest) (usecase.TransferResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transfer", ctx, request)
+	ret0, _ := ret[0].(usecase.TransferResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transfer indicates an expected call of Transfer.
+func (mr *MockITransferUsecaseMockRecorder) Transfer(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockITransferUsecase)(nil).Transfer), ctx, request)
+}
+
+// MockIScheduleUsecase is a mock of IScheduleUsecase interface.
+type MockIScheduleUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIScheduleUsecaseMockRecorder
+}
+
+// MockIScheduleUsecaseMockRecorder is the mock recorder for MockIScheduleUsecase.
+type MockIScheduleUsecaseMockRecorder struct {
+	mock *MockIScheduleUsecase
+}
+
+// NewMockIScheduleUsecase creates a new mock instance.
+func NewMockIScheduleUsecase(ctrl *gomock.Controller) *MockIScheduleUsecase {
+	mock := &MockIScheduleUsecase{ctrl: ctrl}
+	mock.recorder = &MockIScheduleUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIScheduleUsecase) EXPECT() *MockIScheduleUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CancelSchedule mocks base method.
+func (m *MockIScheduleUsecase) CancelSchedule(ctx context.Context, userID, scheduleID int32) (postgres.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSchedule", ctx, userID, scheduleID)
+	ret0, _ := ret[0].(postgres.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelSchedule indicates an expected call of CancelSchedule.
+func (mr *MockIScheduleUsecaseMockRecorder) CancelSchedule(ctx, userID, scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSchedule", reflect.TypeOf((*MockIScheduleUsecase)(nil).CancelSchedule), ctx, userID, scheduleID)
+}
+
+// CreateSchedule mocks base method.
+func (m *MockIScheduleUsecase) CreateSchedule(ctx context.Context, request request.CreateScheduleRequest) (postgres.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSchedule", ctx, request)
+	ret0, _ := ret[0].(postgres.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSchedule indicates an expected call of CreateSchedule.
+func (mr *MockIScheduleUsecaseMockRecorder) CreateSchedule(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSchedule", reflect.TypeOf((*MockIScheduleUsecase)(nil).CreateSchedule), ctx, request)
+}
+
+// GetSchedule mocks base method.
+func (m *MockIScheduleUsecase) GetSchedule(ctx context.Context, userID, scheduleID int32) (postgres.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedule", ctx, userID, scheduleID)
+	ret0, _ := ret[0].(postgres.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedule indicates an expected call of GetSchedule.
+func (mr *MockIScheduleUsecaseMockRecorder) GetSchedule(ctx, userID, scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedule", reflect.TypeOf((*MockIScheduleUsecase)(nil).GetSchedule), ctx, userID, scheduleID)
+}
+
+// ListRuns mocks base method.
+func (m *MockIScheduleUsecase) ListRuns(ctx context.Context, request request.ListScheduleRunsRequest) ([]postgres.ScheduledTransferRun, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRuns", ctx, request)
+	ret0, _ := ret[0].([]postgres.ScheduledTransferRun)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListRuns indicates an expected call of ListRuns.
+func (mr *MockIScheduleUsecaseMockRecorder) ListRuns(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRuns", reflect.TypeOf((*MockIScheduleUsecase)(nil).ListRuns), ctx, request)
+}
+
+// ListSchedules mocks base method.
+func (m *MockIScheduleUsecase) ListSchedules(ctx context.Context, userID int32) ([]postgres.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSchedules", ctx, userID)
+	ret0, _ := ret[0].([]postgres.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSchedules indicates an expected call of ListSchedules.
+func (mr *MockIScheduleUsecaseMockRecorder) ListSchedules(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSchedules", reflect.TypeOf((*MockIScheduleUsecase)(nil).ListSchedules), ctx, userID)
+}
+
+// Run mocks base method.
+func (m *MockIScheduleUsecase) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockIScheduleUsecaseMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockIScheduleUsecase)(nil).Run), ctx)
+}
+
+// MockIPaymentRequestUsecase is a mock of IPaymentRequestUsecase interface.
+type MockIPaymentRequestUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPaymentRequestUsecaseMockRecorder
+}
+
+// MockIPaymentRequestUsecaseMockRecorder is the mock recorder for MockIPaymentRequestUsecase.
+type MockIPaymentRequestUsecaseMockRecorder struct {
+	mock *MockIPaymentRequestUsecase
+}
+
+// NewMockIPaymentRequestUsecase creates a new mock instance.
+func NewMockIPaymentRequestUsecase(ctrl *gomock.Controller) *MockIPaymentRequestUsecase {
+	mock := &MockIPaymentRequestUsecase{ctrl: ctrl}
+	mock.recorder = &MockIPaymentRequestUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPaymentRequestUsecase) EXPECT() *MockIPaymentRequestUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockIPaymentRequestUsecase) Cancel(ctx context.Context, userID, requestID int32) (postgres.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, userID, requestID)
+	ret0, _ := ret[0].(postgres.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockIPaymentRequestUsecaseMockRecorder) Cancel(ctx, userID, requestID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockIPaymentRequestUsecase)(nil).Cancel), ctx, userID, requestID)
+}
+
+// CreateRequest mocks base method.
+func (m *MockIPaymentRequestUsecase) CreateRequest(ctx context.Context, request request.CreatePaymentRequestRequest) (postgres.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRequest", ctx, request)
+	ret0, _ := ret[0].(postgres.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRequest indicates an expected call of CreateRequest.
+func (mr *MockIPaymentRequestUsecaseMockRecorder) CreateRequest(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRequest", reflect.TypeOf((*MockIPaymentRequestUsecase)(nil).CreateRequest), ctx, request)
+}
+
+// Decline mocks base method.
+func (m *MockIPaymentRequestUsecase) Decline(ctx context.Context, userID, requestID int32) (postgres.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decline", ctx, userID, requestID)
+	ret0, _ := ret[0].(postgres.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decline indicates an expected call of Decline.
+func (mr *MockIPaymentRequestUsecaseMockRecorder) Decline(ctx, userID, requestID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decline", reflect.TypeOf((*MockIPaymentRequestUsecase)(nil).Decline), ctx, userID, requestID)
+}
+
+// GetRequest mocks base method.
+func (m *MockIPaymentRequestUsecase) GetRequest(ctx context.Context, userID, requestID int32) (postgres.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRequest", ctx, userID, requestID)
+	ret0, _ := ret[0].(postgres.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRequest indicates an expected call of GetRequest.
+func (mr *MockIPaymentRequestUsecaseMockRecorder) GetRequest(ctx, userID, requestID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRequest", reflect.TypeOf((*MockIPaymentRequestUsecase)(nil).GetRequest), ctx, userID, requestID)
+}
+
+// ListRequests mocks base method.
+func (m *MockIPaymentRequestUsecase) ListRequests(ctx context.Context, request request.ListPaymentRequestsRequest) ([]postgres.PaymentRequest, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRequests", ctx, request)
+	ret0, _ := ret[0].([]postgres.PaymentRequest)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListRequests indicates an expected call of ListRequests.
+func (mr *MockIPaymentRequestUsecaseMockRecorder) ListRequests(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRequests", reflect.TypeOf((*MockIPaymentRequestUsecase)(nil).ListRequests), ctx, request)
+}
+
+// Pay mocks base method.
+func (m *MockIPaymentRequestUsecase) Pay(ctx context.Context, userID, requestID int32) (usecase.TransferResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pay", ctx, userID, requestID)
+	ret0, _ := ret[0].(usecase.TransferResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pay indicates an expected call of Pay.
+func (mr *MockIPaymentRequestUsecaseMockRecorder) Pay(ctx, userID, requestID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pay", reflect.TypeOf((*MockIPaymentRequestUsecase)(nil).Pay), ctx, userID, requestID)
+}
+
+// Run mocks base method.
+func (m *MockIPaymentRequestUsecase) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockIPaymentRequestUsecaseMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockIPaymentRequestUsecase)(nil).Run), ctx)
+}
+
+// MockIBillUsecase is a mock of IBillUsecase interface.
+type MockIBillUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIBillUsecaseMockRecorder
+}
+
+// MockIBillUsecaseMockRecorder is the mock recorder for MockIBillUsecase.
+type MockIBillUsecaseMockRecorder struct {
+	mock *MockIBillUsecase
+}
+
+// NewMockIBillUsecase creates a new mock instance.
+func NewMockIBillUsecase(ctrl *gomock.Controller) *MockIBillUsecase {
+	mock := &MockIBillUsecase{ctrl: ctrl}
+	mock.recorder = &MockIBillUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIBillUsecase) EXPECT() *MockIBillUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateBill mocks base method.
+func (m *MockIBillUsecase) CreateBill(ctx context.Context, request request.CreateBillRequest) (usecase.BillDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBill", ctx, request)
+	ret0, _ := ret[0].(usecase.BillDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBill indicates an expected call of CreateBill.
+func (mr *MockIBillUsecaseMockRecorder) CreateBill(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBill", reflect.TypeOf((*MockIBillUsecase)(nil).CreateBill), ctx, request)
+}
+
+// GetBill mocks base method.
+func (m *MockIBillUsecase) GetBill(ctx context.Context, userID, billID int32) (usecase.BillDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBill", ctx, userID, billID)
+	ret0, _ := ret[0].(usecase.BillDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBill indicates an expected call of GetBill.
+func (mr *MockIBillUsecaseMockRecorder) GetBill(ctx, userID, billID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBill", reflect.TypeOf((*MockIBillUsecase)(nil).GetBill), ctx, userID, billID)
+}
+
+// ListBills mocks base method.
+func (m *MockIBillUsecase) ListBills(ctx context.Context, request request.ListBillsRequest) ([]postgres.Bill, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBills", ctx, request)
+	ret0, _ := ret[0].([]postgres.Bill)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListBills indicates an expected call of ListBills.
+func (mr *MockIBillUsecaseMockRecorder) ListBills(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBills", reflect.TypeOf((*MockIBillUsecase)(nil).ListBills), ctx, request)
+}
+
+// ListOwedShares mocks base method.
+func (m *MockIBillUsecase) ListOwedShares(ctx context.Context, request request.ListOwedBillSharesRequest) ([]postgres.ListBillSharesByUserIDRow, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOwedShares", ctx, request)
+	ret0, _ := ret[0].([]postgres.ListBillSharesByUserIDRow)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListOwedShares indicates an expected call of ListOwedShares.
+func (mr *MockIBillUsecaseMockRecorder) ListOwedShares(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOwedShares", reflect.TypeOf((*MockIBillUsecase)(nil).ListOwedShares), ctx, request)
+}
+
+// PayShare mocks base method.
+func (m *MockIBillUsecase) PayShare(ctx context.Context, userID, billID int32) (usecase.TransferResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PayShare", ctx, userID, billID)
+	ret0, _ := ret[0].(usecase.TransferResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PayShare indicates an expected call of PayShare.
+func (mr *MockIBillUsecaseMockRecorder) PayShare(ctx, userID, billID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayShare", reflect.TypeOf((*MockIBillUsecase)(nil).PayShare), ctx, userID, billID)
+}
+
+// Remind mocks base method.
+func (m *MockIBillUsecase) Remind(ctx context.Context, userID, billID int32) ([]postgres.BillShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remind", ctx, userID, billID)
+	ret0, _ := ret[0].([]postgres.BillShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Remind indicates an expected call of Remind.
+func (mr *MockIBillUsecaseMockRecorder) Remind(ctx, userID, billID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remind", reflect.TypeOf((*MockIBillUsecase)(nil).Remind), ctx, userID, billID)
+}
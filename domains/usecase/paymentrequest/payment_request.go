@@ -0,0 +1,361 @@
+package paymentrequest
+
+import (
+	"context"
+	"database/sql"
+	goerrors "errors"
+	"fmt"
+	"kc-ewallet/configurations"
+	"kc-ewallet/domains/repository"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/domains/usecase/audit"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/internals/helpers/logging"
+	"kc-ewallet/internals/helpers/money"
+	"kc-ewallet/protocols/http/request"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+const (
+	StatusPending   = "pending"
+	StatusPaid      = "paid"
+	StatusDeclined  = "declined"
+	StatusExpired   = "expired"
+	StatusCancelled = "cancelled"
+)
+
+type paymentRequestUsecase struct {
+	repository repository.IRepository
+	transfer   usecase.ITransferUsecase
+	audit      usecase.IAuditUsecase
+	trace      trace.Tracer
+
+	defaultTTL   time.Duration
+	maxTTL       time.Duration
+	pollInterval time.Duration
+}
+
+// NewPaymentRequestUsecase keeps the payment requests and pays them through the transfer
+// usecase, Run must be started for the unpaid ones to expire
+func NewPaymentRequestUsecase(
+	repository repository.IRepository,
+	config configurations.IPaymentRequestConfiguration,
+	transferUsecase usecase.ITransferUsecase,
+	auditUsecase usecase.IAuditUsecase,
+	trace trace.Tracer,
+) *paymentRequestUsecase {
+	if trace == nil {
+		trace = noop.NewTracerProvider().Tracer("")
+	}
+
+	return &paymentRequestUsecase{
+		repository:   repository,
+		transfer:     transferUsecase,
+		audit:        auditUsecase,
+		trace:        trace,
+		defaultTTL:   config.GetDefaultTTL(),
+		maxTTL:       config.GetMaxTTL(),
+		pollInterval: config.GetExpiryPollInterval(),
+	}
+}
+
+// CreateRequest asks the payer for an amount, payable until the expiry
+func (p *paymentRequestUsecase) CreateRequest(ctx context.Context, request request.CreatePaymentRequestRequest) (postgres.PaymentRequest, error) {
+	ctx, span := p.trace.Start(ctx, "paymentRequestUsecase.CreateRequest", trace.WithAttributes(
+		attribute.Int("user_id", int(request.UserID)),
+		attribute.Int("payer_id", int(request.PayerID)),
+		attribute.String("currency", request.Currency),
+		attribute.Float64("amount", request.Amount),
+	))
+	defer span.End()
+
+	if request.PayerID == request.UserID {
+		return postgres.PaymentRequest{}, errors.BadRequest.NewWithUserMsg(nil, "can't request money from yourself")
+	}
+
+	now := time.Now().UTC()
+	expiresAt := now.Add(p.defaultTTL)
+	if request.ExpiresAt != nil {
+		expiresAt = request.ExpiresAt.UTC()
+		if !expiresAt.After(now) {
+			return postgres.PaymentRequest{}, errors.BadRequest.NewWithUserMsg(nil, "expires_at must be in the future")
+		}
+		if expiresAt.After(now.Add(p.maxTTL)) {
+			return postgres.PaymentRequest{}, errors.BadRequest.NewWithUserMsg(nil, "expires_at is too far ahead")
+		}
+	}
+
+	currency, err := p.repository.GetCurrency(ctx, request.Currency)
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return postgres.PaymentRequest{}, errors.WithCode(errors.BadRequest.NewWithUserMsg(err, "currency is not supported"), errors.CodeUnsupportedCurrency)
+		}
+		logging.NewFromContext(ctx).Error("CreateRequest failed to get currency", zap.Error(err))
+		return postgres.PaymentRequest{}, errors.InternalServer.NewWithUserMsg(err, "failed to create payment request")
+	}
+	if !money.HasPrecision(request.Amount, currency.MinorUnits) {
+		return postgres.PaymentRequest{}, errors.WithCode(errors.BadRequest.NewWithUserMsg(nil, "amount has too many decimals"), errors.CodeInvalidAmountPrecision)
+	}
+
+	if _, err := p.repository.GetUserByID(ctx, request.PayerID); err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return postgres.PaymentRequest{}, errors.WithCode(errors.NotFound.NewWithUserMsg(err, "payer not found"), errors.CodeUserNotFound)
+		}
+		logging.NewFromContext(ctx).Error("CreateRequest failed to get payer", zap.Error(err))
+		return postgres.PaymentRequest{}, errors.InternalServer.NewWithUserMsg(err, "failed to create payment request")
+	}
+
+	paymentRequest, err := p.repository.CreatePaymentRequest(ctx, postgres.CreatePaymentRequestParams{
+		RequesterID: request.UserID,
+		PayerID:     request.PayerID,
+		Currency:    currency.Code,
+		Amount:      request.Amount,
+		Note:        strings.TrimSpace(request.Note),
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		logging.NewFromContext(ctx).Error("CreateRequest failed to create payment request", zap.Error(err))
+		return postgres.PaymentRequest{}, errors.InternalServer.NewWithUserMsg(err, "failed to create payment request")
+	}
+
+	audit.RecordOrLog(ctx, p.audit, audit.Event{
+		Type:      audit.EventPaymentRequestCreated,
+		SubjectID: request.UserID,
+		Metadata: map[string]interface{}{
+			"payment_request_id": paymentRequest.ID,
+			"payer_id":           paymentRequest.PayerID,
+			"currency":           paymentRequest.Currency,
+			"amount":             paymentRequest.Amount,
+			"expires_at":         paymentRequest.ExpiresAt,
+		},
+	})
+
+	return paymentRequest, nil
+}
+
+// ListRequests returns a page of the incoming or outgoing requests of the user, newest first
+func (p *paymentRequestUsecase) ListRequests(ctx context.Context, request request.ListPaymentRequestsRequest) ([]postgres.PaymentRequest, int64, error) {
+	ctx, span := p.trace.Start(ctx, "paymentRequestUsecase.ListRequests", trace.WithAttributes(
+		attribute.Int("user_id", int(request.UserID)),
+		attribute.Bool("incoming", request.Incoming),
+	))
+	defer span.End()
+
+	user := sql.NullInt32{Int32: request.UserID, Valid: true}
+	count := postgres.CountPaymentRequestsParams{
+		RequesterID: user,
+		Status:      sql.NullString{String: request.Status, Valid: request.Status != ""},
+	}
+	if request.Incoming {
+		count.RequesterID, count.PayerID = sql.NullInt32{}, user
+	}
+
+	total, err := p.repository.CountPaymentRequests(ctx, count)
+	if err != nil {
+		logging.NewFromContext(ctx).Error("ListRequests failed to count payment requests", zap.Error(err))
+		return nil, 0, errors.InternalServer.NewWithUserMsg(err, "failed to list payment requests")
+	}
+
+	paymentRequests, err := p.repository.ListPaymentRequests(ctx, postgres.ListPaymentRequestsParams{
+		RequesterID: count.RequesterID,
+		PayerID:     count.PayerID,
+		Status:      count.Status,
+		RowLimit:    int32(request.Limit),
+		RowOffset:   int32(request.Offset),
+	})
+	if err != nil {
+		logging.NewFromContext(ctx).Error("ListRequests failed to list payment requests", zap.Error(err))
+		return nil, 0, errors.InternalServer.NewWithUserMsg(err, "failed to list payment requests")
+	}
+
+	return paymentRequests, total, nil
+}
+
+// GetRequest returns a request the user made or has to pay
+func (p *paymentRequestUsecase) GetRequest(ctx context.Context, userID, requestID int32) (postgres.PaymentRequest, error) {
+	ctx, span := p.trace.Start(ctx, "paymentRequestUsecase.GetRequest", trace.WithAttributes(
+		attribute.Int("user_id", int(userID)),
+		attribute.Int("payment_request_id", int(requestID)),
+	))
+	defer span.End()
+
+	return p.getRequest(ctx, userID, requestID)
+}
+
+// Pay transfers the amount of a pending request to its requester, the request is
+// marked paid in the database transaction of the transfer
+func (p *paymentRequestUsecase) Pay(ctx context.Context, userID, requestID int32) (usecase.TransferResult, error) {
+	ctx, span := p.trace.Start(ctx, "paymentRequestUsecase.Pay", trace.WithAttributes(
+		attribute.Int("user_id", int(userID)),
+		attribute.Int("payment_request_id", int(requestID)),
+	))
+	defer span.End()
+
+	paymentRequest, err := p.getRequest(ctx, userID, requestID)
+	if err != nil {
+		return usecase.TransferResult{}, err
+	}
+	if paymentRequest.PayerID != userID {
+		return usecase.TransferResult{}, errors.Forbidden.NewWithUserMsg(nil, "only the payer can pay a payment request")
+	}
+	if !payable(paymentRequest, time.Now()) {
+		return usecase.TransferResult{}, errors.WithCode(errors.BadRequest.NewWithUserMsg(nil, "payment request is no longer pending"), errors.CodePaymentRequestResolved)
+	}
+
+	return p.transfer.Transfer(ctx, request.CreateTransferRequest{
+		UserID:           userID,
+		ToUserID:         paymentRequest.RequesterID,
+		Currency:         paymentRequest.Currency,
+		Amount:           paymentRequest.Amount,
+		Note:             paymentRequest.Note,
+		IdempotencyKey:   fmt.Sprintf("payment-request:%d", paymentRequest.ID),
+		PaymentRequestID: paymentRequest.ID,
+	})
+}
+
+// Decline refuses a pending request the user has to pay
+func (p *paymentRequestUsecase) Decline(ctx context.Context, userID, requestID int32) (postgres.PaymentRequest, error) {
+	ctx, span := p.trace.Start(ctx, "paymentRequestUsecase.Decline", trace.WithAttributes(
+		attribute.Int("user_id", int(userID)),
+		attribute.Int("payment_request_id", int(requestID)),
+	))
+	defer span.End()
+
+	paymentRequest, err := p.repository.DeclinePaymentRequest(ctx, postgres.DeclinePaymentRequestParams{
+		Now:     time.Now().UTC(),
+		ID:      requestID,
+		PayerID: userID,
+	})
+	if err != nil {
+		return postgres.PaymentRequest{}, p.unresolvable(ctx, err, userID, requestID, "decline")
+	}
+
+	audit.RecordOrLog(ctx, p.audit, audit.Event{
+		Type:      audit.EventPaymentRequestDeclined,
+		SubjectID: userID,
+		Before:    map[string]interface{}{"status": StatusPending},
+		After:     map[string]interface{}{"status": paymentRequest.Status},
+		Metadata:  map[string]interface{}{"payment_request_id": paymentRequest.ID},
+	})
+
+	return paymentRequest, nil
+}
+
+// Cancel withdraws a pending request the user made
+func (p *paymentRequestUsecase) Cancel(ctx context.Context, userID, requestID int32) (postgres.PaymentRequest, error) {
+	ctx, span := p.trace.Start(ctx, "paymentRequestUsecase.Cancel", trace.WithAttributes(
+		attribute.Int("user_id", int(userID)),
+		attribute.Int("payment_request_id", int(requestID)),
+	))
+	defer span.End()
+
+	paymentRequest, err := p.repository.CancelPaymentRequest(ctx, postgres.CancelPaymentRequestParams{
+		Now:         time.Now().UTC(),
+		ID:          requestID,
+		RequesterID: userID,
+	})
+	if err != nil {
+		return postgres.PaymentRequest{}, p.unresolvable(ctx, err, userID, requestID, "cancel")
+	}
+
+	audit.RecordOrLog(ctx, p.audit, audit.Event{
+		Type:      audit.EventPaymentRequestCancelled,
+		SubjectID: userID,
+		Before:    map[string]interface{}{"status": StatusPending},
+		After:     map[string]interface{}{"status": paymentRequest.Status},
+		Metadata:  map[string]interface{}{"payment_request_id": paymentRequest.ID},
+	})
+
+	return paymentRequest, nil
+}
+
+// Run expires the unpaid requests every poll interval until ctx is done
+func (p *paymentRequestUsecase) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.ExpireDue(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			logging.NewFromContext(ctx).Warn("failed to expire payment requests", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ExpireDue expires the pending requests past their expiry at now. Each request is
+// expired by one update, so every instance can run the sweeper
+func (p *paymentRequestUsecase) ExpireDue(ctx context.Context, now time.Time) (int, error) {
+	ctx, span := p.trace.Start(ctx, "paymentRequestUsecase.ExpireDue")
+	defer span.End()
+
+	expired, err := p.repository.ExpirePaymentRequests(ctx, now.UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	for _, paymentRequest := range expired {
+		audit.RecordOrLog(ctx, p.audit, audit.Event{
+			Type:      audit.EventPaymentRequestExpired,
+			SubjectID: paymentRequest.RequesterID,
+			Before:    map[string]interface{}{"status": StatusPending},
+			After:     map[string]interface{}{"status": paymentRequest.Status},
+			Metadata: map[string]interface{}{
+				"payment_request_id": paymentRequest.ID,
+				"payer_id":           paymentRequest.PayerID,
+				"expires_at":         paymentRequest.ExpiresAt,
+			},
+		})
+	}
+	return len(expired), nil
+}
+
+func (p *paymentRequestUsecase) getRequest(ctx context.Context, userID, requestID int32) (postgres.PaymentRequest, error) {
+	paymentRequest, err := p.repository.GetPaymentRequest(ctx, postgres.GetPaymentRequestParams{ID: requestID, UserID: userID})
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return postgres.PaymentRequest{}, errors.WithCode(errors.NotFound.NewWithUserMsg(err, "payment request not found"), errors.CodePaymentRequestNotFound)
+		}
+		logging.NewFromContext(ctx).Error("failed to get payment request", zap.Error(err))
+		return postgres.PaymentRequest{}, errors.InternalServer.NewWithUserMsg(err, "failed to get payment request")
+	}
+	return paymentRequest, nil
+}
+
+// unresolvable explains why a decline or cancel changed nothing: the request is not the
+// user's, belongs to the other side, or is no longer pending
+func (p *paymentRequestUsecase) unresolvable(ctx context.Context, err error, userID, requestID int32, action string) error {
+	if !goerrors.Is(err, sql.ErrNoRows) {
+		logging.NewFromContext(ctx).Error("failed to "+action+" payment request", zap.Error(err))
+		return errors.InternalServer.NewWithUserMsg(err, "failed to "+action+" payment request")
+	}
+
+	paymentRequest, err := p.getRequest(ctx, userID, requestID)
+	if err != nil {
+		return err
+	}
+	if action == "decline" && paymentRequest.PayerID != userID {
+		return errors.Forbidden.NewWithUserMsg(nil, "only the payer can decline a payment request")
+	}
+	if action == "cancel" && paymentRequest.RequesterID != userID {
+		return errors.Forbidden.NewWithUserMsg(nil, "only the requester can cancel a payment request")
+	}
+	return errors.WithCode(errors.BadRequest.NewWithUserMsg(nil, "payment request is no longer pending"), errors.CodePaymentRequestResolved)
+}
+
+// payable reports whether the request is pending and not past its expiry, the sweeper
+// may not have expired it yet
+func payable(paymentRequest postgres.PaymentRequest, now time.Time) bool {
+	return paymentRequest.Status == StatusPending && paymentRequest.ExpiresAt.After(now.UTC())
+}
@@ -0,0 +1,241 @@
+package paymentrequest
+
+import (
+	"context"
+	"database/sql"
+	mock_configuration "kc-ewallet/configurations/mocks"
+	mock_repository "kc-ewallet/domains/repository/mocks"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/usecase"
+	mock_usecase "kc-ewallet/domains/usecase/mocks"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/protocols/http/request"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPaymentRequestUsecase(ctrl *gomock.Controller, repo *mock_repository.MockIRepository, transfer usecase.ITransferUsecase) *paymentRequestUsecase {
+	config := mock_configuration.NewMockIPaymentRequestConfiguration(ctrl)
+	config.EXPECT().GetDefaultTTL().Return(24 * time.Hour)
+	config.EXPECT().GetMaxTTL().Return(48 * time.Hour)
+	config.EXPECT().GetExpiryPollInterval().Return(time.Second)
+	return NewPaymentRequestUsecase(repo, config, transfer, nil, nil)
+}
+
+func TestPaymentRequestUsecase_CreateRequest(t *testing.T) {
+	tooFar := time.Now().Add(72 * time.Hour)
+	past := time.Now().Add(-time.Minute)
+
+	testCases := []struct {
+		name         string
+		request      request.CreatePaymentRequestRequest
+		mock         func(repo *mock_repository.MockIRepository)
+		expectedCode errors.Code
+	}{
+		{
+			name:    "should expire after the default ttl",
+			request: request.CreatePaymentRequestRequest{UserID: 1, PayerID: 2, Currency: "IDR", Amount: 1500, Note: " dinner "},
+			mock: func(repo *mock_repository.MockIRepository) {
+				repo.EXPECT().GetCurrency(gomock.Any(), "IDR").Return(postgres.Currency{Code: "IDR", MinorUnits: 2}, nil)
+				repo.EXPECT().GetUserByID(gomock.Any(), int32(2)).Return(postgres.User{ID: 2}, nil)
+				repo.EXPECT().CreatePaymentRequest(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, params postgres.CreatePaymentRequestParams) (postgres.PaymentRequest, error) {
+						assert.Equal(t, "dinner", params.Note)
+						assert.WithinDuration(t, time.Now().Add(24*time.Hour), params.ExpiresAt, time.Minute)
+						return postgres.PaymentRequest{ID: 5, Status: StatusPending}, nil
+					})
+			},
+		},
+		{
+			name:         "should reject a request to yourself",
+			request:      request.CreatePaymentRequestRequest{UserID: 1, PayerID: 1, Currency: "IDR", Amount: 1500},
+			mock:         func(repo *mock_repository.MockIRepository) {},
+			expectedCode: errors.CodeBadRequest,
+		},
+		{
+			name:         "should reject an expiry in the past",
+			request:      request.CreatePaymentRequestRequest{UserID: 1, PayerID: 2, Currency: "IDR", Amount: 1500, ExpiresAt: &past},
+			mock:         func(repo *mock_repository.MockIRepository) {},
+			expectedCode: errors.CodeBadRequest,
+		},
+		{
+			name:         "should reject an expiry beyond the max ttl",
+			request:      request.CreatePaymentRequestRequest{UserID: 1, PayerID: 2, Currency: "IDR", Amount: 1500, ExpiresAt: &tooFar},
+			mock:         func(repo *mock_repository.MockIRepository) {},
+			expectedCode: errors.CodeBadRequest,
+		},
+		{
+			name:    "should reject an unknown payer",
+			request: request.CreatePaymentRequestRequest{UserID: 1, PayerID: 2, Currency: "IDR", Amount: 1500},
+			mock: func(repo *mock_repository.MockIRepository) {
+				repo.EXPECT().GetCurrency(gomock.Any(), "IDR").Return(postgres.Currency{Code: "IDR", MinorUnits: 2}, nil)
+				repo.EXPECT().GetUserByID(gomock.Any(), int32(2)).Return(postgres.User{}, sql.ErrNoRows)
+			},
+			expectedCode: errors.CodeUserNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mock_repository.NewMockIRepository(ctrl)
+			tc.mock(repo)
+
+			paymentRequest, err := newPaymentRequestUsecase(ctrl, repo, nil).CreateRequest(context.Background(), tc.request)
+			if tc.expectedCode != "" {
+				assert.Equal(t, tc.expectedCode, errors.CatalogEntryOf(err).Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int32(5), paymentRequest.ID)
+		})
+	}
+}
+
+func TestPaymentRequestUsecase_Pay(t *testing.T) {
+	pending := postgres.PaymentRequest{
+		ID: 5, RequesterID: 1, PayerID: 2, Currency: "IDR", Amount: 1500, Note: "dinner",
+		Status: StatusPending, ExpiresAt: time.Now().Add(time.Hour),
+	}
+	expired := pending
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+	declined := pending
+	declined.Status = StatusDeclined
+
+	testCases := []struct {
+		name           string
+		userID         int32
+		paymentRequest postgres.PaymentRequest
+		mock           func(transfer *mock_usecase.MockITransferUsecase)
+		expectedCode   errors.Code
+	}{
+		{
+			name:           "should transfer the amount to the requester",
+			userID:         2,
+			paymentRequest: pending,
+			mock: func(transfer *mock_usecase.MockITransferUsecase) {
+				transfer.EXPECT().Transfer(gomock.Any(), request.CreateTransferRequest{
+					UserID:           2,
+					ToUserID:         1,
+					Currency:         "IDR",
+					Amount:           1500,
+					Note:             "dinner",
+					IdempotencyKey:   "payment-request:5",
+					PaymentRequestID: 5,
+				}).Return(usecase.TransferResult{Transfer: postgres.Transfer{ID: 9}, Balance: 500}, nil)
+			},
+		},
+		{
+			name:           "should only let the payer pay",
+			userID:         1,
+			paymentRequest: pending,
+			mock:           func(transfer *mock_usecase.MockITransferUsecase) {},
+			expectedCode:   errors.CodeForbidden,
+		},
+		{
+			name:           "should reject a request past its expiry not swept yet",
+			userID:         2,
+			paymentRequest: expired,
+			mock:           func(transfer *mock_usecase.MockITransferUsecase) {},
+			expectedCode:   errors.CodePaymentRequestResolved,
+		},
+		{
+			name:           "should reject a declined request",
+			userID:         2,
+			paymentRequest: declined,
+			mock:           func(transfer *mock_usecase.MockITransferUsecase) {},
+			expectedCode:   errors.CodePaymentRequestResolved,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mock_repository.NewMockIRepository(ctrl)
+			repo.EXPECT().GetPaymentRequest(gomock.Any(), postgres.GetPaymentRequestParams{ID: 5, UserID: tc.userID}).Return(tc.paymentRequest, nil)
+			transfer := mock_usecase.NewMockITransferUsecase(ctrl)
+			tc.mock(transfer)
+
+			result, err := newPaymentRequestUsecase(ctrl, repo, transfer).Pay(context.Background(), tc.userID, 5)
+			if tc.expectedCode != "" {
+				assert.Equal(t, tc.expectedCode, errors.CatalogEntryOf(err).Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int32(9), result.Transfer.ID)
+		})
+	}
+}
+
+func TestPaymentRequestUsecase_Decline(t *testing.T) {
+	testCases := []struct {
+		name         string
+		mock         func(repo *mock_repository.MockIRepository)
+		expectedCode errors.Code
+	}{
+		{
+			name: "should decline a pending request",
+			mock: func(repo *mock_repository.MockIRepository) {
+				repo.EXPECT().DeclinePaymentRequest(gomock.Any(), gomock.Any()).Return(postgres.PaymentRequest{ID: 5, Status: StatusDeclined}, nil)
+			},
+		},
+		{
+			name: "should not find a request of other users",
+			mock: func(repo *mock_repository.MockIRepository) {
+				repo.EXPECT().DeclinePaymentRequest(gomock.Any(), gomock.Any()).Return(postgres.PaymentRequest{}, sql.ErrNoRows)
+				repo.EXPECT().GetPaymentRequest(gomock.Any(), postgres.GetPaymentRequestParams{ID: 5, UserID: 2}).Return(postgres.PaymentRequest{}, sql.ErrNoRows)
+			},
+			expectedCode: errors.CodePaymentRequestNotFound,
+		},
+		{
+			name: "should only let the payer decline",
+			mock: func(repo *mock_repository.MockIRepository) {
+				repo.EXPECT().DeclinePaymentRequest(gomock.Any(), gomock.Any()).Return(postgres.PaymentRequest{}, sql.ErrNoRows)
+				repo.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Any()).Return(postgres.PaymentRequest{ID: 5, RequesterID: 2, PayerID: 3, Status: StatusPending}, nil)
+			},
+			expectedCode: errors.CodeForbidden,
+		},
+		{
+			name: "should reject a request already paid",
+			mock: func(repo *mock_repository.MockIRepository) {
+				repo.EXPECT().DeclinePaymentRequest(gomock.Any(), gomock.Any()).Return(postgres.PaymentRequest{}, sql.ErrNoRows)
+				repo.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Any()).Return(postgres.PaymentRequest{ID: 5, RequesterID: 1, PayerID: 2, Status: StatusPaid}, nil)
+			},
+			expectedCode: errors.CodePaymentRequestResolved,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mock_repository.NewMockIRepository(ctrl)
+			tc.mock(repo)
+
+			paymentRequest, err := newPaymentRequestUsecase(ctrl, repo, nil).Decline(context.Background(), 2, 5)
+			if tc.expectedCode != "" {
+				assert.Equal(t, tc.expectedCode, errors.CatalogEntryOf(err).Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, StatusDeclined, paymentRequest.Status)
+		})
+	}
+}
+
+func TestPaymentRequestUsecase_ExpireDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mock_repository.NewMockIRepository(ctrl)
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	repo.EXPECT().ExpirePaymentRequests(gomock.Any(), now).Return([]postgres.PaymentRequest{
+		{ID: 5, Status: StatusExpired},
+		{ID: 6, Status: StatusExpired},
+	}, nil)
+
+	expired, err := newPaymentRequestUsecase(ctrl, repo, nil).ExpireDue(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, expired)
+}
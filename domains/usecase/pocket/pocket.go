@@ -0,0 +1,430 @@
+package pocket
+
+import (
+	"context"
+	"database/sql"
+	goerrors "errors"
+	"kc-ewallet/constants"
+	"kc-ewallet/domains/repository"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/domains/usecase/audit"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/internals/helpers/logging"
+	"kc-ewallet/internals/helpers/money"
+	"kc-ewallet/protocols/http/request"
+	"strings"
+
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+// balanceDecimals is the precision of the balance columns, enough for every currency
+const balanceDecimals = 4
+
+type pocketUsecase struct {
+	db         *sql.DB
+	repository repository.IRepository
+	userCache  repository.IUserCache
+	audit      usecase.IAuditUsecase
+	trace      trace.Tracer
+}
+
+func NewPocketUsecase(
+	db *sql.DB,
+	repository repository.IRepository,
+	userCache repository.IUserCache,
+	auditUsecase usecase.IAuditUsecase,
+	trace trace.Tracer,
+) *pocketUsecase {
+	if trace == nil {
+		trace = noop.NewTracerProvider().Tracer("")
+	}
+
+	return &pocketUsecase{
+		db:         db,
+		repository: repository,
+		userCache:  userCache,
+		audit:      auditUsecase,
+		trace:      trace,
+	}
+}
+
+// CreatePocket opens an empty pocket in a wallet of the user, the names of the pockets
+// of a wallet are unique
+func (p *pocketUsecase) CreatePocket(ctx context.Context, request request.CreatePocketRequest) (postgres.Pocket, error) {
+	ctx, span := p.trace.Start(ctx, "pocketUsecase.CreatePocket", trace.WithAttributes(
+		attribute.Int("user_id", int(request.UserID)),
+		attribute.String("currency", request.Currency),
+	))
+	defer span.End()
+
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		return postgres.Pocket{}, errors.BadRequest.NewWithUserMsg(nil, "pocket name is required")
+	}
+
+	var pocket postgres.Pocket
+	err := usecase.InTx(ctx, p.db, p.repository, func(query repository.IRepository) error {
+		if _, err := p.lockWallet(ctx, query, request.UserID, request.Currency); err != nil {
+			return err
+		}
+
+		if request.IsDefault {
+			if err := query.ClearDefaultPocket(ctx, postgres.ClearDefaultPocketParams{UserID: request.UserID, Currency: request.Currency}); err != nil {
+				logging.NewFromContext(ctx).Error("CreatePocket failed to clear default pocket", zap.Error(err))
+				return errors.InternalServer.NewWithUserMsg(err, "failed to create pocket")
+			}
+		}
+
+		var err error
+		pocket, err = query.CreatePocket(ctx, postgres.CreatePocketParams{
+			UserID:    request.UserID,
+			Currency:  request.Currency,
+			Name:      name,
+			IsDefault: request.IsDefault,
+		})
+		if err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+				return errors.WithCode(errors.BadRequest.NewWithUserMsg(err, "pocket already exists"), errors.CodePocketExists)
+			}
+			logging.NewFromContext(ctx).Error("CreatePocket failed to create pocket", zap.Error(err))
+			return errors.InternalServer.NewWithUserMsg(err, "failed to create pocket")
+		}
+		return nil
+	})
+	if err != nil {
+		return postgres.Pocket{}, err
+	}
+	usecase.InvalidateBalances(ctx, p.userCache, request.UserID)
+
+	audit.RecordOrLog(ctx, p.audit, audit.Event{
+		Type:      audit.EventPocketCreated,
+		SubjectID: request.UserID,
+		Metadata: map[string]interface{}{
+			"pocket_id":  pocket.ID,
+			"currency":   pocket.Currency,
+			"name":       pocket.Name,
+			"is_default": pocket.IsDefault,
+		},
+	})
+
+	return pocket, nil
+}
+
+// ListBalances splits each wallet of the user into its pockets and the unallocated rest,
+// read through the user cache
+func (p *pocketUsecase) ListBalances(ctx context.Context, userID int32) ([]usecase.WalletBalance, error) {
+	ctx, span := p.trace.Start(ctx, "pocketUsecase.ListBalances", trace.WithAttributes(
+		attribute.Int("user_id", int(userID)),
+	))
+	defer span.End()
+
+	wallets, pockets, err := p.balancesOf(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make([]usecase.WalletBalance, 0, len(wallets))
+	for _, wallet := range wallets {
+		balances = append(balances, walletBalance(wallet, pockets))
+	}
+	return balances, nil
+}
+
+// balancesOf reads the wallets and pockets of the user from the cache, or from the
+// database filling the cache
+func (p *pocketUsecase) balancesOf(ctx context.Context, userID int32) ([]postgres.Wallet, []postgres.Pocket, error) {
+	if p.userCache != nil {
+		if wallets, pockets, err := p.userCache.GetBalances(ctx, userID); err == nil {
+			return wallets, pockets, nil
+		}
+	}
+
+	wallets, err := p.repository.ListWalletsByUserID(ctx, userID)
+	if err != nil {
+		logging.NewFromContext(ctx).Error("ListBalances failed to list wallets", zap.Error(err))
+		return nil, nil, errors.InternalServer.NewWithUserMsg(err, "failed to list balances")
+	}
+	pockets, err := p.repository.ListPocketsByUserID(ctx, userID)
+	if err != nil {
+		logging.NewFromContext(ctx).Error("ListBalances failed to list pockets", zap.Error(err))
+		return nil, nil, errors.InternalServer.NewWithUserMsg(err, "failed to list balances")
+	}
+
+	if p.userCache != nil {
+		if err := p.userCache.SetBalances(ctx, userID, wallets, pockets); err != nil {
+			logging.NewFromContext(ctx).Warn("ListBalances failed to cache balances", zap.Error(err))
+		}
+	}
+	return wallets, pockets, nil
+}
+
+// MoveMoney moves an amount between two pockets of the same wallet. The wallet is locked
+// first, like for every balance change, so a debit never spends money being moved
+func (p *pocketUsecase) MoveMoney(ctx context.Context, request request.MovePocketMoneyRequest) (usecase.WalletBalance, error) {
+	ctx, span := p.trace.Start(ctx, "pocketUsecase.MoveMoney", trace.WithAttributes(
+		attribute.Int("user_id", int(request.UserID)),
+		attribute.Int("from_pocket_id", int(request.FromPocketID)),
+		attribute.Int("to_pocket_id", int(request.ToPocketID)),
+		attribute.Float64("amount", request.Amount),
+	))
+	defer span.End()
+
+	if request.FromPocketID == request.ToPocketID {
+		return usecase.WalletBalance{}, errors.BadRequest.NewWithUserMsg(nil, "money must move between two different pockets")
+	}
+
+	var result usecase.WalletBalance
+	err := usecase.InTx(ctx, p.db, p.repository, func(query repository.IRepository) error {
+		// the currency of a pocket never changes, it names the wallet to lock
+		pocketID := request.FromPocketID
+		if pocketID == 0 {
+			pocketID = request.ToPocketID
+		}
+		named, err := p.getPocket(ctx, query, request.UserID, pocketID)
+		if err != nil {
+			return err
+		}
+
+		currency, err := query.GetCurrency(ctx, named.Currency)
+		if err != nil {
+			logging.NewFromContext(ctx).Error("MoveMoney failed to get currency", zap.Error(err))
+			return errors.InternalServer.NewWithUserMsg(err, "failed to move money")
+		}
+		if !money.HasPrecision(request.Amount, currency.MinorUnits) {
+			return errors.WithCode(errors.BadRequest.NewWithUserMsg(nil, "amount has too many decimals"), errors.CodeInvalidAmountPrecision)
+		}
+
+		wallet, err := p.lockWallet(ctx, query, request.UserID, currency.Code)
+		if err != nil {
+			return err
+		}
+
+		from, err := p.lockPocket(ctx, query, request.UserID, request.FromPocketID, currency.Code)
+		if err != nil {
+			return err
+		}
+		to, err := p.lockPocket(ctx, query, request.UserID, request.ToPocketID, currency.Code)
+		if err != nil {
+			return err
+		}
+
+		available := from.Balance
+		if from.ID == 0 {
+			allocated, err := query.SumPocketBalances(ctx, postgres.SumPocketBalancesParams{UserID: request.UserID, Currency: currency.Code})
+			if err != nil {
+				logging.NewFromContext(ctx).Error("MoveMoney failed to sum pocket balances", zap.Error(err))
+				return errors.InternalServer.NewWithUserMsg(err, "failed to move money")
+			}
+			available = money.Round(wallet.Balance-allocated, currency.MinorUnits)
+		}
+		if available < request.Amount {
+			return errors.WithCode(errors.BadRequest.NewWithUserMsg(nil, "Insufficient funds"), errors.CodeInsufficientFunds)
+		}
+
+		if from.ID != 0 {
+			if err := p.postEntry(ctx, query, from, constants.PocketEntryMoveOut, to.ID, money.Round(from.Balance-request.Amount, currency.MinorUnits), request.Amount); err != nil {
+				return err
+			}
+		}
+		if to.ID != 0 {
+			if err := p.postEntry(ctx, query, to, constants.PocketEntryMoveIn, from.ID, money.Round(to.Balance+request.Amount, currency.MinorUnits), request.Amount); err != nil {
+				return err
+			}
+		}
+
+		pockets, err := query.ListPocketsByUserID(ctx, request.UserID)
+		if err != nil {
+			logging.NewFromContext(ctx).Error("MoveMoney failed to list pockets", zap.Error(err))
+			return errors.InternalServer.NewWithUserMsg(err, "failed to move money")
+		}
+		result = walletBalance(wallet, pockets)
+		return nil
+	})
+	if err != nil {
+		return usecase.WalletBalance{}, err
+	}
+	usecase.InvalidateBalances(ctx, p.userCache, request.UserID)
+
+	audit.RecordOrLog(ctx, p.audit, audit.Event{
+		Type:      audit.EventPocketMoneyMoved,
+		SubjectID: request.UserID,
+		Metadata: map[string]interface{}{
+			"currency":       result.Wallet.Currency,
+			"from_pocket_id": request.FromPocketID,
+			"to_pocket_id":   request.ToPocketID,
+			"amount":         request.Amount,
+		},
+	})
+
+	return result, nil
+}
+
+// SetDefaultPocket makes the pocket receive the credits of its wallet, or stops it.
+// A wallet has at most one default pocket
+func (p *pocketUsecase) SetDefaultPocket(ctx context.Context, userID, pocketID int32, isDefault bool) (postgres.Pocket, error) {
+	ctx, span := p.trace.Start(ctx, "pocketUsecase.SetDefaultPocket", trace.WithAttributes(
+		attribute.Int("user_id", int(userID)),
+		attribute.Int("pocket_id", int(pocketID)),
+		attribute.Bool("is_default", isDefault),
+	))
+	defer span.End()
+
+	var pocket postgres.Pocket
+	err := usecase.InTx(ctx, p.db, p.repository, func(query repository.IRepository) error {
+		var err error
+		pocket, err = p.getPocket(ctx, query, userID, pocketID)
+		if err != nil {
+			return err
+		}
+		if _, err := p.lockWallet(ctx, query, userID, pocket.Currency); err != nil {
+			return err
+		}
+
+		if err := query.ClearDefaultPocket(ctx, postgres.ClearDefaultPocketParams{UserID: userID, Currency: pocket.Currency}); err != nil {
+			logging.NewFromContext(ctx).Error("SetDefaultPocket failed to clear default pocket", zap.Error(err))
+			return errors.InternalServer.NewWithUserMsg(err, "failed to set default pocket")
+		}
+		if isDefault {
+			if err := query.SetDefaultPocket(ctx, pocket.ID); err != nil {
+				logging.NewFromContext(ctx).Error("SetDefaultPocket failed to set default pocket", zap.Error(err))
+				return errors.InternalServer.NewWithUserMsg(err, "failed to set default pocket")
+			}
+		}
+		pocket.IsDefault = isDefault
+		return nil
+	})
+	if err != nil {
+		return postgres.Pocket{}, err
+	}
+	usecase.InvalidateBalances(ctx, p.userCache, userID)
+
+	audit.RecordOrLog(ctx, p.audit, audit.Event{
+		Type:      audit.EventPocketDefaultChanged,
+		SubjectID: userID,
+		After:     map[string]interface{}{"is_default": isDefault},
+		Metadata: map[string]interface{}{
+			"pocket_id": pocket.ID,
+			"currency":  pocket.Currency,
+		},
+	})
+
+	return pocket, nil
+}
+
+// ListEntries returns the history of a pocket of the user, newest first
+func (p *pocketUsecase) ListEntries(ctx context.Context, request request.ListPocketEntriesRequest) ([]postgres.PocketEntry, int64, error) {
+	ctx, span := p.trace.Start(ctx, "pocketUsecase.ListEntries", trace.WithAttributes(
+		attribute.Int("user_id", int(request.UserID)),
+		attribute.Int("pocket_id", int(request.PocketID)),
+	))
+	defer span.End()
+
+	if _, err := p.getPocket(ctx, p.repository, request.UserID, request.PocketID); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := p.repository.CountPocketEntries(ctx, request.PocketID)
+	if err != nil {
+		logging.NewFromContext(ctx).Error("ListEntries failed to count entries", zap.Error(err))
+		return nil, 0, errors.InternalServer.NewWithUserMsg(err, "failed to list pocket entries")
+	}
+
+	entries, err := p.repository.ListPocketEntries(ctx, postgres.ListPocketEntriesParams{
+		PocketID:  request.PocketID,
+		RowLimit:  int32(request.Limit),
+		RowOffset: int32(request.Offset),
+	})
+	if err != nil {
+		logging.NewFromContext(ctx).Error("ListEntries failed to list entries", zap.Error(err))
+		return nil, 0, errors.InternalServer.NewWithUserMsg(err, "failed to list pocket entries")
+	}
+
+	return entries, total, nil
+}
+
+func (p *pocketUsecase) getPocket(ctx context.Context, query repository.IRepository, userID, pocketID int32) (postgres.Pocket, error) {
+	pocket, err := query.GetPocket(ctx, postgres.GetPocketParams{ID: pocketID, UserID: userID})
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return postgres.Pocket{}, errors.WithCode(errors.NotFound.NewWithUserMsg(err, "pocket not found"), errors.CodePocketNotFound)
+		}
+		logging.NewFromContext(ctx).Error("failed to get pocket", zap.Error(err))
+		return postgres.Pocket{}, errors.InternalServer.NewWithUserMsg(err, "failed to get pocket")
+	}
+	return pocket, nil
+}
+
+// lockPocket locks a pocket of the wallet for update, the zero pocket is the
+// unallocated balance and has nothing to lock
+func (p *pocketUsecase) lockPocket(ctx context.Context, query repository.IRepository, userID, pocketID int32, currency string) (postgres.Pocket, error) {
+	if pocketID == 0 {
+		return postgres.Pocket{}, nil
+	}
+
+	pocket, err := query.GetPocketLock(ctx, postgres.GetPocketLockParams{ID: pocketID, UserID: userID})
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return postgres.Pocket{}, errors.WithCode(errors.NotFound.NewWithUserMsg(err, "pocket not found"), errors.CodePocketNotFound)
+		}
+		logging.NewFromContext(ctx).Error("failed to lock pocket", zap.Error(err))
+		return postgres.Pocket{}, errors.InternalServer.NewWithUserMsg(err, "failed to get pocket")
+	}
+	if pocket.Currency != currency {
+		return postgres.Pocket{}, errors.BadRequest.NewWithUserMsg(nil, "pockets are in different currencies")
+	}
+	return pocket, nil
+}
+
+func (p *pocketUsecase) lockWallet(ctx context.Context, query repository.IRepository, userID int32, currency string) (postgres.Wallet, error) {
+	wallet, err := query.GetWalletLock(ctx, postgres.GetWalletLockParams{UserID: userID, Currency: currency})
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return postgres.Wallet{}, errors.WithCode(errors.NotFound.NewWithUserMsg(err, "wallet not found"), errors.CodeWalletNotFound)
+		}
+		logging.NewFromContext(ctx).Error("failed to lock wallet", zap.Error(err))
+		return postgres.Wallet{}, errors.InternalServer.NewWithUserMsg(err, "failed to get wallet")
+	}
+	return wallet, nil
+}
+
+// postEntry sets the new balance of a locked pocket and records it in its history
+func (p *pocketUsecase) postEntry(ctx context.Context, query repository.IRepository, pocket postgres.Pocket, entryType string, counterpartID int32, newBalance, amount float64) error {
+	if err := query.UpdatePocketBalance(ctx, postgres.UpdatePocketBalanceParams{ID: pocket.ID, Balance: newBalance}); err != nil {
+		logging.NewFromContext(ctx).Error("failed to update pocket balance", zap.Error(err))
+		return errors.InternalServer.NewWithUserMsg(err, "failed to move money")
+	}
+
+	if err := query.CreatePocketEntry(ctx, postgres.CreatePocketEntryParams{
+		PocketID:            pocket.ID,
+		Type:                entryType,
+		Amount:              amount,
+		Balance:             newBalance,
+		CounterpartPocketID: sql.NullInt32{Int32: counterpartID, Valid: counterpartID != 0},
+	}); err != nil {
+		logging.NewFromContext(ctx).Error("failed to create pocket entry", zap.Error(err))
+		return errors.InternalServer.NewWithUserMsg(err, "failed to move money")
+	}
+	return nil
+}
+
+// walletBalance picks the pockets of the wallet out of all the pockets of the user
+func walletBalance(wallet postgres.Wallet, pockets []postgres.Pocket) usecase.WalletBalance {
+	balance := usecase.WalletBalance{
+		Wallet:      wallet,
+		Unallocated: wallet.Balance,
+		Pockets:     []postgres.Pocket{},
+	}
+	for _, pocket := range pockets {
+		if pocket.Currency != wallet.Currency {
+			continue
+		}
+		balance.Pockets = append(balance.Pockets, pocket)
+		balance.Unallocated = money.Round(balance.Unallocated-pocket.Balance, balanceDecimals)
+	}
+	return balance
+}
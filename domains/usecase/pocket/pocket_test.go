@@ -0,0 +1,238 @@
+package pocket
+
+import (
+	"context"
+	"database/sql"
+	"kc-ewallet/constants"
+	mock_repository "kc-ewallet/domains/repository/mocks"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/protocols/http/request"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gomodule/redigo/redis"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPocketUsecase_CreatePocket(t *testing.T) {
+	testCases := []struct {
+		name         string
+		request      request.CreatePocketRequest
+		mock         func(repo *mock_repository.MockIRepository)
+		expectedCode errors.Code
+	}{
+		{
+			name:    "should make the new pocket the only default one",
+			request: request.CreatePocketRequest{UserID: 1, Currency: "IDR", Name: " Rent ", IsDefault: true},
+			mock: func(repo *mock_repository.MockIRepository) {
+				repo.EXPECT().GetWalletLock(gomock.Any(), postgres.GetWalletLockParams{UserID: 1, Currency: "IDR"}).Return(postgres.Wallet{ID: 21}, nil)
+				repo.EXPECT().ClearDefaultPocket(gomock.Any(), postgres.ClearDefaultPocketParams{UserID: 1, Currency: "IDR"}).Return(nil)
+				repo.EXPECT().CreatePocket(gomock.Any(), postgres.CreatePocketParams{UserID: 1, Currency: "IDR", Name: "Rent", IsDefault: true}).
+					Return(postgres.Pocket{ID: 7, UserID: 1, Currency: "IDR", Name: "Rent", IsDefault: true}, nil)
+			},
+		},
+		{
+			name:    "should reject a currency without wallet",
+			request: request.CreatePocketRequest{UserID: 1, Currency: "USD", Name: "Travel"},
+			mock: func(repo *mock_repository.MockIRepository) {
+				repo.EXPECT().GetWalletLock(gomock.Any(), gomock.Any()).Return(postgres.Wallet{}, sql.ErrNoRows)
+			},
+			expectedCode: errors.CodeWalletNotFound,
+		},
+		{
+			name:    "should reject a name taken in the wallet",
+			request: request.CreatePocketRequest{UserID: 1, Currency: "IDR", Name: "Rent"},
+			mock: func(repo *mock_repository.MockIRepository) {
+				repo.EXPECT().GetWalletLock(gomock.Any(), gomock.Any()).Return(postgres.Wallet{ID: 21}, nil)
+				repo.EXPECT().CreatePocket(gomock.Any(), gomock.Any()).Return(postgres.Pocket{}, &pq.Error{Code: "23505"})
+			},
+			expectedCode: errors.CodePocketExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mock_repository.NewMockIRepository(ctrl)
+			tc.mock(repo)
+
+			pocket, err := NewPocketUsecase(nil, repo, nil, nil, nil).CreatePocket(context.Background(), tc.request)
+			if tc.expectedCode != "" {
+				assert.Equal(t, tc.expectedCode, errors.CatalogEntryOf(err).Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int32(7), pocket.ID)
+		})
+	}
+}
+
+func TestPocketUsecase_MoveMoney(t *testing.T) {
+	rent := postgres.Pocket{ID: 7, UserID: 1, Currency: "IDR", Name: "Rent", Balance: 30}
+	travel := postgres.Pocket{ID: 8, UserID: 1, Currency: "IDR", Name: "Travel", Balance: 10}
+	wallet := postgres.Wallet{ID: 21, UserID: 1, Currency: "IDR", Balance: 100}
+
+	expectWallet := func(repo *mock_repository.MockIRepository) {
+		repo.EXPECT().GetCurrency(gomock.Any(), "IDR").Return(postgres.Currency{Code: "IDR", MinorUnits: 2}, nil)
+		repo.EXPECT().GetWalletLock(gomock.Any(), postgres.GetWalletLockParams{UserID: 1, Currency: "IDR"}).Return(wallet, nil)
+	}
+
+	testCases := []struct {
+		name                string
+		request             request.MovePocketMoneyRequest
+		mock                func(repo *mock_repository.MockIRepository)
+		expectedUnallocated float64
+		expectedCode        errors.Code
+	}{
+		{
+			name:    "should set unallocated money aside",
+			request: request.MovePocketMoneyRequest{UserID: 1, ToPocketID: 7, Amount: 60},
+			mock: func(repo *mock_repository.MockIRepository) {
+				repo.EXPECT().GetPocket(gomock.Any(), postgres.GetPocketParams{ID: 7, UserID: 1}).Return(rent, nil)
+				expectWallet(repo)
+				repo.EXPECT().GetPocketLock(gomock.Any(), postgres.GetPocketLockParams{ID: 7, UserID: 1}).Return(rent, nil)
+				repo.EXPECT().SumPocketBalances(gomock.Any(), postgres.SumPocketBalancesParams{UserID: 1, Currency: "IDR"}).Return(40.0, nil)
+				repo.EXPECT().UpdatePocketBalance(gomock.Any(), postgres.UpdatePocketBalanceParams{ID: 7, Balance: 90}).Return(nil)
+				repo.EXPECT().CreatePocketEntry(gomock.Any(), postgres.CreatePocketEntryParams{
+					PocketID: 7,
+					Type:     constants.PocketEntryMoveIn,
+					Amount:   60,
+					Balance:  90,
+				}).Return(nil)
+				repo.EXPECT().ListPocketsByUserID(gomock.Any(), int32(1)).Return([]postgres.Pocket{{ID: 7, Currency: "IDR", Balance: 90}, travel}, nil)
+			},
+			expectedUnallocated: 0,
+		},
+		{
+			name:    "should move between two pockets",
+			request: request.MovePocketMoneyRequest{UserID: 1, FromPocketID: 7, ToPocketID: 8, Amount: 30},
+			mock: func(repo *mock_repository.MockIRepository) {
+				repo.EXPECT().GetPocket(gomock.Any(), postgres.GetPocketParams{ID: 7, UserID: 1}).Return(rent, nil)
+				expectWallet(repo)
+				repo.EXPECT().GetPocketLock(gomock.Any(), postgres.GetPocketLockParams{ID: 7, UserID: 1}).Return(rent, nil)
+				repo.EXPECT().GetPocketLock(gomock.Any(), postgres.GetPocketLockParams{ID: 8, UserID: 1}).Return(travel, nil)
+				repo.EXPECT().UpdatePocketBalance(gomock.Any(), postgres.UpdatePocketBalanceParams{ID: 7, Balance: 0}).Return(nil)
+				repo.EXPECT().CreatePocketEntry(gomock.Any(), postgres.CreatePocketEntryParams{
+					PocketID:            7,
+					Type:                constants.PocketEntryMoveOut,
+					Amount:              30,
+					Balance:             0,
+					CounterpartPocketID: sql.NullInt32{Int32: 8, Valid: true},
+				}).Return(nil)
+				repo.EXPECT().UpdatePocketBalance(gomock.Any(), postgres.UpdatePocketBalanceParams{ID: 8, Balance: 40}).Return(nil)
+				repo.EXPECT().CreatePocketEntry(gomock.Any(), postgres.CreatePocketEntryParams{
+					PocketID:            8,
+					Type:                constants.PocketEntryMoveIn,
+					Amount:              30,
+					Balance:             40,
+					CounterpartPocketID: sql.NullInt32{Int32: 7, Valid: true},
+				}).Return(nil)
+				repo.EXPECT().ListPocketsByUserID(gomock.Any(), int32(1)).
+					Return([]postgres.Pocket{{ID: 7, Currency: "IDR"}, {ID: 8, Currency: "IDR", Balance: 40}}, nil)
+			},
+			expectedUnallocated: 60,
+		},
+		{
+			name:    "should reject more than the pocket holds",
+			request: request.MovePocketMoneyRequest{UserID: 1, FromPocketID: 8, Amount: 10.5},
+			mock: func(repo *mock_repository.MockIRepository) {
+				repo.EXPECT().GetPocket(gomock.Any(), postgres.GetPocketParams{ID: 8, UserID: 1}).Return(travel, nil)
+				expectWallet(repo)
+				repo.EXPECT().GetPocketLock(gomock.Any(), postgres.GetPocketLockParams{ID: 8, UserID: 1}).Return(travel, nil)
+			},
+			expectedCode: errors.CodeInsufficientFunds,
+		},
+		{
+			name:    "should reject a pocket of another user",
+			request: request.MovePocketMoneyRequest{UserID: 1, ToPocketID: 9, Amount: 10},
+			mock: func(repo *mock_repository.MockIRepository) {
+				repo.EXPECT().GetPocket(gomock.Any(), postgres.GetPocketParams{ID: 9, UserID: 1}).Return(postgres.Pocket{}, sql.ErrNoRows)
+			},
+			expectedCode: errors.CodePocketNotFound,
+		},
+		{
+			name:    "should reject more decimals than the currency has",
+			request: request.MovePocketMoneyRequest{UserID: 1, ToPocketID: 7, Amount: 10.005},
+			mock: func(repo *mock_repository.MockIRepository) {
+				repo.EXPECT().GetPocket(gomock.Any(), gomock.Any()).Return(rent, nil)
+				repo.EXPECT().GetCurrency(gomock.Any(), "IDR").Return(postgres.Currency{Code: "IDR", MinorUnits: 2}, nil)
+			},
+			expectedCode: errors.CodeInvalidAmountPrecision,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mock_repository.NewMockIRepository(ctrl)
+			tc.mock(repo)
+
+			balance, err := NewPocketUsecase(nil, repo, nil, nil, nil).MoveMoney(context.Background(), tc.request)
+			if tc.expectedCode != "" {
+				assert.Equal(t, tc.expectedCode, errors.CatalogEntryOf(err).Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 100.0, balance.Wallet.Balance)
+			assert.Equal(t, tc.expectedUnallocated, balance.Unallocated)
+			assert.Len(t, balance.Pockets, 2)
+		})
+	}
+}
+
+func TestPocketUsecase_ListBalances(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mock_repository.NewMockIRepository(ctrl)
+
+	repo.EXPECT().ListWalletsByUserID(gomock.Any(), int32(1)).Return([]postgres.Wallet{
+		{ID: 21, UserID: 1, Currency: "IDR", Balance: 100.3},
+		{ID: 22, UserID: 1, Currency: "USD", Balance: 5},
+	}, nil)
+	repo.EXPECT().ListPocketsByUserID(gomock.Any(), int32(1)).Return([]postgres.Pocket{
+		{ID: 7, Currency: "IDR", Name: "Rent", Balance: 33.1},
+		{ID: 8, Currency: "IDR", Name: "Travel", Balance: 0.1},
+	}, nil)
+
+	balances, err := NewPocketUsecase(nil, repo, nil, nil, nil).ListBalances(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, balances, 2)
+	assert.Equal(t, 67.1, balances[0].Unallocated)
+	assert.Len(t, balances[0].Pockets, 2)
+	assert.Equal(t, 5.0, balances[1].Unallocated)
+	assert.Empty(t, balances[1].Pockets)
+}
+
+func TestPocketUsecase_ListBalances_Cache(t *testing.T) {
+	wallets := []postgres.Wallet{{ID: 21, UserID: 1, Currency: "IDR", Balance: 100}}
+	pockets := []postgres.Pocket{{ID: 7, Currency: "IDR", Name: "Rent", Balance: 40}}
+
+	t.Run("should serve the cached balances", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockIRepository(ctrl)
+		userCache := mock_repository.NewMockIUserCache(ctrl)
+		userCache.EXPECT().GetBalances(gomock.Any(), int32(1)).Return(wallets, pockets, nil)
+
+		balances, err := NewPocketUsecase(nil, repo, userCache, nil, nil).ListBalances(context.Background(), 1)
+		require.NoError(t, err)
+		require.Len(t, balances, 1)
+		assert.Equal(t, 60.0, balances[0].Unallocated)
+	})
+
+	t.Run("should fill the cache on a miss", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockIRepository(ctrl)
+		userCache := mock_repository.NewMockIUserCache(ctrl)
+		userCache.EXPECT().GetBalances(gomock.Any(), int32(1)).Return(nil, nil, redis.ErrNil)
+		repo.EXPECT().ListWalletsByUserID(gomock.Any(), int32(1)).Return(wallets, nil)
+		repo.EXPECT().ListPocketsByUserID(gomock.Any(), int32(1)).Return(pockets, nil)
+		userCache.EXPECT().SetBalances(gomock.Any(), int32(1), wallets, pockets).Return(nil)
+
+		balances, err := NewPocketUsecase(nil, repo, userCache, nil, nil).ListBalances(context.Background(), 1)
+		require.NoError(t, err)
+		require.Len(t, balances, 1)
+		assert.Equal(t, 60.0, balances[0].Unallocated)
+	})
+}
@@ -0,0 +1,222 @@
+package realtime
+
+import (
+	"context"
+	"kc-ewallet/configurations"
+	"kc-ewallet/domains/repository"
+	"kc-ewallet/domains/repository/stream"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/internals/helpers/logging"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+// subscriptionBuffer is how many live events a slow connection may lag behind before
+// it is closed, the client reconnects with Last-Event-ID and catches up from the replay
+const subscriptionBuffer = 32
+
+// listenRetryInterval is the wait before listening again after the pub/sub connection fails
+const listenRetryInterval = time.Second
+
+type realtimeUsecase struct {
+	stream         repository.IEventStream
+	maxConnections int
+	trace          trace.Tracer
+
+	mu          sync.Mutex
+	closed      bool
+	subscribers map[int32]map[*Subscription]struct{}
+}
+
+// NewRealtimeUsecase fans the events received from the stream out to the
+// subscriptions of this instance, Run must be started to receive them
+func NewRealtimeUsecase(eventStream repository.IEventStream, config configurations.IRealtimeConfiguration, trace trace.Tracer) *realtimeUsecase {
+	if trace == nil {
+		trace = noop.NewTracerProvider().Tracer("")
+	}
+
+	return &realtimeUsecase{
+		stream:         eventStream,
+		maxConnections: config.GetMaxConnectionsPerUser(),
+		trace:          trace,
+		subscribers:    map[int32]map[*Subscription]struct{}{},
+	}
+}
+
+// Subscribe opens a stream of the user events. With a lastEventID the kept events after
+// it are delivered first, then the live ones, an event is never delivered twice
+func (r *realtimeUsecase) Subscribe(ctx context.Context, userID int32, lastEventID string) (*Subscription, error) {
+	ctx, span := r.trace.Start(ctx, "realtimeUsecase.Subscribe", trace.WithAttributes(
+		attribute.Int("user_id", int(userID)),
+		attribute.String("last_event_id", lastEventID),
+	))
+	defer span.End()
+
+	subscription := newSubscription(r, userID)
+
+	// registered before reading the replay so nothing published in between is missed
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return nil, errors.ServiceUnavailable.New("event stream is shutting down")
+	}
+	if len(r.subscribers[userID]) >= r.maxConnections {
+		r.mu.Unlock()
+		return nil, errors.TooManyRequests.New("too many open event streams")
+	}
+	if r.subscribers[userID] == nil {
+		r.subscribers[userID] = map[*Subscription]struct{}{}
+	}
+	r.subscribers[userID][subscription] = struct{}{}
+	r.mu.Unlock()
+
+	var replay []stream.Event
+	if stream.ValidID(lastEventID) {
+		var err error
+		replay, err = r.stream.Since(ctx, userID, lastEventID)
+		if err != nil {
+			subscription.Close()
+			logging.NewFromContext(ctx).Error("failed to read missed events", zap.Error(err))
+			return nil, errors.InternalServer.NewWithUserMsg(err, "failed to read missed events")
+		}
+	}
+
+	go subscription.forward(replay, lastEventID)
+	return subscription, nil
+}
+
+// Run receives the events published by every instance until ctx is done
+func (r *realtimeUsecase) Run(ctx context.Context) {
+	for {
+		err := r.stream.Listen(ctx, r.dispatch)
+		if ctx.Err() != nil {
+			return
+		}
+		logging.NewFromContext(ctx).Warn("event stream listener stopped, retrying", zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryInterval):
+		}
+	}
+}
+
+// Close ends every subscription and rejects new ones, so open streams
+// don't hold the http server shutdown until the drain timeout
+func (r *realtimeUsecase) Close() {
+	r.mu.Lock()
+	r.closed = true
+	var subscriptions []*Subscription
+	for _, userSubscriptions := range r.subscribers {
+		for subscription := range userSubscriptions {
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+	r.mu.Unlock()
+
+	for _, subscription := range subscriptions {
+		subscription.Close()
+	}
+}
+
+func (r *realtimeUsecase) dispatch(event stream.Event) {
+	var slow []*Subscription
+
+	r.mu.Lock()
+	for subscription := range r.subscribers[event.UserID] {
+		select {
+		case subscription.live <- event:
+		default:
+			slow = append(slow, subscription)
+		}
+	}
+	r.mu.Unlock()
+
+	for _, subscription := range slow {
+		subscription.Close()
+	}
+}
+
+func (r *realtimeUsecase) remove(subscription *Subscription) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.subscribers[subscription.userID], subscription)
+	if len(r.subscribers[subscription.userID]) == 0 {
+		delete(r.subscribers, subscription.userID)
+	}
+}
+
+// Subscription is one open event stream of a user
+type Subscription struct {
+	hub    *realtimeUsecase
+	userID int32
+	live   chan stream.Event
+	events chan stream.Event
+	done   chan struct{}
+	once   sync.Once
+}
+
+func newSubscription(hub *realtimeUsecase, userID int32) *Subscription {
+	return &Subscription{
+		hub:    hub,
+		userID: userID,
+		live:   make(chan stream.Event, subscriptionBuffer),
+		events: make(chan stream.Event),
+		done:   make(chan struct{}),
+	}
+}
+
+// Events delivers the replayed then live events, it is closed when the subscription ends
+func (s *Subscription) Events() <-chan stream.Event {
+	return s.events
+}
+
+// Close ends the subscription, it is safe to call more than once
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.hub.remove(s)
+		close(s.done)
+	})
+}
+
+func (s *Subscription) forward(replay []stream.Event, lastEventID string) {
+	defer close(s.events)
+
+	last := lastEventID
+	send := func(event stream.Event) bool {
+		// live events already replayed are skipped
+		if stream.CompareIDs(event.ID, last) <= 0 {
+			return true
+		}
+		select {
+		case s.events <- event:
+			last = event.ID
+			return true
+		case <-s.done:
+			return false
+		}
+	}
+
+	for _, event := range replay {
+		if !send(event) {
+			return
+		}
+	}
+	for {
+		select {
+		case event := <-s.live:
+			if !send(event) {
+				return
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package realtime
+
+import (
+	"context"
+	"fmt"
+	mock_configuration "kc-ewallet/configurations/mocks"
+	mock_repository "kc-ewallet/domains/repository/mocks"
+	"kc-ewallet/domains/repository/stream"
+	"kc-ewallet/internals/errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUsecase(t *testing.T, maxConnections int) (*realtimeUsecase, *mock_repository.MockIEventStream) {
+	ctrl := gomock.NewController(t)
+
+	config := mock_configuration.NewMockIRealtimeConfiguration(ctrl)
+	config.EXPECT().GetMaxConnectionsPerUser().Return(maxConnections)
+
+	eventStream := mock_repository.NewMockIEventStream(ctrl)
+	return NewRealtimeUsecase(eventStream, config, nil), eventStream
+}
+
+func event(id string, userID int32) stream.Event {
+	return stream.Event{ID: id, Type: stream.EventBalanceChanged, UserID: userID}
+}
+
+func receive(t *testing.T, subscription *Subscription) (stream.Event, bool) {
+	select {
+	case e, ok := <-subscription.Events():
+		return e, ok
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for an event")
+		return stream.Event{}, false
+	}
+}
+
+func TestRealtimeUsecase_Subscribe(t *testing.T) {
+	t.Run("should replay missed events then live ones without duplicates", func(t *testing.T) {
+		usecase, eventStream := newTestUsecase(t, 5)
+		eventStream.EXPECT().Since(gomock.Any(), int32(1), "1-0").
+			Return([]stream.Event{event("2-0", 1), event("3-0", 1)}, nil)
+
+		subscription, err := usecase.Subscribe(context.Background(), 1, "1-0")
+		require.NoError(t, err)
+		defer subscription.Close()
+
+		// published while the replay was read, already part of it
+		usecase.dispatch(event("3-0", 1))
+		usecase.dispatch(event("4-0", 1))
+		usecase.dispatch(event("5-0", 2))
+
+		for _, id := range []string{"2-0", "3-0", "4-0"} {
+			e, ok := receive(t, subscription)
+			require.True(t, ok)
+			assert.Equal(t, id, e.ID)
+		}
+		select {
+		case e := <-subscription.Events():
+			t.Fatalf("unexpected event %s", e.ID)
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("should skip the replay without a valid last event id", func(t *testing.T) {
+		usecase, _ := newTestUsecase(t, 5)
+
+		subscription, err := usecase.Subscribe(context.Background(), 1, "not-an-id")
+		require.NoError(t, err)
+		defer subscription.Close()
+
+		usecase.dispatch(event("1-0", 1))
+		e, ok := receive(t, subscription)
+		require.True(t, ok)
+		assert.Equal(t, "1-0", e.ID)
+	})
+
+	t.Run("should limit the connections per user", func(t *testing.T) {
+		usecase, _ := newTestUsecase(t, 1)
+
+		first, err := usecase.Subscribe(context.Background(), 1, "")
+		require.NoError(t, err)
+
+		_, err = usecase.Subscribe(context.Background(), 1, "")
+		assert.Equal(t, errors.TooManyRequests, errors.GetType(err))
+
+		other, err := usecase.Subscribe(context.Background(), 2, "")
+		require.NoError(t, err)
+		defer other.Close()
+
+		first.Close()
+		again, err := usecase.Subscribe(context.Background(), 1, "")
+		require.NoError(t, err)
+		again.Close()
+	})
+
+	t.Run("should release the connection when the replay fails", func(t *testing.T) {
+		usecase, eventStream := newTestUsecase(t, 1)
+		eventStream.EXPECT().Since(gomock.Any(), int32(1), "1-0").Return(nil, assert.AnError)
+
+		_, err := usecase.Subscribe(context.Background(), 1, "1-0")
+		assert.Equal(t, errors.InternalServer, errors.GetType(err))
+
+		subscription, err := usecase.Subscribe(context.Background(), 1, "")
+		require.NoError(t, err)
+		subscription.Close()
+	})
+}
+
+func TestRealtimeUsecase_SlowSubscription(t *testing.T) {
+	usecase, _ := newTestUsecase(t, 5)
+
+	subscription, err := usecase.Subscribe(context.Background(), 1, "")
+	require.NoError(t, err)
+
+	// nothing reads the events, the forward goroutine holds one and the buffer fills up
+	for i := 1; i <= subscriptionBuffer+2; i++ {
+		usecase.dispatch(event(fmt.Sprintf("%d-0", i), 1))
+	}
+
+	for {
+		if _, ok := receive(t, subscription); !ok {
+			break
+		}
+	}
+	assert.Empty(t, usecase.subscribers)
+}
+
+func TestRealtimeUsecase_Close(t *testing.T) {
+	usecase, _ := newTestUsecase(t, 5)
+
+	subscription, err := usecase.Subscribe(context.Background(), 1, "")
+	require.NoError(t, err)
+
+	usecase.Close()
+	_, ok := receive(t, subscription)
+	assert.False(t, ok)
+
+	_, err = usecase.Subscribe(context.Background(), 1, "")
+	assert.Equal(t, errors.ServiceUnavailable, errors.GetType(err))
+}
@@ -0,0 +1,441 @@
+package savings
+
+import (
+	"context"
+	"database/sql"
+	goerrors "errors"
+	"kc-ewallet/configurations"
+	"kc-ewallet/constants"
+	"kc-ewallet/domains/repository"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/domains/usecase/audit"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/internals/helpers/logging"
+	"kc-ewallet/internals/helpers/money"
+	"kc-ewallet/protocols/http/request"
+	"math"
+	"time"
+
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+// balanceDecimals is the precision of the balance columns, enough for every currency
+const balanceDecimals = 4
+
+// errNothingDue ends a sweep pass once no goal is due anymore
+var errNothingDue = goerrors.New("no sweep due")
+
+type savingsUsecase struct {
+	db           *sql.DB
+	repository   repository.IRepository
+	userCache    repository.IUserCache
+	audit        usecase.IAuditUsecase
+	trace        trace.Tracer
+	pollInterval time.Duration
+}
+
+func NewSavingsUsecase(
+	db *sql.DB,
+	repository repository.IRepository,
+	userCache repository.IUserCache,
+	config configurations.ISavingsConfiguration,
+	auditUsecase usecase.IAuditUsecase,
+	trace trace.Tracer,
+) *savingsUsecase {
+	if trace == nil {
+		trace = noop.NewTracerProvider().Tracer("")
+	}
+
+	return &savingsUsecase{
+		db:           db,
+		repository:   repository,
+		userCache:    userCache,
+		audit:        auditUsecase,
+		trace:        trace,
+		pollInterval: config.GetSweepPollInterval(),
+	}
+}
+
+// CreateGoal sets a target on a pocket of the user, a pocket saves toward one goal.
+// The first sweep is due one period after now
+func (s *savingsUsecase) CreateGoal(ctx context.Context, request request.CreateSavingsGoalRequest) (usecase.SavingsProgress, error) {
+	ctx, span := s.trace.Start(ctx, "savingsUsecase.CreateGoal", trace.WithAttributes(
+		attribute.Int("user_id", int(request.UserID)),
+		attribute.Int("pocket_id", int(request.PocketID)),
+	))
+	defer span.End()
+
+	now := time.Now()
+	targetDate, err := time.Parse(time.DateOnly, request.TargetDate)
+	if err != nil {
+		return usecase.SavingsProgress{}, errors.BadRequest.NewWithUserMsg(err, "target date must be YYYY-MM-DD")
+	}
+	if !targetDate.After(today(now)) {
+		return usecase.SavingsProgress{}, errors.BadRequest.NewWithUserMsg(nil, "target date must be after today")
+	}
+
+	pocket, err := s.getPocket(ctx, request.UserID, request.PocketID)
+	if err != nil {
+		return usecase.SavingsProgress{}, err
+	}
+
+	currency, err := s.repository.GetCurrency(ctx, pocket.Currency)
+	if err != nil {
+		logging.NewFromContext(ctx).Error("CreateGoal failed to get currency", zap.Error(err))
+		return usecase.SavingsProgress{}, errors.InternalServer.NewWithUserMsg(err, "failed to create savings goal")
+	}
+	for _, amount := range []float64{request.TargetAmount, request.RoundUpTo, request.SweepAmount} {
+		if !money.HasPrecision(amount, currency.MinorUnits) {
+			return usecase.SavingsProgress{}, errors.WithCode(errors.BadRequest.NewWithUserMsg(nil, "amount has too many decimals"), errors.CodeInvalidAmountPrecision)
+		}
+	}
+
+	params := postgres.CreateSavingsGoalParams{
+		UserID:       request.UserID,
+		PocketID:     pocket.ID,
+		TargetAmount: request.TargetAmount,
+		TargetDate:   targetDate,
+		RoundUpTo:    sql.NullFloat64{Float64: request.RoundUpTo, Valid: request.RoundUpTo > 0},
+	}
+	if request.SweepFrequency != "" {
+		params.SweepAmount = sql.NullFloat64{Float64: request.SweepAmount, Valid: true}
+		params.SweepFrequency = sql.NullString{String: request.SweepFrequency, Valid: true}
+		params.NextSweepAt = sql.NullTime{Time: nextSweep(now, request.SweepFrequency), Valid: true}
+	}
+
+	goal, err := s.repository.CreateSavingsGoal(ctx, params)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return usecase.SavingsProgress{}, errors.WithCode(errors.BadRequest.NewWithUserMsg(err, "pocket already has a savings goal"), errors.CodeSavingsGoalExists)
+		}
+		logging.NewFromContext(ctx).Error("CreateGoal failed to create savings goal", zap.Error(err))
+		return usecase.SavingsProgress{}, errors.InternalServer.NewWithUserMsg(err, "failed to create savings goal")
+	}
+
+	audit.RecordOrLog(ctx, s.audit, audit.Event{
+		Type:      audit.EventSavingsGoalCreated,
+		SubjectID: request.UserID,
+		Metadata: map[string]interface{}{
+			"goal_id":         goal.ID,
+			"pocket_id":       pocket.ID,
+			"target_amount":   goal.TargetAmount,
+			"target_date":     request.TargetDate,
+			"round_up_to":     request.RoundUpTo,
+			"sweep_amount":    request.SweepAmount,
+			"sweep_frequency": request.SweepFrequency,
+		},
+	})
+
+	return progressOf(goal, pocket, now), nil
+}
+
+// ListGoals returns the progress of every goal of the user, oldest first
+func (s *savingsUsecase) ListGoals(ctx context.Context, userID int32) ([]usecase.SavingsProgress, error) {
+	ctx, span := s.trace.Start(ctx, "savingsUsecase.ListGoals", trace.WithAttributes(
+		attribute.Int("user_id", int(userID)),
+	))
+	defer span.End()
+
+	goals, err := s.repository.ListSavingsGoalsByUserID(ctx, userID)
+	if err != nil {
+		logging.NewFromContext(ctx).Error("ListGoals failed to list savings goals", zap.Error(err))
+		return nil, errors.InternalServer.NewWithUserMsg(err, "failed to list savings goals")
+	}
+	pockets, err := s.repository.ListPocketsByUserID(ctx, userID)
+	if err != nil {
+		logging.NewFromContext(ctx).Error("ListGoals failed to list pockets", zap.Error(err))
+		return nil, errors.InternalServer.NewWithUserMsg(err, "failed to list savings goals")
+	}
+
+	byID := make(map[int32]postgres.Pocket, len(pockets))
+	for _, pocket := range pockets {
+		byID[pocket.ID] = pocket
+	}
+
+	now := time.Now()
+	progress := make([]usecase.SavingsProgress, 0, len(goals))
+	for _, goal := range goals {
+		progress = append(progress, progressOf(goal, byID[goal.PocketID], now))
+	}
+	return progress, nil
+}
+
+// GetGoal returns the progress of a goal of the user
+func (s *savingsUsecase) GetGoal(ctx context.Context, userID, goalID int32) (usecase.SavingsProgress, error) {
+	ctx, span := s.trace.Start(ctx, "savingsUsecase.GetGoal", trace.WithAttributes(
+		attribute.Int("user_id", int(userID)),
+		attribute.Int("goal_id", int(goalID)),
+	))
+	defer span.End()
+
+	goal, err := s.repository.GetSavingsGoal(ctx, postgres.GetSavingsGoalParams{ID: goalID, UserID: userID})
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return usecase.SavingsProgress{}, errors.WithCode(errors.NotFound.NewWithUserMsg(err, "savings goal not found"), errors.CodeSavingsGoalNotFound)
+		}
+		logging.NewFromContext(ctx).Error("GetGoal failed to get savings goal", zap.Error(err))
+		return usecase.SavingsProgress{}, errors.InternalServer.NewWithUserMsg(err, "failed to get savings goal")
+	}
+
+	pocket, err := s.getPocket(ctx, userID, goal.PocketID)
+	if err != nil {
+		return usecase.SavingsProgress{}, err
+	}
+
+	return progressOf(goal, pocket, time.Now()), nil
+}
+
+// ApplyDebitTx saves the round up change of a debit into the goals of its wallet, inside
+// the transaction of the debit whose wallet row is already locked. A round up the
+// unallocated balance can't cover is skipped, the debit itself never fails for it
+func (s *savingsUsecase) ApplyDebitTx(ctx context.Context, tx *sql.Tx, debit usecase.SavingsDebit) error {
+	query := s.repository
+	if tx != nil {
+		query = s.repository.WithTx(tx)
+	}
+
+	goals, err := query.ListRoundUpSavingsGoals(ctx, postgres.ListRoundUpSavingsGoalsParams{UserID: debit.UserID, Currency: debit.Currency})
+	if err != nil {
+		return err
+	}
+
+	spendable := debit.Spendable
+	for _, goal := range goals {
+		change := roundUpChange(debit.Amount, goal.RoundUpTo.Float64, debit.MinorUnits)
+		if change == 0 {
+			continue
+		}
+
+		pocket, err := query.GetPocketLock(ctx, postgres.GetPocketLockParams{ID: goal.PocketID, UserID: debit.UserID})
+		if err != nil {
+			return err
+		}
+
+		// a reached goal stops saving
+		change = math.Min(change, money.Round(goal.TargetAmount-pocket.Balance, debit.MinorUnits))
+		if change <= 0 {
+			continue
+		}
+		if change > spendable {
+			logging.NewFromContext(ctx).Info("round up skipped on insufficient funds",
+				zap.Int32("goal_id", goal.ID),
+				zap.Int32("transaction_id", debit.TransactionID),
+				zap.Float64("change", change),
+			)
+			continue
+		}
+
+		if err := s.save(ctx, query, pocket, constants.PocketEntryRoundUp, debit.TransactionID, change, debit.MinorUnits); err != nil {
+			return err
+		}
+		spendable = money.Round(spendable-change, debit.MinorUnits)
+	}
+	return nil
+}
+
+// Run sweeps the due goals once per poll interval until ctx is done
+func (s *savingsUsecase) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.SweepDue(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			logging.NewFromContext(ctx).Warn("failed to sweep savings goals", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SweepDue runs the sweeps due at now, one goal per database transaction. A goal locked
+// by another instance is skipped so every instance can run the job, and a goal whose
+// sweeps were missed during a downtime is swept once
+func (s *savingsUsecase) SweepDue(ctx context.Context, now time.Time) (int, error) {
+	ctx, span := s.trace.Start(ctx, "savingsUsecase.SweepDue")
+	defer span.End()
+
+	swept := 0
+	for ctx.Err() == nil {
+		ok, err := s.sweepNext(ctx, now)
+		if goerrors.Is(err, errNothingDue) {
+			return swept, nil
+		}
+		if err != nil {
+			return swept, err
+		}
+		if ok {
+			swept++
+		}
+	}
+	return swept, ctx.Err()
+}
+
+// sweepNext moves the sweep amount of the next due goal from the unallocated balance
+// into its pocket and schedules the next sweep. It reports false when the goal is
+// already reached or its wallet can't cover the sweep, that period is skipped
+func (s *savingsUsecase) sweepNext(ctx context.Context, now time.Time) (bool, error) {
+	var (
+		goal   postgres.SavingsGoal
+		amount float64
+	)
+	err := usecase.InTx(ctx, s.db, s.repository, func(query repository.IRepository) error {
+		var err error
+		goal, err = query.GetDueSavingsGoalLock(ctx, sql.NullTime{Time: now, Valid: true})
+		if err != nil {
+			if goerrors.Is(err, sql.ErrNoRows) {
+				return errNothingDue
+			}
+			return err
+		}
+
+		if err := query.UpdateSavingsGoalNextSweep(ctx, postgres.UpdateSavingsGoalNextSweepParams{
+			ID:          goal.ID,
+			NextSweepAt: sql.NullTime{Time: nextSweepAfter(goal.NextSweepAt.Time, goal.SweepFrequency.String, now), Valid: true},
+		}); err != nil {
+			return err
+		}
+
+		// the currency of a pocket never changes, it names the wallet to lock first
+		named, err := query.GetPocket(ctx, postgres.GetPocketParams{ID: goal.PocketID, UserID: goal.UserID})
+		if err != nil {
+			return err
+		}
+		currency, err := query.GetCurrency(ctx, named.Currency)
+		if err != nil {
+			return err
+		}
+		wallet, err := query.GetWalletLock(ctx, postgres.GetWalletLockParams{UserID: goal.UserID, Currency: currency.Code})
+		if err != nil {
+			return err
+		}
+		pocket, err := query.GetPocketLock(ctx, postgres.GetPocketLockParams{ID: goal.PocketID, UserID: goal.UserID})
+		if err != nil {
+			return err
+		}
+
+		amount = math.Min(goal.SweepAmount.Float64, money.Round(goal.TargetAmount-pocket.Balance, currency.MinorUnits))
+		if amount <= 0 {
+			amount = 0
+			return nil
+		}
+
+		allocated, err := query.SumPocketBalances(ctx, postgres.SumPocketBalancesParams{UserID: goal.UserID, Currency: currency.Code})
+		if err != nil {
+			return err
+		}
+		if money.Round(wallet.Balance-allocated, currency.MinorUnits) < amount {
+			logging.NewFromContext(ctx).Info("sweep skipped on insufficient funds", zap.Int32("goal_id", goal.ID), zap.Float64("amount", amount))
+			amount = 0
+			return nil
+		}
+
+		return s.save(ctx, query, pocket, constants.PocketEntrySweep, 0, amount, currency.MinorUnits)
+	})
+	if err != nil || amount == 0 {
+		return false, err
+	}
+	usecase.InvalidateBalances(ctx, s.userCache, goal.UserID)
+
+	audit.RecordOrLog(ctx, s.audit, audit.Event{
+		Type:      audit.EventSavingsSwept,
+		SubjectID: goal.UserID,
+		Metadata: map[string]interface{}{
+			"goal_id":   goal.ID,
+			"pocket_id": goal.PocketID,
+			"amount":    amount,
+		},
+	})
+	return true, nil
+}
+
+// save adds amount to a locked pocket and records it in its history, transactionID is
+// the debit a round up came from
+func (s *savingsUsecase) save(ctx context.Context, query repository.IRepository, pocket postgres.Pocket, entryType string, transactionID int32, amount float64, minorUnits int16) error {
+	newBalance := money.Round(pocket.Balance+amount, minorUnits)
+	if err := query.UpdatePocketBalance(ctx, postgres.UpdatePocketBalanceParams{ID: pocket.ID, Balance: newBalance}); err != nil {
+		return err
+	}
+
+	return query.CreatePocketEntry(ctx, postgres.CreatePocketEntryParams{
+		PocketID:      pocket.ID,
+		Type:          entryType,
+		Amount:        amount,
+		Balance:       newBalance,
+		TransactionID: sql.NullInt32{Int32: transactionID, Valid: transactionID != 0},
+	})
+}
+
+func (s *savingsUsecase) getPocket(ctx context.Context, userID, pocketID int32) (postgres.Pocket, error) {
+	pocket, err := s.repository.GetPocket(ctx, postgres.GetPocketParams{ID: pocketID, UserID: userID})
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return postgres.Pocket{}, errors.WithCode(errors.NotFound.NewWithUserMsg(err, "pocket not found"), errors.CodePocketNotFound)
+		}
+		logging.NewFromContext(ctx).Error("failed to get pocket", zap.Error(err))
+		return postgres.Pocket{}, errors.InternalServer.NewWithUserMsg(err, "failed to get pocket")
+	}
+	return pocket, nil
+}
+
+// progressOf compares the balance of the pocket of a goal with its target
+func progressOf(goal postgres.SavingsGoal, pocket postgres.Pocket, now time.Time) usecase.SavingsProgress {
+	remaining := math.Max(money.Round(goal.TargetAmount-pocket.Balance, balanceDecimals), 0)
+	daysLeft := int(goal.TargetDate.Sub(today(now)).Hours() / 24)
+	if daysLeft < 0 {
+		daysLeft = 0
+	}
+
+	return usecase.SavingsProgress{
+		Goal:      goal,
+		Pocket:    pocket,
+		Remaining: remaining,
+		Percent:   math.Min(money.Round(pocket.Balance/goal.TargetAmount*100, 2), 100),
+		DaysLeft:  daysLeft,
+		Reached:   remaining == 0,
+	}
+}
+
+// roundUpChange is what rounds amount up to the next multiple of step, zero when it
+// already is one
+func roundUpChange(amount, step float64, minorUnits int16) float64 {
+	multiples := amount / step
+	if math.Abs(multiples-math.Round(multiples)) < 1e-9 {
+		return 0
+	}
+	return money.Round(math.Ceil(multiples)*step-amount, minorUnits)
+}
+
+// nextSweep is one period of frequency after from
+func nextSweep(from time.Time, frequency string) time.Time {
+	switch frequency {
+	case constants.SweepDaily:
+		return from.AddDate(0, 0, 1)
+	case constants.SweepWeekly:
+		return from.AddDate(0, 0, 7)
+	default:
+		return from.AddDate(0, 1, 0)
+	}
+}
+
+// nextSweepAfter skips the periods missed before now, so a late sweep keeps its schedule
+func nextSweepAfter(due time.Time, frequency string, now time.Time) time.Time {
+	next := nextSweep(due, frequency)
+	for !next.After(now) {
+		next = nextSweep(next, frequency)
+	}
+	return next
+}
+
+// today is the start of the UTC day of now, the target dates are UTC days
+func today(now time.Time) time.Time {
+	return now.UTC().Truncate(24 * time.Hour)
+}
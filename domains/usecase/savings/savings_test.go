@@ -0,0 +1,221 @@
+package savings
+
+import (
+	"context"
+	"database/sql"
+	mock_configuration "kc-ewallet/configurations/mocks"
+	"kc-ewallet/constants"
+	mock_repository "kc-ewallet/domains/repository/mocks"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/protocols/http/request"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSavingsUsecase(ctrl *gomock.Controller, repo *mock_repository.MockIRepository) *savingsUsecase {
+	config := mock_configuration.NewMockISavingsConfiguration(ctrl)
+	config.EXPECT().GetSweepPollInterval().Return(time.Minute)
+	return NewSavingsUsecase(nil, repo, nil, config, nil, nil)
+}
+
+func TestSavingsUsecase_CreateGoal(t *testing.T) {
+	pocket := postgres.Pocket{ID: 7, UserID: 1, Currency: "IDR", Name: "Holiday", Balance: 250}
+	nextYear := time.Now().UTC().AddDate(1, 0, 0).Format(time.DateOnly)
+
+	testCases := []struct {
+		name         string
+		request      request.CreateSavingsGoalRequest
+		mock         func(repo *mock_repository.MockIRepository)
+		expectedCode errors.Code
+	}{
+		{
+			name:    "should schedule the first sweep one period ahead",
+			request: request.CreateSavingsGoalRequest{UserID: 1, PocketID: 7, TargetAmount: 1000, TargetDate: nextYear, RoundUpTo: 100, SweepAmount: 50, SweepFrequency: constants.SweepWeekly},
+			mock: func(repo *mock_repository.MockIRepository) {
+				repo.EXPECT().GetPocket(gomock.Any(), postgres.GetPocketParams{ID: 7, UserID: 1}).Return(pocket, nil)
+				repo.EXPECT().GetCurrency(gomock.Any(), "IDR").Return(postgres.Currency{Code: "IDR", MinorUnits: 2}, nil)
+				repo.EXPECT().CreateSavingsGoal(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, arg postgres.CreateSavingsGoalParams) (postgres.SavingsGoal, error) {
+					assert.Equal(t, sql.NullFloat64{Float64: 100, Valid: true}, arg.RoundUpTo)
+					assert.Equal(t, sql.NullString{String: constants.SweepWeekly, Valid: true}, arg.SweepFrequency)
+					assert.WithinDuration(t, time.Now().AddDate(0, 0, 7), arg.NextSweepAt.Time, time.Minute)
+					return postgres.SavingsGoal{ID: 3, UserID: 1, PocketID: 7, TargetAmount: arg.TargetAmount, TargetDate: arg.TargetDate}, nil
+				})
+			},
+		},
+		{
+			name:         "should reject a target date that passed",
+			request:      request.CreateSavingsGoalRequest{UserID: 1, PocketID: 7, TargetAmount: 1000, TargetDate: "2020-01-01"},
+			mock:         func(repo *mock_repository.MockIRepository) {},
+			expectedCode: errors.CodeBadRequest,
+		},
+		{
+			name:    "should reject a round up finer than the currency",
+			request: request.CreateSavingsGoalRequest{UserID: 1, PocketID: 7, TargetAmount: 1000, TargetDate: nextYear, RoundUpTo: 0.001},
+			mock: func(repo *mock_repository.MockIRepository) {
+				repo.EXPECT().GetPocket(gomock.Any(), gomock.Any()).Return(pocket, nil)
+				repo.EXPECT().GetCurrency(gomock.Any(), "IDR").Return(postgres.Currency{Code: "IDR", MinorUnits: 2}, nil)
+			},
+			expectedCode: errors.CodeInvalidAmountPrecision,
+		},
+		{
+			name:    "should reject a pocket that already has a goal",
+			request: request.CreateSavingsGoalRequest{UserID: 1, PocketID: 7, TargetAmount: 1000, TargetDate: nextYear},
+			mock: func(repo *mock_repository.MockIRepository) {
+				repo.EXPECT().GetPocket(gomock.Any(), gomock.Any()).Return(pocket, nil)
+				repo.EXPECT().GetCurrency(gomock.Any(), "IDR").Return(postgres.Currency{Code: "IDR", MinorUnits: 2}, nil)
+				repo.EXPECT().CreateSavingsGoal(gomock.Any(), gomock.Any()).Return(postgres.SavingsGoal{}, &pq.Error{Code: "23505"})
+			},
+			expectedCode: errors.CodeSavingsGoalExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mock_repository.NewMockIRepository(ctrl)
+			tc.mock(repo)
+
+			progress, err := newSavingsUsecase(ctrl, repo).CreateGoal(context.Background(), tc.request)
+			if tc.expectedCode != "" {
+				assert.Equal(t, tc.expectedCode, errors.CatalogEntryOf(err).Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int32(3), progress.Goal.ID)
+			assert.Equal(t, float64(750), progress.Remaining)
+			assert.Equal(t, float64(25), progress.Percent)
+			assert.False(t, progress.Reached)
+		})
+	}
+}
+
+func TestSavingsUsecase_ApplyDebitTx(t *testing.T) {
+	goal := postgres.SavingsGoal{ID: 3, UserID: 1, PocketID: 7, TargetAmount: 1000, RoundUpTo: sql.NullFloat64{Float64: 1000, Valid: true}}
+	listGoals := func(repo *mock_repository.MockIRepository) {
+		repo.EXPECT().ListRoundUpSavingsGoals(gomock.Any(), postgres.ListRoundUpSavingsGoalsParams{UserID: 1, Currency: "IDR"}).Return([]postgres.SavingsGoal{goal}, nil)
+	}
+
+	testCases := []struct {
+		name  string
+		debit usecase.SavingsDebit
+		mock  func(repo *mock_repository.MockIRepository)
+	}{
+		{
+			name:  "should save the change of the rounded up debit",
+			debit: usecase.SavingsDebit{TransactionID: 40, UserID: 1, Currency: "IDR", MinorUnits: 2, Amount: 12300, Spendable: 5000},
+			mock: func(repo *mock_repository.MockIRepository) {
+				listGoals(repo)
+				repo.EXPECT().GetPocketLock(gomock.Any(), postgres.GetPocketLockParams{ID: 7, UserID: 1}).Return(postgres.Pocket{ID: 7, Balance: 100}, nil)
+				repo.EXPECT().UpdatePocketBalance(gomock.Any(), postgres.UpdatePocketBalanceParams{ID: 7, Balance: 800}).Return(nil)
+				repo.EXPECT().CreatePocketEntry(gomock.Any(), postgres.CreatePocketEntryParams{
+					PocketID:      7,
+					Type:          constants.PocketEntryRoundUp,
+					Amount:        700,
+					Balance:       800,
+					TransactionID: sql.NullInt32{Int32: 40, Valid: true},
+				}).Return(nil)
+			},
+		},
+		{
+			name:  "should cap the change at what the goal lacks",
+			debit: usecase.SavingsDebit{TransactionID: 40, UserID: 1, Currency: "IDR", MinorUnits: 2, Amount: 12300, Spendable: 5000},
+			mock: func(repo *mock_repository.MockIRepository) {
+				listGoals(repo)
+				repo.EXPECT().GetPocketLock(gomock.Any(), gomock.Any()).Return(postgres.Pocket{ID: 7, Balance: 900}, nil)
+				repo.EXPECT().UpdatePocketBalance(gomock.Any(), postgres.UpdatePocketBalanceParams{ID: 7, Balance: 1000}).Return(nil)
+				repo.EXPECT().CreatePocketEntry(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:  "should save nothing on an exact multiple",
+			debit: usecase.SavingsDebit{TransactionID: 40, UserID: 1, Currency: "IDR", MinorUnits: 2, Amount: 12000, Spendable: 5000},
+			mock:  listGoals,
+		},
+		{
+			name:  "should skip a change the unallocated balance can't cover",
+			debit: usecase.SavingsDebit{TransactionID: 40, UserID: 1, Currency: "IDR", MinorUnits: 2, Amount: 12300, Spendable: 500},
+			mock: func(repo *mock_repository.MockIRepository) {
+				listGoals(repo)
+				repo.EXPECT().GetPocketLock(gomock.Any(), gomock.Any()).Return(postgres.Pocket{ID: 7, Balance: 100}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mock_repository.NewMockIRepository(ctrl)
+			tc.mock(repo)
+
+			require.NoError(t, newSavingsUsecase(ctrl, repo).ApplyDebitTx(context.Background(), nil, tc.debit))
+		})
+	}
+}
+
+func TestSavingsUsecase_SweepDue(t *testing.T) {
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	goal := postgres.SavingsGoal{
+		ID:             3,
+		UserID:         1,
+		PocketID:       7,
+		TargetAmount:   1000,
+		SweepAmount:    sql.NullFloat64{Float64: 50, Valid: true},
+		SweepFrequency: sql.NullString{String: constants.SweepDaily, Valid: true},
+		// missed for two days
+		NextSweepAt: sql.NullTime{Time: now.AddDate(0, 0, -2), Valid: true},
+	}
+	pocket := postgres.Pocket{ID: 7, UserID: 1, Currency: "IDR", Balance: 100}
+
+	testCases := []struct {
+		name          string
+		unallocated   float64
+		expectedSwept int
+	}{
+		{name: "should sweep a missed schedule once", unallocated: 200, expectedSwept: 1},
+		{name: "should skip a sweep the unallocated balance can't cover", unallocated: 20, expectedSwept: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mock_repository.NewMockIRepository(ctrl)
+
+			gomock.InOrder(
+				repo.EXPECT().GetDueSavingsGoalLock(gomock.Any(), sql.NullTime{Time: now, Valid: true}).Return(goal, nil),
+				repo.EXPECT().GetDueSavingsGoalLock(gomock.Any(), gomock.Any()).Return(postgres.SavingsGoal{}, sql.ErrNoRows),
+			)
+			repo.EXPECT().UpdateSavingsGoalNextSweep(gomock.Any(), postgres.UpdateSavingsGoalNextSweepParams{
+				ID:          3,
+				NextSweepAt: sql.NullTime{Time: now.AddDate(0, 0, 1), Valid: true},
+			}).Return(nil)
+			repo.EXPECT().GetPocket(gomock.Any(), postgres.GetPocketParams{ID: 7, UserID: 1}).Return(pocket, nil)
+			repo.EXPECT().GetCurrency(gomock.Any(), "IDR").Return(postgres.Currency{Code: "IDR", MinorUnits: 2}, nil)
+			repo.EXPECT().GetWalletLock(gomock.Any(), postgres.GetWalletLockParams{UserID: 1, Currency: "IDR"}).Return(postgres.Wallet{ID: 21, Balance: 100 + tc.unallocated}, nil)
+			repo.EXPECT().GetPocketLock(gomock.Any(), postgres.GetPocketLockParams{ID: 7, UserID: 1}).Return(pocket, nil)
+			repo.EXPECT().SumPocketBalances(gomock.Any(), postgres.SumPocketBalancesParams{UserID: 1, Currency: "IDR"}).Return(float64(100), nil)
+			if tc.expectedSwept > 0 {
+				repo.EXPECT().UpdatePocketBalance(gomock.Any(), postgres.UpdatePocketBalanceParams{ID: 7, Balance: 150}).Return(nil)
+				repo.EXPECT().CreatePocketEntry(gomock.Any(), postgres.CreatePocketEntryParams{PocketID: 7, Type: constants.PocketEntrySweep, Amount: 50, Balance: 150}).Return(nil)
+			}
+
+			swept, err := newSavingsUsecase(ctrl, repo).SweepDue(context.Background(), now)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSwept, swept)
+		})
+	}
+}
+
+func TestRoundUpChange(t *testing.T) {
+	assert.Equal(t, 700.0, roundUpChange(12300, 1000, 2))
+	assert.Equal(t, 0.0, roundUpChange(12000, 1000, 2))
+	assert.Equal(t, 0.9, roundUpChange(1.1, 1, 2))
+	assert.Equal(t, 0.0, roundUpChange(1.1, 0.1, 2))
+	assert.Equal(t, 0.05, roundUpChange(1.15, 0.1, 2))
+}
@@ -0,0 +1,300 @@
+package schedule
+
+import (
+	"context"
+	"database/sql"
+	goerrors "errors"
+	"kc-ewallet/configurations"
+	"kc-ewallet/domains/repository"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/domains/usecase/audit"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/internals/helpers/logging"
+	"kc-ewallet/internals/helpers/money"
+	"kc-ewallet/protocols/http/request"
+	"strings"
+	"time"
+
+	"github.com/teambition/rrule-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+const (
+	StatusActive    = "active"
+	StatusCompleted = "completed"
+	StatusCancelled = "cancelled"
+	StatusFailed    = "failed"
+)
+
+// Outcomes of a run, one attempt of an occurrence
+const (
+	RunSucceeded         = "succeeded"
+	RunInsufficientFunds = "insufficient_funds"
+	RunFailed            = "failed"
+)
+
+// Policies of an occurrence the balance can't cover
+const (
+	PolicyRetry = "retry"
+	PolicySkip  = "skip"
+)
+
+type scheduleUsecase struct {
+	db         *sql.DB
+	repository repository.IRepository
+	transfer   usecase.ITransferUsecase
+	audit      usecase.IAuditUsecase
+	trace      trace.Tracer
+
+	pollInterval  time.Duration
+	retryInterval time.Duration
+	maxAttempts   int32
+}
+
+// NewScheduleUsecase keeps the scheduled transfers and makes them through the transfer
+// usecase, Run must be started for them to go out
+func NewScheduleUsecase(
+	db *sql.DB,
+	repository repository.IRepository,
+	config configurations.IScheduleConfiguration,
+	transferUsecase usecase.ITransferUsecase,
+	auditUsecase usecase.IAuditUsecase,
+	trace trace.Tracer,
+) *scheduleUsecase {
+	if trace == nil {
+		trace = noop.NewTracerProvider().Tracer("")
+	}
+
+	return &scheduleUsecase{
+		db:            db,
+		repository:    repository,
+		transfer:      transferUsecase,
+		audit:         auditUsecase,
+		trace:         trace,
+		pollInterval:  config.GetPollInterval(),
+		retryInterval: config.GetRetryInterval(),
+		maxAttempts:   int32(config.GetMaxAttempts()),
+	}
+}
+
+// CreateSchedule checks the instruction and schedules its first occurrence at start_at
+func (s *scheduleUsecase) CreateSchedule(ctx context.Context, request request.CreateScheduleRequest) (postgres.ScheduledTransfer, error) {
+	ctx, span := s.trace.Start(ctx, "scheduleUsecase.CreateSchedule", trace.WithAttributes(
+		attribute.Int("user_id", int(request.UserID)),
+		attribute.Int("to_user_id", int(request.ToUserID)),
+		attribute.String("currency", request.Currency),
+		attribute.Float64("amount", request.Amount),
+	))
+	defer span.End()
+
+	if request.ToUserID == request.UserID {
+		return postgres.ScheduledTransfer{}, errors.BadRequest.NewWithUserMsg(nil, "can't transfer to yourself")
+	}
+
+	// the occurrences of a recurrence are computed to the second
+	startAt := request.StartAt.UTC().Truncate(time.Second)
+	if !startAt.After(time.Now()) {
+		return postgres.ScheduledTransfer{}, errors.BadRequest.NewWithUserMsg(nil, "start_at must be in the future")
+	}
+
+	params := postgres.CreateScheduledTransferParams{
+		UserID:              request.UserID,
+		ToUserID:            request.ToUserID,
+		Amount:              request.Amount,
+		Note:                strings.TrimSpace(request.Note),
+		StartAt:             startAt,
+		MaxOccurrences:      sql.NullInt32{Int32: request.MaxOccurrences, Valid: request.MaxOccurrences > 0},
+		OnInsufficientFunds: request.OnInsufficientFunds,
+	}
+	if params.OnInsufficientFunds == "" {
+		params.OnInsufficientFunds = PolicyRetry
+	}
+
+	recurrence := strings.TrimPrefix(strings.TrimSpace(request.Recurrence), "RRULE:")
+	if recurrence != "" {
+		rule, err := ruleOf(recurrence, startAt)
+		if err != nil {
+			return postgres.ScheduledTransfer{}, errors.BadRequest.NewWithUserMsg(err, "recurrence must be an RRULE, e.g. FREQ=MONTHLY;BYMONTHDAY=1")
+		}
+		if isSubDaily(rule) {
+			return postgres.ScheduledTransfer{}, errors.BadRequest.NewWithUserMsg(nil, "recurrence can't repeat more than once a day")
+		}
+		// start_at is the first occurrence, it must be one of the rule
+		if first := rule.After(startAt, true); !first.Equal(startAt) {
+			if first.IsZero() {
+				return postgres.ScheduledTransfer{}, errors.BadRequest.NewWithUserMsg(nil, "recurrence has no occurrence from start_at")
+			}
+			return postgres.ScheduledTransfer{}, errors.BadRequest.NewWithUserMsg(nil, "start_at must be an occurrence of the recurrence, the next one is "+first.Format(time.RFC3339))
+		}
+		params.Recurrence = sql.NullString{String: recurrence, Valid: true}
+	} else if request.EndAt != nil || request.MaxOccurrences > 0 {
+		return postgres.ScheduledTransfer{}, errors.BadRequest.NewWithUserMsg(nil, "end_at and max_occurrences need a recurrence")
+	}
+
+	if request.EndAt != nil {
+		endAt := request.EndAt.UTC()
+		if !endAt.After(startAt) {
+			return postgres.ScheduledTransfer{}, errors.BadRequest.NewWithUserMsg(nil, "end_at must be after start_at")
+		}
+		params.EndAt = sql.NullTime{Time: endAt, Valid: true}
+	}
+
+	currency, err := s.repository.GetCurrency(ctx, request.Currency)
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return postgres.ScheduledTransfer{}, errors.WithCode(errors.BadRequest.NewWithUserMsg(err, "currency is not supported"), errors.CodeUnsupportedCurrency)
+		}
+		logging.NewFromContext(ctx).Error("CreateSchedule failed to get currency", zap.Error(err))
+		return postgres.ScheduledTransfer{}, errors.InternalServer.NewWithUserMsg(err, "failed to create scheduled transfer")
+	}
+	if !money.HasPrecision(request.Amount, currency.MinorUnits) {
+		return postgres.ScheduledTransfer{}, errors.WithCode(errors.BadRequest.NewWithUserMsg(nil, "amount has too many decimals"), errors.CodeInvalidAmountPrecision)
+	}
+	params.Currency = currency.Code
+
+	// the wallet of the recipient is only checked when the transfer is made, it may be opened meanwhile
+	if _, err := s.repository.GetUserByID(ctx, request.ToUserID); err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return postgres.ScheduledTransfer{}, errors.WithCode(errors.NotFound.NewWithUserMsg(err, "recipient not found"), errors.CodeRecipientNotFound)
+		}
+		logging.NewFromContext(ctx).Error("CreateSchedule failed to get recipient", zap.Error(err))
+		return postgres.ScheduledTransfer{}, errors.InternalServer.NewWithUserMsg(err, "failed to create scheduled transfer")
+	}
+
+	schedule, err := s.repository.CreateScheduledTransfer(ctx, params)
+	if err != nil {
+		logging.NewFromContext(ctx).Error("CreateSchedule failed to create scheduled transfer", zap.Error(err))
+		return postgres.ScheduledTransfer{}, errors.InternalServer.NewWithUserMsg(err, "failed to create scheduled transfer")
+	}
+
+	audit.RecordOrLog(ctx, s.audit, audit.Event{
+		Type:      audit.EventScheduleCreated,
+		SubjectID: request.UserID,
+		Metadata: map[string]interface{}{
+			"schedule_id":     schedule.ID,
+			"to_user_id":      schedule.ToUserID,
+			"currency":        schedule.Currency,
+			"amount":          schedule.Amount,
+			"start_at":        schedule.StartAt,
+			"recurrence":      schedule.Recurrence.String,
+			"max_occurrences": request.MaxOccurrences,
+		},
+	})
+
+	return schedule, nil
+}
+
+// ListSchedules returns every scheduled transfer of the user, newest first
+func (s *scheduleUsecase) ListSchedules(ctx context.Context, userID int32) ([]postgres.ScheduledTransfer, error) {
+	ctx, span := s.trace.Start(ctx, "scheduleUsecase.ListSchedules", trace.WithAttributes(
+		attribute.Int("user_id", int(userID)),
+	))
+	defer span.End()
+
+	schedules, err := s.repository.ListScheduledTransfersByUserID(ctx, userID)
+	if err != nil {
+		logging.NewFromContext(ctx).Error("ListSchedules failed to list scheduled transfers", zap.Error(err))
+		return nil, errors.InternalServer.NewWithUserMsg(err, "failed to list scheduled transfers")
+	}
+	return schedules, nil
+}
+
+// GetSchedule returns a scheduled transfer of the user
+func (s *scheduleUsecase) GetSchedule(ctx context.Context, userID, scheduleID int32) (postgres.ScheduledTransfer, error) {
+	ctx, span := s.trace.Start(ctx, "scheduleUsecase.GetSchedule", trace.WithAttributes(
+		attribute.Int("user_id", int(userID)),
+		attribute.Int("schedule_id", int(scheduleID)),
+	))
+	defer span.End()
+
+	schedule, err := s.repository.GetScheduledTransfer(ctx, postgres.GetScheduledTransferParams{ID: scheduleID, UserID: userID})
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return postgres.ScheduledTransfer{}, errors.WithCode(errors.NotFound.NewWithUserMsg(err, "scheduled transfer not found"), errors.CodeScheduleNotFound)
+		}
+		logging.NewFromContext(ctx).Error("GetSchedule failed to get scheduled transfer", zap.Error(err))
+		return postgres.ScheduledTransfer{}, errors.InternalServer.NewWithUserMsg(err, "failed to get scheduled transfer")
+	}
+	return schedule, nil
+}
+
+// CancelSchedule stops an active scheduled transfer, an occurrence already being made
+// still goes through
+func (s *scheduleUsecase) CancelSchedule(ctx context.Context, userID, scheduleID int32) (postgres.ScheduledTransfer, error) {
+	ctx, span := s.trace.Start(ctx, "scheduleUsecase.CancelSchedule", trace.WithAttributes(
+		attribute.Int("user_id", int(userID)),
+		attribute.Int("schedule_id", int(scheduleID)),
+	))
+	defer span.End()
+
+	schedule, err := s.repository.CancelScheduledTransfer(ctx, postgres.CancelScheduledTransferParams{ID: scheduleID, UserID: userID})
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return postgres.ScheduledTransfer{}, errors.WithCode(errors.NotFound.NewWithUserMsg(err, "active scheduled transfer not found"), errors.CodeScheduleNotFound)
+		}
+		logging.NewFromContext(ctx).Error("CancelSchedule failed to cancel scheduled transfer", zap.Error(err))
+		return postgres.ScheduledTransfer{}, errors.InternalServer.NewWithUserMsg(err, "failed to cancel scheduled transfer")
+	}
+
+	audit.RecordOrLog(ctx, s.audit, audit.Event{
+		Type:      audit.EventScheduleCancelled,
+		SubjectID: userID,
+		Before:    map[string]interface{}{"status": StatusActive},
+		After:     map[string]interface{}{"status": schedule.Status},
+		Metadata:  map[string]interface{}{"schedule_id": schedule.ID},
+	})
+
+	return schedule, nil
+}
+
+// ListRuns returns a page of the runs of a scheduled transfer of the user, newest first
+func (s *scheduleUsecase) ListRuns(ctx context.Context, request request.ListScheduleRunsRequest) ([]postgres.ScheduledTransferRun, int64, error) {
+	ctx, span := s.trace.Start(ctx, "scheduleUsecase.ListRuns", trace.WithAttributes(
+		attribute.Int("user_id", int(request.UserID)),
+		attribute.Int("schedule_id", int(request.ScheduleID)),
+	))
+	defer span.End()
+
+	if _, err := s.GetSchedule(ctx, request.UserID, request.ScheduleID); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.repository.CountScheduledTransferRuns(ctx, request.ScheduleID)
+	if err != nil {
+		logging.NewFromContext(ctx).Error("ListRuns failed to count runs", zap.Error(err))
+		return nil, 0, errors.InternalServer.NewWithUserMsg(err, "failed to list scheduled transfer runs")
+	}
+
+	runs, err := s.repository.ListScheduledTransferRuns(ctx, postgres.ListScheduledTransferRunsParams{
+		ScheduledTransferID: request.ScheduleID,
+		RowLimit:            int32(request.Limit),
+		RowOffset:           int32(request.Offset),
+	})
+	if err != nil {
+		logging.NewFromContext(ctx).Error("ListRuns failed to list runs", zap.Error(err))
+		return nil, 0, errors.InternalServer.NewWithUserMsg(err, "failed to list scheduled transfer runs")
+	}
+
+	return runs, total, nil
+}
+
+// ruleOf parses an RRULE whose first occurrence is startAt
+func ruleOf(recurrence string, startAt time.Time) (*rrule.RRule, error) {
+	option, err := rrule.StrToROption(recurrence)
+	if err != nil {
+		return nil, err
+	}
+	option.Dtstart = startAt
+	return rrule.NewRRule(*option)
+}
+
+// isSubDaily reports whether the rule can occur more than once a day, either by its
+// frequency or by several hours, minutes or seconds within a day
+func isSubDaily(rule *rrule.RRule) bool {
+	option := rule.OrigOptions
+	return option.Freq > rrule.DAILY || len(option.Byhour) > 1 || len(option.Byminute) > 1 || len(option.Bysecond) > 1
+}
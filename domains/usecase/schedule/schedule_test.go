@@ -0,0 +1,298 @@
+package schedule
+
+import (
+	"context"
+	"database/sql"
+	goerrors "errors"
+	mock_configuration "kc-ewallet/configurations/mocks"
+	mock_repository "kc-ewallet/domains/repository/mocks"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/usecase"
+	mock_usecase "kc-ewallet/domains/usecase/mocks"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/protocols/http/request"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newScheduleUsecase(ctrl *gomock.Controller, repo *mock_repository.MockIRepository, transfer usecase.ITransferUsecase) *scheduleUsecase {
+	config := mock_configuration.NewMockIScheduleConfiguration(ctrl)
+	config.EXPECT().GetPollInterval().Return(time.Second)
+	config.EXPECT().GetRetryInterval().Return(time.Hour)
+	config.EXPECT().GetMaxAttempts().Return(3)
+	return NewScheduleUsecase(nil, repo, config, transfer, nil, nil)
+}
+
+func TestScheduleUsecase_CreateSchedule(t *testing.T) {
+	// the first day of next month, an occurrence of the monthly rules below
+	now := time.Now().UTC()
+	startAt := time.Date(now.Year(), now.Month()+1, 1, 9, 0, 0, 0, time.UTC)
+	endAt := startAt.AddDate(1, 0, 0)
+	mockLookups := func(repo *mock_repository.MockIRepository) {
+		repo.EXPECT().GetCurrency(gomock.Any(), "IDR").Return(postgres.Currency{Code: "IDR", MinorUnits: 2}, nil)
+		repo.EXPECT().GetUserByID(gomock.Any(), int32(2)).Return(postgres.User{ID: 2}, nil)
+	}
+
+	testCases := []struct {
+		name         string
+		request      request.CreateScheduleRequest
+		mock         func(repo *mock_repository.MockIRepository)
+		expectedCode errors.Code
+	}{
+		{
+			name:    "should schedule the first occurrence at start_at and retry by default",
+			request: request.CreateScheduleRequest{UserID: 1, ToUserID: 2, Currency: "IDR", Amount: 1500, StartAt: startAt, Recurrence: "RRULE:FREQ=MONTHLY;BYMONTHDAY=1", EndAt: &endAt, MaxOccurrences: 12},
+			mock: func(repo *mock_repository.MockIRepository) {
+				mockLookups(repo)
+				repo.EXPECT().CreateScheduledTransfer(gomock.Any(), postgres.CreateScheduledTransferParams{
+					UserID:              1,
+					ToUserID:            2,
+					Currency:            "IDR",
+					Amount:              1500,
+					Recurrence:          sql.NullString{String: "FREQ=MONTHLY;BYMONTHDAY=1", Valid: true},
+					StartAt:             startAt,
+					EndAt:               sql.NullTime{Time: endAt, Valid: true},
+					MaxOccurrences:      sql.NullInt32{Int32: 12, Valid: true},
+					OnInsufficientFunds: PolicyRetry,
+				}).Return(postgres.ScheduledTransfer{ID: 5, Status: StatusActive}, nil)
+			},
+		},
+		{
+			name:         "should reject a start in the past",
+			request:      request.CreateScheduleRequest{UserID: 1, ToUserID: 2, Currency: "IDR", Amount: 1500, StartAt: time.Now().Add(-time.Minute)},
+			mock:         func(repo *mock_repository.MockIRepository) {},
+			expectedCode: errors.CodeBadRequest,
+		},
+		{
+			name:         "should reject a recurrence that is not an RRULE",
+			request:      request.CreateScheduleRequest{UserID: 1, ToUserID: 2, Currency: "IDR", Amount: 1500, StartAt: startAt, Recurrence: "0 0 1 * *"},
+			mock:         func(repo *mock_repository.MockIRepository) {},
+			expectedCode: errors.CodeBadRequest,
+		},
+		{
+			name:         "should reject a recurrence more frequent than daily",
+			request:      request.CreateScheduleRequest{UserID: 1, ToUserID: 2, Currency: "IDR", Amount: 1500, StartAt: startAt, Recurrence: "FREQ=MINUTELY"},
+			mock:         func(repo *mock_repository.MockIRepository) {},
+			expectedCode: errors.CodeBadRequest,
+		},
+		{
+			name:         "should reject a daily recurrence at several hours",
+			request:      request.CreateScheduleRequest{UserID: 1, ToUserID: 2, Currency: "IDR", Amount: 1500, StartAt: startAt, Recurrence: "FREQ=DAILY;BYHOUR=9,21"},
+			mock:         func(repo *mock_repository.MockIRepository) {},
+			expectedCode: errors.CodeBadRequest,
+		},
+		{
+			name:         "should reject a start that is not an occurrence",
+			request:      request.CreateScheduleRequest{UserID: 1, ToUserID: 2, Currency: "IDR", Amount: 1500, StartAt: startAt, Recurrence: "FREQ=MONTHLY;BYMONTHDAY=15"},
+			mock:         func(repo *mock_repository.MockIRepository) {},
+			expectedCode: errors.CodeBadRequest,
+		},
+		{
+			name:         "should reject an end before the start",
+			request:      request.CreateScheduleRequest{UserID: 1, ToUserID: 2, Currency: "IDR", Amount: 1500, StartAt: startAt, Recurrence: "FREQ=WEEKLY", EndAt: &startAt},
+			mock:         func(repo *mock_repository.MockIRepository) {},
+			expectedCode: errors.CodeBadRequest,
+		},
+		{
+			name:         "should reject a max count on a one-off transfer",
+			request:      request.CreateScheduleRequest{UserID: 1, ToUserID: 2, Currency: "IDR", Amount: 1500, StartAt: startAt, MaxOccurrences: 3},
+			mock:         func(repo *mock_repository.MockIRepository) {},
+			expectedCode: errors.CodeBadRequest,
+		},
+		{
+			name:    "should reject an unknown recipient",
+			request: request.CreateScheduleRequest{UserID: 1, ToUserID: 2, Currency: "IDR", Amount: 1500, StartAt: startAt},
+			mock: func(repo *mock_repository.MockIRepository) {
+				repo.EXPECT().GetCurrency(gomock.Any(), "IDR").Return(postgres.Currency{Code: "IDR", MinorUnits: 2}, nil)
+				repo.EXPECT().GetUserByID(gomock.Any(), int32(2)).Return(postgres.User{}, sql.ErrNoRows)
+			},
+			expectedCode: errors.CodeRecipientNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mock_repository.NewMockIRepository(ctrl)
+			tc.mock(repo)
+
+			schedule, err := newScheduleUsecase(ctrl, repo, nil).CreateSchedule(context.Background(), tc.request)
+			if tc.expectedCode != "" {
+				assert.Equal(t, tc.expectedCode, errors.CatalogEntryOf(err).Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int32(5), schedule.ID)
+		})
+	}
+}
+
+func TestScheduleUsecase_NextState(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 30, 0, time.UTC)
+	occurrence := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	monthly := postgres.ScheduledTransfer{
+		ID:                  5,
+		Recurrence:          sql.NullString{String: "FREQ=MONTHLY;BYMONTHDAY=1", Valid: true},
+		StartAt:             time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+		OnInsufficientFunds: PolicyRetry,
+		Status:              StatusActive,
+		OccurrenceAt:        sql.NullTime{Time: occurrence, Valid: true},
+		OccurrenceCount:     1,
+	}
+	nextMonth := sql.NullTime{Time: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+	insufficient := errors.WithCode(errors.BadRequest.NewWithUserMsg(nil, "Insufficient funds"), errors.CodeInsufficientFunds)
+
+	with := func(change func(schedule *postgres.ScheduledTransfer)) postgres.ScheduledTransfer {
+		schedule := monthly
+		change(&schedule)
+		return schedule
+	}
+
+	testCases := []struct {
+		name          string
+		schedule      postgres.ScheduledTransfer
+		err           error
+		expectedRun   string
+		expectedState postgres.UpdateScheduledTransferStateParams
+	}{
+		{
+			name:        "should move on to the next occurrence once made",
+			schedule:    monthly,
+			expectedRun: RunSucceeded,
+			expectedState: postgres.UpdateScheduledTransferStateParams{
+				ID: 5, Status: StatusActive, OccurrenceAt: nextMonth, OccurrenceCount: 2, NextRunAt: nextMonth,
+			},
+		},
+		{
+			name:        "should retry an occurrence the balance can't cover",
+			schedule:    monthly,
+			err:         insufficient,
+			expectedRun: RunInsufficientFunds,
+			expectedState: postgres.UpdateScheduledTransferStateParams{
+				ID: 5, Status: StatusActive, OccurrenceAt: monthly.OccurrenceAt, OccurrenceCount: 1, Attempts: 1,
+				NextRunAt: sql.NullTime{Time: now.Add(time.Hour), Valid: true},
+			},
+		},
+		{
+			name:        "should skip an occurrence the balance can't cover with the skip policy",
+			schedule:    with(func(s *postgres.ScheduledTransfer) { s.OnInsufficientFunds = PolicySkip }),
+			err:         insufficient,
+			expectedRun: RunInsufficientFunds,
+			expectedState: postgres.UpdateScheduledTransferStateParams{
+				ID: 5, Status: StatusActive, OccurrenceAt: nextMonth, OccurrenceCount: 2, NextRunAt: nextMonth,
+			},
+		},
+		{
+			name:        "should skip an occurrence out of attempts",
+			schedule:    with(func(s *postgres.ScheduledTransfer) { s.Attempts = 2 }),
+			err:         goerrors.New("connection reset"),
+			expectedRun: RunFailed,
+			expectedState: postgres.UpdateScheduledTransferStateParams{
+				ID: 5, Status: StatusActive, OccurrenceAt: nextMonth, OccurrenceCount: 2, NextRunAt: nextMonth,
+			},
+		},
+		{
+			name:        "should fail when the recipient is gone",
+			schedule:    monthly,
+			err:         errors.WithCode(errors.NotFound.NewWithUserMsg(nil, "recipient not found"), errors.CodeRecipientNotFound),
+			expectedRun: RunFailed,
+			expectedState: postgres.UpdateScheduledTransferStateParams{
+				ID: 5, Status: StatusFailed, OccurrenceAt: monthly.OccurrenceAt, OccurrenceCount: 1, Attempts: 1,
+			},
+		},
+		{
+			name:        "should complete once max occurrences are made",
+			schedule:    with(func(s *postgres.ScheduledTransfer) { s.MaxOccurrences = sql.NullInt32{Int32: 2, Valid: true} }),
+			expectedRun: RunSucceeded,
+			expectedState: postgres.UpdateScheduledTransferStateParams{
+				ID: 5, Status: StatusCompleted, OccurrenceAt: monthly.OccurrenceAt, OccurrenceCount: 2,
+			},
+		},
+		{
+			name: "should complete when the next occurrence is after end_at",
+			schedule: with(func(s *postgres.ScheduledTransfer) {
+				s.EndAt = sql.NullTime{Time: occurrence.AddDate(0, 0, 15), Valid: true}
+			}),
+			expectedRun: RunSucceeded,
+			expectedState: postgres.UpdateScheduledTransferStateParams{
+				ID: 5, Status: StatusCompleted, OccurrenceAt: monthly.OccurrenceAt, OccurrenceCount: 2,
+			},
+		},
+		{
+			name:        "should complete a one-off transfer once made",
+			schedule:    with(func(s *postgres.ScheduledTransfer) { s.Recurrence = sql.NullString{} }),
+			expectedRun: RunSucceeded,
+			expectedState: postgres.UpdateScheduledTransferStateParams{
+				ID: 5, Status: StatusCompleted, OccurrenceAt: monthly.OccurrenceAt, OccurrenceCount: 2,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			s := newScheduleUsecase(ctrl, mock_repository.NewMockIRepository(ctrl), nil)
+
+			run, state := s.nextState(tc.schedule, 9, tc.err, now)
+			assert.Equal(t, tc.expectedRun, run.Status)
+			assert.Equal(t, tc.schedule.Attempts+1, run.Attempt)
+			assert.Equal(t, occurrence, run.OccurrenceAt)
+			assert.Equal(t, tc.err == nil, run.TransferID.Valid)
+			assert.Equal(t, tc.expectedState, state)
+		})
+	}
+}
+
+func TestScheduleUsecase_RunDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mock_repository.NewMockIRepository(ctrl)
+	transfer := mock_usecase.NewMockITransferUsecase(ctrl)
+
+	occurrence := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	schedule := postgres.ScheduledTransfer{
+		ID:                  5,
+		UserID:              1,
+		ToUserID:            2,
+		Currency:            "IDR",
+		Amount:              1500,
+		Note:                "rent",
+		StartAt:             occurrence,
+		OnInsufficientFunds: PolicyRetry,
+		Status:              StatusActive,
+		OccurrenceAt:        sql.NullTime{Time: occurrence, Valid: true},
+	}
+
+	repo.EXPECT().ClaimScheduledTransfers(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, arg postgres.ClaimScheduledTransfersParams) ([]postgres.ScheduledTransfer, error) {
+		assert.Equal(t, claimLease, arg.LeaseUntil.Sub(arg.Now))
+		return []postgres.ScheduledTransfer{schedule}, nil
+	})
+	transfer.EXPECT().Transfer(gomock.Any(), request.CreateTransferRequest{
+		UserID:         1,
+		ToUserID:       2,
+		Currency:       "IDR",
+		Amount:         1500,
+		Note:           "rent",
+		IdempotencyKey: "schedule:5:1790812800",
+	}).Return(usecase.TransferResult{Transfer: postgres.Transfer{ID: 9}}, nil)
+	repo.EXPECT().CreateScheduledTransferRun(gomock.Any(), postgres.CreateScheduledTransferRunParams{
+		ScheduledTransferID: 5,
+		OccurrenceAt:        occurrence,
+		Attempt:             1,
+		Status:              RunSucceeded,
+		TransferID:          sql.NullInt32{Int32: 9, Valid: true},
+	}).Return(nil)
+	repo.EXPECT().UpdateScheduledTransferState(gomock.Any(), postgres.UpdateScheduledTransferStateParams{
+		ID:              5,
+		Status:          StatusCompleted,
+		OccurrenceAt:    schedule.OccurrenceAt,
+		OccurrenceCount: 1,
+	}).Return(nil)
+
+	claimed, err := newScheduleUsecase(ctrl, repo, transfer).runDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, claimed)
+}
@@ -0,0 +1,226 @@
+package schedule
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"kc-ewallet/domains/repository"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/internals/helpers/logging"
+	"kc-ewallet/protocols/http/request"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// scheduleBatchSize bounds the scheduled transfers claimed per poll, they are made one by one
+const scheduleBatchSize int32 = 20
+
+// claimLease is how long a claimed scheduled transfer is held, another instance only
+// picks it up again if this one died before recording the run. The transfer is made
+// with an idempotency key per occurrence so that retry never pays twice
+const claimLease = 5 * time.Minute
+
+// maxRunErrorSize is how much of a failure is kept in the run
+const maxRunErrorSize = 255
+
+// Run makes the due scheduled transfers every poll interval until ctx is done
+func (s *scheduleUsecase) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// a full batch means more are due, keep going without waiting for the ticker
+		for {
+			claimed, err := s.runDue(ctx)
+			if err != nil && ctx.Err() == nil {
+				logging.NewFromContext(ctx).Warn("failed to claim scheduled transfers", zap.Error(err))
+			}
+			if claimed < int(scheduleBatchSize) || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runDue claims a batch of due scheduled transfers and attempts the pending occurrence
+// of each of them once
+func (s *scheduleUsecase) runDue(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	schedules, err := s.repository.ClaimScheduledTransfers(ctx, postgres.ClaimScheduledTransfersParams{
+		LeaseUntil: now.Add(claimLease),
+		Now:        now,
+		BatchSize:  scheduleBatchSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, schedule := range schedules {
+		if ctx.Err() != nil {
+			break
+		}
+		s.execute(ctx, schedule)
+	}
+	return len(schedules), nil
+}
+
+// execute makes the transfer of the pending occurrence and records the run with the
+// next state of the scheduled transfer
+func (s *scheduleUsecase) execute(ctx context.Context, schedule postgres.ScheduledTransfer) {
+	ctx, span := s.trace.Start(ctx, "scheduleUsecase.execute", trace.WithAttributes(
+		attribute.Int("schedule_id", int(schedule.ID)),
+		attribute.Int("attempt", int(schedule.Attempts+1)),
+	))
+	defer span.End()
+
+	result, err := s.transfer.Transfer(ctx, request.CreateTransferRequest{
+		UserID:         schedule.UserID,
+		ToUserID:       schedule.ToUserID,
+		Currency:       schedule.Currency,
+		Amount:         schedule.Amount,
+		Note:           schedule.Note,
+		IdempotencyKey: fmt.Sprintf("schedule:%d:%d", schedule.ID, schedule.OccurrenceAt.Time.Unix()),
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	run, state := s.nextState(schedule, result.Transfer.ID, err, time.Now().UTC())
+	span.SetAttributes(attribute.String("run_status", run.Status), attribute.String("status", state.Status))
+	if run.Status != RunSucceeded {
+		logging.NewFromContext(ctx).Warn("scheduled transfer run failed",
+			zap.Int32("schedule_id", schedule.ID),
+			zap.String("run_status", run.Status),
+			zap.String("status", state.Status),
+			zap.Error(err),
+		)
+	}
+
+	// not bound to ctx, a shutdown after the transfer still records its run
+	recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if err := usecase.InTx(recordCtx, s.db, s.repository, func(query repository.IRepository) error {
+		if err := query.CreateScheduledTransferRun(recordCtx, run); err != nil {
+			return err
+		}
+		return query.UpdateScheduledTransferState(recordCtx, state)
+	}); err != nil {
+		logging.NewFromContext(ctx).Error("failed to record scheduled transfer run", zap.Int32("schedule_id", schedule.ID), zap.Error(err))
+	}
+}
+
+// nextState is the run of an attempt and the scheduled transfer after it. A made
+// transfer moves on to the next occurrence. One the balance can't cover is retried
+// after the retry interval or skipped, following the policy of the scheduled transfer.
+// A recipient or wallet that is gone fails the scheduled transfer, any other error is
+// retried. An occurrence out of attempts is skipped
+func (s *scheduleUsecase) nextState(schedule postgres.ScheduledTransfer, transferID int32, err error, now time.Time) (postgres.CreateScheduledTransferRunParams, postgres.UpdateScheduledTransferStateParams) {
+	run := postgres.CreateScheduledTransferRunParams{
+		ScheduledTransferID: schedule.ID,
+		OccurrenceAt:        schedule.OccurrenceAt.Time,
+		Attempt:             schedule.Attempts + 1,
+		Status:              RunSucceeded,
+		TransferID:          sql.NullInt32{Int32: transferID, Valid: err == nil},
+	}
+	if err != nil {
+		run.Error = err.Error()
+		if len(run.Error) > maxRunErrorSize {
+			run.Error = run.Error[:maxRunErrorSize]
+		}
+	}
+
+	retry := func() postgres.UpdateScheduledTransferStateParams {
+		if run.Attempt >= s.maxAttempts {
+			return advance(schedule)
+		}
+		return postgres.UpdateScheduledTransferStateParams{
+			ID:              schedule.ID,
+			Status:          StatusActive,
+			OccurrenceAt:    schedule.OccurrenceAt,
+			OccurrenceCount: schedule.OccurrenceCount,
+			Attempts:        run.Attempt,
+			NextRunAt:       sql.NullTime{Time: now.Add(s.retryInterval), Valid: true},
+		}
+	}
+
+	switch {
+	case err == nil:
+		return run, advance(schedule)
+	case errors.CatalogEntryOf(err).Code == errors.CodeInsufficientFunds:
+		run.Status = RunInsufficientFunds
+		if schedule.OnInsufficientFunds == PolicySkip {
+			return run, advance(schedule)
+		}
+		return run, retry()
+	case errors.GetType(err) == errors.NotFound || errors.HasCode(err):
+		run.Status = RunFailed
+		return run, postgres.UpdateScheduledTransferStateParams{
+			ID:              schedule.ID,
+			Status:          StatusFailed,
+			OccurrenceAt:    schedule.OccurrenceAt,
+			OccurrenceCount: schedule.OccurrenceCount,
+			Attempts:        run.Attempt,
+		}
+	default:
+		run.Status = RunFailed
+		return run, retry()
+	}
+}
+
+// advance finishes the pending occurrence, made or skipped, and schedules the next one.
+// A next occurrence already past after a downtime is made right away
+func advance(schedule postgres.ScheduledTransfer) postgres.UpdateScheduledTransferStateParams {
+	params := postgres.UpdateScheduledTransferStateParams{
+		ID:              schedule.ID,
+		Status:          StatusCompleted,
+		OccurrenceAt:    schedule.OccurrenceAt,
+		OccurrenceCount: schedule.OccurrenceCount + 1,
+	}
+
+	next := nextOccurrence(schedule, params.OccurrenceCount)
+	if next.IsZero() {
+		return params
+	}
+
+	params.Status = StatusActive
+	params.OccurrenceAt = sql.NullTime{Time: next, Valid: true}
+	params.NextRunAt = sql.NullTime{Time: next, Valid: true}
+	return params
+}
+
+// nextOccurrence is the occurrence of the recurrence after the pending one, zero when
+// the scheduled transfer is one-off, has reached end_at or max_occurrences, or its
+// recurrence has no more
+func nextOccurrence(schedule postgres.ScheduledTransfer, occurrenceCount int32) time.Time {
+	if !schedule.Recurrence.Valid {
+		return time.Time{}
+	}
+	if schedule.MaxOccurrences.Valid && occurrenceCount >= schedule.MaxOccurrences.Int32 {
+		return time.Time{}
+	}
+
+	rule, err := ruleOf(schedule.Recurrence.String, schedule.StartAt.UTC())
+	if err != nil {
+		// checked when the scheduled transfer was created
+		return time.Time{}
+	}
+
+	next := rule.After(schedule.OccurrenceAt.Time.UTC(), false)
+	if schedule.EndAt.Valid && next.After(schedule.EndAt.Time) {
+		return time.Time{}
+	}
+	return next
+}
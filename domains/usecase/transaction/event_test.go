@@ -0,0 +1,93 @@
+package transaction
+
+import (
+	"context"
+	"encoding/json"
+	mock_repository "kc-ewallet/domains/repository/mocks"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/repository/stream"
+	mock_usecase "kc-ewallet/domains/usecase/mocks"
+	"kc-ewallet/protocols/http/request"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionUsecase_Events(t *testing.T) {
+	testCases := []struct {
+		name          string
+		insertErr     error
+		enqueueErr    error
+		expectEnqueue bool
+		expectPublish bool
+	}{
+		{name: "should publish and drop the cached balances after commit", expectEnqueue: true, expectPublish: true},
+		{name: "should not publish on rollback", insertErr: assert.AnError},
+		{name: "should roll back when webhooks can't be enqueued", enqueueErr: assert.AnError, expectEnqueue: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			eventStream := mock_repository.NewMockIEventStream(ctrl)
+			webhook := mock_usecase.NewMockIWebhookUsecase(ctrl)
+			userCache := mock_repository.NewMockIUserCache(ctrl)
+
+			db, sqlMock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			expectCurrency(sqlMock, "IDR", 2)
+			sqlMock.ExpectBegin()
+			expectLockedWallet(sqlMock, 1, "standard", 21, "IDR", 100)
+			sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE wallets")).WithArgs(int32(21), 150.0).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			if tc.insertErr != nil {
+				sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO transactions")).WillReturnError(tc.insertErr)
+			} else {
+				sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO transactions")).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+				expectNoDefaultPocket(sqlMock, 1, "IDR")
+			}
+			if tc.expectPublish {
+				sqlMock.ExpectCommit()
+			} else {
+				sqlMock.ExpectRollback()
+			}
+
+			if tc.expectEnqueue {
+				webhook.EXPECT().EnqueueTx(gomock.Any(), gomock.Not(gomock.Nil()), gomock.Len(2)).Return(tc.enqueueErr)
+			}
+
+			var published []stream.Event
+			if tc.expectPublish {
+				userCache.EXPECT().DeleteBalances(gomock.Any(), int32(1)).Return(nil)
+				eventStream.EXPECT().Publish(gomock.Any(), gomock.Any()).Times(2).
+					DoAndReturn(func(ctx context.Context, event stream.Event) (stream.Event, error) {
+						published = append(published, event)
+						return event, nil
+					})
+			}
+
+			usecase := NewTransactionUsecase(Dependencies{DB: db, Repository: postgres.New(db), Events: eventStream, Cache: userCache, Webhook: webhook})
+			_, _, err = usecase.CreateCreditTransaction(context.Background(), request.CreateCreditTransactionRequest{UserID: 1, Amount: 50, Currency: "IDR"})
+			assert.Equal(t, tc.expectPublish, err == nil)
+			assert.NoError(t, sqlMock.ExpectationsWereMet())
+
+			if !tc.expectPublish {
+				return
+			}
+			require.Len(t, published, 2)
+			assert.Equal(t, stream.EventTransactionCreated, published[0].Type)
+
+			var balance stream.BalanceChangedData
+			require.NoError(t, json.Unmarshal(published[1].Data, &balance))
+			assert.Equal(t, stream.EventBalanceChanged, published[1].Type)
+			assert.Equal(t, stream.BalanceChangedData{Currency: "IDR", Balance: 150, PreviousBalance: 100, TransactionID: 9}, balance)
+		})
+	}
+}
@@ -0,0 +1,351 @@
+package transaction
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"kc-ewallet/configurations"
+	"kc-ewallet/constants"
+	"kc-ewallet/domains/repository"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/repository/stream"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/domains/usecase/audit"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/internals/helpers/logging"
+	"kc-ewallet/internals/helpers/money"
+	"kc-ewallet/internals/metric"
+	"kc-ewallet/protocols/http/request"
+	"time"
+
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// rateDecimals is the precision an exchange rate is stored and applied with
+const rateDecimals = 10
+
+// exchangeTerms are the signed content of an exchange quote id
+type exchangeTerms struct {
+	Nonce         string  `json:"nonce"`
+	UserID        int32   `json:"user_id"`
+	From          string  `json:"from"`
+	To            string  `json:"to"`
+	FromAmount    float64 `json:"from_amount"`
+	ToAmount      float64 `json:"to_amount"`
+	Rate          float64 `json:"rate"`
+	MidRate       float64 `json:"mid_rate"`
+	SpreadPercent float64 `json:"spread_percent"`
+	ExpiresAt     int64   `json:"expires_at"`
+}
+
+// exchangeUsecase converts between the wallets of a user at a quoted rate, it shares
+// the locking, audit and event publishing of the transactions. The spread is kept by
+// the platform, only the exchanged amounts move between the wallets
+type exchangeUsecase struct {
+	*transactionUscase
+
+	rates         repository.IRateSource
+	spreadPercent float64
+	exchangeTTL   time.Duration
+}
+
+func NewExchangeUsecase(deps Dependencies, rates repository.IRateSource, fxConfig configurations.IFXConfiguration) *exchangeUsecase {
+	return &exchangeUsecase{
+		transactionUscase: NewTransactionUsecase(deps),
+		rates:             rates,
+		spreadPercent:     fxConfig.GetSpreadPercent(),
+		exchangeTTL:       fxConfig.GetQuoteTTL(),
+	}
+}
+
+// QuoteExchange prices selling an amount of a wallet of the user for another of its
+// wallets. Nothing is reserved, the balance is checked again on execution
+func (e *exchangeUsecase) QuoteExchange(ctx context.Context, request request.CreateExchangeQuoteRequest) (usecase.ExchangeQuote, error) {
+	ctx, span := e.trace.Start(ctx, "exchangeUsecase.QuoteExchange", trace.WithAttributes(
+		attribute.Int("user_id", int(request.UserID)),
+		attribute.String("from", request.From),
+		attribute.String("to", request.To),
+		attribute.Float64("amount", request.Amount),
+	))
+	defer span.End()
+
+	if len(e.quoteKey) == 0 {
+		return usecase.ExchangeQuote{}, errors.ServiceUnavailable.New("exchange quotes are not configured")
+	}
+
+	from, err := e.currencyOf(ctx, request.From, request.Amount)
+	if err != nil {
+		return usecase.ExchangeQuote{}, err
+	}
+	to, err := e.currencyOf(ctx, request.To, 0)
+	if err != nil {
+		return usecase.ExchangeQuote{}, err
+	}
+
+	fromWallet, err := e.walletOf(ctx, request.UserID, from.Code)
+	if err != nil {
+		return usecase.ExchangeQuote{}, err
+	}
+	if _, err := e.walletOf(ctx, request.UserID, to.Code); err != nil {
+		return usecase.ExchangeQuote{}, err
+	}
+	if fromWallet.Balance < request.Amount {
+		return usecase.ExchangeQuote{}, errors.WithCode(errors.BadRequest.NewWithUserMsg(nil, "Insufficient funds"), errors.CodeInsufficientFunds)
+	}
+
+	midRate, err := e.rates.GetRate(ctx, from.Code, to.Code)
+	if err != nil {
+		logging.NewFromContext(ctx).Warn("QuoteExchange failed to get rate", zap.String("from", from.Code), zap.String("to", to.Code), zap.Error(err))
+		return usecase.ExchangeQuote{}, errors.WithCode(errors.BadRequest.NewWithUserMsg(err, "no exchange rate for this currency pair"), errors.CodeRateUnavailable)
+	}
+
+	// the amounts are computed from the stored rate, so the recorded exchange adds up
+	midRate = money.Round(midRate, rateDecimals)
+	rate := money.Round(midRate*(1-e.spreadPercent/100), rateDecimals)
+	toAmount := money.Round(request.Amount*rate, to.MinorUnits)
+	if toAmount <= 0 {
+		return usecase.ExchangeQuote{}, errors.BadRequest.NewWithUserMsg(nil, "amount is too small to exchange")
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return usecase.ExchangeQuote{}, errors.InternalServer.NewWithUserMsg(err, "failed to create quote")
+	}
+
+	quote := usecase.ExchangeQuote{
+		From:          from.Code,
+		To:            to.Code,
+		FromAmount:    request.Amount,
+		ToAmount:      toAmount,
+		Rate:          rate,
+		MidRate:       midRate,
+		SpreadPercent: e.spreadPercent,
+		ExpiresAt:     time.Now().Add(e.exchangeTTL).Truncate(time.Second),
+	}
+	quote.QuoteID, err = signQuote(e.quoteKey, exchangeTerms{
+		Nonce:         hex.EncodeToString(nonce),
+		UserID:        request.UserID,
+		From:          quote.From,
+		To:            quote.To,
+		FromAmount:    quote.FromAmount,
+		ToAmount:      quote.ToAmount,
+		Rate:          quote.Rate,
+		MidRate:       quote.MidRate,
+		SpreadPercent: quote.SpreadPercent,
+		ExpiresAt:     quote.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return usecase.ExchangeQuote{}, errors.InternalServer.NewWithUserMsg(err, "failed to create quote")
+	}
+
+	return quote, nil
+}
+
+// Exchange executes a quote, debiting the sold wallet and crediting the bought one in
+// one database transaction. The quote nonce is stored with the exchange so a quote
+// is executed once
+func (e *exchangeUsecase) Exchange(ctx context.Context, request request.CreateExchangeRequest) (usecase.ExchangeResult, error) {
+	ctx, span := e.trace.Start(ctx, "exchangeUsecase.Exchange", trace.WithAttributes(
+		attribute.Int("user_id", int(request.UserID)),
+	))
+	defer span.End()
+
+	var (
+		terms   exchangeTerms
+		outcome = metric.OutcomeError
+		events  []stream.Event
+		result  usecase.ExchangeResult
+	)
+
+	// registered first so it runs after commit or rollback
+	defer func() {
+		e.metric.RecordTransaction(ctx, constants.TransactionTypeExchangeOut, outcome, terms.FromAmount)
+	}()
+
+	terms, err := e.parseExchangeQuote(request.QuoteID, request.UserID)
+	if err != nil {
+		return usecase.ExchangeResult{}, err
+	}
+
+	from, err := e.currencyOf(ctx, terms.From, terms.FromAmount)
+	if err != nil {
+		return usecase.ExchangeResult{}, err
+	}
+	to, err := e.currencyOf(ctx, terms.To, terms.ToAmount)
+	if err != nil {
+		return usecase.ExchangeResult{}, err
+	}
+
+	err = usecase.InSQLTx(ctx, e.db, e.repository, func(tx *sql.Tx, query repository.IRepository) error {
+		fromWallet, toWallet, err := e.lockWallets(ctx, query, request.UserID, from.Code, to.Code)
+		if err != nil {
+			if errors.GetType(err) == errors.NotFound {
+				outcome = metric.OutcomeNotFound
+			}
+			return err
+		}
+
+		// the pockets set their balances aside
+		spendable, err := e.spendableOf(ctx, query, from, fromWallet)
+		if err != nil {
+			return err
+		}
+		if spendable < terms.FromAmount {
+			outcome = metric.OutcomeInsufficientFunds
+			e.metric.RecordInsufficientFunds(ctx, constants.TransactionTypeExchangeOut)
+			return errors.WithCode(errors.BadRequest.NewWithUserMsg(nil, "Insufficient funds"), errors.CodeInsufficientFunds)
+		}
+
+		// Update balances
+		fromBalance := money.Round(fromWallet.Balance-terms.FromAmount, from.MinorUnits)
+		if err := query.UpdateWalletBalanceByID(ctx, postgres.UpdateWalletBalanceByIDParams{
+			ID:      fromWallet.ID,
+			Balance: fromBalance,
+		}); err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to update balance")
+		}
+		toBalance := money.Round(toWallet.Balance+terms.ToAmount, to.MinorUnits)
+		if err := query.UpdateWalletBalanceByID(ctx, postgres.UpdateWalletBalanceByIDParams{
+			ID:      toWallet.ID,
+			Balance: toBalance,
+		}); err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to update balance")
+		}
+
+		// Create transaction records
+		debitTransactionID, err := query.CreateTransaction(ctx, postgres.CreateTransactionParams{
+			UserID:   sql.NullInt32{Int32: request.UserID, Valid: true},
+			Amount:   terms.FromAmount,
+			Type:     constants.TransactionTypeExchangeOut,
+			Currency: from.Code,
+		})
+		if err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to create transaction")
+		}
+		creditTransactionID, err := query.CreateTransaction(ctx, postgres.CreateTransactionParams{
+			UserID:   sql.NullInt32{Int32: request.UserID, Valid: true},
+			Amount:   terms.ToAmount,
+			Type:     constants.TransactionTypeExchangeIn,
+			Currency: to.Code,
+		})
+		if err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to create transaction")
+		}
+
+		exchange, err := query.CreateExchange(ctx, postgres.CreateExchangeParams{
+			UserID:              request.UserID,
+			QuoteNonce:          terms.Nonce,
+			FromCurrency:        from.Code,
+			ToCurrency:          to.Code,
+			FromAmount:          terms.FromAmount,
+			ToAmount:            terms.ToAmount,
+			Rate:                terms.Rate,
+			MidRate:             terms.MidRate,
+			SpreadPercent:       terms.SpreadPercent,
+			DebitTransactionID:  debitTransactionID,
+			CreditTransactionID: creditTransactionID,
+		})
+		if err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+				return invalidQuote("quote was already used")
+			}
+			return errors.InternalServer.NewWithUserMsg(err, "failed to create exchange")
+		}
+
+		// audited inside the same database transaction as the balance changes
+		if e.audit != nil {
+			if err := e.audit.RecordTx(ctx, tx, audit.Event{
+				Type:      audit.EventTransactionExchange,
+				SubjectID: request.UserID,
+				Before:    map[string]interface{}{"from_balance": fromWallet.Balance, "to_balance": toWallet.Balance},
+				After:     map[string]interface{}{"from_balance": fromBalance, "to_balance": toBalance},
+				Metadata: map[string]interface{}{
+					"exchange_id": exchange.ID,
+					"from":        from.Code,
+					"to":          to.Code,
+					"from_amount": terms.FromAmount,
+					"to_amount":   terms.ToAmount,
+					"rate":        terms.Rate,
+					"mid_rate":    terms.MidRate,
+				},
+			}); err != nil {
+				return errors.InternalServer.NewWithUserMsg(err, "failed to record exchange")
+			}
+		}
+
+		// queued with the balance changes, a rollback never notifies a partner
+		events = append(
+			transactionEvents(fromWallet, fromBalance, debitTransactionID, constants.TransactionTypeExchangeOut, terms.FromAmount),
+			transactionEvents(toWallet, toBalance, creditTransactionID, constants.TransactionTypeExchangeIn, terms.ToAmount)...,
+		)
+		if err := e.enqueueWebhooks(ctx, tx, events); err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to enqueue webhooks")
+		}
+
+		result = usecase.ExchangeResult{
+			Exchange:    exchange,
+			FromBalance: fromBalance,
+			ToBalance:   toBalance,
+		}
+		return nil
+	})
+	if err != nil {
+		return usecase.ExchangeResult{}, err
+	}
+
+	outcome = metric.OutcomeSuccess
+	e.invalidateBalances(ctx, events)
+	e.publishEvents(ctx, events)
+	return result, nil
+}
+
+// parseExchangeQuote verifies the quote id was issued to the user and is still valid
+func (e *exchangeUsecase) parseExchangeQuote(quoteID string, userID int32) (exchangeTerms, error) {
+	if len(e.quoteKey) == 0 {
+		return exchangeTerms{}, invalidQuote("quotes are not configured")
+	}
+
+	var terms exchangeTerms
+	if err := parseSigned(e.quoteKey, quoteID, &terms); err != nil {
+		return exchangeTerms{}, invalidQuote(err.Error())
+	}
+	// a transaction quote is signed with the same key but has no currency pair
+	if terms.From == "" || terms.To == "" || terms.Nonce == "" {
+		return exchangeTerms{}, invalidQuote("not an exchange quote")
+	}
+	if terms.UserID != userID {
+		return exchangeTerms{}, invalidQuote("quote is for another user")
+	}
+	if time.Until(time.Unix(terms.ExpiresAt, 0)) <= 0 {
+		return exchangeTerms{}, errors.WithCode(errors.BadRequest.NewWithUserMsg(nil, "quote expired"), errors.CodeQuoteExpired)
+	}
+
+	return terms, nil
+}
+
+// lockWallets locks both wallets in currency order, so two opposite exchanges of
+// the user can't deadlock
+func (e *exchangeUsecase) lockWallets(ctx context.Context, query repository.IRepository, userID int32, from, to string) (postgres.Wallet, postgres.Wallet, error) {
+	first, second := from, to
+	if second < first {
+		first, second = second, first
+	}
+
+	_, firstWallet, err := e.lockWallet(ctx, query, userID, first)
+	if err != nil {
+		return postgres.Wallet{}, postgres.Wallet{}, err
+	}
+	_, secondWallet, err := e.lockWallet(ctx, query, userID, second)
+	if err != nil {
+		return postgres.Wallet{}, postgres.Wallet{}, err
+	}
+
+	if first == from {
+		return firstWallet, secondWallet, nil
+	}
+	return secondWallet, firstWallet, nil
+}
@@ -0,0 +1,214 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	mock_configuration "kc-ewallet/configurations/mocks"
+	"kc-ewallet/constants"
+	"kc-ewallet/domains/repository/fx"
+	mock_repository "kc-ewallet/domains/repository/mocks"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/protocols/http/request"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newExchangeConfigs(ctrl *gomock.Controller) (*mock_configuration.MockIFXConfiguration, *mock_configuration.MockIQuoteConfiguration) {
+	fxConfig := mock_configuration.NewMockIFXConfiguration(ctrl)
+	fxConfig.EXPECT().GetSpreadPercent().Return(0.5)
+	fxConfig.EXPECT().GetQuoteTTL().Return(30 * time.Second)
+	quoteConfig := mock_configuration.NewMockIQuoteConfiguration(ctrl)
+	quoteConfig.EXPECT().GetSigningKey().Return("secret")
+	quoteConfig.EXPECT().GetTTL().Return(30 * time.Second)
+	return fxConfig, quoteConfig
+}
+
+func TestExchangeUsecase_QuoteExchange(t *testing.T) {
+	testCases := []struct {
+		name         string
+		to           string
+		balance      float64
+		expectedCode errors.Code
+	}{
+		{name: "should quote the mid-market rate less the spread", to: "IDR", balance: 100},
+		{name: "should reject a pair without rate", to: "JPY", balance: 100, expectedCode: errors.CodeRateUnavailable},
+		{name: "should reject more than the balance", to: "IDR", balance: 10, expectedCode: errors.CodeInsufficientFunds},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mock_repository.NewMockIRepository(ctrl)
+			fxConfig, quoteConfig := newExchangeConfigs(ctrl)
+
+			repo.EXPECT().GetCurrency(gomock.Any(), "USD").Return(postgres.Currency{Code: "USD", MinorUnits: 2}, nil)
+			repo.EXPECT().GetCurrency(gomock.Any(), tc.to).Return(postgres.Currency{Code: tc.to, MinorUnits: 0}, nil)
+			repo.EXPECT().GetWallet(gomock.Any(), postgres.GetWalletParams{UserID: 1, Currency: "USD"}).
+				Return(postgres.Wallet{ID: 22, UserID: 1, Currency: "USD", Balance: tc.balance}, nil)
+			repo.EXPECT().GetWallet(gomock.Any(), postgres.GetWalletParams{UserID: 1, Currency: tc.to}).
+				Return(postgres.Wallet{ID: 21, UserID: 1, Currency: tc.to}, nil)
+
+			rates := fx.NewStaticRateSource(map[string]float64{"USD/IDR": 16000})
+			uc := NewExchangeUsecase(Dependencies{Repository: repo, Quote: quoteConfig}, rates, fxConfig)
+			quote, err := uc.QuoteExchange(context.Background(), request.CreateExchangeQuoteRequest{
+				UserID: 1,
+				From:   "USD",
+				To:     tc.to,
+				Amount: 10.5,
+			})
+
+			if tc.expectedCode != "" {
+				assert.Equal(t, tc.expectedCode, errors.CatalogEntryOf(err).Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 16000.0, quote.MidRate)
+			assert.Equal(t, 15920.0, quote.Rate)
+			assert.Equal(t, 167160.0, quote.ToAmount)
+			assert.WithinDuration(t, time.Now().Add(30*time.Second), quote.ExpiresAt, 2*time.Second)
+
+			var terms exchangeTerms
+			require.NoError(t, parseSigned([]byte("secret"), quote.QuoteID, &terms))
+			assert.Equal(t, "USD", terms.From)
+			assert.Equal(t, "IDR", terms.To)
+			assert.Equal(t, 10.5, terms.FromAmount)
+			assert.Equal(t, 167160.0, terms.ToAmount)
+			assert.Equal(t, 15920.0, terms.Rate)
+			assert.NotEmpty(t, terms.Nonce)
+		})
+	}
+}
+
+func TestExchangeUsecase_Exchange(t *testing.T) {
+	validTerms := exchangeTerms{
+		Nonce:         "abc",
+		UserID:        1,
+		From:          "USD",
+		To:            "IDR",
+		FromAmount:    10.5,
+		ToAmount:      167160,
+		Rate:          15920,
+		MidRate:       16000,
+		SpreadPercent: 0.5,
+		ExpiresAt:     time.Now().Add(time.Minute).Unix(),
+	}
+
+	testCases := []struct {
+		name         string
+		quoteID      func() string
+		balance      float64
+		used         bool
+		expectedCode errors.Code
+	}{
+		{name: "should exchange at the quoted rate", balance: 100},
+		{
+			name: "should reject an expired quote",
+			quoteID: func() string {
+				terms := validTerms
+				terms.ExpiresAt = time.Now().Add(-time.Second).Unix()
+				quoteID, _ := signQuote([]byte("secret"), terms)
+				return quoteID
+			},
+			expectedCode: errors.CodeQuoteExpired,
+		},
+		{
+			name: "should reject a quote of another user",
+			quoteID: func() string {
+				terms := validTerms
+				terms.UserID = 2
+				quoteID, _ := signQuote([]byte("secret"), terms)
+				return quoteID
+			},
+			expectedCode: errors.CodeInvalidQuote,
+		},
+		{
+			name: "should reject a transaction quote",
+			quoteID: func() string {
+				quoteID, _ := signQuote([]byte("secret"), quoteTerms{Nonce: "abc", UserID: 1, Currency: "IDR", Amount: 10, ExpiresAt: validTerms.ExpiresAt})
+				return quoteID
+			},
+			expectedCode: errors.CodeInvalidQuote,
+		},
+		{name: "should reject a used quote", balance: 100, used: true, expectedCode: errors.CodeInvalidQuote},
+		{name: "should reject when the balance no longer covers it", balance: 10, expectedCode: errors.CodeInsufficientFunds},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fxConfig, quoteConfig := newExchangeConfigs(ctrl)
+
+			quoteID, err := signQuote([]byte("secret"), validTerms)
+			require.NoError(t, err)
+			if tc.quoteID != nil {
+				quoteID = tc.quoteID()
+			}
+
+			db, sqlMock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			if tc.balance != 0 {
+				expectCurrency(sqlMock, "USD", 2)
+				expectCurrency(sqlMock, "IDR", 2)
+				sqlMock.ExpectBegin()
+				// locked in currency order
+				expectLockedWallet(sqlMock, 1, "standard", 21, "IDR", 1000)
+				expectLockedWallet(sqlMock, 1, "standard", 22, "USD", tc.balance)
+				expectSpendable(sqlMock, 1, "USD", 0)
+			}
+			if tc.balance >= validTerms.FromAmount {
+				sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE wallets")).WithArgs(int32(22), 89.5).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE wallets")).WithArgs(int32(21), 168160.0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO transactions")).
+					WithArgs(sql.NullInt32{Int32: 1, Valid: true}, 10.5, constants.TransactionTypeExchangeOut, "USD").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+				sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO transactions")).
+					WithArgs(sql.NullInt32{Int32: 1, Valid: true}, 167160.0, constants.TransactionTypeExchangeIn, "IDR").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+				insert := sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO exchanges")).
+					WithArgs(int32(1), "abc", "USD", "IDR", 10.5, 167160.0, 15920.0, 16000.0, 0.5, int32(9), int32(10))
+				if tc.used {
+					insert.WillReturnError(&pq.Error{Code: "23505"})
+				} else {
+					insert.WillReturnRows(sqlmock.NewRows([]string{
+						"id", "user_id", "quote_nonce", "from_currency", "to_currency", "from_amount", "to_amount",
+						"rate", "mid_rate", "spread_percent", "debit_transaction_id", "credit_transaction_id", "created_at",
+					}).AddRow(5, 1, "abc", "USD", "IDR", 10.5, 167160, 15920, 16000, 0.5, 9, 10, time.Now()))
+				}
+			}
+			if tc.expectedCode == "" {
+				sqlMock.ExpectCommit()
+			} else if tc.balance != 0 {
+				sqlMock.ExpectRollback()
+			}
+
+			rates := fx.NewStaticRateSource(nil)
+			uc := NewExchangeUsecase(Dependencies{DB: db, Repository: postgres.New(db), Quote: quoteConfig}, rates, fxConfig)
+			result, err := uc.Exchange(context.Background(), request.CreateExchangeRequest{
+				UserID:  1,
+				QuoteID: quoteID,
+			})
+			assert.NoError(t, sqlMock.ExpectationsWereMet())
+
+			if tc.expectedCode != "" {
+				assert.Equal(t, tc.expectedCode, errors.CatalogEntryOf(err).Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int32(5), result.Exchange.ID)
+			assert.Equal(t, 89.5, result.FromBalance)
+			assert.Equal(t, 168160.0, result.ToBalance)
+		})
+	}
+}
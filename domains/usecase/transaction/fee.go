@@ -0,0 +1,52 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/internals/errors"
+)
+
+// feeOf charges the fee of the executed quote, or the current fee without one.
+// A quote only holds while the wallet balance is the quoted one
+func (t *transactionUscase) feeOf(ctx context.Context, quote *quoteTerms, transactionType, channel string, user postgres.User, currency postgres.Currency, wallet postgres.Wallet, amount float64) (usecase.Fee, error) {
+	if quote == nil {
+		return t.calculateFee(ctx, transactionType, channel, user, currency, amount)
+	}
+	if wallet.Balance != quote.Balance {
+		return usecase.Fee{}, errors.WithCode(errors.BadRequest.NewWithUserMsg(nil, "balance changed since the quote"), errors.CodeQuoteChanged)
+	}
+
+	return usecase.Fee{RuleID: quote.FeeRuleID, Amount: quote.Fee}, nil
+}
+
+// calculateFee returns no fee when the fee engine isn't wired
+func (t *transactionUscase) calculateFee(ctx context.Context, transactionType, channel string, user postgres.User, currency postgres.Currency, amount float64) (usecase.Fee, error) {
+	if t.fees == nil {
+		return usecase.Fee{}, nil
+	}
+
+	return t.fees.Calculate(ctx, usecase.FeeInput{
+		TransactionType: transactionType,
+		Currency:        currency.Code,
+		MinorUnits:      currency.MinorUnits,
+		Channel:         channel,
+		UserTier:        user.Tier,
+		Amount:          amount,
+	})
+}
+
+// postFee books the fee of the transaction inside the same database transaction
+func (t *transactionUscase) postFee(ctx context.Context, tx *sql.Tx, transactionID int32, wallet postgres.Wallet, fee usecase.Fee) error {
+	if t.fees == nil || fee.Amount == 0 {
+		return nil
+	}
+
+	return t.fees.PostTx(ctx, tx, usecase.FeePosting{
+		TransactionID: transactionID,
+		UserID:        wallet.UserID,
+		Currency:      wallet.Currency,
+		Fee:           fee,
+	})
+}
@@ -0,0 +1,80 @@
+package transaction
+
+import (
+	"context"
+	"kc-ewallet/constants"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/usecase"
+	mock_usecase "kc-ewallet/domains/usecase/mocks"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/protocols/http/request"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionUsecase_DebitWithFee(t *testing.T) {
+	testCases := []struct {
+		name        string
+		balance     float64
+		expectedErr errors.ErrorType
+	}{
+		{name: "should debit the amount and the fee", balance: 100},
+		{name: "should reject when the fee doesn't fit the balance", balance: 50.5, expectedErr: errors.BadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fees := mock_usecase.NewMockIFeeUsecase(ctrl)
+			fee := usecase.Fee{RuleID: 4, Amount: 1}
+
+			db, sqlMock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			expectCurrency(sqlMock, "IDR", 2)
+			sqlMock.ExpectBegin()
+			expectLockedWallet(sqlMock, 1, "premium", 21, "IDR", tc.balance)
+			expectSpendable(sqlMock, 1, "IDR", 0)
+			fees.EXPECT().Calculate(gomock.Any(), usecase.FeeInput{
+				TransactionType: constants.TransactionTypeDebit,
+				Currency:        "IDR",
+				MinorUnits:      2,
+				Channel:         constants.ChannelApp,
+				UserTier:        "premium",
+				Amount:          50,
+			}).Return(fee, nil)
+			if tc.expectedErr == 0 {
+				sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE wallets")).WithArgs(int32(21), 49.0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO transactions")).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+				fees.EXPECT().PostTx(gomock.Any(), gomock.Not(gomock.Nil()), usecase.FeePosting{TransactionID: 9, UserID: 1, Currency: "IDR", Fee: fee}).Return(nil)
+				sqlMock.ExpectCommit()
+			} else {
+				sqlMock.ExpectRollback()
+			}
+
+			usecase := NewTransactionUsecase(Dependencies{DB: db, Repository: postgres.New(db), Fees: fees})
+			_, newBalance, err := usecase.CreateDebitTransaction(context.Background(), request.CreateDebitTransactionRequest{
+				UserID:   1,
+				Amount:   50,
+				Currency: "IDR",
+				Channel:  constants.ChannelApp,
+			})
+			assert.NoError(t, sqlMock.ExpectationsWereMet())
+
+			if tc.expectedErr != 0 {
+				assert.Equal(t, tc.expectedErr, errors.GetType(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 49.0, newBalance)
+		})
+	}
+}
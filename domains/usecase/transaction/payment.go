@@ -0,0 +1,215 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"kc-ewallet/constants"
+	"kc-ewallet/domains/repository"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/repository/stream"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/domains/usecase/audit"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/internals/helpers/logging"
+	"kc-ewallet/internals/helpers/money"
+	"kc-ewallet/internals/metric"
+	"kc-ewallet/protocols/http/request"
+
+	goerrors "errors"
+
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// maxPaymentMetadataSize bounds the metadata a merchant order carries
+const maxPaymentMetadataSize = 4 << 10
+
+// CreatePayment debits the user and captures the payment for the merchant, the
+// merchant wallet is only credited when the payment is settled
+func (t *transactionUscase) CreatePayment(ctx context.Context, request request.CreatePaymentRequest) (postgres.Payment, float64, error) {
+	ctx, span := t.trace.Start(ctx, "transactionUsecase.CreatePayment", trace.WithAttributes(
+		attribute.Int("user_id", int(request.UserID)),
+		attribute.Int("merchant_id", int(request.MerchantID)),
+		attribute.Float64("amount", request.Amount),
+	))
+	defer span.End()
+
+	metadata, err := paymentMetadata(request.Metadata)
+	if err != nil {
+		return postgres.Payment{}, 0, err
+	}
+
+	var (
+		outcome    = metric.OutcomeError
+		events     []stream.Event
+		payment    postgres.Payment
+		newBalance float64
+	)
+
+	// registered first so it runs after commit or rollback
+	defer func() {
+		t.metric.RecordTransaction(ctx, constants.TransactionTypePayment, outcome, request.Amount)
+	}()
+
+	// merchants are paid in the default currency, the payment keeps it to be settled in
+	currency, err := t.currencyOf(ctx, constants.DefaultCurrency, request.Amount)
+	if err != nil {
+		return postgres.Payment{}, 0, err
+	}
+
+	quote, err := t.verifyQuote(request.QuoteID, quoteTerms{
+		UserID:          request.UserID,
+		TransactionType: constants.TransactionTypePayment,
+		Currency:        currency.Code,
+		Channel:         request.Channel,
+		Amount:          request.Amount,
+	})
+	if err != nil {
+		return postgres.Payment{}, 0, err
+	}
+
+	err = usecase.InSQLTx(ctx, t.db, t.repository, func(tx *sql.Tx, query repository.IRepository) error {
+		if err := t.redeemQuote(ctx, query, quote); err != nil {
+			return err
+		}
+
+		merchant, err := query.GetMerchantByID(ctx, request.MerchantID)
+		if err != nil {
+			if goerrors.Is(err, sql.ErrNoRows) {
+				outcome = metric.OutcomeNotFound
+				return errors.NotFound.NewWithUserMsg(err, "merchant not found")
+			}
+			logging.NewFromContext(ctx).Error("error get merchant by id", zap.Error(err))
+			return errors.InternalServer.NewWithUserMsg(err, "failed to get merchant")
+		}
+		if !merchant.UserID.Valid {
+			return errors.BadRequest.NewWithUserMsg(nil, "merchant can't take payments yet")
+		}
+		if merchant.UserID.Int32 == request.UserID {
+			return errors.BadRequest.NewWithUserMsg(nil, "can't pay your own merchant")
+		}
+
+		// Lock the wallet row for update
+		user, wallet, err := t.lockWallet(ctx, query, request.UserID, currency.Code)
+		if err != nil {
+			if errors.GetType(err) == errors.NotFound {
+				outcome = metric.OutcomeNotFound
+			}
+			return err
+		}
+
+		fee, err := t.feeOf(ctx, quote, constants.TransactionTypePayment, request.Channel, user, currency, wallet, request.Amount)
+		if err != nil {
+			return err
+		}
+
+		// Check if balance is sufficient, the pockets set their balances aside
+		spendable, err := t.spendableOf(ctx, query, currency, wallet)
+		if err != nil {
+			return err
+		}
+		if spendable < request.Amount+fee.Amount {
+			outcome = metric.OutcomeInsufficientFunds
+			t.metric.RecordInsufficientFunds(ctx, constants.TransactionTypePayment)
+			return errors.WithCode(errors.BadRequest.NewWithUserMsg(nil, "Insufficient funds"), errors.CodeInsufficientFunds)
+		}
+
+		// Update balance
+		newBalance = money.Round(wallet.Balance-request.Amount-fee.Amount, currency.MinorUnits)
+		if err := query.UpdateWalletBalanceByID(ctx, postgres.UpdateWalletBalanceByIDParams{
+			ID:      wallet.ID,
+			Balance: newBalance,
+		}); err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to update balance")
+		}
+
+		// Create transaction record
+		transactionID, err := query.CreateTransaction(ctx, postgres.CreateTransactionParams{
+			UserID:   sql.NullInt32{Int32: request.UserID, Valid: true},
+			Amount:   request.Amount,
+			Type:     constants.TransactionTypePayment,
+			Currency: currency.Code,
+		})
+		if err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to create transaction")
+		}
+
+		payment, err = query.CreatePayment(ctx, postgres.CreatePaymentParams{
+			MerchantID:     merchant.ID,
+			UserID:         user.ID,
+			Currency:       currency.Code,
+			Amount:         request.Amount,
+			OrderReference: request.OrderReference,
+			Metadata:       metadata,
+			TransactionID:  transactionID,
+		})
+		if err != nil {
+			if pqErr, ok := err.(*pq.Error); ok {
+				if pqErr.Code == "23505" {
+					return errors.WithCode(errors.BadRequest.NewWithUserMsg(err, "order is already paid"), errors.CodeOrderAlreadyPaid)
+				}
+			}
+			return errors.InternalServer.NewWithUserMsg(err, "failed to create payment")
+		}
+
+		if err := t.postFee(ctx, tx, transactionID, wallet, fee); err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to post fee")
+		}
+
+		// audited inside the same database transaction as the balance change
+		if t.audit != nil {
+			if err := t.audit.RecordTx(ctx, tx, audit.Event{
+				Type:      audit.EventPaymentCaptured,
+				SubjectID: user.ID,
+				Before:    map[string]interface{}{"balance": wallet.Balance},
+				After:     map[string]interface{}{"balance": newBalance},
+				Metadata: map[string]interface{}{
+					"transaction_id":  transactionID,
+					"payment_id":      payment.ID,
+					"merchant_id":     merchant.ID,
+					"order_reference": payment.OrderReference,
+					"currency":        currency.Code,
+					"amount":          request.Amount,
+					"fee":             fee.Amount,
+				},
+			}); err != nil {
+				return errors.InternalServer.NewWithUserMsg(err, "failed to record transaction")
+			}
+		}
+
+		// queued with the balance change, a rollback never notifies a partner
+		events = transactionEvents(wallet, newBalance, transactionID, constants.TransactionTypePayment, request.Amount)
+		if err := t.enqueueWebhooks(ctx, tx, events); err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to enqueue webhooks")
+		}
+		return nil
+	})
+	if err != nil {
+		return postgres.Payment{}, 0, err
+	}
+
+	outcome = metric.OutcomeSuccess
+	t.invalidateBalances(ctx, events)
+	t.publishEvents(ctx, events)
+	return payment, newBalance, nil
+}
+
+// paymentMetadata encodes the metadata stored with the payment, an empty object when there is none
+func paymentMetadata(metadata map[string]interface{}) (json.RawMessage, error) {
+	if len(metadata) == 0 {
+		return json.RawMessage(`{}`), nil
+	}
+
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, errors.BadRequest.NewWithUserMsg(err, "metadata must be a json object")
+	}
+	if len(encoded) > maxPaymentMetadataSize {
+		return nil, errors.BadRequest.NewWithUserMsg(nil, "metadata is larger than 4KB")
+	}
+
+	return encoded, nil
+}
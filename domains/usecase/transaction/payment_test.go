@@ -0,0 +1,105 @@
+package transaction
+
+import (
+	"context"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/protocols/http/request"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionUsecase_CreatePayment(t *testing.T) {
+	merchantColumns := []string{"id", "name", "created_at", "user_id"}
+	paymentColumns := []string{"id", "merchant_id", "user_id", "currency", "amount", "order_reference", "metadata", "status", "transaction_id", "settlement_id", "created_at"}
+
+	testCases := []struct {
+		name           string
+		merchantWallet interface{}
+		paymentErr     error
+		expectedErr    errors.ErrorType
+		expectedCode   bool
+	}{
+		{name: "should debit the user and capture the payment", merchantWallet: int64(2)},
+		{name: "should reject paying your own merchant", merchantWallet: int64(1), expectedErr: errors.BadRequest},
+		{name: "should reject a merchant without wallet", merchantWallet: nil, expectedErr: errors.BadRequest},
+		{
+			name:           "should reject an order paid twice",
+			merchantWallet: int64(2),
+			paymentErr:     &pq.Error{Code: "23505"},
+			expectedErr:    errors.BadRequest,
+			expectedCode:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, sqlMock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			expectCurrency(sqlMock, "IDR", 2)
+			sqlMock.ExpectBegin()
+			sqlMock.ExpectQuery(regexp.QuoteMeta("FROM merchants")).WithArgs(int32(3)).
+				WillReturnRows(sqlmock.NewRows(merchantColumns).AddRow(3, "mugiwara", time.Now(), tc.merchantWallet))
+			if tc.merchantWallet == int64(2) {
+				expectLockedWallet(sqlMock, 1, "standard", 21, "IDR", 100)
+				expectSpendable(sqlMock, 1, "IDR", 0)
+				sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE wallets")).WithArgs(int32(21), 60.0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO transactions")).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+				if tc.paymentErr != nil {
+					sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO payments")).WillReturnError(tc.paymentErr)
+				} else {
+					sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO payments")).
+						WithArgs(int32(3), int32(1), "IDR", 40.0, "order-1", []byte(`{"table":7}`), int32(9)).
+						WillReturnRows(sqlmock.NewRows(paymentColumns).
+							AddRow(5, 3, 1, "IDR", 40.0, "order-1", []byte(`{"table":7}`), "captured", 9, nil, time.Now()))
+				}
+			}
+			if tc.expectedErr != 0 {
+				sqlMock.ExpectRollback()
+			} else {
+				sqlMock.ExpectCommit()
+			}
+
+			usecase := NewTransactionUsecase(Dependencies{DB: db, Repository: postgres.New(db)})
+			payment, newBalance, err := usecase.CreatePayment(context.Background(), request.CreatePaymentRequest{
+				UserID:         1,
+				MerchantID:     3,
+				Amount:         40,
+				OrderReference: "order-1",
+				Metadata:       map[string]interface{}{"table": 7},
+			})
+			assert.NoError(t, sqlMock.ExpectationsWereMet())
+
+			if tc.expectedErr != 0 {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr, errors.GetType(err))
+				assert.Equal(t, tc.expectedCode, errors.HasCode(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int32(5), payment.ID)
+			assert.Equal(t, int32(9), payment.TransactionID)
+			assert.Equal(t, 60.0, newBalance)
+		})
+	}
+}
+
+func TestPaymentMetadata(t *testing.T) {
+	metadata, err := paymentMetadata(nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(metadata))
+
+	_, err = paymentMetadata(map[string]interface{}{"note": strings.Repeat("a", maxPaymentMetadataSize)})
+	assert.Equal(t, errors.BadRequest, errors.GetType(err))
+}
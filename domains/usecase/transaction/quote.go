@@ -0,0 +1,252 @@
+package transaction
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"kc-ewallet/constants"
+	"kc-ewallet/domains/repository"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/internals/helpers/logging"
+	"kc-ewallet/internals/helpers/money"
+	"kc-ewallet/protocols/http/request"
+	"strings"
+	"time"
+
+	goerrors "errors"
+
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// quoteTerms are the signed content of a quote id
+type quoteTerms struct {
+	Nonce           string  `json:"nonce"`
+	UserID          int32   `json:"user_id"`
+	TransactionType string  `json:"type"`
+	Currency        string  `json:"currency"`
+	Channel         string  `json:"channel"`
+	ToUserID        int32   `json:"to_user_id,omitempty"`
+	Amount          float64 `json:"amount"`
+	Fee             float64 `json:"fee"`
+	FeeRuleID       int32   `json:"fee_rule_id"`
+	Balance         float64 `json:"balance"`
+	ExpiresAt       int64   `json:"expires_at"`
+}
+
+// QuoteTransaction previews the fee and resulting balance without moving money. The
+// quote id is only issued when quotes are configured
+func (t *transactionUscase) QuoteTransaction(ctx context.Context, request request.CreateTransactionQuoteRequest) (usecase.TransactionQuote, error) {
+	ctx, span := t.trace.Start(ctx, "transactionUsecase.QuoteTransaction", trace.WithAttributes(
+		attribute.Int("user_id", int(request.UserID)),
+		attribute.String("type", request.Type),
+		attribute.String("currency", request.Currency),
+		attribute.Float64("amount", request.Amount),
+	))
+	defer span.End()
+
+	code := request.Currency
+	if code == "" || request.Type == constants.TransactionTypePayment {
+		code = constants.DefaultCurrency
+	}
+	currency, err := t.currencyOf(ctx, code, request.Amount)
+	if err != nil {
+		return usecase.TransactionQuote{}, err
+	}
+
+	user, err := t.repository.GetUserByID(ctx, request.UserID)
+	if err != nil {
+		logging.NewFromContext(ctx).Error("error get user by id", zap.Error(err))
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return usecase.TransactionQuote{}, errors.WithCode(errors.NotFound.NewWithUserMsg(err, "user not found"), errors.CodeUserNotFound)
+		}
+		return usecase.TransactionQuote{}, errors.InternalServer.NewWithUserMsg(err, "failed to get user by id")
+	}
+
+	wallet, err := t.walletOf(ctx, user.ID, currency.Code)
+	if err != nil {
+		return usecase.TransactionQuote{}, err
+	}
+
+	var toUserID int32
+	if request.Type == constants.TransactionTypeTransfer {
+		toUserID = request.ToUserID
+		if err := t.checkRecipient(ctx, user.ID, toUserID, currency.Code); err != nil {
+			return usecase.TransactionQuote{}, err
+		}
+	}
+
+	fee, err := t.calculateFee(ctx, request.Type, request.Channel, user, currency, request.Amount)
+	if err != nil {
+		return usecase.TransactionQuote{}, err
+	}
+
+	resultingBalance := money.Round(wallet.Balance-request.Amount-fee.Amount, currency.MinorUnits)
+	if request.Type == constants.TransactionTypeCredit {
+		resultingBalance = money.Round(wallet.Balance+request.Amount-fee.Amount, currency.MinorUnits)
+	}
+	if resultingBalance < 0 {
+		return usecase.TransactionQuote{}, errors.WithCode(errors.BadRequest.NewWithUserMsg(nil, "Insufficient funds"), errors.CodeInsufficientFunds)
+	}
+
+	quote := usecase.TransactionQuote{
+		Type:             request.Type,
+		Currency:         currency.Code,
+		Amount:           request.Amount,
+		Fee:              fee.Amount,
+		Balance:          wallet.Balance,
+		ResultingBalance: resultingBalance,
+	}
+	if len(t.quoteKey) == 0 {
+		return quote, nil
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return usecase.TransactionQuote{}, errors.InternalServer.NewWithUserMsg(err, "failed to create quote")
+	}
+	quote.ExpiresAt = time.Now().Add(t.quoteTTL).Truncate(time.Second)
+	quote.QuoteID, err = signQuote(t.quoteKey, quoteTerms{
+		Nonce:           hex.EncodeToString(nonce),
+		UserID:          user.ID,
+		TransactionType: request.Type,
+		Currency:        currency.Code,
+		Channel:         request.Channel,
+		ToUserID:        toUserID,
+		Amount:          request.Amount,
+		Fee:             fee.Amount,
+		FeeRuleID:       fee.RuleID,
+		Balance:         wallet.Balance,
+		ExpiresAt:       quote.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return usecase.TransactionQuote{}, errors.InternalServer.NewWithUserMsg(err, "failed to create quote")
+	}
+
+	return quote, nil
+}
+
+// checkRecipient refuses a transfer quote to the user itself or to a user without wallet
+// in the currency
+func (t *transactionUscase) checkRecipient(ctx context.Context, userID, toUserID int32, currency string) error {
+	if toUserID == userID {
+		return errors.BadRequest.NewWithUserMsg(nil, "can't transfer to yourself")
+	}
+
+	if _, err := t.walletOf(ctx, toUserID, currency); err != nil {
+		if errors.GetType(err) == errors.NotFound {
+			return errors.WithCode(errors.NotFound.NewWithUserMsg(err, "recipient not found"), errors.CodeRecipientNotFound)
+		}
+		return err
+	}
+	return nil
+}
+
+// verifyQuote checks the quote id of an execution is for it and still valid, it is
+// claimed by redeemQuote. The nonce, fee and balance of the execution aren't compared. No
+// quote id executes with the current fee
+func (t *transactionUscase) verifyQuote(quoteID string, execution quoteTerms) (*quoteTerms, error) {
+	if quoteID == "" {
+		return nil, nil
+	}
+	if len(t.quoteKey) == 0 {
+		return nil, invalidQuote("quotes are not configured")
+	}
+
+	terms, err := parseQuote(t.quoteKey, quoteID)
+	if err != nil {
+		return nil, invalidQuote(err.Error())
+	}
+	if terms.UserID != execution.UserID || terms.TransactionType != execution.TransactionType || terms.Currency != execution.Currency ||
+		terms.Channel != execution.Channel || terms.ToUserID != execution.ToUserID || terms.Amount != execution.Amount {
+		return nil, invalidQuote("quote is for another transaction")
+	}
+
+	if time.Until(time.Unix(terms.ExpiresAt, 0)) <= 0 {
+		return nil, errors.WithCode(errors.BadRequest.NewWithUserMsg(nil, "quote expired"), errors.CodeQuoteExpired)
+	}
+
+	return &terms, nil
+}
+
+// redeemQuote claims the quote in the database transaction executing it, so a quote is
+// executed once and an execution rolled back leaves it usable until it expires
+func (t *transactionUscase) redeemQuote(ctx context.Context, query repository.IRepository, quote *quoteTerms) error {
+	if quote == nil {
+		return nil
+	}
+
+	err := query.CreateQuoteRedemption(ctx, postgres.CreateQuoteRedemptionParams{
+		Nonce:     quote.Nonce,
+		UserID:    quote.UserID,
+		ExpiresAt: time.Unix(quote.ExpiresAt, 0).UTC(),
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return invalidQuote("quote was already used")
+		}
+		logging.NewFromContext(ctx).Error("redeemQuote failed to claim quote", zap.Error(err))
+		return errors.InternalServer.NewWithUserMsg(err, "failed to verify quote")
+	}
+	return nil
+}
+
+func invalidQuote(reason string) error {
+	return errors.WithCode(errors.BadRequest.NewWithUserMsg(nil, reason), errors.CodeInvalidQuote)
+}
+
+// signQuote encodes the terms as base64url JSON followed by the hex HMAC-SHA256 of it
+func signQuote(key []byte, terms interface{}) (string, error) {
+	encoded, err := json.Marshal(terms)
+	if err != nil {
+		return "", err
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString(encoded)
+	return payload + "." + quoteSignature(key, payload), nil
+}
+
+func parseQuote(key []byte, quoteID string) (quoteTerms, error) {
+	var terms quoteTerms
+	if err := parseSigned(key, quoteID, &terms); err != nil {
+		return quoteTerms{}, err
+	}
+	return terms, nil
+}
+
+// parseSigned verifies the signature of a quote id and decodes its terms into v
+func parseSigned(key []byte, quoteID string, v interface{}) error {
+	payload, signature, ok := strings.Cut(quoteID, ".")
+	if !ok {
+		return fmt.Errorf("malformed quote id")
+	}
+	if !hmac.Equal([]byte(signature), []byte(quoteSignature(key, payload))) {
+		return fmt.Errorf("invalid quote signature")
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return fmt.Errorf("malformed quote id")
+	}
+
+	if err := json.Unmarshal(decoded, v); err != nil {
+		return fmt.Errorf("malformed quote id")
+	}
+	return nil
+}
+
+func quoteSignature(key []byte, payload string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,294 @@
+package transaction
+
+import (
+	"context"
+	mock_configuration "kc-ewallet/configurations/mocks"
+	"kc-ewallet/constants"
+	mock_repository "kc-ewallet/domains/repository/mocks"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/usecase"
+	mock_usecase "kc-ewallet/domains/usecase/mocks"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/protocols/http/request"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuoteSignature(t *testing.T) {
+	terms := quoteTerms{Nonce: "n", UserID: 1, TransactionType: constants.TransactionTypeDebit, Currency: "IDR", Amount: 50, Fee: 1, ExpiresAt: 1}
+
+	quoteID, err := signQuote([]byte("secret"), terms)
+	require.NoError(t, err)
+
+	parsed, err := parseQuote([]byte("secret"), quoteID)
+	require.NoError(t, err)
+	assert.Equal(t, terms, parsed)
+
+	_, err = parseQuote([]byte("other"), quoteID)
+	assert.Error(t, err)
+	_, err = parseQuote([]byte("secret"), "not-a-quote")
+	assert.Error(t, err)
+}
+
+func TestTransactionUsecase_QuoteTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mock_repository.NewMockIRepository(ctrl)
+	fees := mock_usecase.NewMockIFeeUsecase(ctrl)
+	config := mock_configuration.NewMockIQuoteConfiguration(ctrl)
+	config.EXPECT().GetSigningKey().Return("secret")
+	config.EXPECT().GetTTL().Return(30 * time.Second)
+
+	repo.EXPECT().GetCurrency(gomock.Any(), "IDR").Return(postgres.Currency{Code: "IDR", MinorUnits: 2}, nil)
+	repo.EXPECT().GetUserByID(gomock.Any(), int32(1)).Return(postgres.User{ID: 1, Tier: "standard"}, nil)
+	repo.EXPECT().GetWallet(gomock.Any(), postgres.GetWalletParams{UserID: 1, Currency: "IDR"}).
+		Return(postgres.Wallet{ID: 21, UserID: 1, Currency: "IDR", Balance: 100}, nil)
+	fees.EXPECT().Calculate(gomock.Any(), gomock.Any()).Return(usecase.Fee{RuleID: 4, Amount: 1}, nil)
+
+	uc := NewTransactionUsecase(Dependencies{Repository: repo, Fees: fees, Quote: config})
+	quote, err := uc.QuoteTransaction(context.Background(), request.CreateTransactionQuoteRequest{
+		UserID:   1,
+		Type:     constants.TransactionTypeDebit,
+		Amount:   50,
+		Currency: "IDR",
+		Channel:  constants.ChannelApp,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 49.0, quote.ResultingBalance)
+	assert.WithinDuration(t, time.Now().Add(30*time.Second), quote.ExpiresAt, 2*time.Second)
+
+	terms, err := parseQuote([]byte("secret"), quote.QuoteID)
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), terms.FeeRuleID)
+	assert.Equal(t, "IDR", terms.Currency)
+	assert.Equal(t, 100.0, terms.Balance)
+	assert.Equal(t, quote.ExpiresAt.Unix(), terms.ExpiresAt)
+}
+
+func TestTransactionUsecase_DebitWithQuote(t *testing.T) {
+	validTerms := quoteTerms{
+		Nonce:           "abc",
+		UserID:          1,
+		TransactionType: constants.TransactionTypeDebit,
+		Currency:        "IDR",
+		Channel:         constants.ChannelApp,
+		Amount:          50,
+		Fee:             1,
+		FeeRuleID:       4,
+		Balance:         100,
+		ExpiresAt:       time.Now().Add(time.Minute).Unix(),
+	}
+
+	testCases := []struct {
+		name         string
+		terms        func(terms quoteTerms) quoteTerms
+		key          string
+		balance      float64
+		used         bool
+		expectedCode errors.Code
+	}{
+		{name: "should charge the quoted fee", balance: 100},
+		{
+			name:         "should reject an expired quote",
+			terms:        func(terms quoteTerms) quoteTerms { terms.ExpiresAt = time.Now().Add(-time.Second).Unix(); return terms },
+			expectedCode: errors.CodeQuoteExpired,
+		},
+		{
+			name:         "should reject a quote of another amount",
+			terms:        func(terms quoteTerms) quoteTerms { terms.Amount = 40; return terms },
+			expectedCode: errors.CodeInvalidQuote,
+		},
+		{
+			name:         "should reject a quote of another currency",
+			terms:        func(terms quoteTerms) quoteTerms { terms.Currency = "USD"; return terms },
+			expectedCode: errors.CodeInvalidQuote,
+		},
+		{name: "should reject a forged quote", key: "forged", expectedCode: errors.CodeInvalidQuote},
+		{name: "should reject a used quote", used: true, expectedCode: errors.CodeInvalidQuote},
+		{name: "should reject when the balance changed", balance: 120, expectedCode: errors.CodeQuoteChanged},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fees := mock_usecase.NewMockIFeeUsecase(ctrl)
+			config := mock_configuration.NewMockIQuoteConfiguration(ctrl)
+			config.EXPECT().GetSigningKey().Return("secret")
+			config.EXPECT().GetTTL().Return(30 * time.Second)
+
+			terms := validTerms
+			if tc.terms != nil {
+				terms = tc.terms(terms)
+			}
+			key := "secret"
+			if tc.key != "" {
+				key = tc.key
+			}
+			quoteID, err := signQuote([]byte(key), terms)
+			require.NoError(t, err)
+
+			db, sqlMock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			expectCurrency(sqlMock, "IDR", 2)
+			if tc.balance != 0 {
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO quote_redemptions")).
+					WithArgs("abc", int32(1), time.Unix(terms.ExpiresAt, 0).UTC()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectLockedWallet(sqlMock, 1, "standard", 21, "IDR", tc.balance)
+			}
+			if tc.used {
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO quote_redemptions")).
+					WillReturnError(&pq.Error{Code: "23505"})
+				sqlMock.ExpectRollback()
+			}
+			if tc.expectedCode == "" {
+				expectSpendable(sqlMock, 1, "IDR", 0)
+				sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE wallets")).WithArgs(int32(21), 49.0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO transactions")).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+				fees.EXPECT().PostTx(gomock.Any(), gomock.Any(), usecase.FeePosting{
+					TransactionID: 9,
+					UserID:        1,
+					Currency:      "IDR",
+					Fee:           usecase.Fee{RuleID: 4, Amount: 1},
+				}).Return(nil)
+				sqlMock.ExpectCommit()
+			} else if tc.balance != 0 {
+				sqlMock.ExpectRollback()
+			}
+
+			uc := NewTransactionUsecase(Dependencies{DB: db, Repository: postgres.New(db), Fees: fees, Quote: config})
+			_, newBalance, err := uc.CreateDebitTransaction(context.Background(), request.CreateDebitTransactionRequest{
+				UserID:   1,
+				Amount:   50,
+				Currency: "IDR",
+				QuoteID:  quoteID,
+				Channel:  constants.ChannelApp,
+			})
+			assert.NoError(t, sqlMock.ExpectationsWereMet())
+
+			if tc.expectedCode != "" {
+				assert.Equal(t, tc.expectedCode, errors.CatalogEntryOf(err).Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 49.0, newBalance)
+		})
+	}
+}
+
+func TestTransactionUsecase_TransferWithQuote(t *testing.T) {
+	validTerms := quoteTerms{
+		Nonce:           "abc",
+		UserID:          1,
+		TransactionType: constants.TransactionTypeTransfer,
+		Currency:        "IDR",
+		Channel:         constants.ChannelApp,
+		ToUserID:        2,
+		Amount:          250,
+		Fee:             5,
+		FeeRuleID:       6,
+		Balance:         1000,
+		ExpiresAt:       time.Now().Add(time.Minute).Unix(),
+	}
+
+	testCases := []struct {
+		name         string
+		terms        func(terms quoteTerms) quoteTerms
+		expectedCode errors.Code
+	}{
+		{name: "should charge the quoted fee to the sender"},
+		{
+			name:         "should reject a quote for another recipient",
+			terms:        func(terms quoteTerms) quoteTerms { terms.ToUserID = 3; return terms },
+			expectedCode: errors.CodeInvalidQuote,
+		},
+		{
+			name: "should reject a quote of another type",
+			terms: func(terms quoteTerms) quoteTerms {
+				terms.TransactionType = constants.TransactionTypeDebit
+				return terms
+			},
+			expectedCode: errors.CodeInvalidQuote,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fees := mock_usecase.NewMockIFeeUsecase(ctrl)
+			config := mock_configuration.NewMockIQuoteConfiguration(ctrl)
+			config.EXPECT().GetSigningKey().Return("secret")
+			config.EXPECT().GetTTL().Return(30 * time.Second)
+
+			terms := validTerms
+			if tc.terms != nil {
+				terms = tc.terms(terms)
+			}
+			quoteID, err := signQuote([]byte("secret"), terms)
+			require.NoError(t, err)
+
+			db, sqlMock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			expectCurrency(sqlMock, "IDR", 2)
+			if tc.expectedCode == "" {
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO quote_redemptions")).
+					WithArgs("abc", int32(1), time.Unix(terms.ExpiresAt, 0).UTC()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectLockedWallet(sqlMock, 1, "standard", 11, "IDR", 1000)
+				expectLockedWallet(sqlMock, 2, "standard", 12, "IDR", 50)
+				expectSpendable(sqlMock, 1, "IDR", 0)
+				sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE wallets")).WithArgs(int32(11), 745.0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE wallets")).WithArgs(int32(12), 300.0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO transactions")).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+				sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO transactions")).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+				fees.EXPECT().PostTx(gomock.Any(), gomock.Any(), usecase.FeePosting{
+					TransactionID: 9,
+					UserID:        1,
+					Currency:      "IDR",
+					Fee:           usecase.Fee{RuleID: 6, Amount: 5},
+				}).Return(nil)
+				expectNoDefaultPocket(sqlMock, 2, "IDR")
+				sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO transfers")).
+					WillReturnRows(sqlmock.NewRows(transferColumns).AddRow(5, 1, 2, "IDR", 250, "", nil, 9, 10, time.Now()))
+				sqlMock.ExpectCommit()
+			}
+
+			uc := NewTransactionUsecase(Dependencies{DB: db, Repository: postgres.New(db), Fees: fees, Quote: config})
+			result, err := uc.Transfer(context.Background(), request.CreateTransferRequest{
+				UserID:   1,
+				ToUserID: 2,
+				Currency: "IDR",
+				Amount:   250,
+				QuoteID:  quoteID,
+				Channel:  constants.ChannelApp,
+			})
+			assert.NoError(t, sqlMock.ExpectationsWereMet())
+
+			if tc.expectedCode != "" {
+				assert.Equal(t, tc.expectedCode, errors.CatalogEntryOf(err).Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 745.0, result.Balance)
+		})
+	}
+}
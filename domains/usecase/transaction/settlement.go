@@ -0,0 +1,313 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	"kc-ewallet/configurations"
+	"kc-ewallet/constants"
+	"kc-ewallet/domains/repository"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/repository/stream"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/domains/usecase/audit"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/internals/helpers/logging"
+	"kc-ewallet/internals/helpers/money"
+	"kc-ewallet/internals/metric"
+	"kc-ewallet/protocols/http/request"
+	"time"
+
+	goerrors "errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// errNothingToSettle rolls back a settlement that found no payment, or whose day was already settled
+var errNothingToSettle = goerrors.New("nothing to settle")
+
+// settlementUsecase credits the merchant wallets with their captured payments, it
+// shares the locking, audit and event publishing of the transactions
+type settlementUsecase struct {
+	*transactionUscase
+
+	feePercent   float64
+	pollInterval time.Duration
+}
+
+func NewSettlementUsecase(deps Dependencies, config configurations.ISettlementConfiguration) *settlementUsecase {
+	return &settlementUsecase{
+		transactionUscase: NewTransactionUsecase(deps),
+		feePercent:        config.GetFeePercent(),
+		pollInterval:      config.GetPollInterval(),
+	}
+}
+
+// Run settles the previous day as soon as it is over and then once per poll interval,
+// until ctx is done. A settled day is skipped so every instance can run the job
+func (s *settlementUsecase) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.SettleDue(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			logging.NewFromContext(ctx).Warn("failed to settle payments", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SettleDue settles, for each merchant and currency, the payments captured before the
+// start of the UTC day of now into one settlement dated the day before. Payments left
+// from a missed day are included, a failing merchant doesn't stop the others
+func (s *settlementUsecase) SettleDue(ctx context.Context, now time.Time) (int, error) {
+	ctx, span := s.trace.Start(ctx, "settlementUsecase.SettleDue")
+	defer span.End()
+
+	cutoff := now.UTC().Truncate(24 * time.Hour)
+	settlementDate := cutoff.AddDate(0, 0, -1)
+
+	batches, err := s.repository.ListCapturedPaymentBatches(ctx, cutoff)
+	if err != nil {
+		return 0, err
+	}
+
+	settled := 0
+	for _, batch := range batches {
+		if ctx.Err() != nil {
+			return settled, ctx.Err()
+		}
+
+		settlement, err := s.settleMerchant(ctx, batch.MerchantID, batch.Currency, settlementDate, cutoff)
+		if goerrors.Is(err, errNothingToSettle) {
+			continue
+		}
+		if err != nil {
+			logging.NewFromContext(ctx).Error("failed to settle merchant", zap.Int32("merchant_id", batch.MerchantID), zap.String("currency", batch.Currency), zap.Error(err))
+			continue
+		}
+
+		logging.NewFromContext(ctx).Info("merchant settled",
+			zap.Int32("merchant_id", batch.MerchantID),
+			zap.String("currency", batch.Currency),
+			zap.Int32("settlement_id", settlement.ID),
+			zap.Int32("payment_count", settlement.PaymentCount),
+			zap.Float64("net_amount", settlement.NetAmount),
+		)
+		settled++
+	}
+
+	return settled, nil
+}
+
+// settleMerchant marks the captured payments in currencyCode as settled and credits the
+// net amount to the merchant wallet in that currency in one database transaction
+func (s *settlementUsecase) settleMerchant(ctx context.Context, merchantID int32, currencyCode string, settlementDate, cutoff time.Time) (postgres.Settlement, error) {
+	ctx, span := s.trace.Start(ctx, "settlementUsecase.settleMerchant", trace.WithAttributes(
+		attribute.Int("merchant_id", int(merchantID)),
+		attribute.String("currency", currencyCode),
+		attribute.String("settlement_date", settlementDate.Format(time.DateOnly)),
+	))
+	defer span.End()
+
+	var (
+		events     []stream.Event
+		settlement postgres.Settlement
+		netAmount  float64
+	)
+
+	err := usecase.InSQLTx(ctx, s.db, s.repository, func(tx *sql.Tx, query repository.IRepository) error {
+		// the unique merchant, day and currency makes a concurrent run skip the merchant
+		var err error
+		settlement, err = query.CreateSettlement(ctx, postgres.CreateSettlementParams{
+			MerchantID:     merchantID,
+			SettlementDate: settlementDate,
+			Currency:       currencyCode,
+		})
+		if err != nil {
+			if goerrors.Is(err, sql.ErrNoRows) {
+				return errNothingToSettle
+			}
+			return errors.InternalServer.NewWithUserMsg(err, "failed to create settlement")
+		}
+
+		totals, err := query.SettlePayments(ctx, postgres.SettlePaymentsParams{
+			SettlementID:   sql.NullInt32{Int32: settlement.ID, Valid: true},
+			MerchantID:     merchantID,
+			Currency:       currencyCode,
+			CapturedBefore: cutoff,
+		})
+		if err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to settle payments")
+		}
+		if totals.PaymentCount == 0 {
+			return errNothingToSettle
+		}
+
+		currency, err := query.GetCurrency(ctx, currencyCode)
+		if err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to get currency")
+		}
+
+		grossAmount := money.Round(totals.GrossAmount, currency.MinorUnits)
+		feeAmount := money.Round(grossAmount*s.feePercent/100, currency.MinorUnits)
+		netAmount = money.Round(grossAmount-feeAmount, currency.MinorUnits)
+
+		merchant, err := query.GetMerchantByID(ctx, merchantID)
+		if err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to get merchant")
+		}
+		if !merchant.UserID.Valid {
+			return errors.InternalServer.New("merchant %d has no wallet account", merchantID)
+		}
+
+		// Lock the wallet row for update, the payments are credited in their currency
+		lockStart := time.Now()
+		wallet, err := query.GetWalletLock(ctx, postgres.GetWalletLockParams{UserID: merchant.UserID.Int32, Currency: currency.Code})
+		s.metric.RecordLockWait(ctx, time.Since(lockStart))
+		if err != nil {
+			logging.NewFromContext(ctx).Error("error get wallet", zap.Error(err))
+			return errors.InternalServer.NewWithUserMsg(err, "failed to get wallet")
+		}
+
+		// Update balance
+		newBalance := money.Round(wallet.Balance+netAmount, currency.MinorUnits)
+		if err := query.UpdateWalletBalanceByID(ctx, postgres.UpdateWalletBalanceByIDParams{
+			ID:      wallet.ID,
+			Balance: newBalance,
+		}); err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to update balance")
+		}
+
+		// Create transaction record
+		transactionID, err := query.CreateTransaction(ctx, postgres.CreateTransactionParams{
+			UserID:   sql.NullInt32{Int32: wallet.UserID, Valid: true},
+			Amount:   netAmount,
+			Type:     constants.TransactionTypeSettlement,
+			Currency: wallet.Currency,
+		})
+		if err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to create transaction")
+		}
+
+		// the fee kept from the gross goes to the fee revenue account, so the ledger balances
+		if err := s.postFee(ctx, tx, transactionID, wallet, usecase.Fee{Amount: feeAmount}); err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to post fee")
+		}
+
+		settlement, err = query.UpdateSettlementTotals(ctx, postgres.UpdateSettlementTotalsParams{
+			ID:            settlement.ID,
+			PaymentCount:  totals.PaymentCount,
+			GrossAmount:   grossAmount,
+			FeeAmount:     feeAmount,
+			NetAmount:     netAmount,
+			TransactionID: sql.NullInt32{Int32: transactionID, Valid: true},
+		})
+		if err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to update settlement")
+		}
+
+		// audited inside the same database transaction as the balance change
+		if s.audit != nil {
+			if err := s.audit.RecordTx(ctx, tx, audit.Event{
+				Type:      audit.EventSettlementCreated,
+				SubjectID: wallet.UserID,
+				Before:    map[string]interface{}{"balance": wallet.Balance},
+				After:     map[string]interface{}{"balance": newBalance},
+				Metadata: map[string]interface{}{
+					"transaction_id":  transactionID,
+					"settlement_id":   settlement.ID,
+					"merchant_id":     merchantID,
+					"settlement_date": settlementDate.Format(time.DateOnly),
+					"currency":        wallet.Currency,
+					"payment_count":   settlement.PaymentCount,
+					"gross_amount":    grossAmount,
+					"fee_amount":      feeAmount,
+					"net_amount":      netAmount,
+				},
+			}); err != nil {
+				return errors.InternalServer.NewWithUserMsg(err, "failed to record transaction")
+			}
+		}
+
+		// queued with the balance change, a rollback never notifies a partner
+		events = transactionEvents(wallet, newBalance, transactionID, constants.TransactionTypeSettlement, netAmount)
+		if err := s.enqueueWebhooks(ctx, tx, events); err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to enqueue webhooks")
+		}
+		return nil
+	})
+	if err != nil {
+		// a merchant with nothing to settle isn't a failed settlement
+		if !goerrors.Is(err, errNothingToSettle) {
+			s.metric.RecordTransaction(ctx, constants.TransactionTypeSettlement, metric.OutcomeError, netAmount)
+		}
+		return postgres.Settlement{}, err
+	}
+
+	s.metric.RecordTransaction(ctx, constants.TransactionTypeSettlement, metric.OutcomeSuccess, netAmount)
+	s.invalidateBalances(ctx, events)
+	s.publishEvents(ctx, events)
+	return settlement, nil
+}
+
+// ListSettlements returns the settlements of the merchant, newest day first
+func (s *settlementUsecase) ListSettlements(ctx context.Context, request request.ListSettlementsRequest) ([]postgres.Settlement, int64, error) {
+	ctx, span := s.trace.Start(ctx, "settlementUsecase.ListSettlements", trace.WithAttributes(
+		attribute.Int("merchant_id", int(request.MerchantID)),
+	))
+	defer span.End()
+
+	total, err := s.repository.CountSettlementsByMerchantID(ctx, request.MerchantID)
+	if err != nil {
+		logging.NewFromContext(ctx).Error("ListSettlements failed to count settlements", zap.Error(err))
+		return nil, 0, errors.InternalServer.NewWithUserMsg(err, "failed to list settlements")
+	}
+
+	settlements, err := s.repository.ListSettlementsByMerchantID(ctx, postgres.ListSettlementsByMerchantIDParams{
+		MerchantID: request.MerchantID,
+		RowLimit:   int32(request.Limit),
+		RowOffset:  int32(request.Offset),
+	})
+	if err != nil {
+		logging.NewFromContext(ctx).Error("ListSettlements failed to list settlements", zap.Error(err))
+		return nil, 0, errors.InternalServer.NewWithUserMsg(err, "failed to list settlements")
+	}
+
+	return settlements, total, nil
+}
+
+// GetSettlementReport returns a settlement of the merchant with the payments it settled
+func (s *settlementUsecase) GetSettlementReport(ctx context.Context, merchantID, settlementID int32) (postgres.Settlement, []postgres.Payment, error) {
+	ctx, span := s.trace.Start(ctx, "settlementUsecase.GetSettlementReport", trace.WithAttributes(
+		attribute.Int("merchant_id", int(merchantID)),
+		attribute.Int("settlement_id", int(settlementID)),
+	))
+	defer span.End()
+
+	settlement, err := s.repository.GetSettlement(ctx, postgres.GetSettlementParams{
+		ID:         settlementID,
+		MerchantID: merchantID,
+	})
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return postgres.Settlement{}, nil, errors.NotFound.NewWithUserMsg(err, "settlement not found")
+		}
+		logging.NewFromContext(ctx).Error("GetSettlementReport failed to get settlement", zap.Error(err))
+		return postgres.Settlement{}, nil, errors.InternalServer.NewWithUserMsg(err, "failed to get settlement")
+	}
+
+	payments, err := s.repository.ListPaymentsBySettlementID(ctx, sql.NullInt32{Int32: settlement.ID, Valid: true})
+	if err != nil {
+		logging.NewFromContext(ctx).Error("GetSettlementReport failed to list payments", zap.Error(err))
+		return postgres.Settlement{}, nil, errors.InternalServer.NewWithUserMsg(err, "failed to get settlement")
+	}
+
+	return settlement, payments, nil
+}
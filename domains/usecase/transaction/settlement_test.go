@@ -0,0 +1,105 @@
+package transaction
+
+import (
+	"context"
+	mock_configuration "kc-ewallet/configurations/mocks"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/usecase"
+	mock_usecase "kc-ewallet/domains/usecase/mocks"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettlementUsecase_SettleDue(t *testing.T) {
+	settlementColumns := []string{"id", "merchant_id", "settlement_date", "currency", "payment_count", "gross_amount", "fee_amount", "net_amount", "transaction_id", "created_at"}
+	now := time.Date(2026, 10, 19, 3, 0, 0, 0, time.UTC)
+	cutoff := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	settlementDate := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name            string
+		currency        string
+		minorUnits      int16
+		grossAmount     float64
+		feeAmount       float64
+		netAmount       float64
+		alreadySettled  bool
+		paymentCount    int32
+		expectedSettled int
+	}{
+		// 0.7% of the gross amount is kept as fee
+		{name: "should credit the net amount to the merchant wallet", currency: "IDR", minorUnits: 2, grossAmount: 100, feeAmount: 0.7, netAmount: 99.3, paymentCount: 2, expectedSettled: 1},
+		{name: "should round the amounts to the minor units of the currency", currency: "JPY", minorUnits: 0, grossAmount: 150, feeAmount: 1, netAmount: 149, paymentCount: 1, expectedSettled: 1},
+		{name: "should skip a day already settled", currency: "IDR", alreadySettled: true},
+		{name: "should not keep an empty settlement", currency: "IDR", paymentCount: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			config := mock_configuration.NewMockISettlementConfiguration(ctrl)
+			config.EXPECT().GetFeePercent().Return(0.7)
+			config.EXPECT().GetPollInterval().Return(time.Hour)
+
+			db, sqlMock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT DISTINCT merchant_id, currency")).WithArgs(cutoff).
+				WillReturnRows(sqlmock.NewRows([]string{"merchant_id", "currency"}).AddRow(3, tc.currency))
+			sqlMock.ExpectBegin()
+			if tc.alreadySettled {
+				sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO settlements")).WithArgs(int32(3), settlementDate, tc.currency).
+					WillReturnRows(sqlmock.NewRows(settlementColumns))
+				sqlMock.ExpectRollback()
+			} else {
+				sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO settlements")).WithArgs(int32(3), settlementDate, tc.currency).
+					WillReturnRows(sqlmock.NewRows(settlementColumns).AddRow(4, 3, settlementDate, tc.currency, 0, 0.0, 0.0, 0.0, nil, now))
+				sqlMock.ExpectQuery(regexp.QuoteMeta("UPDATE payments")).WithArgs(int64(4), int32(3), tc.currency, cutoff).
+					WillReturnRows(sqlmock.NewRows([]string{"payment_count", "gross_amount"}).AddRow(tc.paymentCount, tc.grossAmount))
+			}
+			if tc.paymentCount > 0 {
+				expectCurrency(sqlMock, tc.currency, tc.minorUnits)
+				sqlMock.ExpectQuery(regexp.QuoteMeta("FROM merchants")).WithArgs(int32(3)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "user_id"}).AddRow(3, "mugiwara", now, 2))
+				sqlMock.ExpectQuery(regexp.QuoteMeta("FROM wallets")).WithArgs(int32(2), tc.currency).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "currency", "balance", "created_at"}).
+						AddRow(22, 2, tc.currency, 10.0, now))
+				sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE wallets")).WithArgs(int32(22), 10+tc.netAmount).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO transactions")).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(11))
+				sqlMock.ExpectQuery(regexp.QuoteMeta("UPDATE settlements")).
+					WithArgs(int32(4), tc.paymentCount, tc.grossAmount, tc.feeAmount, tc.netAmount, int64(11)).
+					WillReturnRows(sqlmock.NewRows(settlementColumns).
+						AddRow(4, 3, settlementDate, tc.currency, tc.paymentCount, tc.grossAmount, tc.feeAmount, tc.netAmount, 11, now))
+				sqlMock.ExpectCommit()
+			} else if !tc.alreadySettled {
+				sqlMock.ExpectRollback()
+			}
+
+			// the fee kept from the gross is booked to the fee revenue account
+			fees := mock_usecase.NewMockIFeeUsecase(ctrl)
+			if tc.paymentCount > 0 {
+				fees.EXPECT().PostTx(gomock.Any(), gomock.Not(gomock.Nil()), usecase.FeePosting{
+					TransactionID: 11,
+					UserID:        2,
+					Currency:      tc.currency,
+					Fee:           usecase.Fee{Amount: tc.feeAmount},
+				}).Return(nil)
+			}
+
+			uc := NewSettlementUsecase(Dependencies{DB: db, Repository: postgres.New(db), Fees: fees}, config)
+			settled, err := uc.SettleDue(context.Background(), now)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSettled, settled)
+			assert.NoError(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
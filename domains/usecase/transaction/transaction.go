@@ -3,165 +3,404 @@ package transaction
 import (
 	"context"
 	"database/sql"
+	"kc-ewallet/configurations"
 	"kc-ewallet/constants"
 	"kc-ewallet/domains/repository"
 	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/repository/stream"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/domains/usecase/audit"
 	"kc-ewallet/internals/errors"
-	log_color "kc-ewallet/internals/helpers/color"
+	"kc-ewallet/internals/helpers/logging"
+	"kc-ewallet/internals/helpers/money"
+	"kc-ewallet/internals/metric"
 	"kc-ewallet/protocols/http/request"
+	"slices"
+	"time"
 
-	goerrors "errors"
-
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
 )
 
 type transactionUscase struct {
 	db         *sql.DB
 	repository repository.IRepository
 	trace      trace.Tracer
+	metric     metric.Metric
+	audit      usecase.IAuditUsecase
+	events     repository.IEventStream
+	cache      repository.IUserCache
+	webhook    usecase.IWebhookUsecase
+	fees       usecase.IFeeUsecase
+	savings    usecase.ISavingsUsecase
+	quoteKey   []byte
+	quoteTTL   time.Duration
+}
+
+// Dependencies are the collaborators of the transaction, exchange and settlement
+// usecases. DB and Repository are required, a nil Trace or Metric is a no-op one and
+// any other nil collaborator is skipped
+type Dependencies struct {
+	DB         *sql.DB
+	Repository repository.IRepository
+	Trace      trace.Tracer
+	Metric     metric.Metric
+	Audit      usecase.IAuditUsecase
+	Events     repository.IEventStream
+	Cache      repository.IUserCache
+	Webhook    usecase.IWebhookUsecase
+	Fees       usecase.IFeeUsecase
+	Quote      configurations.IQuoteConfiguration
+	Savings    usecase.ISavingsUsecase
 }
 
-func NewTransactionUsecase(
-	db *sql.DB,
-	repository repository.IRepository,
-	trace trace.Tracer,
-) *transactionUscase {
+func NewTransactionUsecase(deps Dependencies) *transactionUscase {
+	if deps.Trace == nil {
+		deps.Trace = noop.NewTracerProvider().Tracer("")
+	}
+	if deps.Metric == nil {
+		deps.Metric = metric.NewNoopMetric()
+	}
+
+	var (
+		quoteKey []byte
+		quoteTTL time.Duration
+	)
+	if deps.Quote != nil {
+		quoteKey = []byte(deps.Quote.GetSigningKey())
+		quoteTTL = deps.Quote.GetTTL()
+	}
+
 	return &transactionUscase{
-		db:         db,
-		repository: repository,
-		trace:      trace,
+		db:         deps.DB,
+		repository: deps.Repository,
+		trace:      deps.Trace,
+		metric:     deps.Metric,
+		audit:      deps.Audit,
+		events:     deps.Events,
+		cache:      deps.Cache,
+		webhook:    deps.Webhook,
+		fees:       deps.Fees,
+		savings:    deps.Savings,
+		quoteKey:   quoteKey,
+		quoteTTL:   quoteTTL,
 	}
 }
 
 func (t *transactionUscase) CreateCreditTransaction(ctx context.Context, request request.CreateCreditTransactionRequest) (int32, float64, error) {
+	ctx, span := t.trace.Start(ctx, "transactionUsecase.CreateCreditTransaction", trace.WithAttributes(
+		attribute.Int("user_id", int(request.UserID)),
+		attribute.String("currency", request.Currency),
+		attribute.Float64("amount", request.Amount),
+	))
+	defer span.End()
+
 	var (
-		tx  *sql.Tx
-		err error
+		outcome       = metric.OutcomeError
+		events        []stream.Event
+		transactionID int32
+		newBalance    float64
 	)
 
-	// Begin transaction
-	if t.db != nil {
-		tx, err = t.db.Begin()
+	// registered first so it runs after commit or rollback
+	defer func() {
+		t.metric.RecordTransaction(ctx, constants.TransactionTypeCredit, outcome, request.Amount)
+	}()
+
+	currency, err := t.currencyOf(ctx, request.Currency, request.Amount)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	quote, err := t.verifyQuote(request.QuoteID, quoteTerms{
+		UserID:          request.UserID,
+		TransactionType: constants.TransactionTypeCredit,
+		Currency:        currency.Code,
+		Channel:         request.Channel,
+		Amount:          request.Amount,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	err = usecase.InSQLTx(ctx, t.db, t.repository, func(tx *sql.Tx, query repository.IRepository) error {
+		if err := t.redeemQuote(ctx, query, quote); err != nil {
+			return err
+		}
+
+		// Lock the wallet row for update
+		user, wallet, err := t.lockWallet(ctx, query, request.UserID, currency.Code)
 		if err != nil {
-			log_color.PrintRedf("Failed to begin transaction: %v\n", err)
-			return 0, 0, errors.InternalServer.NewWithUserMsg(err, "failed to begin transaction")
+			if errors.GetType(err) == errors.NotFound {
+				outcome = metric.OutcomeNotFound
+			}
+			return err
 		}
-	}
 
-	// Ensure to commit or rollback transaction at the end
-	defer func() {
-		if tx == nil {
-			log_color.PrintRedf("Transaction is nil, cannot rollback\n")
-			return
+		fee, err := t.feeOf(ctx, quote, constants.TransactionTypeCredit, request.Channel, user, currency, wallet, request.Amount)
+		if err != nil {
+			return err
+		}
+
+		// the fee is taken from the credited amount, it can't exceed it and the balance
+		newBalance = money.Round(wallet.Balance+request.Amount-fee.Amount, currency.MinorUnits)
+		if newBalance < 0 {
+			outcome = metric.OutcomeInsufficientFunds
+			t.metric.RecordInsufficientFunds(ctx, constants.TransactionTypeCredit)
+			return errors.WithCode(errors.BadRequest.NewWithUserMsg(nil, "Insufficient funds"), errors.CodeInsufficientFunds)
 		}
+
+		// Update balance
+		if err := query.UpdateWalletBalanceByID(ctx, postgres.UpdateWalletBalanceByIDParams{
+			ID:      wallet.ID,
+			Balance: newBalance,
+		}); err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to update balance")
+		}
+
+		// Create transaction record
+		transactionID, err = query.CreateTransaction(ctx, postgres.CreateTransactionParams{
+			UserID:   sql.NullInt32{Int32: request.UserID, Valid: true},
+			Amount:   request.Amount,
+			Type:     constants.TransactionTypeCredit,
+			Currency: currency.Code,
+		})
 		if err != nil {
-			errRollback := tx.Rollback()
-			log_color.PrintRedf("Transaction rollback due to error: %v, rollback error: %v\n", err, errRollback)
-			return
+			return errors.InternalServer.NewWithUserMsg(err, "failed to create transaction")
 		}
-		tx.Commit()
-	}()
 
-	// Use transaction if available
-	query := t.repository
-	if tx != nil {
-		query = t.repository.WithTx(tx)
-	}
+		if err := t.postFee(ctx, tx, transactionID, wallet, fee); err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to post fee")
+		}
 
-	// Lock the row for update
-	user, err := query.GetUserByIDLock(ctx, request.UserID)
-	if err != nil {
-		log_color.PrintRedf("error get user by id: %v", err)
-		if goerrors.Is(err, sql.ErrNoRows) {
-			return 0, 0, errors.NotFound.NewWithUserMsg(err, "user not found")
+		if err := t.creditDefaultPocket(ctx, query, currency, wallet, transactionID, money.Round(request.Amount-fee.Amount, currency.MinorUnits)); err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to credit pocket")
 		}
-		return 0, 0, errors.InternalServer.NewWithUserMsg(err, "failed to get user by id")
-	}
 
-	// Update balance
-	newBalance := user.Balance + request.Amount
-	if err := query.UpdateUserBalanceByID(ctx, postgres.UpdateUserBalanceByIDParams{
-		ID:      user.ID,
-		Balance: newBalance,
-	}); err != nil {
-		return 0, 0, errors.InternalServer.NewWithUserMsg(err, "failed to update balance")
-	}
+		// audited inside the same database transaction as the balance change
+		if err := t.recordTransaction(ctx, tx, audit.EventTransactionCredit, wallet, newBalance, transactionID, request.Amount, fee.Amount); err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to record transaction")
+		}
 
-	// Create transaction record
-	transactionID, err := query.CreateTransaction(ctx, postgres.CreateTransactionParams{
-		UserID: sql.NullInt32{Int32: request.UserID, Valid: true},
-		Amount: request.Amount,
-		Type:   constants.TransactionTypeCredit,
+		// queued with the balance change, a rollback never notifies a partner
+		events = transactionEvents(wallet, newBalance, transactionID, constants.TransactionTypeCredit, request.Amount)
+		if err := t.enqueueWebhooks(ctx, tx, events); err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to enqueue webhooks")
+		}
+		return nil
 	})
+	if err != nil {
+		return 0, 0, err
+	}
 
+	outcome = metric.OutcomeSuccess
+	t.invalidateBalances(ctx, events)
+	t.publishEvents(ctx, events)
 	return transactionID, newBalance, nil
 }
 
 func (t *transactionUscase) CreateDebitTransaction(ctx context.Context, request request.CreateDebitTransactionRequest) (int32, float64, error) {
+	ctx, span := t.trace.Start(ctx, "transactionUsecase.CreateDebitTransaction", trace.WithAttributes(
+		attribute.Int("user_id", int(request.UserID)),
+		attribute.String("currency", request.Currency),
+		attribute.Float64("amount", request.Amount),
+	))
+	defer span.End()
+
 	var (
-		tx  *sql.Tx
-		err error
+		outcome       = metric.OutcomeError
+		events        []stream.Event
+		transactionID int32
+		newBalance    float64
 	)
 
-	// Begin transaction
-	if t.db != nil {
-		tx, err = t.db.Begin()
+	// registered first so it runs after commit or rollback
+	defer func() {
+		t.metric.RecordTransaction(ctx, constants.TransactionTypeDebit, outcome, request.Amount)
+	}()
+
+	currency, err := t.currencyOf(ctx, request.Currency, request.Amount)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	quote, err := t.verifyQuote(request.QuoteID, quoteTerms{
+		UserID:          request.UserID,
+		TransactionType: constants.TransactionTypeDebit,
+		Currency:        currency.Code,
+		Channel:         request.Channel,
+		Amount:          request.Amount,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	err = usecase.InSQLTx(ctx, t.db, t.repository, func(tx *sql.Tx, query repository.IRepository) error {
+		if err := t.redeemQuote(ctx, query, quote); err != nil {
+			return err
+		}
+
+		// Lock the wallet row for update
+		user, wallet, err := t.lockWallet(ctx, query, request.UserID, currency.Code)
 		if err != nil {
-			log_color.PrintRedf("Failed to begin transaction: %v\n", err)
-			return 0, 0, errors.InternalServer.NewWithUserMsg(err, "failed to begin transaction")
+			if errors.GetType(err) == errors.NotFound {
+				outcome = metric.OutcomeNotFound
+			}
+			return err
 		}
-	}
 
-	// Ensure to commit or rollback transaction at the end
-	defer func() {
-		if tx == nil {
-			log_color.PrintRedf("Transaction is nil, cannot rollback\n")
-			return
+		fee, err := t.feeOf(ctx, quote, constants.TransactionTypeDebit, request.Channel, user, currency, wallet, request.Amount)
+		if err != nil {
+			return err
 		}
+
+		// Check if balance is sufficient, the pockets set their balances aside
+		spendable, err := t.spendableOf(ctx, query, currency, wallet)
 		if err != nil {
-			errRollback := tx.Rollback()
-			log_color.PrintRedf("Transaction rollback due to error: %v, rollback error: %v\n", err, errRollback)
-			return
+			return err
+		}
+		if spendable < request.Amount+fee.Amount {
+			outcome = metric.OutcomeInsufficientFunds
+			t.metric.RecordInsufficientFunds(ctx, constants.TransactionTypeDebit)
+			return errors.WithCode(errors.BadRequest.NewWithUserMsg(nil, "Insufficient funds"), errors.CodeInsufficientFunds)
 		}
-		tx.Commit()
-	}()
 
-	// Use transaction if available
-	query := t.repository
-	if tx != nil {
-		query = t.repository.WithTx(tx)
-	}
+		// Update balance
+		newBalance = money.Round(wallet.Balance-request.Amount-fee.Amount, currency.MinorUnits)
+		if err := query.UpdateWalletBalanceByID(ctx, postgres.UpdateWalletBalanceByIDParams{
+			ID:      wallet.ID,
+			Balance: newBalance,
+		}); err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to update balance")
+		}
 
-	// Lock the row for update
-	user, err := query.GetUserByIDLock(ctx, request.UserID)
-	if err != nil {
-		log_color.PrintRedf("error get user by id: %v", err)
-		if goerrors.Is(err, sql.ErrNoRows) {
-			return 0, 0, errors.NotFound.NewWithUserMsg(err, "user not found")
+		// Create transaction record
+		transactionID, err = query.CreateTransaction(ctx, postgres.CreateTransactionParams{
+			UserID:   sql.NullInt32{Int32: request.UserID, Valid: true},
+			Amount:   request.Amount,
+			Type:     constants.TransactionTypeDebit,
+			Currency: currency.Code,
+		})
+		if err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to create transaction")
+		}
+
+		if err := t.postFee(ctx, tx, transactionID, wallet, fee); err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to post fee")
 		}
-		return 0, 0, errors.InternalServer.NewWithUserMsg(err, "failed to get user by id")
-	}
 
-	// Check if balance is sufficient
-	if user.Balance < request.Amount {
-		return 0, 0, errors.BadRequest.NewWithUserMsg(nil, "Insufficient funds")
+		spendable = money.Round(spendable-request.Amount-fee.Amount, currency.MinorUnits)
+		if err := t.applySavingsRules(ctx, tx, transactionID, currency, wallet, request.Amount, spendable); err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to apply savings rules")
+		}
+
+		// audited inside the same database transaction as the balance change
+		if err := t.recordTransaction(ctx, tx, audit.EventTransactionDebit, wallet, newBalance, transactionID, request.Amount, fee.Amount); err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to record transaction")
+		}
+
+		// queued with the balance change, a rollback never notifies a partner
+		events = transactionEvents(wallet, newBalance, transactionID, constants.TransactionTypeDebit, request.Amount)
+		if err := t.enqueueWebhooks(ctx, tx, events); err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to enqueue webhooks")
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
 	}
 
-	// Update balance
-	newBalance := user.Balance - request.Amount
-	if err := query.UpdateUserBalanceByID(ctx, postgres.UpdateUserBalanceByIDParams{
-		ID:      user.ID,
-		Balance: newBalance,
-	}); err != nil {
-		return 0, 0, errors.InternalServer.NewWithUserMsg(err, "failed to update balance")
+	outcome = metric.OutcomeSuccess
+	t.invalidateBalances(ctx, events)
+	t.publishEvents(ctx, events)
+	return transactionID, newBalance, nil
+}
+
+func (t *transactionUscase) recordTransaction(
+	ctx context.Context,
+	tx *sql.Tx,
+	eventType audit.EventType,
+	wallet postgres.Wallet,
+	newBalance float64,
+	transactionID int32,
+	amount float64,
+	fee float64,
+) error {
+	if t.audit == nil {
+		return nil
 	}
 
-	// Create transaction record
-	transactionID, err := query.CreateTransaction(ctx, postgres.CreateTransactionParams{
-		UserID: sql.NullInt32{Int32: request.UserID, Valid: true},
-		Amount: request.Amount,
-		Type:   constants.TransactionTypeDebit,
+	return t.audit.RecordTx(ctx, tx, audit.Event{
+		Type:      eventType,
+		SubjectID: wallet.UserID,
+		Before:    map[string]interface{}{"balance": wallet.Balance},
+		After:     map[string]interface{}{"balance": newBalance},
+		Metadata: map[string]interface{}{
+			"transaction_id": transactionID,
+			"currency":       wallet.Currency,
+			"amount":         amount,
+			"fee":            fee,
+		},
 	})
+}
 
-	return transactionID, newBalance, nil
+func (t *transactionUscase) enqueueWebhooks(ctx context.Context, tx *sql.Tx, events []stream.Event) error {
+	if t.webhook == nil {
+		return nil
+	}
+
+	return t.webhook.EnqueueTx(ctx, tx, events)
+}
+
+// transactionEvents are published once the transaction is committed
+func transactionEvents(wallet postgres.Wallet, newBalance float64, transactionID int32, transactionType string, amount float64) []stream.Event {
+	var events []stream.Event
+
+	if event, err := stream.NewEvent(stream.EventTransactionCreated, wallet.UserID, stream.TransactionCreatedData{
+		TransactionID: transactionID,
+		Type:          transactionType,
+		Currency:      wallet.Currency,
+		Amount:        amount,
+	}); err == nil {
+		events = append(events, event)
+	}
+	if event, err := stream.NewEvent(stream.EventBalanceChanged, wallet.UserID, stream.BalanceChangedData{
+		Currency:        wallet.Currency,
+		Balance:         newBalance,
+		PreviousBalance: wallet.Balance,
+		TransactionID:   transactionID,
+	}); err == nil {
+		events = append(events, event)
+	}
+
+	return events
+}
+
+// publishEvents pushes the committed changes to the user event streams, a failure only
+// costs the realtime update, clients still read the balance from the api
+// invalidateBalances drops the cached balances of the users the committed events are
+// for. The fee revenue account isn't, credited by every fee its balance is only
+// refreshed when the cache expires
+func (t *transactionUscase) invalidateBalances(ctx context.Context, events []stream.Event) {
+	var userIDs []int32
+	for _, event := range events {
+		if !slices.Contains(userIDs, event.UserID) {
+			userIDs = append(userIDs, event.UserID)
+		}
+	}
+	usecase.InvalidateBalances(ctx, t.cache, userIDs...)
+}
+
+func (t *transactionUscase) publishEvents(ctx context.Context, events []stream.Event) {
+	if t.events == nil {
+		return
+	}
+
+	for _, event := range events {
+		if _, err := t.events.Publish(ctx, event); err != nil {
+			logging.NewFromContext(ctx).Warn("Failed to publish event", zap.String("type", string(event.Type)), zap.Error(err))
+		}
+	}
 }
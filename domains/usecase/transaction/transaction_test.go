@@ -5,8 +5,8 @@ import (
 	"database/sql"
 	"fmt"
 	"kc-ewallet/configurations"
+	"kc-ewallet/constants"
 	"kc-ewallet/domains/repository/postgres"
-	log_color "kc-ewallet/internals/helpers/color"
 	"kc-ewallet/protocols/http/request"
 	"path/filepath"
 	"sync"
@@ -49,7 +49,7 @@ func TestTransactionUsecase_ConcurrentTransactions(t *testing.T) {
 
 	ctx := context.Background()
 	repo := postgres.New(db)
-	usecase := NewTransactionUsecase(db, repo, nil)
+	usecase := NewTransactionUsecase(Dependencies{DB: db, Repository: repo})
 
 	// create user
 	userID, err := repo.CreateUser(ctx, postgres.CreateUserParams{
@@ -57,6 +57,8 @@ func TestTransactionUsecase_ConcurrentTransactions(t *testing.T) {
 		Password: "password",
 	})
 	assert.NoError(t, err)
+	_, err = repo.CreateWallet(ctx, postgres.CreateWalletParams{UserID: userID, Currency: constants.DefaultCurrency})
+	assert.NoError(t, err)
 
 	// concurrency params
 	numThreads := 100
@@ -71,8 +73,9 @@ func TestTransactionUsecase_ConcurrentTransactions(t *testing.T) {
 			defer wg.Done()
 			for j := 0; j < numTransactions; j++ {
 				_, _, err := usecase.CreateCreditTransaction(ctx, request.CreateCreditTransactionRequest{
-					UserID: userID,
-					Amount: amount,
+					UserID:   userID,
+					Amount:   amount,
+					Currency: constants.DefaultCurrency,
 				})
 				if err != nil {
 					// fail test if any transaction error
@@ -85,20 +88,21 @@ func TestTransactionUsecase_ConcurrentTransactions(t *testing.T) {
 	wg.Wait()
 
 	// check final balance
-	user, err := repo.GetUserByIDLock(ctx, userID)
+	wallet, err := repo.GetWallet(ctx, postgres.GetWalletParams{UserID: userID, Currency: constants.DefaultCurrency})
 	assert.NoError(t, err)
 
 	expected := float64(numThreads * numTransactions * 1000)
 	assert.Equal(
 		t, expected,
-		user.Balance,
-		fmt.Sprintf("expected final balance %f, got %f", expected, user.Balance),
+		wallet.Balance,
+		fmt.Sprintf("expected final balance %f, got %f", expected, wallet.Balance),
 	)
-	log_color.PrintGreenf("expected final balance %f, got %f", expected, user.Balance)
+	t.Logf("expected final balance %f, got %f", expected, wallet.Balance)
 
 	// clean up
 	defer func() {
 		db.Exec("DELETE FROM transactions WHERE user_id = $1", userID)
+		db.Exec("DELETE FROM wallets WHERE user_id = $1", userID)
 		db.Exec("DELETE FROM users WHERE id = $1", userID)
 	}()
 }
@@ -0,0 +1,343 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	"kc-ewallet/constants"
+	"kc-ewallet/domains/repository"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/repository/stream"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/domains/usecase/audit"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/internals/helpers/logging"
+	"kc-ewallet/internals/helpers/money"
+	"kc-ewallet/internals/metric"
+	"kc-ewallet/protocols/http/request"
+	"strings"
+	"time"
+
+	goerrors "errors"
+
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Transfer moves an amount from a wallet of the user to the wallet of another user in
+// the same currency, the fee is paid on top by the sender. A transfer with an
+// idempotency key already transferred returns the first transfer instead of moving the
+// money again
+func (t *transactionUscase) Transfer(ctx context.Context, request request.CreateTransferRequest) (usecase.TransferResult, error) {
+	ctx, span := t.trace.Start(ctx, "transactionUsecase.Transfer", trace.WithAttributes(
+		attribute.Int("user_id", int(request.UserID)),
+		attribute.Int("to_user_id", int(request.ToUserID)),
+		attribute.String("currency", request.Currency),
+		attribute.Float64("amount", request.Amount),
+	))
+	defer span.End()
+
+	var (
+		outcome     = metric.OutcomeError
+		events      []stream.Event
+		transfer    postgres.Transfer
+		fromBalance float64
+	)
+
+	// registered first so it runs after commit or rollback
+	defer func() {
+		t.metric.RecordTransaction(ctx, constants.TransactionTypeTransferOut, outcome, request.Amount)
+	}()
+
+	if request.ToUserID == request.UserID {
+		return usecase.TransferResult{}, errors.BadRequest.NewWithUserMsg(nil, "can't transfer to yourself")
+	}
+
+	idempotencyKey := sql.NullString{String: request.IdempotencyKey, Valid: request.IdempotencyKey != ""}
+	if idempotencyKey.Valid {
+		result, found, err := t.transferOf(ctx, idempotencyKey)
+		if err != nil {
+			return usecase.TransferResult{}, err
+		}
+		if found {
+			outcome = metric.OutcomeSuccess
+			return result, nil
+		}
+	}
+
+	currency, err := t.currencyOf(ctx, request.Currency, request.Amount)
+	if err != nil {
+		return usecase.TransferResult{}, err
+	}
+
+	quote, err := t.verifyQuote(request.QuoteID, quoteTerms{
+		UserID:          request.UserID,
+		TransactionType: constants.TransactionTypeTransfer,
+		Currency:        currency.Code,
+		Channel:         request.Channel,
+		ToUserID:        request.ToUserID,
+		Amount:          request.Amount,
+	})
+	if err != nil {
+		return usecase.TransferResult{}, err
+	}
+
+	err = usecase.InSQLTx(ctx, t.db, t.repository, func(tx *sql.Tx, query repository.IRepository) error {
+		if err := t.redeemQuote(ctx, query, quote); err != nil {
+			return err
+		}
+
+		user, fromWallet, toWallet, err := t.lockTransferWallets(ctx, query, request.UserID, request.ToUserID, currency.Code)
+		if err != nil {
+			if errors.GetType(err) == errors.NotFound {
+				outcome = metric.OutcomeNotFound
+			}
+			return err
+		}
+
+		fee, err := t.feeOf(ctx, quote, constants.TransactionTypeTransfer, request.Channel, user, currency, fromWallet, request.Amount)
+		if err != nil {
+			return err
+		}
+
+		// the pockets set their balances aside
+		spendable, err := t.spendableOf(ctx, query, currency, fromWallet)
+		if err != nil {
+			return err
+		}
+		if spendable < request.Amount+fee.Amount {
+			outcome = metric.OutcomeInsufficientFunds
+			t.metric.RecordInsufficientFunds(ctx, constants.TransactionTypeTransferOut)
+			return errors.WithCode(errors.BadRequest.NewWithUserMsg(nil, "Insufficient funds"), errors.CodeInsufficientFunds)
+		}
+
+		// Update balances
+		fromBalance = money.Round(fromWallet.Balance-request.Amount-fee.Amount, currency.MinorUnits)
+		if err := query.UpdateWalletBalanceByID(ctx, postgres.UpdateWalletBalanceByIDParams{
+			ID:      fromWallet.ID,
+			Balance: fromBalance,
+		}); err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to update balance")
+		}
+		toBalance := money.Round(toWallet.Balance+request.Amount, currency.MinorUnits)
+		if err := query.UpdateWalletBalanceByID(ctx, postgres.UpdateWalletBalanceByIDParams{
+			ID:      toWallet.ID,
+			Balance: toBalance,
+		}); err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to update balance")
+		}
+
+		// Create transaction records
+		debitTransactionID, err := query.CreateTransaction(ctx, postgres.CreateTransactionParams{
+			UserID:   sql.NullInt32{Int32: request.UserID, Valid: true},
+			Amount:   request.Amount,
+			Type:     constants.TransactionTypeTransferOut,
+			Currency: currency.Code,
+		})
+		if err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to create transaction")
+		}
+		creditTransactionID, err := query.CreateTransaction(ctx, postgres.CreateTransactionParams{
+			UserID:   sql.NullInt32{Int32: request.ToUserID, Valid: true},
+			Amount:   request.Amount,
+			Type:     constants.TransactionTypeTransferIn,
+			Currency: currency.Code,
+		})
+		if err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to create transaction")
+		}
+
+		if err := t.postFee(ctx, tx, debitTransactionID, fromWallet, fee); err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to post fee")
+		}
+
+		if err := t.creditDefaultPocket(ctx, query, currency, toWallet, creditTransactionID, request.Amount); err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to credit default pocket")
+		}
+
+		transfer, err = query.CreateTransfer(ctx, postgres.CreateTransferParams{
+			FromUserID:          request.UserID,
+			ToUserID:            request.ToUserID,
+			Currency:            currency.Code,
+			Amount:              request.Amount,
+			Note:                strings.TrimSpace(request.Note),
+			IdempotencyKey:      idempotencyKey,
+			DebitTransactionID:  debitTransactionID,
+			CreditTransactionID: creditTransactionID,
+		})
+		if err != nil {
+			// a concurrent transfer with the same key won, the caller reads it on retry
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+				return errors.BadRequest.NewWithUserMsg(err, "transfer is already being made")
+			}
+			return errors.InternalServer.NewWithUserMsg(err, "failed to create transfer")
+		}
+
+		if request.PaymentRequestID != 0 {
+			if err := t.payPaymentRequest(ctx, query, request, transfer); err != nil {
+				return err
+			}
+		}
+		if request.BillID != 0 {
+			if err := t.payBillShare(ctx, query, request, transfer); err != nil {
+				return err
+			}
+		}
+
+		// audited inside the same database transaction as the balance changes
+		if t.audit != nil {
+			metadata := map[string]interface{}{
+				"transfer_id": transfer.ID,
+				"to_user_id":  request.ToUserID,
+				"currency":    currency.Code,
+				"amount":      request.Amount,
+				"fee":         fee.Amount,
+			}
+			if request.PaymentRequestID != 0 {
+				metadata["payment_request_id"] = request.PaymentRequestID
+			}
+			if request.BillID != 0 {
+				metadata["bill_id"] = request.BillID
+			}
+			if err := t.audit.RecordTx(ctx, tx, audit.Event{
+				Type:      audit.EventTransactionTransfer,
+				SubjectID: request.UserID,
+				Before:    map[string]interface{}{"balance": fromWallet.Balance},
+				After:     map[string]interface{}{"balance": fromBalance},
+				Metadata:  metadata,
+			}); err != nil {
+				return errors.InternalServer.NewWithUserMsg(err, "failed to record transfer")
+			}
+		}
+
+		// queued with the balance changes, a rollback never notifies a partner
+		events = append(
+			transactionEvents(fromWallet, fromBalance, debitTransactionID, constants.TransactionTypeTransferOut, request.Amount),
+			transactionEvents(toWallet, toBalance, creditTransactionID, constants.TransactionTypeTransferIn, request.Amount)...,
+		)
+		if err := t.enqueueWebhooks(ctx, tx, events); err != nil {
+			return errors.InternalServer.NewWithUserMsg(err, "failed to enqueue webhooks")
+		}
+		return nil
+	})
+	if err != nil {
+		return usecase.TransferResult{}, err
+	}
+
+	outcome = metric.OutcomeSuccess
+	t.invalidateBalances(ctx, events)
+	t.publishEvents(ctx, events)
+	return usecase.TransferResult{
+		Transfer: transfer,
+		Balance:  fromBalance,
+	}, nil
+}
+
+// payPaymentRequest marks the payment request paid by the transfer, in its database
+// transaction. A request no longer pending or of other terms rolls the transfer back
+func (t *transactionUscase) payPaymentRequest(ctx context.Context, query repository.IRepository, request request.CreateTransferRequest, transfer postgres.Transfer) error {
+	_, err := query.PayPaymentRequest(ctx, postgres.PayPaymentRequestParams{
+		TransferID:  transfer.ID,
+		Now:         time.Now().UTC(),
+		ID:          request.PaymentRequestID,
+		PayerID:     request.UserID,
+		RequesterID: request.ToUserID,
+		Currency:    transfer.Currency,
+		Amount:      transfer.Amount,
+	})
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return errors.WithCode(errors.BadRequest.NewWithUserMsg(err, "payment request is no longer pending"), errors.CodePaymentRequestResolved)
+		}
+		logging.NewFromContext(ctx).Error("failed to pay payment request", zap.Error(err))
+		return errors.InternalServer.NewWithUserMsg(err, "failed to pay payment request")
+	}
+	return nil
+}
+
+// payBillShare marks the share of the user in the bill paid by the transfer and moves it
+// to the paid amount of the bill, in the database transaction of the transfer. A share
+// already paid rolls the transfer back
+func (t *transactionUscase) payBillShare(ctx context.Context, query repository.IRepository, request request.CreateTransferRequest, transfer postgres.Transfer) error {
+	now := time.Now().UTC()
+	_, err := query.PayBillShare(ctx, postgres.PayBillShareParams{
+		TransferID: transfer.ID,
+		Now:        now,
+		BillID:     request.BillID,
+		UserID:     request.UserID,
+		Amount:     transfer.Amount,
+	})
+	if err == nil {
+		_, err = query.RecordBillPayment(ctx, postgres.RecordBillPaymentParams{
+			Amount:    transfer.Amount,
+			Now:       now,
+			ID:        request.BillID,
+			CreatorID: request.ToUserID,
+			Currency:  transfer.Currency,
+		})
+	}
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return errors.WithCode(errors.BadRequest.NewWithUserMsg(err, "bill share is already paid"), errors.CodeBillSharePaid)
+		}
+		logging.NewFromContext(ctx).Error("failed to pay bill share", zap.Error(err))
+		return errors.InternalServer.NewWithUserMsg(err, "failed to pay bill share")
+	}
+	return nil
+}
+
+// transferOf returns the transfer already made with the idempotency key, with the
+// current balance of the sending wallet
+func (t *transactionUscase) transferOf(ctx context.Context, idempotencyKey sql.NullString) (usecase.TransferResult, bool, error) {
+	transfer, err := t.repository.GetTransferByIdempotencyKey(ctx, idempotencyKey)
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return usecase.TransferResult{}, false, nil
+		}
+		logging.NewFromContext(ctx).Error("failed to get transfer", zap.Error(err))
+		return usecase.TransferResult{}, false, errors.InternalServer.NewWithUserMsg(err, "failed to get transfer")
+	}
+
+	wallet, err := t.walletOf(ctx, transfer.FromUserID, transfer.Currency)
+	if err != nil {
+		return usecase.TransferResult{}, false, err
+	}
+	return usecase.TransferResult{Transfer: transfer, Balance: wallet.Balance}, true, nil
+}
+
+// lockTransferWallets locks both wallets in user id order, so two opposite transfers
+// can't deadlock, and returns the sender for its tier. A recipient without wallet in
+// the currency is reported as such
+func (t *transactionUscase) lockTransferWallets(ctx context.Context, query repository.IRepository, fromUserID, toUserID int32, currency string) (postgres.User, postgres.Wallet, postgres.Wallet, error) {
+	var sender postgres.User
+	lock := func(userID int32) (postgres.Wallet, error) {
+		user, wallet, err := t.lockWallet(ctx, query, userID, currency)
+		if err != nil && userID == toUserID && errors.GetType(err) == errors.NotFound {
+			return postgres.Wallet{}, errors.WithCode(errors.NotFound.NewWithUserMsg(err, "recipient not found"), errors.CodeRecipientNotFound)
+		}
+		if userID == fromUserID {
+			sender = user
+		}
+		return wallet, err
+	}
+
+	first, second := fromUserID, toUserID
+	if second < first {
+		first, second = second, first
+	}
+
+	firstWallet, err := lock(first)
+	if err != nil {
+		return postgres.User{}, postgres.Wallet{}, postgres.Wallet{}, err
+	}
+	secondWallet, err := lock(second)
+	if err != nil {
+		return postgres.User{}, postgres.Wallet{}, postgres.Wallet{}, err
+	}
+
+	if first == fromUserID {
+		return sender, firstWallet, secondWallet, nil
+	}
+	return sender, secondWallet, firstWallet, nil
+}
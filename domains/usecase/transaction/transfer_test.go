@@ -0,0 +1,184 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	"kc-ewallet/constants"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/protocols/http/request"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var transferColumns = []string{
+	"id", "from_user_id", "to_user_id", "currency", "amount", "note", "idempotency_key",
+	"debit_transaction_id", "credit_transaction_id", "created_at",
+}
+
+func TestTransactionUsecase_Transfer(t *testing.T) {
+	testCases := []struct {
+		name            string
+		request         request.CreateTransferRequest
+		mock            func(sqlMock sqlmock.Sqlmock)
+		expectedCode    errors.Code
+		expectedBalance float64
+	}{
+		{
+			name:    "should move the amount between the wallets",
+			request: request.CreateTransferRequest{UserID: 1, ToUserID: 2, Currency: "IDR", Amount: 250, Note: " rent "},
+			mock: func(sqlMock sqlmock.Sqlmock) {
+				expectCurrency(sqlMock, "IDR", 2)
+				sqlMock.ExpectBegin()
+				expectLockedWallet(sqlMock, 1, "standard", 11, "IDR", 1000)
+				expectLockedWallet(sqlMock, 2, "standard", 12, "IDR", 50)
+				expectSpendable(sqlMock, 1, "IDR", 0)
+				sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE wallets")).WithArgs(int32(11), 750.0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE wallets")).WithArgs(int32(12), 300.0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO transactions")).
+					WithArgs(sql.NullInt32{Int32: 1, Valid: true}, 250.0, constants.TransactionTypeTransferOut, "IDR").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+				sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO transactions")).
+					WithArgs(sql.NullInt32{Int32: 2, Valid: true}, 250.0, constants.TransactionTypeTransferIn, "IDR").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+				expectNoDefaultPocket(sqlMock, 2, "IDR")
+				sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO transfers")).
+					WithArgs(int32(1), int32(2), "IDR", 250.0, "rent", sql.NullString{}, int32(9), int32(10)).
+					WillReturnRows(sqlmock.NewRows(transferColumns).AddRow(5, 1, 2, "IDR", 250, "rent", nil, 9, 10, time.Now()))
+				sqlMock.ExpectCommit()
+			},
+			expectedBalance: 750,
+		},
+		{
+			name:    "should return the transfer already made with the idempotency key",
+			request: request.CreateTransferRequest{UserID: 1, ToUserID: 2, Currency: "IDR", Amount: 250, IdempotencyKey: "schedule:3:1790812800"},
+			mock: func(sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectQuery(regexp.QuoteMeta("FROM transfers")).WithArgs(sql.NullString{String: "schedule:3:1790812800", Valid: true}).
+					WillReturnRows(sqlmock.NewRows(transferColumns).AddRow(5, 1, 2, "IDR", 250, "", "schedule:3:1790812800", 9, 10, time.Now()))
+				sqlMock.ExpectQuery(regexp.QuoteMeta("FROM wallets")).WithArgs(int32(1), "IDR").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "currency", "balance", "created_at"}).
+						AddRow(11, 1, "IDR", 750, time.Now()))
+			},
+			expectedBalance: 750,
+		},
+		{
+			name:    "should not transfer what the pockets set aside",
+			request: request.CreateTransferRequest{UserID: 2, ToUserID: 1, Currency: "IDR", Amount: 250},
+			mock: func(sqlMock sqlmock.Sqlmock) {
+				expectCurrency(sqlMock, "IDR", 2)
+				sqlMock.ExpectBegin()
+				// locked in user id order
+				expectLockedWallet(sqlMock, 1, "standard", 11, "IDR", 1000)
+				expectLockedWallet(sqlMock, 2, "standard", 12, "IDR", 300)
+				expectSpendable(sqlMock, 2, "IDR", 100)
+				sqlMock.ExpectRollback()
+			},
+			expectedCode: errors.CodeInsufficientFunds,
+		},
+		{
+			name:    "should reject a recipient without wallet in the currency",
+			request: request.CreateTransferRequest{UserID: 1, ToUserID: 2, Currency: "IDR", Amount: 250},
+			mock: func(sqlMock sqlmock.Sqlmock) {
+				expectCurrency(sqlMock, "IDR", 2)
+				sqlMock.ExpectBegin()
+				expectLockedWallet(sqlMock, 1, "standard", 11, "IDR", 1000)
+				sqlMock.ExpectQuery(regexp.QuoteMeta("FROM users")).WithArgs(int32(2)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username", "password", "created_at", "tier"}).
+						AddRow(2, "zoro", "", time.Now(), "standard"))
+				sqlMock.ExpectQuery(regexp.QuoteMeta("FROM wallets")).WithArgs(int32(2), "IDR").WillReturnError(sql.ErrNoRows)
+				sqlMock.ExpectRollback()
+			},
+			expectedCode: errors.CodeRecipientNotFound,
+		},
+		{
+			name:    "should roll the transfer back when the payment request is no longer pending",
+			request: request.CreateTransferRequest{UserID: 1, ToUserID: 2, Currency: "IDR", Amount: 250, IdempotencyKey: "payment-request:7", PaymentRequestID: 7},
+			mock: func(sqlMock sqlmock.Sqlmock) {
+				idempotencyKey := sql.NullString{String: "payment-request:7", Valid: true}
+				sqlMock.ExpectQuery(regexp.QuoteMeta("FROM transfers")).WithArgs(idempotencyKey).WillReturnError(sql.ErrNoRows)
+				expectCurrency(sqlMock, "IDR", 2)
+				sqlMock.ExpectBegin()
+				expectLockedWallet(sqlMock, 1, "standard", 11, "IDR", 1000)
+				expectLockedWallet(sqlMock, 2, "standard", 12, "IDR", 50)
+				expectSpendable(sqlMock, 1, "IDR", 0)
+				sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE wallets")).WithArgs(int32(11), 750.0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE wallets")).WithArgs(int32(12), 300.0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO transactions")).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+				sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO transactions")).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+				expectNoDefaultPocket(sqlMock, 2, "IDR")
+				sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO transfers")).
+					WithArgs(int32(1), int32(2), "IDR", 250.0, "", idempotencyKey, int32(9), int32(10)).
+					WillReturnRows(sqlmock.NewRows(transferColumns).AddRow(5, 1, 2, "IDR", 250, "", "payment-request:7", 9, 10, time.Now()))
+				sqlMock.ExpectQuery(regexp.QuoteMeta("UPDATE payment_requests")).
+					WithArgs(int32(5), sqlmock.AnyArg(), int32(7), int32(1), int32(2), "IDR", 250.0).
+					WillReturnError(sql.ErrNoRows)
+				sqlMock.ExpectRollback()
+			},
+			expectedCode: errors.CodePaymentRequestResolved,
+		},
+		{
+			name:    "should pay the bill share with the transfer",
+			request: request.CreateTransferRequest{UserID: 1, ToUserID: 2, Currency: "IDR", Amount: 250, Note: "dinner", BillID: 4},
+			mock: func(sqlMock sqlmock.Sqlmock) {
+				expectCurrency(sqlMock, "IDR", 2)
+				sqlMock.ExpectBegin()
+				expectLockedWallet(sqlMock, 1, "standard", 11, "IDR", 1000)
+				expectLockedWallet(sqlMock, 2, "standard", 12, "IDR", 50)
+				expectSpendable(sqlMock, 1, "IDR", 0)
+				sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE wallets")).WithArgs(int32(11), 750.0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE wallets")).WithArgs(int32(12), 300.0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO transactions")).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+				sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO transactions")).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+				expectNoDefaultPocket(sqlMock, 2, "IDR")
+				sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO transfers")).
+					WillReturnRows(sqlmock.NewRows(transferColumns).AddRow(5, 1, 2, "IDR", 250, "dinner", nil, 9, 10, time.Now()))
+				sqlMock.ExpectQuery(regexp.QuoteMeta("UPDATE bill_shares")).
+					WithArgs(int32(5), sqlmock.AnyArg(), int32(4), int32(1), 250.0).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "bill_id", "user_id", "amount", "status", "transfer_id", "paid_at", "reminded_at", "created_at"}).
+						AddRow(7, 4, 1, 250, "paid", 5, time.Now(), nil, time.Now()))
+				sqlMock.ExpectQuery(regexp.QuoteMeta("UPDATE bills")).
+					WithArgs(250.0, sqlmock.AnyArg(), int32(4), int32(2), "IDR").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "creator_id", "title", "currency", "total", "split", "paid_amount", "outstanding_amount", "status", "settled_at", "created_at"}).
+						AddRow(4, 2, "dinner", "IDR", 500, "equal", 250, 0, "settled", time.Now(), time.Now()))
+				sqlMock.ExpectCommit()
+			},
+			expectedBalance: 750,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, sqlMock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			tc.mock(sqlMock)
+
+			uc := NewTransactionUsecase(Dependencies{DB: db, Repository: postgres.New(db)})
+			result, err := uc.Transfer(context.Background(), tc.request)
+			assert.NoError(t, sqlMock.ExpectationsWereMet())
+
+			if tc.expectedCode != "" {
+				assert.Equal(t, tc.expectedCode, errors.CatalogEntryOf(err).Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int32(5), result.Transfer.ID)
+			assert.Equal(t, tc.expectedBalance, result.Balance)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	"kc-ewallet/constants"
+	"kc-ewallet/domains/repository"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/internals/helpers/logging"
+	"kc-ewallet/internals/helpers/money"
+	"time"
+
+	goerrors "errors"
+
+	"go.uber.org/zap"
+)
+
+// currencyOf returns the supported currency of the amount, the amount can't have more
+// decimals than the minor units of the currency
+func (t *transactionUscase) currencyOf(ctx context.Context, code string, amount float64) (postgres.Currency, error) {
+	currency, err := t.repository.GetCurrency(ctx, code)
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return postgres.Currency{}, errors.WithCode(errors.BadRequest.NewWithUserMsg(err, "currency is not supported"), errors.CodeUnsupportedCurrency)
+		}
+		logging.NewFromContext(ctx).Error("error get currency", zap.Error(err))
+		return postgres.Currency{}, errors.InternalServer.NewWithUserMsg(err, "failed to get currency")
+	}
+
+	if !money.HasPrecision(amount, currency.MinorUnits) {
+		return postgres.Currency{}, errors.WithCode(errors.BadRequest.NewWithUserMsg(nil, "amount has too many decimals"), errors.CodeInvalidAmountPrecision)
+	}
+
+	return currency, nil
+}
+
+// lockWallet reads the user for its tier and locks its wallet in the currency for update
+func (t *transactionUscase) lockWallet(ctx context.Context, query repository.IRepository, userID int32, currency string) (postgres.User, postgres.Wallet, error) {
+	user, err := query.GetUserByID(ctx, userID)
+	if err != nil {
+		logging.NewFromContext(ctx).Error("error get user by id", zap.Error(err))
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return postgres.User{}, postgres.Wallet{}, errors.WithCode(errors.NotFound.NewWithUserMsg(err, "user not found"), errors.CodeUserNotFound)
+		}
+		return postgres.User{}, postgres.Wallet{}, errors.InternalServer.NewWithUserMsg(err, "failed to get user by id")
+	}
+
+	lockStart := time.Now()
+	wallet, err := query.GetWalletLock(ctx, postgres.GetWalletLockParams{UserID: userID, Currency: currency})
+	t.metric.RecordLockWait(ctx, time.Since(lockStart))
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return postgres.User{}, postgres.Wallet{}, errors.WithCode(errors.NotFound.NewWithUserMsg(err, "wallet not found"), errors.CodeWalletNotFound)
+		}
+		logging.NewFromContext(ctx).Error("error get wallet", zap.Error(err))
+		return postgres.User{}, postgres.Wallet{}, errors.InternalServer.NewWithUserMsg(err, "failed to get wallet")
+	}
+
+	return user, wallet, nil
+}
+
+// walletOf reads the wallet of the user in the currency without locking it
+func (t *transactionUscase) walletOf(ctx context.Context, userID int32, currency string) (postgres.Wallet, error) {
+	wallet, err := t.repository.GetWallet(ctx, postgres.GetWalletParams{UserID: userID, Currency: currency})
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return postgres.Wallet{}, errors.WithCode(errors.NotFound.NewWithUserMsg(err, "wallet not found"), errors.CodeWalletNotFound)
+		}
+		logging.NewFromContext(ctx).Error("error get wallet", zap.Error(err))
+		return postgres.Wallet{}, errors.InternalServer.NewWithUserMsg(err, "failed to get wallet")
+	}
+
+	return wallet, nil
+}
+
+// spendableOf is the balance of the locked wallet that no pocket sets aside, the pockets
+// can't change while the wallet is locked
+func (t *transactionUscase) spendableOf(ctx context.Context, query repository.IRepository, currency postgres.Currency, wallet postgres.Wallet) (float64, error) {
+	allocated, err := query.SumPocketBalances(ctx, postgres.SumPocketBalancesParams{UserID: wallet.UserID, Currency: wallet.Currency})
+	if err != nil {
+		logging.NewFromContext(ctx).Error("error sum pocket balances", zap.Error(err))
+		return 0, errors.InternalServer.NewWithUserMsg(err, "failed to get pocket balances")
+	}
+
+	return money.Round(wallet.Balance-allocated, currency.MinorUnits), nil
+}
+
+// creditDefaultPocket sets a credited amount aside in the default pocket of the locked
+// wallet, a wallet without default pocket keeps it unallocated
+func (t *transactionUscase) creditDefaultPocket(ctx context.Context, query repository.IRepository, currency postgres.Currency, wallet postgres.Wallet, transactionID int32, amount float64) error {
+	if amount <= 0 {
+		return nil
+	}
+
+	pocket, err := query.GetDefaultPocketLock(ctx, postgres.GetDefaultPocketLockParams{UserID: wallet.UserID, Currency: wallet.Currency})
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	newBalance := money.Round(pocket.Balance+amount, currency.MinorUnits)
+	if err := query.UpdatePocketBalance(ctx, postgres.UpdatePocketBalanceParams{ID: pocket.ID, Balance: newBalance}); err != nil {
+		return err
+	}
+
+	return query.CreatePocketEntry(ctx, postgres.CreatePocketEntryParams{
+		PocketID:      pocket.ID,
+		Type:          constants.PocketEntryCredit,
+		Amount:        amount,
+		Balance:       newBalance,
+		TransactionID: sql.NullInt32{Int32: transactionID, Valid: true},
+	})
+}
+
+// applySavingsRules saves the round ups of a debit into the savings goals of the locked
+// wallet, spendable is the unallocated balance left after the debit
+func (t *transactionUscase) applySavingsRules(ctx context.Context, tx *sql.Tx, transactionID int32, currency postgres.Currency, wallet postgres.Wallet, amount, spendable float64) error {
+	if t.savings == nil {
+		return nil
+	}
+
+	return t.savings.ApplyDebitTx(ctx, tx, usecase.SavingsDebit{
+		TransactionID: transactionID,
+		UserID:        wallet.UserID,
+		Currency:      currency.Code,
+		MinorUnits:    currency.MinorUnits,
+		Amount:        amount,
+		Spendable:     spendable,
+	})
+}
@@ -0,0 +1,227 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	"kc-ewallet/constants"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/usecase"
+	mock_usecase "kc-ewallet/domains/usecase/mocks"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/protocols/http/request"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// expectCurrency expects the currency lookup made before the database transaction begins
+func expectCurrency(sqlMock sqlmock.Sqlmock, code string, minorUnits int16) {
+	sqlMock.ExpectQuery(regexp.QuoteMeta("FROM currencies")).WithArgs(code).
+		WillReturnRows(sqlmock.NewRows([]string{"code", "minor_units"}).AddRow(code, minorUnits))
+}
+
+// expectLockedWallet expects the user read for its tier then the lock of its wallet
+func expectLockedWallet(sqlMock sqlmock.Sqlmock, userID int32, tier string, walletID int32, currency string, balance float64) {
+	sqlMock.ExpectQuery(regexp.QuoteMeta("FROM users")).WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "password", "created_at", "tier"}).
+			AddRow(userID, "luffy", "", time.Now(), tier))
+	sqlMock.ExpectQuery(regexp.QuoteMeta("FROM wallets")).WithArgs(userID, currency).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "currency", "balance", "created_at"}).
+			AddRow(walletID, userID, currency, balance, time.Now()))
+}
+
+// expectSpendable expects the sum of the pocket balances of the locked wallet
+func expectSpendable(sqlMock sqlmock.Sqlmock, userID int32, currency string, allocated float64) {
+	sqlMock.ExpectQuery(regexp.QuoteMeta("FROM pockets")).WithArgs(userID, currency).
+		WillReturnRows(sqlmock.NewRows([]string{"allocated"}).AddRow(allocated))
+}
+
+// expectNoDefaultPocket expects a credited wallet to have no default pocket
+func expectNoDefaultPocket(sqlMock sqlmock.Sqlmock, userID int32, currency string) {
+	sqlMock.ExpectQuery(regexp.QuoteMeta("FROM pockets")).WithArgs(userID, currency).WillReturnError(sql.ErrNoRows)
+}
+
+func TestTransactionUsecase_CreditCurrency(t *testing.T) {
+	testCases := []struct {
+		name         string
+		currency     string
+		amount       float64
+		mock         func(sqlMock sqlmock.Sqlmock)
+		expectedCode errors.Code
+	}{
+		{
+			name:     "should credit the wallet of the currency",
+			currency: "JPY",
+			amount:   500,
+			mock: func(sqlMock sqlmock.Sqlmock) {
+				expectCurrency(sqlMock, "JPY", 0)
+				sqlMock.ExpectBegin()
+				expectLockedWallet(sqlMock, 1, "standard", 21, "JPY", 1000)
+				sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE wallets")).WithArgs(int32(21), 1500.0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO transactions")).
+					WithArgs(sql.NullInt32{Int32: 1, Valid: true}, 500.0, constants.TransactionTypeCredit, "JPY").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+				expectNoDefaultPocket(sqlMock, 1, "JPY")
+				sqlMock.ExpectCommit()
+			},
+		},
+		{
+			name:     "should reject an unsupported currency",
+			currency: "XAU",
+			amount:   1,
+			mock: func(sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectQuery(regexp.QuoteMeta("FROM currencies")).WithArgs("XAU").WillReturnError(sql.ErrNoRows)
+			},
+			expectedCode: errors.CodeUnsupportedCurrency,
+		},
+		{
+			name:     "should reject more decimals than the currency has",
+			currency: "JPY",
+			amount:   10.5,
+			mock: func(sqlMock sqlmock.Sqlmock) {
+				expectCurrency(sqlMock, "JPY", 0)
+			},
+			expectedCode: errors.CodeInvalidAmountPrecision,
+		},
+		{
+			name:     "should reject a currency without wallet",
+			currency: "USD",
+			amount:   10.25,
+			mock: func(sqlMock sqlmock.Sqlmock) {
+				expectCurrency(sqlMock, "USD", 2)
+				sqlMock.ExpectBegin()
+				sqlMock.ExpectQuery(regexp.QuoteMeta("FROM users")).WithArgs(int32(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username", "password", "created_at", "tier"}).
+						AddRow(1, "luffy", "", time.Now(), "standard"))
+				sqlMock.ExpectQuery(regexp.QuoteMeta("FROM wallets")).WithArgs(int32(1), "USD").WillReturnError(sql.ErrNoRows)
+				sqlMock.ExpectRollback()
+			},
+			expectedCode: errors.CodeWalletNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, sqlMock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			tc.mock(sqlMock)
+
+			usecase := NewTransactionUsecase(Dependencies{DB: db, Repository: postgres.New(db)})
+			_, newBalance, err := usecase.CreateCreditTransaction(context.Background(), request.CreateCreditTransactionRequest{
+				UserID:   1,
+				Amount:   tc.amount,
+				Currency: tc.currency,
+			})
+			assert.NoError(t, sqlMock.ExpectationsWereMet())
+
+			if tc.expectedCode != "" {
+				assert.Equal(t, tc.expectedCode, errors.CatalogEntryOf(err).Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 1500.0, newBalance)
+		})
+	}
+}
+
+func TestTransactionUsecase_Pockets(t *testing.T) {
+	pocketColumns := []string{"id", "user_id", "currency", "name", "balance", "is_default", "created_at"}
+
+	t.Run("should set a credit aside in the default pocket", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		expectCurrency(sqlMock, "IDR", 2)
+		sqlMock.ExpectBegin()
+		expectLockedWallet(sqlMock, 1, "standard", 21, "IDR", 100)
+		sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE wallets")).WithArgs(int32(21), 150.0).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO transactions")).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("FROM pockets")).WithArgs(int32(1), "IDR").
+			WillReturnRows(sqlmock.NewRows(pocketColumns).AddRow(7, 1, "IDR", "Rent", 20, true, time.Now()))
+		sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE pockets")).WithArgs(int32(7), 70.0).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO pocket_entries")).
+			WithArgs(int32(7), constants.PocketEntryCredit, 50.0, 70.0, sql.NullInt32{Int32: 9, Valid: true}, sql.NullInt32{}).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectCommit()
+
+		usecase := NewTransactionUsecase(Dependencies{DB: db, Repository: postgres.New(db)})
+		_, newBalance, err := usecase.CreateCreditTransaction(context.Background(), request.CreateCreditTransactionRequest{
+			UserID:   1,
+			Amount:   50,
+			Currency: "IDR",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 150.0, newBalance)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("should not debit what the pockets set aside", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		expectCurrency(sqlMock, "IDR", 2)
+		sqlMock.ExpectBegin()
+		expectLockedWallet(sqlMock, 1, "standard", 21, "IDR", 100)
+		expectSpendable(sqlMock, 1, "IDR", 60)
+		sqlMock.ExpectRollback()
+
+		usecase := NewTransactionUsecase(Dependencies{DB: db, Repository: postgres.New(db)})
+		_, _, err = usecase.CreateDebitTransaction(context.Background(), request.CreateDebitTransactionRequest{
+			UserID:   1,
+			Amount:   50,
+			Currency: "IDR",
+		})
+		assert.Equal(t, errors.CodeInsufficientFunds, errors.CatalogEntryOf(err).Code)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("should hand the savings rules what the debit left unallocated", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		expectCurrency(sqlMock, "IDR", 2)
+		sqlMock.ExpectBegin()
+		expectLockedWallet(sqlMock, 1, "standard", 21, "IDR", 100)
+		expectSpendable(sqlMock, 1, "IDR", 20)
+		sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE wallets")).WithArgs(int32(21), 50.0).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectQuery(regexp.QuoteMeta("INSERT INTO transactions")).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+		sqlMock.ExpectCommit()
+
+		ctrl := gomock.NewController(t)
+		savings := mock_usecase.NewMockISavingsUsecase(ctrl)
+		savings.EXPECT().ApplyDebitTx(gomock.Any(), gomock.Not(gomock.Nil()), usecase.SavingsDebit{
+			TransactionID: 9,
+			UserID:        1,
+			Currency:      "IDR",
+			MinorUnits:    2,
+			Amount:        50,
+			Spendable:     30,
+		}).Return(nil)
+
+		uc := NewTransactionUsecase(Dependencies{DB: db, Repository: postgres.New(db), Savings: savings})
+		_, newBalance, err := uc.CreateDebitTransaction(context.Background(), request.CreateDebitTransactionRequest{
+			UserID:   1,
+			Amount:   50,
+			Currency: "IDR",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 50.0, newBalance)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"kc-ewallet/domains/repository"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/internals/helpers/logging"
+
+	"go.uber.org/zap"
+)
+
+// InTx runs fn in a database transaction, committed when fn succeeds. Without a
+// database fn runs on repo directly
+func InTx(ctx context.Context, db *sql.DB, repo repository.IRepository, fn func(query repository.IRepository) error) error {
+	return InSQLTx(ctx, db, repo, func(_ *sql.Tx, query repository.IRepository) error {
+		return fn(query)
+	})
+}
+
+// InSQLTx is InTx for the usecases other usecases join with their RecordTx, EnqueueTx or
+// PostTx, fn also gets the transaction. It is nil without a database
+func InSQLTx(ctx context.Context, db *sql.DB, repo repository.IRepository, fn func(tx *sql.Tx, query repository.IRepository) error) error {
+	if db == nil {
+		return fn(nil, repo)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		logging.NewFromContext(ctx).Error("Failed to begin transaction", zap.Error(err))
+		return errors.InternalServer.NewWithUserMsg(err, "failed to begin transaction")
+	}
+
+	if err := fn(tx, repo.WithTx(tx)); err != nil {
+		if errRollback := tx.Rollback(); errRollback != nil {
+			logging.NewFromContext(ctx).Error("Transaction rollback error", zap.Error(errRollback))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logging.NewFromContext(ctx).Error("Transaction commit error", zap.Error(err))
+		return errors.InternalServer.NewWithUserMsg(err, "failed to commit transaction")
+	}
+	return nil
+}
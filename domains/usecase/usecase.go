@@ -2,11 +2,16 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
 	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/repository/stream"
+	"kc-ewallet/domains/usecase/audit"
+	"kc-ewallet/domains/usecase/realtime"
 	"kc-ewallet/protocols/http/request"
+	"time"
 )
 
-//go:generate mockgen -destination=mocks/mock_usecase.go -source=usecase.go IUserUsecase,ITransactionUsecase
+//go:generate mockgen -destination=mocks/mock_usecase.go -source=usecase.go IUserUsecase,ITransactionUsecase,IAuditUsecase,IRealtimeUsecase,IWebhookUsecase,IMerchantUsecase,ISettlementUsecase,IFeeUsecase,IWalletUsecase,IExchangeUsecase,IPocketUsecase,ISavingsUsecase,ITransferUsecase,IScheduleUsecase,IPaymentRequestUsecase,IBillUsecase
 type IUserUsecase interface {
 	CreateUser(ctx context.Context, request request.RegisterUserRequest) error
 	GetUserByID(ctx context.Context, userID int32) (*postgres.User, error)
@@ -16,6 +21,233 @@ type IUserUsecase interface {
 type ITransactionUsecase interface {
 	CreateCreditTransaction(ctx context.Context, request request.CreateCreditTransactionRequest) (int32, float64, error)
 	CreateDebitTransaction(ctx context.Context, request request.CreateDebitTransactionRequest) (int32, float64, error)
+	CreatePayment(ctx context.Context, request request.CreatePaymentRequest) (postgres.Payment, float64, error)
+	QuoteTransaction(ctx context.Context, request request.CreateTransactionQuoteRequest) (TransactionQuote, error)
+}
+
+// IAuditUsecase appends to the hash-chained audit log, RecordTx joins the caller transaction
+type IAuditUsecase interface {
+	Record(ctx context.Context, event audit.Event) error
+	RecordTx(ctx context.Context, tx *sql.Tx, event audit.Event) error
+	Verify(ctx context.Context) (audit.VerifyResult, error)
+}
+
+// IRealtimeUsecase streams the events of a user, Subscribe replays the ones after lastEventID first
+type IRealtimeUsecase interface {
+	Subscribe(ctx context.Context, userID int32, lastEventID string) (*realtime.Subscription, error)
+}
+
+// IWebhookUsecase manages the webhook subscriptions of a user, EnqueueTx joins the caller transaction
+type IWebhookUsecase interface {
+	CreateSubscription(ctx context.Context, request request.CreateWebhookRequest) (postgres.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context, userID int32) ([]postgres.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, userID, subscriptionID int32) error
+	ListDeliveries(ctx context.Context, request request.ListWebhookDeliveriesRequest) ([]postgres.WebhookDelivery, int64, error)
+	Redeliver(ctx context.Context, userID, subscriptionID int32, deliveryID int64) (postgres.WebhookDelivery, error)
+	EnqueueTx(ctx context.Context, tx *sql.Tx, events []stream.Event) error
+}
+
+// IMerchantUsecase manages merchants and their api keys, Authenticate verifies a signed request
+type IMerchantUsecase interface {
+	CreateMerchant(ctx context.Context, request request.CreateMerchantRequest) (postgres.Merchant, IssuedMerchantKey, error)
+	GetMerchant(ctx context.Context, merchantID int32) (postgres.Merchant, error)
+	ListKeys(ctx context.Context, merchantID int32) ([]postgres.MerchantApiKey, error)
+	RotateKey(ctx context.Context, merchantID int32) (IssuedMerchantKey, error)
+	RevokeKey(ctx context.Context, merchantID int32, keyID string) error
+	Authenticate(ctx context.Context, signature request.MerchantSignature) (postgres.MerchantApiKey, error)
+}
+
+// ISettlementUsecase settles the captured payments of the merchants once a day, Run is the job
+type ISettlementUsecase interface {
+	Run(ctx context.Context)
+	ListSettlements(ctx context.Context, request request.ListSettlementsRequest) ([]postgres.Settlement, int64, error)
+	GetSettlementReport(ctx context.Context, merchantID, settlementID int32) (postgres.Settlement, []postgres.Payment, error)
+}
+
+// IFeeUsecase computes transaction fees from the fee rules, PostTx joins the caller transaction
+type IFeeUsecase interface {
+	Calculate(ctx context.Context, input FeeInput) (Fee, error)
+	PostTx(ctx context.Context, tx *sql.Tx, posting FeePosting) error
+	CreateRule(ctx context.Context, request request.CreateFeeRuleRequest) (postgres.FeeRule, error)
+	ListRules(ctx context.Context, activeOnly bool) ([]postgres.FeeRule, error)
+	DeactivateRule(ctx context.Context, ruleID int32) error
+}
+
+// IWalletUsecase manages the per-currency wallets of a user, a user holds one wallet per currency
+type IWalletUsecase interface {
+	OpenWallet(ctx context.Context, request request.OpenWalletRequest) (postgres.Wallet, error)
+	ListWallets(ctx context.Context, userID int32) ([]postgres.Wallet, error)
+	ListCurrencies(ctx context.Context) ([]postgres.Currency, error)
+}
+
+// IExchangeUsecase converts between the wallets of a user, an exchange executes a quote
+type IExchangeUsecase interface {
+	QuoteExchange(ctx context.Context, request request.CreateExchangeQuoteRequest) (ExchangeQuote, error)
+	Exchange(ctx context.Context, request request.CreateExchangeRequest) (ExchangeResult, error)
+}
+
+// IPocketUsecase splits the wallets of a user into named pockets, the balance of a
+// pocket can't be spent until it is moved back out
+type IPocketUsecase interface {
+	CreatePocket(ctx context.Context, request request.CreatePocketRequest) (postgres.Pocket, error)
+	ListBalances(ctx context.Context, userID int32) ([]WalletBalance, error)
+	MoveMoney(ctx context.Context, request request.MovePocketMoneyRequest) (WalletBalance, error)
+	SetDefaultPocket(ctx context.Context, userID, pocketID int32, isDefault bool) (postgres.Pocket, error)
+	ListEntries(ctx context.Context, request request.ListPocketEntriesRequest) ([]postgres.PocketEntry, int64, error)
+}
+
+// ISavingsUsecase saves into pockets toward the goals of a user, ApplyDebitTx joins the
+// caller transaction and Run is the sweep job
+type ISavingsUsecase interface {
+	CreateGoal(ctx context.Context, request request.CreateSavingsGoalRequest) (SavingsProgress, error)
+	ListGoals(ctx context.Context, userID int32) ([]SavingsProgress, error)
+	GetGoal(ctx context.Context, userID, goalID int32) (SavingsProgress, error)
+	ApplyDebitTx(ctx context.Context, tx *sql.Tx, debit SavingsDebit) error
+	Run(ctx context.Context)
+}
+
+// ITransferUsecase moves money between the wallets of two users in the same currency
+type ITransferUsecase interface {
+	Transfer(ctx context.Context, request request.CreateTransferRequest) (TransferResult, error)
+}
+
+// IScheduleUsecase keeps the scheduled transfers of the users, Run is the worker
+// executing the due ones
+type IScheduleUsecase interface {
+	CreateSchedule(ctx context.Context, request request.CreateScheduleRequest) (postgres.ScheduledTransfer, error)
+	ListSchedules(ctx context.Context, userID int32) ([]postgres.ScheduledTransfer, error)
+	GetSchedule(ctx context.Context, userID, scheduleID int32) (postgres.ScheduledTransfer, error)
+	CancelSchedule(ctx context.Context, userID, scheduleID int32) (postgres.ScheduledTransfer, error)
+	ListRuns(ctx context.Context, request request.ListScheduleRunsRequest) ([]postgres.ScheduledTransferRun, int64, error)
+	Run(ctx context.Context)
+}
+
+// IPaymentRequestUsecase keeps the money users request from each other, Pay makes the
+// transfer and Run is the sweeper expiring the requests left unpaid
+type IPaymentRequestUsecase interface {
+	CreateRequest(ctx context.Context, request request.CreatePaymentRequestRequest) (postgres.PaymentRequest, error)
+	ListRequests(ctx context.Context, request request.ListPaymentRequestsRequest) ([]postgres.PaymentRequest, int64, error)
+	GetRequest(ctx context.Context, userID, requestID int32) (postgres.PaymentRequest, error)
+	Pay(ctx context.Context, userID, requestID int32) (TransferResult, error)
+	Decline(ctx context.Context, userID, requestID int32) (postgres.PaymentRequest, error)
+	Cancel(ctx context.Context, userID, requestID int32) (postgres.PaymentRequest, error)
+	Run(ctx context.Context)
+}
+
+// IBillUsecase splits group expenses into the shares participants owe the creator,
+// PayShare settles one with a transfer
+type IBillUsecase interface {
+	CreateBill(ctx context.Context, request request.CreateBillRequest) (BillDetail, error)
+	ListBills(ctx context.Context, request request.ListBillsRequest) ([]postgres.Bill, int64, error)
+	ListOwedShares(ctx context.Context, request request.ListOwedBillSharesRequest) ([]postgres.ListBillSharesByUserIDRow, int64, error)
+	GetBill(ctx context.Context, userID, billID int32) (BillDetail, error)
+	PayShare(ctx context.Context, userID, billID int32) (TransferResult, error)
+	Remind(ctx context.Context, userID, billID int32) ([]postgres.BillShare, error)
+}
+
+// FeeInput is what a fee rule is matched and computed on
+type FeeInput struct {
+	TransactionType string
+	Currency        string
+	MinorUnits      int16
+	Channel         string
+	UserTier        string
+	Amount          float64
+}
+
+// Fee is charged on top of a transaction, RuleID is zero when no rule applies
+type Fee struct {
+	RuleID int32
+	Amount float64
+}
+
+// FeePosting records a fee the user balance was already charged with
+type FeePosting struct {
+	TransactionID int32
+	UserID        int32
+	Currency      string
+	Fee           Fee
+}
+
+// TransactionQuote previews a transaction without reserving any funds. Executing
+// it with QuoteID before ExpiresAt charges the quoted fee
+type TransactionQuote struct {
+	Type             string
+	Currency         string
+	Amount           float64
+	Fee              float64
+	Balance          float64
+	ResultingBalance float64
+	QuoteID          string
+	ExpiresAt        time.Time
+}
+
+// ExchangeQuote sells FromAmount of From for ToAmount of To at Rate, the mid-market
+// rate less the spread. Executing QuoteID before ExpiresAt exchanges at it
+type ExchangeQuote struct {
+	From          string
+	To            string
+	FromAmount    float64
+	ToAmount      float64
+	Rate          float64
+	MidRate       float64
+	SpreadPercent float64
+	QuoteID       string
+	ExpiresAt     time.Time
+}
+
+// ExchangeResult is the executed exchange with the new balances of both wallets
+type ExchangeResult struct {
+	Exchange    postgres.Exchange
+	FromBalance float64
+	ToBalance   float64
+}
+
+// WalletBalance splits the balance of a wallet, Unallocated is what no pocket sets aside
+type WalletBalance struct {
+	Wallet      postgres.Wallet
+	Unallocated float64
+	Pockets     []postgres.Pocket
+}
+
+// SavingsDebit is a debit the wallet was already charged with, Spendable is the
+// unallocated balance left after it
+type SavingsDebit struct {
+	TransactionID int32
+	UserID        int32
+	Currency      string
+	MinorUnits    int16
+	Amount        float64
+	Spendable     float64
+}
+
+// SavingsProgress is a goal with the pocket it saves into, Remaining is what the
+// pocket lacks to reach the target and DaysLeft is zero once the target date passed
+type SavingsProgress struct {
+	Goal      postgres.SavingsGoal
+	Pocket    postgres.Pocket
+	Remaining float64
+	Percent   float64
+	DaysLeft  int
+	Reached   bool
+}
+
+// TransferResult is the transfer with the new balance of the sending wallet
+type TransferResult struct {
+	Transfer postgres.Transfer
+	Balance  float64
+}
+
+// BillDetail is a bill with the share of every participant
+type BillDetail struct {
+	Bill   postgres.Bill
+	Shares []postgres.BillShare
+}
+
+// IssuedMerchantKey is a new api key with its secret, the only time the secret is available
+type IssuedMerchantKey struct {
+	postgres.MerchantApiKey
+	Secret string
 }
 
 type GetUserByIDResponse struct {
@@ -4,77 +4,128 @@ import (
 	"context"
 	"database/sql"
 	"kc-ewallet/configurations"
+	"kc-ewallet/constants"
 	"kc-ewallet/domains/repository"
 	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/domains/usecase/audit"
 	"kc-ewallet/internals/errors"
-	log_color "kc-ewallet/internals/helpers/color"
 	jwtHelper "kc-ewallet/internals/helpers/jwt"
+	"kc-ewallet/internals/helpers/logging"
 	strhelper "kc-ewallet/internals/helpers/str"
+	"kc-ewallet/internals/metric"
 	"kc-ewallet/protocols/http/request"
+	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
 )
 
 type userUsecase struct {
 	db         *sql.DB
 	repository repository.IRepository
+	userCache  repository.IUserCache
 	jwthelpers configurations.IJWTConfiguration
 	tracer     trace.Tracer
+	metric     metric.Metric
+	audit      usecase.IAuditUsecase
+
+	// collapses concurrent cache misses for the same user into one query
+	userGroup singleflight.Group
 }
 
 func NewUserUsecase(
 	db *sql.DB,
 	repository repository.IRepository,
+	userCache repository.IUserCache,
 	jwtConfig configurations.IJWTConfiguration,
 	trace trace.Tracer,
+	appMetric metric.Metric,
+	auditUsecase usecase.IAuditUsecase,
 ) *userUsecase {
+	if trace == nil {
+		trace = noop.NewTracerProvider().Tracer("")
+	}
+	if appMetric == nil {
+		appMetric = metric.NewNoopMetric()
+	}
+
 	return &userUsecase{
 		db:         db,
 		repository: repository,
+		userCache:  userCache,
 		jwthelpers: jwtConfig,
 		tracer:     trace,
+		metric:     appMetric,
+		audit:      auditUsecase,
 	}
 }
 
 func (u *userUsecase) CreateUser(ctx context.Context, request request.RegisterUserRequest) error {
+	ctx, span := u.tracer.Start(ctx, "userUsecase.CreateUser")
+	defer span.End()
+
 	passwordHash, err := strhelper.Hash(request.Password)
 	if err != nil {
-		log_color.PrintRedf("CreateUser failed to hash password: %v\n", err)
+		logging.NewFromContext(ctx).Error("CreateUser failed to hash password", zap.Error(err))
 		return errors.InternalServer.NewWithUserMsg(err, "failed to create user")
 	}
 
-	if _, err := u.repository.CreateUser(ctx, postgres.CreateUserParams{
-		Username: request.Username,
-		Password: passwordHash,
-	}); err != nil {
-		log_color.PrintRedf("CreateUser failed to create user: %v\n", err)
-		if pqErr, ok := err.(*pq.Error); ok {
-			if pqErr.Code == "23505" {
-				return errors.BadRequest.NewWithUserMsg(err, "username already exists")
+	// the user starts with an empty wallet in the default currency
+	return usecase.InTx(ctx, u.db, u.repository, func(query repository.IRepository) error {
+		userID, err := query.CreateUser(ctx, postgres.CreateUserParams{
+			Username: request.Username,
+			Password: passwordHash,
+		})
+		if err != nil {
+			logging.NewFromContext(ctx).Error("CreateUser failed to create user", zap.Error(err))
+			if pqErr, ok := err.(*pq.Error); ok {
+				if pqErr.Code == "23505" {
+					return errors.WithCode(errors.BadRequest.NewWithUserMsg(err, "username already exists"), errors.CodeUsernameTaken)
+				}
 			}
+			return errors.InternalServer.NewWithUserMsg(err, "failed to create user")
 		}
-		return errors.InternalServer.NewWithUserMsg(err, "failed to create user")
-	}
 
-	return nil
+		if _, err := query.CreateWallet(ctx, postgres.CreateWalletParams{
+			UserID:   userID,
+			Currency: constants.DefaultCurrency,
+		}); err != nil {
+			logging.NewFromContext(ctx).Error("CreateUser failed to create wallet", zap.Error(err))
+			return errors.InternalServer.NewWithUserMsg(err, "failed to create user")
+		}
+		return nil
+	})
 }
 
 func (u *userUsecase) Login(ctx context.Context, request request.LoginRequest) (string, *postgres.User, error) {
+	ctx, span := u.tracer.Start(ctx, "userUsecase.Login")
+	defer span.End()
+
 	user, err := u.repository.GetUserByUsername(ctx, request.Username)
 	if err != nil {
-		log_color.PrintRedf("Login failed to get user by username: %v\n", err)
+		logging.NewFromContext(ctx).Error("Login failed to get user by username", zap.Error(err))
 		if err == sql.ErrNoRows {
-			return "", nil, errors.NotFound.NewWithUserMsg(err, "user not found")
+			u.metric.RecordLoginFailure(ctx, metric.LoginReasonUserNotFound)
+			u.recordLoginFailure(ctx, 0, request.Username, metric.LoginReasonUserNotFound)
+			return "", nil, errors.WithCode(errors.NotFound.NewWithUserMsg(err, "user not found"), errors.CodeUserNotFound)
 		}
+		u.metric.RecordLoginFailure(ctx, metric.LoginReasonError)
+		u.recordLoginFailure(ctx, 0, request.Username, metric.LoginReasonError)
 		return "", nil, errors.InternalServer.NewWithUserMsg(err, "failed to get user by username")
 	}
 
 	if !strhelper.CheckHash(user.Password, request.Password) {
-		log_color.PrintRedf("Login password mismatch\n")
-		return "", nil, errors.Unauthorized.New("invalid credentials")
+		logging.NewFromContext(ctx).Warn("Login password mismatch")
+		u.metric.RecordLoginFailure(ctx, metric.LoginReasonInvalidPassword)
+		u.recordLoginFailure(ctx, user.ID, request.Username, metric.LoginReasonInvalidPassword)
+		return "", nil, errors.WithCode(errors.Unauthorized.New("invalid credentials"), errors.CodeInvalidCredentials)
 	}
 
 	accessTokenEXP := time.Now().Add(time.Duration(u.jwthelpers.GetExpireInMinute()) * time.Minute)
@@ -90,22 +141,72 @@ func (u *userUsecase) Login(ctx context.Context, request request.LoginRequest) (
 
 	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, accessTokenClaims).SignedString([]byte(u.jwthelpers.GetSigningKey()))
 	if err != nil {
-		log_color.PrintRedf("Login failed to create access token: %v\n", err)
+		logging.NewFromContext(ctx).Error("Login failed to create access token", zap.Error(err))
+		u.metric.RecordLoginFailure(ctx, metric.LoginReasonError)
+		u.recordLoginFailure(ctx, user.ID, request.Username, metric.LoginReasonError)
 		return "", nil, errors.InternalServer.NewWithUserMsg(err, "failed to login")
 	}
 
+	u.metric.RecordLoginSuccess(ctx)
+	audit.RecordOrLog(ctx, u.audit, audit.Event{
+		Type:      audit.EventLoginSuccess,
+		SubjectID: user.ID,
+	})
+
 	return accessToken, &user, nil
 }
 
+// userLoadTimeout bounds the load shared by the callers of GetUserByID
+const userLoadTimeout = 5 * time.Second
+
 func (u *userUsecase) GetUserByID(ctx context.Context, userID int32) (*postgres.User, error) {
-	user, err := u.repository.GetUserByIDLock(ctx, userID)
+	ctx, span := u.tracer.Start(ctx, "userUsecase.GetUserByID", trace.WithAttributes(attribute.Int("user_id", int(userID))))
+	defer span.End()
+
+	if u.userCache != nil {
+		if user, err := u.userCache.GetUser(ctx, userID); err == nil {
+			return user, nil
+		}
+	}
+
+	result, err, _ := u.userGroup.Do(strconv.Itoa(int(userID)), func() (interface{}, error) {
+		// shared by every caller of the flight, the first one cancelling must not fail the others
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), userLoadTimeout)
+		defer cancel()
+
+		user, err := u.repository.GetUserByID(loadCtx, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		if u.userCache != nil {
+			if errCache := u.userCache.SetUser(loadCtx, user); errCache != nil {
+				logging.NewFromContext(ctx).Warn("GetUserByID failed to cache user", zap.Error(errCache))
+			}
+		}
+
+		return &user, nil
+	})
 	if err != nil {
-		log_color.PrintRedf("GetUserByID failed to get user by id: %v\n", err)
+		logging.NewFromContext(ctx).Error("GetUserByID failed to get user by id", zap.Error(err))
 		if err == sql.ErrNoRows {
-			return nil, errors.NotFound.NewWithUserMsg(err, "user not found")
+			return nil, errors.WithCode(errors.NotFound.NewWithUserMsg(err, "user not found"), errors.CodeUserNotFound)
 		}
 		return nil, errors.InternalServer.NewWithUserMsg(err, "failed to get user by id")
 	}
 
+	// copy so callers sharing a flight never mutate each other's result
+	user := *result.(*postgres.User)
 	return &user, nil
 }
+
+func (u *userUsecase) recordLoginFailure(ctx context.Context, userID int32, username, reason string) {
+	audit.RecordOrLog(ctx, u.audit, audit.Event{
+		Type:      audit.EventLoginFailure,
+		SubjectID: userID,
+		Metadata: map[string]interface{}{
+			"username": username,
+			"reason":   reason,
+		},
+	})
+}
@@ -2,9 +2,14 @@ package user
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	mock_configuration "kc-ewallet/configurations/mocks"
+	"kc-ewallet/constants"
 	mock_repository "kc-ewallet/domains/repository/mocks"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/internals/metric"
+	mock_metric "kc-ewallet/internals/metric/mocks"
 	"kc-ewallet/protocols/http/request"
 	"testing"
 
@@ -18,10 +23,11 @@ func TestUserUsecase_RegisterUser(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
 	mockRepo := mock_repository.NewMockIRepository(ctrl)
+	mockCache := mock_repository.NewMockIUserCache(ctrl)
 	mockJwtConfig := mock_configuration.NewMockIJWTConfiguration(ctrl)
-	sqlDB, _, _ := sqlmock.New()
 
-	usecase := NewUserUsecase(sqlDB, mockRepo, mockJwtConfig, nil)
+	// without a database the user and its wallet are created outside a transaction
+	usecase := NewUserUsecase(nil, mockRepo, mockCache, mockJwtConfig, nil, nil, nil)
 
 	testCases := []struct {
 		name          string
@@ -35,7 +41,9 @@ func TestUserUsecase_RegisterUser(t *testing.T) {
 				Username: "luffy",
 			},
 			mock: func() {
-				mockRepo.EXPECT().CreateUser(gomock.Any(), "luffy").Return(int32(1), nil)
+				mockRepo.EXPECT().CreateUser(gomock.Any(), gomock.AssignableToTypeOf(postgres.CreateUserParams{})).Return(int32(1), nil)
+				mockRepo.EXPECT().CreateWallet(gomock.Any(), postgres.CreateWalletParams{UserID: 1, Currency: constants.DefaultCurrency}).
+					Return(postgres.Wallet{ID: 1, UserID: 1, Currency: constants.DefaultCurrency}, nil)
 			},
 		},
 		{
@@ -44,7 +52,7 @@ func TestUserUsecase_RegisterUser(t *testing.T) {
 				Username: "zore",
 			},
 			mock: func() {
-				mockRepo.EXPECT().CreateUser(gomock.Any(), "zore").Return(int32(1), &pq.Error{Code: "23505"})
+				mockRepo.EXPECT().CreateUser(gomock.Any(), gomock.AssignableToTypeOf(postgres.CreateUserParams{})).Return(int32(0), &pq.Error{Code: "23505"})
 			},
 			expectedError: errors.New("username already exists"),
 		},
@@ -54,7 +62,18 @@ func TestUserUsecase_RegisterUser(t *testing.T) {
 				Username: "sanji",
 			},
 			mock: func() {
-				mockRepo.EXPECT().CreateUser(gomock.Any(), "sanji").Return(int32(1), assert.AnError)
+				mockRepo.EXPECT().CreateUser(gomock.Any(), gomock.AssignableToTypeOf(postgres.CreateUserParams{})).Return(int32(0), assert.AnError)
+			},
+			expectedError: errors.New("failed to create user"),
+		},
+		{
+			name: "should not keep a user without wallet",
+			request: request.RegisterUserRequest{
+				Username: "usopp",
+			},
+			mock: func() {
+				mockRepo.EXPECT().CreateUser(gomock.Any(), gomock.AssignableToTypeOf(postgres.CreateUserParams{})).Return(int32(2), nil)
+				mockRepo.EXPECT().CreateWallet(gomock.Any(), gomock.Any()).Return(postgres.Wallet{}, assert.AnError)
 			},
 			expectedError: errors.New("failed to create user"),
 		},
@@ -64,10 +83,148 @@ func TestUserUsecase_RegisterUser(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mock()
 
-			if err := usecase.CreateUser(context.Background(), tc.request); err != nil {
+			err := usecase.CreateUser(context.Background(), tc.request)
+			if tc.expectedError == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Equal(t, tc.expectedError.Error(), err.Error())
+		})
+	}
+}
+
+func TestUserUsecase_GetUserByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mockRepo := mock_repository.NewMockIRepository(ctrl)
+	mockCache := mock_repository.NewMockIUserCache(ctrl)
+	mockJwtConfig := mock_configuration.NewMockIJWTConfiguration(ctrl)
+	sqlDB, _, _ := sqlmock.New()
+
+	usecase := NewUserUsecase(sqlDB, mockRepo, mockCache, mockJwtConfig, nil, nil, nil)
+
+	testCases := []struct {
+		name             string
+		userID           int32
+		mock             func()
+		expectedUsername string
+		expectedError    error
+	}{
+		{
+			name:   "should return cached user",
+			userID: 1,
+			mock: func() {
+				mockCache.EXPECT().GetUser(gomock.Any(), int32(1)).Return(&postgres.User{ID: 1, Username: "luffy"}, nil)
+			},
+			expectedUsername: "luffy",
+		},
+		{
+			name:   "should read through and fill cache on miss",
+			userID: 2,
+			mock: func() {
+				user := postgres.User{ID: 2, Username: "zoro"}
+				mockCache.EXPECT().GetUser(gomock.Any(), int32(2)).Return(nil, assert.AnError)
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), int32(2)).Return(user, nil)
+				mockCache.EXPECT().SetUser(gomock.Any(), user).Return(nil)
+			},
+			expectedUsername: "zoro",
+		},
+		{
+			name:   "should still return user when cache fill fails",
+			userID: 3,
+			mock: func() {
+				user := postgres.User{ID: 3, Username: "sanji"}
+				mockCache.EXPECT().GetUser(gomock.Any(), int32(3)).Return(nil, assert.AnError)
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), int32(3)).Return(user, nil)
+				mockCache.EXPECT().SetUser(gomock.Any(), user).Return(assert.AnError)
+			},
+			expectedUsername: "sanji",
+		},
+		{
+			name:   "should error when user not found",
+			userID: 4,
+			mock: func() {
+				mockCache.EXPECT().GetUser(gomock.Any(), int32(4)).Return(nil, assert.AnError)
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), int32(4)).Return(postgres.User{}, sql.ErrNoRows)
+			},
+			expectedError: errors.New("user not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+
+			user, err := usecase.GetUserByID(context.Background(), tc.userID)
+			if tc.expectedError != nil {
 				assert.Error(t, err)
 				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				return
 			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedUsername, user.Username)
+		})
+	}
+
+	t.Run("should not bind the shared load to the caller cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		user := postgres.User{ID: 5, Username: "nami"}
+		mockCache.EXPECT().GetUser(gomock.Any(), int32(5)).Return(nil, assert.AnError)
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), int32(5)).DoAndReturn(func(ctx context.Context, _ int32) (postgres.User, error) {
+			assert.NoError(t, ctx.Err())
+			return user, nil
+		})
+		mockCache.EXPECT().SetUser(gomock.Any(), user).Return(nil)
+
+		_, err := usecase.GetUserByID(ctx, 5)
+		assert.NoError(t, err)
+	})
+}
+
+func TestUserUsecase_LoginMetric(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mockRepo := mock_repository.NewMockIRepository(ctrl)
+	mockCache := mock_repository.NewMockIUserCache(ctrl)
+	mockJwtConfig := mock_configuration.NewMockIJWTConfiguration(ctrl)
+	mockMetric := mock_metric.NewMockMetric(ctrl)
+	sqlDB, _, _ := sqlmock.New()
+
+	usecase := NewUserUsecase(sqlDB, mockRepo, mockCache, mockJwtConfig, nil, mockMetric, nil)
+
+	testCases := []struct {
+		name    string
+		request request.LoginRequest
+		mock    func()
+	}{
+		{
+			name:    "should record failure when user not found",
+			request: request.LoginRequest{Username: "usopp", Password: "secret"},
+			mock: func() {
+				mockRepo.EXPECT().GetUserByUsername(gomock.Any(), "usopp").Return(postgres.User{}, sql.ErrNoRows)
+				mockMetric.EXPECT().RecordLoginFailure(gomock.Any(), metric.LoginReasonUserNotFound)
+			},
+		},
+		{
+			name:    "should record failure when password mismatch",
+			request: request.LoginRequest{Username: "nami", Password: "secret"},
+			mock: func() {
+				mockRepo.EXPECT().GetUserByUsername(gomock.Any(), "nami").Return(postgres.User{Username: "nami", Password: "not-a-hash"}, nil)
+				mockMetric.EXPECT().RecordLoginFailure(gomock.Any(), metric.LoginReasonInvalidPassword)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+
+			_, _, err := usecase.Login(context.Background(), tc.request)
+			assert.Error(t, err)
 		})
 	}
 }
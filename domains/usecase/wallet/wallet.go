@@ -0,0 +1,118 @@
+package wallet
+
+import (
+	"context"
+	"database/sql"
+	goerrors "errors"
+	"kc-ewallet/domains/repository"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/domains/usecase/audit"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/internals/helpers/logging"
+	"kc-ewallet/protocols/http/request"
+
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+type walletUsecase struct {
+	repository repository.IRepository
+	userCache  repository.IUserCache
+	audit      usecase.IAuditUsecase
+	trace      trace.Tracer
+}
+
+func NewWalletUsecase(
+	repository repository.IRepository,
+	userCache repository.IUserCache,
+	auditUsecase usecase.IAuditUsecase,
+	trace trace.Tracer,
+) *walletUsecase {
+	if trace == nil {
+		trace = noop.NewTracerProvider().Tracer("")
+	}
+
+	return &walletUsecase{
+		repository: repository,
+		userCache:  userCache,
+		audit:      auditUsecase,
+		trace:      trace,
+	}
+}
+
+// OpenWallet opens an empty wallet of the user in a supported currency
+func (w *walletUsecase) OpenWallet(ctx context.Context, request request.OpenWalletRequest) (postgres.Wallet, error) {
+	ctx, span := w.trace.Start(ctx, "walletUsecase.OpenWallet", trace.WithAttributes(
+		attribute.Int("user_id", int(request.UserID)),
+		attribute.String("currency", request.Currency),
+	))
+	defer span.End()
+
+	if _, err := w.repository.GetCurrency(ctx, request.Currency); err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return postgres.Wallet{}, errors.WithCode(errors.BadRequest.NewWithUserMsg(err, "currency is not supported"), errors.CodeUnsupportedCurrency)
+		}
+		logging.NewFromContext(ctx).Error("OpenWallet failed to get currency", zap.Error(err))
+		return postgres.Wallet{}, errors.InternalServer.NewWithUserMsg(err, "failed to open wallet")
+	}
+
+	wallet, err := w.repository.CreateWallet(ctx, postgres.CreateWalletParams{
+		UserID:   request.UserID,
+		Currency: request.Currency,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			if pqErr.Code == "23505" {
+				return postgres.Wallet{}, errors.WithCode(errors.BadRequest.NewWithUserMsg(err, "wallet already exists"), errors.CodeWalletExists)
+			}
+		}
+		logging.NewFromContext(ctx).Error("OpenWallet failed to create wallet", zap.Error(err))
+		return postgres.Wallet{}, errors.InternalServer.NewWithUserMsg(err, "failed to open wallet")
+	}
+	usecase.InvalidateBalances(ctx, w.userCache, request.UserID)
+
+	audit.RecordOrLog(ctx, w.audit, audit.Event{
+		Type:      audit.EventWalletOpened,
+		SubjectID: request.UserID,
+		Metadata: map[string]interface{}{
+			"wallet_id": wallet.ID,
+			"currency":  wallet.Currency,
+		},
+	})
+
+	return wallet, nil
+}
+
+// ListWallets returns the wallets of the user ordered by currency
+func (w *walletUsecase) ListWallets(ctx context.Context, userID int32) ([]postgres.Wallet, error) {
+	ctx, span := w.trace.Start(ctx, "walletUsecase.ListWallets", trace.WithAttributes(
+		attribute.Int("user_id", int(userID)),
+	))
+	defer span.End()
+
+	wallets, err := w.repository.ListWalletsByUserID(ctx, userID)
+	if err != nil {
+		logging.NewFromContext(ctx).Error("ListWallets failed to list wallets", zap.Error(err))
+		return nil, errors.InternalServer.NewWithUserMsg(err, "failed to list wallets")
+	}
+
+	return wallets, nil
+}
+
+// ListCurrencies returns the currencies a wallet can be opened in
+func (w *walletUsecase) ListCurrencies(ctx context.Context) ([]postgres.Currency, error) {
+	ctx, span := w.trace.Start(ctx, "walletUsecase.ListCurrencies")
+	defer span.End()
+
+	currencies, err := w.repository.ListCurrencies(ctx)
+	if err != nil {
+		logging.NewFromContext(ctx).Error("ListCurrencies failed to list currencies", zap.Error(err))
+		return nil, errors.InternalServer.NewWithUserMsg(err, "failed to list currencies")
+	}
+
+	return currencies, nil
+}
@@ -0,0 +1,72 @@
+package webhook
+
+import (
+	goerrors "errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// ErrNonPublicAddress is the error of an attempt to a receiver that resolves to a
+// loopback, private, link-local or otherwise internal address
+var ErrNonPublicAddress = goerrors.New("webhook receiver address is not public")
+
+// sharedAddressSpace is the carrier-grade NAT range some clusters use for their services
+var sharedAddressSpace = net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// newClient sends the deliveries. control is run on every resolved address before
+// connecting, so a host re-resolved to an internal address is refused too
+func newClient(timeout time.Duration, control func(network, address string, conn syscall.RawConn) error) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+		Control:   control,
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			// no proxy, it would connect to the internal address on our behalf
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: 1 * time.Second,
+		},
+		// a redirect is a failed delivery, the partner should register the final url
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// publicOnly is the dial control of the deliveries, it refuses every address a partner
+// shouldn't make us reach: loopback, private, link-local (cloud metadata) and the like
+func publicOnly(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !IsPublicIP(ip) {
+		return fmt.Errorf("%w: %s", ErrNonPublicAddress, host)
+	}
+	return nil
+}
+
+// IsPublicIP reports whether ip is routable on the internet
+func IsPublicIP(ip net.IP) bool {
+	return !(ip.IsUnspecified() ||
+		ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		sharedAddressSpace.Contains(ip))
+}
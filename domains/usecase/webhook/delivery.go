@@ -0,0 +1,224 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	goerrors "errors"
+	"fmt"
+	"io"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/internals/helpers/logging"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// deliveryBatchSize bounds the deliveries claimed, and sent concurrently, per poll
+const deliveryBatchSize int32 = 50
+
+// claimLeaseMargin is added to the request timeout while a delivery is claimed, another
+// instance only picks it up again if this one died before recording the attempt
+const claimLeaseMargin = 30 * time.Second
+
+const userAgent = "kc-ewallet-webhook/1.0"
+
+// relayLagPolls is how many poll intervals a due delivery may wait before the relay is reported down
+const relayLagPolls = 3
+
+// Run delivers the due deliveries every poll interval until ctx is done
+func (w *webhookUsecase) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// a full batch means more are due, keep going without waiting for the ticker
+		for {
+			claimed, err := w.deliverDue(ctx)
+			if err != nil && ctx.Err() == nil {
+				logging.NewFromContext(ctx).Warn("failed to claim webhook deliveries", zap.Error(err))
+			}
+			if claimed < int(deliveryBatchSize) || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckRelay is the health check of the delivery relay, the deliveries are the outbox
+// of the events. It fails when the oldest due delivery waited longer than a few polls
+// and a claimed batch, meaning no worker is relaying them
+func (w *webhookUsecase) CheckRelay(ctx context.Context) error {
+	nextAttemptAt, err := w.repository.GetOldestDueWebhookDelivery(ctx)
+	if goerrors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	maxLag := relayLagPolls*w.pollInterval + w.timeout + claimLeaseMargin
+	if lag := time.Now().UTC().Sub(nextAttemptAt); lag > maxLag {
+		return fmt.Errorf("oldest due webhook delivery is %s behind", lag.Truncate(time.Second))
+	}
+	return nil
+}
+
+// deliverDue claims a batch of due deliveries and attempts each of them once
+func (w *webhookUsecase) deliverDue(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	deliveries, err := w.repository.ClaimWebhookDeliveries(ctx, postgres.ClaimWebhookDeliveriesParams{
+		LeaseUntil: now.Add(w.timeout + claimLeaseMargin),
+		Now:        now,
+		BatchSize:  deliveryBatchSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	subscriptions := map[int32]*postgres.WebhookSubscription{}
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		subscription, ok := subscriptions[delivery.SubscriptionID]
+		if !ok {
+			found, err := w.repository.GetWebhookSubscriptionByID(ctx, delivery.SubscriptionID)
+			if err != nil {
+				// deleted meanwhile, its deliveries are gone with it. Otherwise the lease expires and it is retried
+				if !goerrors.Is(err, sql.ErrNoRows) {
+					logging.NewFromContext(ctx).Warn("failed to get webhook subscription", zap.Int32("webhook_id", delivery.SubscriptionID), zap.Error(err))
+				}
+				subscriptions[delivery.SubscriptionID] = nil
+				continue
+			}
+			subscription = &found
+			subscriptions[delivery.SubscriptionID] = subscription
+		}
+		if subscription == nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func(delivery postgres.WebhookDelivery, subscription postgres.WebhookSubscription) {
+			defer wg.Done()
+			w.deliver(ctx, delivery, subscription)
+		}(delivery, *subscription)
+	}
+	wg.Wait()
+
+	return len(deliveries), nil
+}
+
+// deliver makes one attempt and records its outcome
+func (w *webhookUsecase) deliver(ctx context.Context, delivery postgres.WebhookDelivery, subscription postgres.WebhookSubscription) {
+	ctx, span := w.trace.Start(ctx, "webhookUsecase.deliver", trace.WithAttributes(
+		attribute.Int64("delivery_id", delivery.ID),
+		attribute.Int("webhook_id", int(subscription.ID)),
+		attribute.Int("attempt", int(delivery.Attempts+1)),
+	))
+	defer span.End()
+
+	responseStatus, err := w.post(ctx, delivery, subscription)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	params := w.nextAttempt(delivery, responseStatus, err, time.Now().UTC())
+	span.SetAttributes(attribute.String("status", params.Status))
+	if params.Status == StatusDead {
+		logging.NewFromContext(ctx).Warn("webhook delivery dead-lettered",
+			zap.Int64("delivery_id", delivery.ID),
+			zap.Int32("webhook_id", subscription.ID),
+			zap.Int32("attempts", params.Attempts),
+			zap.String("last_error", params.LastError),
+		)
+	}
+
+	// not bound to ctx, a shutdown mid request still records the attempt
+	recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if err := w.repository.UpdateWebhookDeliveryAttempt(recordCtx, params); err != nil {
+		logging.NewFromContext(ctx).Error("failed to record webhook delivery attempt", zap.Int64("delivery_id", delivery.ID), zap.Error(err))
+	}
+}
+
+// post sends the signed payload, any status outside 2xx is a failed attempt
+func (w *webhookUsecase) post(ctx context.Context, delivery postgres.WebhookDelivery, subscription postgres.WebhookSubscription) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(IDHeader, delivery.EventID.String())
+	req.Header.Set(EventTypeHeader, delivery.EventType)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(subscription.Secret, timestamp, delivery.Payload))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// drained so the connection is reused, the body is never kept: last_error is
+	// returned by the delivery log and would let a user read what the receiver answered
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// nextAttempt is the delivery state after an attempt: succeeded, retried after
+// the backoff, or dead once the attempts are used up
+func (w *webhookUsecase) nextAttempt(delivery postgres.WebhookDelivery, responseStatus int, err error, now time.Time) postgres.UpdateWebhookDeliveryAttemptParams {
+	params := postgres.UpdateWebhookDeliveryAttemptParams{
+		ID:             delivery.ID,
+		Status:         StatusSucceeded,
+		Attempts:       delivery.Attempts + 1,
+		NextAttemptAt:  now,
+		LastAttemptAt:  sql.NullTime{Time: now, Valid: true},
+		ResponseStatus: sql.NullInt32{Int32: int32(responseStatus), Valid: responseStatus != 0},
+	}
+	if err == nil {
+		return params
+	}
+
+	params.LastError = err.Error()
+	if params.Attempts >= w.maxAttempts {
+		params.Status = StatusDead
+		return params
+	}
+
+	params.Status = StatusPending
+	params.NextAttemptAt = now.Add(Backoff(params.Attempts, w.backoffBase, w.backoffMax))
+	return params
+}
+
+// Backoff is the wait after the given failed attempt: base, then doubled each time up to max
+func Backoff(attempt int32, base, max time.Duration) time.Duration {
+	wait := base
+	for i := int32(1); i < attempt && wait < max; i++ {
+		wait *= 2
+	}
+	if wait > max {
+		return max
+	}
+	return wait
+}
@@ -0,0 +1,68 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	goerrors "errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// IDHeader is the event id, the same on every retry and subscription so receivers can dedupe
+	IDHeader        = "Webhook-Id"
+	EventTypeHeader = "Webhook-Event"
+	TimestampHeader = "Webhook-Timestamp"
+	SignatureHeader = "Webhook-Signature"
+)
+
+// DefaultTolerance is how old a delivery may be before Verify rejects it as a replay
+const DefaultTolerance = 5 * time.Minute
+
+const signatureVersion = "v1"
+
+var (
+	ErrMissingSignature = goerrors.New("webhook signature is missing")
+	ErrInvalidSignature = goerrors.New("webhook signature doesn't match")
+	ErrTimestampTooOld  = goerrors.New("webhook timestamp is outside the tolerance")
+	ErrInvalidTimestamp = goerrors.New("webhook timestamp is not a unix time")
+)
+
+// Sign returns the signature header value, an HMAC-SHA256 of "<timestamp>.<body>"
+// keyed with the subscription secret
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature headers of a received delivery, the signed timestamp
+// must be within tolerance of now so a captured delivery can't be replayed later
+func Verify(secret string, header http.Header, body []byte, tolerance time.Duration, now time.Time) error {
+	signatures := header.Get(SignatureHeader)
+	if signatures == "" {
+		return ErrMissingSignature
+	}
+
+	timestamp, err := strconv.ParseInt(header.Get(TimestampHeader), 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+	if age := now.Sub(time.Unix(timestamp, 0)); age > tolerance || age < -tolerance {
+		return ErrTimestampTooOld
+	}
+
+	expected := Sign(secret, timestamp, body)
+	// several signatures may be sent, space separated, e.g. while a secret is rotated
+	for _, signature := range strings.Fields(signatures) {
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
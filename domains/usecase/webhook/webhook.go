@@ -0,0 +1,333 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	goerrors "errors"
+	"kc-ewallet/configurations"
+	"kc-ewallet/domains/repository"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/repository/stream"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/domains/usecase/audit"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/internals/helpers/logging"
+	"kc-ewallet/protocols/http/request"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+const (
+	StatusPending   = "pending"
+	StatusSucceeded = "succeeded"
+	StatusDead      = "dead"
+)
+
+// maxSubscriptionsPerUser keeps one account from multiplying the deliveries of every event
+const maxSubscriptionsPerUser = 10
+
+// secretPrefix makes a leaked secret recognizable in logs and secret scanners
+const secretPrefix = "whsec_"
+
+// Payload is the body of every delivery
+type Payload struct {
+	// ID is the same for every subscription and retry of the event
+	ID        uuid.UUID        `json:"id"`
+	Type      stream.EventType `json:"type"`
+	UserID    int32            `json:"user_id"`
+	CreatedAt time.Time        `json:"created_at"`
+	Data      json.RawMessage  `json:"data"`
+}
+
+type webhookUsecase struct {
+	repository repository.IRepository
+	audit      usecase.IAuditUsecase
+	trace      trace.Tracer
+	client     *http.Client
+
+	maxAttempts  int32
+	backoffBase  time.Duration
+	backoffMax   time.Duration
+	timeout      time.Duration
+	pollInterval time.Duration
+}
+
+// NewWebhookUsecase manages the subscriptions and delivers their events, Run must be
+// started for the deliveries to go out
+func NewWebhookUsecase(
+	repository repository.IRepository,
+	config configurations.IWebhookConfiguration,
+	auditUsecase usecase.IAuditUsecase,
+	trace trace.Tracer,
+) *webhookUsecase {
+	if trace == nil {
+		trace = noop.NewTracerProvider().Tracer("")
+	}
+
+	return &webhookUsecase{
+		repository:   repository,
+		audit:        auditUsecase,
+		trace:        trace,
+		client:       newClient(config.GetTimeout(), publicOnly),
+		maxAttempts:  int32(config.GetMaxAttempts()),
+		backoffBase:  config.GetBackoffBase(),
+		backoffMax:   config.GetBackoffMax(),
+		timeout:      config.GetTimeout(),
+		pollInterval: config.GetPollInterval(),
+	}
+}
+
+// CreateSubscription returns the subscription with its secret, it is the only
+// response carrying the secret
+func (w *webhookUsecase) CreateSubscription(ctx context.Context, request request.CreateWebhookRequest) (postgres.WebhookSubscription, error) {
+	ctx, span := w.trace.Start(ctx, "webhookUsecase.CreateSubscription", trace.WithAttributes(
+		attribute.Int("user_id", int(request.UserID)),
+	))
+	defer span.End()
+
+	subscriptions, err := w.repository.ListWebhookSubscriptionsByUserID(ctx, request.UserID)
+	if err != nil {
+		logging.NewFromContext(ctx).Error("CreateSubscription failed to list subscriptions", zap.Error(err))
+		return postgres.WebhookSubscription{}, errors.InternalServer.NewWithUserMsg(err, "failed to create webhook")
+	}
+	if len(subscriptions) >= maxSubscriptionsPerUser {
+		return postgres.WebhookSubscription{}, errors.BadRequest.New("a user can have at most %d webhooks", maxSubscriptionsPerUser)
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		logging.NewFromContext(ctx).Error("CreateSubscription failed to generate secret", zap.Error(err))
+		return postgres.WebhookSubscription{}, errors.InternalServer.NewWithUserMsg(err, "failed to create webhook")
+	}
+
+	eventTypes := request.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	subscription, err := w.repository.CreateWebhookSubscription(ctx, postgres.CreateWebhookSubscriptionParams{
+		UserID:     request.UserID,
+		Url:        request.URL,
+		Secret:     secret,
+		EventTypes: eventTypes,
+	})
+	if err != nil {
+		logging.NewFromContext(ctx).Error("CreateSubscription failed to create subscription", zap.Error(err))
+		return postgres.WebhookSubscription{}, errors.InternalServer.NewWithUserMsg(err, "failed to create webhook")
+	}
+
+	audit.RecordOrLog(ctx, w.audit, audit.Event{
+		Type:      audit.EventWebhookCreated,
+		SubjectID: request.UserID,
+		After: map[string]interface{}{
+			"url":         subscription.Url,
+			"event_types": subscription.EventTypes,
+		},
+		Metadata: map[string]interface{}{"webhook_id": subscription.ID},
+	})
+
+	return subscription, nil
+}
+
+func (w *webhookUsecase) ListSubscriptions(ctx context.Context, userID int32) ([]postgres.WebhookSubscription, error) {
+	ctx, span := w.trace.Start(ctx, "webhookUsecase.ListSubscriptions", trace.WithAttributes(
+		attribute.Int("user_id", int(userID)),
+	))
+	defer span.End()
+
+	subscriptions, err := w.repository.ListWebhookSubscriptionsByUserID(ctx, userID)
+	if err != nil {
+		logging.NewFromContext(ctx).Error("ListSubscriptions failed to list subscriptions", zap.Error(err))
+		return nil, errors.InternalServer.NewWithUserMsg(err, "failed to list webhooks")
+	}
+
+	return subscriptions, nil
+}
+
+// DeleteSubscription removes the subscription with its delivery log, pending deliveries are dropped
+func (w *webhookUsecase) DeleteSubscription(ctx context.Context, userID, subscriptionID int32) error {
+	ctx, span := w.trace.Start(ctx, "webhookUsecase.DeleteSubscription", trace.WithAttributes(
+		attribute.Int("user_id", int(userID)),
+		attribute.Int("webhook_id", int(subscriptionID)),
+	))
+	defer span.End()
+
+	deleted, err := w.repository.DeleteWebhookSubscription(ctx, postgres.DeleteWebhookSubscriptionParams{
+		ID:     subscriptionID,
+		UserID: userID,
+	})
+	if err != nil {
+		logging.NewFromContext(ctx).Error("DeleteSubscription failed to delete subscription", zap.Error(err))
+		return errors.InternalServer.NewWithUserMsg(err, "failed to delete webhook")
+	}
+	if deleted == 0 {
+		return errors.NotFound.New("webhook not found")
+	}
+
+	audit.RecordOrLog(ctx, w.audit, audit.Event{
+		Type:      audit.EventWebhookDeleted,
+		SubjectID: userID,
+		Metadata:  map[string]interface{}{"webhook_id": subscriptionID},
+	})
+
+	return nil
+}
+
+// ListDeliveries returns the delivery log of a subscription, newest first
+func (w *webhookUsecase) ListDeliveries(ctx context.Context, request request.ListWebhookDeliveriesRequest) ([]postgres.WebhookDelivery, int64, error) {
+	ctx, span := w.trace.Start(ctx, "webhookUsecase.ListDeliveries", trace.WithAttributes(
+		attribute.Int("user_id", int(request.UserID)),
+		attribute.Int("webhook_id", int(request.SubscriptionID)),
+	))
+	defer span.End()
+
+	if _, err := w.getOwnSubscription(ctx, request.UserID, request.SubscriptionID); err != nil {
+		return nil, 0, err
+	}
+
+	status := sql.NullString{String: request.Status, Valid: request.Status != ""}
+
+	total, err := w.repository.CountWebhookDeliveries(ctx, postgres.CountWebhookDeliveriesParams{
+		SubscriptionID: request.SubscriptionID,
+		Status:         status,
+	})
+	if err != nil {
+		logging.NewFromContext(ctx).Error("ListDeliveries failed to count deliveries", zap.Error(err))
+		return nil, 0, errors.InternalServer.NewWithUserMsg(err, "failed to list webhook deliveries")
+	}
+
+	deliveries, err := w.repository.ListWebhookDeliveries(ctx, postgres.ListWebhookDeliveriesParams{
+		SubscriptionID: request.SubscriptionID,
+		Status:         status,
+		RowLimit:       int32(request.Limit),
+		RowOffset:      int32(request.Offset),
+	})
+	if err != nil {
+		logging.NewFromContext(ctx).Error("ListDeliveries failed to list deliveries", zap.Error(err))
+		return nil, 0, errors.InternalServer.NewWithUserMsg(err, "failed to list webhook deliveries")
+	}
+
+	return deliveries, total, nil
+}
+
+// Redeliver queues a finished delivery again with a fresh retry budget, the
+// payload and event id are unchanged so the receiver can still dedupe it
+func (w *webhookUsecase) Redeliver(ctx context.Context, userID, subscriptionID int32, deliveryID int64) (postgres.WebhookDelivery, error) {
+	ctx, span := w.trace.Start(ctx, "webhookUsecase.Redeliver", trace.WithAttributes(
+		attribute.Int("user_id", int(userID)),
+		attribute.Int("webhook_id", int(subscriptionID)),
+		attribute.Int64("delivery_id", deliveryID),
+	))
+	defer span.End()
+
+	if _, err := w.getOwnSubscription(ctx, userID, subscriptionID); err != nil {
+		return postgres.WebhookDelivery{}, err
+	}
+
+	delivery, err := w.repository.GetWebhookDelivery(ctx, postgres.GetWebhookDeliveryParams{
+		ID:             deliveryID,
+		SubscriptionID: subscriptionID,
+	})
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return postgres.WebhookDelivery{}, errors.NotFound.NewWithUserMsg(err, "webhook delivery not found")
+		}
+		logging.NewFromContext(ctx).Error("Redeliver failed to get delivery", zap.Error(err))
+		return postgres.WebhookDelivery{}, errors.InternalServer.NewWithUserMsg(err, "failed to redeliver webhook")
+	}
+	if delivery.Status == StatusPending {
+		return postgres.WebhookDelivery{}, errors.BadRequest.New("webhook delivery is already pending")
+	}
+
+	delivery.Status = StatusPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now().UTC()
+	if err := w.repository.UpdateWebhookDeliveryAttempt(ctx, postgres.UpdateWebhookDeliveryAttemptParams{
+		ID:             delivery.ID,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+	}); err != nil {
+		logging.NewFromContext(ctx).Error("Redeliver failed to update delivery", zap.Error(err))
+		return postgres.WebhookDelivery{}, errors.InternalServer.NewWithUserMsg(err, "failed to redeliver webhook")
+	}
+
+	return delivery, nil
+}
+
+// EnqueueTx queues a delivery of each event for every matching subscription of its
+// user inside the caller transaction, so only committed changes are ever delivered
+func (w *webhookUsecase) EnqueueTx(ctx context.Context, tx *sql.Tx, events []stream.Event) error {
+	ctx, span := w.trace.Start(ctx, "webhookUsecase.EnqueueTx", trace.WithAttributes(
+		attribute.Int("events", len(events)),
+	))
+	defer span.End()
+
+	query := w.repository
+	if tx != nil {
+		query = w.repository.WithTx(tx)
+	}
+
+	for _, event := range events {
+		payload := Payload{
+			ID:        uuid.New(),
+			Type:      event.Type,
+			UserID:    event.UserID,
+			CreatedAt: event.CreatedAt,
+			Data:      event.Data,
+		}
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+
+		if _, err := query.CreateWebhookDeliveries(ctx, postgres.CreateWebhookDeliveriesParams{
+			EventID:   payload.ID,
+			EventType: string(event.Type),
+			Payload:   body,
+			CreatedAt: time.Now().UTC(),
+			UserID:    event.UserID,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *webhookUsecase) getOwnSubscription(ctx context.Context, userID, subscriptionID int32) (postgres.WebhookSubscription, error) {
+	subscription, err := w.repository.GetWebhookSubscriptionByID(ctx, subscriptionID)
+	if err != nil {
+		if goerrors.Is(err, sql.ErrNoRows) {
+			return subscription, errors.NotFound.NewWithUserMsg(err, "webhook not found")
+		}
+		logging.NewFromContext(ctx).Error("failed to get webhook subscription", zap.Error(err))
+		return subscription, errors.InternalServer.NewWithUserMsg(err, "failed to get webhook")
+	}
+	// someone else's subscription is reported as missing, its id isn't confirmed
+	if subscription.UserID != userID {
+		return postgres.WebhookSubscription{}, errors.NotFound.New("webhook not found")
+	}
+
+	return subscription, nil
+}
+
+func newSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return secretPrefix + hex.EncodeToString(secret), nil
+}
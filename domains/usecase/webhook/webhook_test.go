@@ -0,0 +1,389 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	mock_configuration "kc-ewallet/configurations/mocks"
+	mock_repository "kc-ewallet/domains/repository/mocks"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/repository/stream"
+	"kc-ewallet/internals/errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "whsec_test"
+
+func newTestUsecase(t *testing.T) (*webhookUsecase, *mock_repository.MockIRepository) {
+	ctrl := gomock.NewController(t)
+
+	config := mock_configuration.NewMockIWebhookConfiguration(ctrl)
+	config.EXPECT().GetMaxAttempts().Return(3).AnyTimes()
+	config.EXPECT().GetBackoffBase().Return(time.Minute).AnyTimes()
+	config.EXPECT().GetBackoffMax().Return(time.Hour).AnyTimes()
+	config.EXPECT().GetTimeout().Return(time.Second).AnyTimes()
+	config.EXPECT().GetPollInterval().Return(time.Second).AnyTimes()
+
+	repo := mock_repository.NewMockIRepository(ctrl)
+	usecase := NewWebhookUsecase(repo, config, nil, nil)
+	// the httptest receivers listen on loopback
+	usecase.client = newClient(time.Second, nil)
+	return usecase, repo
+}
+
+func TestSignature(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	now := time.Unix(1_700_000_000, 0)
+
+	signed := func(secret string, timestamp time.Time, signature string) http.Header {
+		header := http.Header{}
+		header.Set(TimestampHeader, strconv.FormatInt(timestamp.Unix(), 10))
+		if signature == "" {
+			signature = Sign(secret, timestamp.Unix(), body)
+		}
+		header.Set(SignatureHeader, signature)
+		return header
+	}
+
+	testCases := []struct {
+		name        string
+		header      http.Header
+		body        []byte
+		expectedErr error
+	}{
+		{name: "valid", header: signed(testSecret, now, ""), body: body},
+		{name: "one of the rotated secrets", header: signed(testSecret, now, Sign("whsec_old", now.Unix(), body)+" "+Sign(testSecret, now.Unix(), body)), body: body},
+		{name: "tampered body", header: signed(testSecret, now, ""), body: []byte(`{"id":"2"}`), expectedErr: ErrInvalidSignature},
+		{name: "other secret", header: signed("whsec_other", now, ""), body: body, expectedErr: ErrInvalidSignature},
+		{name: "replayed", header: signed(testSecret, now.Add(-10*time.Minute), ""), body: body, expectedErr: ErrTimestampTooOld},
+		{name: "missing", header: http.Header{}, body: body, expectedErr: ErrMissingSignature},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedErr, Verify(testSecret, tc.header, tc.body, DefaultTolerance, now))
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt, expected := range map[int32]time.Duration{
+		1:  30 * time.Second,
+		2:  time.Minute,
+		4:  4 * time.Minute,
+		10: time.Hour,
+	} {
+		assert.Equal(t, expected, Backoff(attempt, 30*time.Second, time.Hour), "attempt %d", attempt)
+	}
+}
+
+func TestWebhookUsecase_DeliverDue(t *testing.T) {
+	payload := json.RawMessage(`{"id":"3f0c","type":"BalanceChanged"}`)
+	eventID := uuid.New()
+
+	testCases := []struct {
+		name               string
+		handler            http.HandlerFunc
+		attempts           int32
+		expectedStatus     string
+		expectedAttempts   int32
+		expectedResponse   int32
+		expectedRetryAfter time.Duration
+		expectedError      string
+	}{
+		{
+			name: "should succeed on a 2xx",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			},
+			expectedStatus:   StatusSucceeded,
+			expectedAttempts: 1,
+			expectedResponse: http.StatusNoContent,
+		},
+		{
+			name: "should retry with backoff on a 5xx",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "maintenance", http.StatusServiceUnavailable)
+			},
+			attempts:           1,
+			expectedStatus:     StatusPending,
+			expectedAttempts:   2,
+			expectedResponse:   http.StatusServiceUnavailable,
+			expectedRetryAfter: 2 * time.Minute,
+			expectedError:      "unexpected status 503",
+		},
+		{
+			name: "should not follow redirects",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, "https://example.com", http.StatusFound)
+			},
+			expectedStatus:     StatusPending,
+			expectedAttempts:   1,
+			expectedResponse:   http.StatusFound,
+			expectedRetryAfter: time.Minute,
+			expectedError:      "unexpected status 302",
+		},
+		{
+			name: "should dead-letter after the last attempt",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			attempts:         2,
+			expectedStatus:   StatusDead,
+			expectedAttempts: 3,
+			expectedResponse: http.StatusInternalServerError,
+			expectedError:    "unexpected status 500",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			usecase, repo := newTestUsecase(t)
+
+			var received *http.Request
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				// every attempt is signed, whatever the receiver answers
+				assert.NoError(t, Verify(testSecret, r.Header, body, DefaultTolerance, time.Now()))
+				assert.JSONEq(t, string(payload), string(body))
+				received = r
+				tc.handler(w, r)
+			}))
+			defer receiver.Close()
+
+			repo.EXPECT().ClaimWebhookDeliveries(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, arg postgres.ClaimWebhookDeliveriesParams) ([]postgres.WebhookDelivery, error) {
+					assert.Equal(t, deliveryBatchSize, arg.BatchSize)
+					assert.True(t, arg.LeaseUntil.After(arg.Now))
+					return []postgres.WebhookDelivery{{
+						ID:             7,
+						SubscriptionID: 2,
+						EventID:        eventID,
+						EventType:      string(stream.EventBalanceChanged),
+						Payload:        payload,
+						Status:         StatusPending,
+						Attempts:       tc.attempts,
+					}}, nil
+				})
+			repo.EXPECT().GetWebhookSubscriptionByID(gomock.Any(), int32(2)).
+				Return(postgres.WebhookSubscription{ID: 2, UserID: 1, Url: receiver.URL, Secret: testSecret}, nil)
+
+			var recorded postgres.UpdateWebhookDeliveryAttemptParams
+			repo.EXPECT().UpdateWebhookDeliveryAttempt(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, arg postgres.UpdateWebhookDeliveryAttemptParams) error {
+					recorded = arg
+					return nil
+				})
+
+			claimed, err := usecase.deliverDue(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, 1, claimed)
+
+			require.NotNil(t, received)
+			assert.Equal(t, eventID.String(), received.Header.Get(IDHeader))
+			assert.Equal(t, string(stream.EventBalanceChanged), received.Header.Get(EventTypeHeader))
+
+			assert.Equal(t, int64(7), recorded.ID)
+			assert.Equal(t, tc.expectedStatus, recorded.Status)
+			assert.Equal(t, tc.expectedAttempts, recorded.Attempts)
+			assert.Equal(t, sql.NullInt32{Int32: tc.expectedResponse, Valid: true}, recorded.ResponseStatus)
+			assert.Contains(t, recorded.LastError, tc.expectedError)
+			assert.NotContains(t, recorded.LastError, "maintenance")
+			assert.True(t, recorded.LastAttemptAt.Valid)
+			assert.WithinDuration(t, recorded.LastAttemptAt.Time.Add(tc.expectedRetryAfter), recorded.NextAttemptAt, time.Second)
+		})
+	}
+}
+
+func TestWebhookUsecase_DeliverDue_UnreachableReceiver(t *testing.T) {
+	usecase, repo := newTestUsecase(t)
+
+	receiver := httptest.NewServer(http.NotFoundHandler())
+	receiver.Close()
+
+	repo.EXPECT().ClaimWebhookDeliveries(gomock.Any(), gomock.Any()).
+		Return([]postgres.WebhookDelivery{{ID: 1, SubscriptionID: 2, Payload: json.RawMessage(`{}`)}}, nil)
+	repo.EXPECT().GetWebhookSubscriptionByID(gomock.Any(), int32(2)).
+		Return(postgres.WebhookSubscription{ID: 2, Url: receiver.URL, Secret: testSecret}, nil)
+	repo.EXPECT().UpdateWebhookDeliveryAttempt(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, arg postgres.UpdateWebhookDeliveryAttemptParams) error {
+			assert.Equal(t, StatusPending, arg.Status)
+			assert.False(t, arg.ResponseStatus.Valid)
+			assert.NotEmpty(t, arg.LastError)
+			return nil
+		})
+
+	_, err := usecase.deliverDue(context.Background())
+	require.NoError(t, err)
+}
+
+func TestWebhookUsecase_DeliverDue_NonPublicReceiver(t *testing.T) {
+	usecase, repo := newTestUsecase(t)
+	usecase.client = newClient(time.Second, publicOnly)
+
+	var reached bool
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+	}))
+	defer receiver.Close()
+
+	repo.EXPECT().ClaimWebhookDeliveries(gomock.Any(), gomock.Any()).
+		Return([]postgres.WebhookDelivery{{ID: 1, SubscriptionID: 2, Payload: json.RawMessage(`{}`)}}, nil)
+	repo.EXPECT().GetWebhookSubscriptionByID(gomock.Any(), int32(2)).
+		Return(postgres.WebhookSubscription{ID: 2, Url: receiver.URL, Secret: testSecret}, nil)
+	repo.EXPECT().UpdateWebhookDeliveryAttempt(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, arg postgres.UpdateWebhookDeliveryAttemptParams) error {
+			assert.Equal(t, StatusPending, arg.Status)
+			assert.False(t, arg.ResponseStatus.Valid)
+			assert.Contains(t, arg.LastError, ErrNonPublicAddress.Error())
+			return nil
+		})
+
+	_, err := usecase.deliverDue(context.Background())
+	require.NoError(t, err)
+	assert.False(t, reached)
+}
+
+func TestIsPublicIP(t *testing.T) {
+	testCases := []struct {
+		ip       string
+		expected bool
+	}{
+		{ip: "93.184.216.34", expected: true},
+		{ip: "2606:2800:220:1:248:1893:25c8:1946", expected: true},
+		{ip: "127.0.0.1"},
+		{ip: "::1"},
+		{ip: "10.1.2.3"},
+		{ip: "172.16.0.1"},
+		{ip: "192.168.1.1"},
+		{ip: "169.254.169.254"},
+		{ip: "fe80::1"},
+		{ip: "fd00::1"},
+		{ip: "100.64.0.1"},
+		{ip: "0.0.0.0"},
+		{ip: "::ffff:127.0.0.1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.ip, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsPublicIP(net.ParseIP(tc.ip)))
+		})
+	}
+}
+
+func TestWebhookUsecase_CheckRelay(t *testing.T) {
+	testCases := []struct {
+		name        string
+		mock        func(repo *mock_repository.MockIRepository)
+		expectedErr bool
+	}{
+		{
+			name: "up when nothing is pending",
+			mock: func(repo *mock_repository.MockIRepository) {
+				repo.EXPECT().GetOldestDueWebhookDelivery(gomock.Any()).Return(time.Time{}, sql.ErrNoRows)
+			},
+		},
+		{
+			name: "up when the oldest delivery is due within a few polls",
+			mock: func(repo *mock_repository.MockIRepository) {
+				repo.EXPECT().GetOldestDueWebhookDelivery(gomock.Any()).Return(time.Now().UTC().Add(-5*time.Second), nil)
+			},
+		},
+		{
+			name: "down when the oldest delivery waits past the lag",
+			mock: func(repo *mock_repository.MockIRepository) {
+				repo.EXPECT().GetOldestDueWebhookDelivery(gomock.Any()).Return(time.Now().UTC().Add(-10*time.Minute), nil)
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			usecase, repo := newTestUsecase(t)
+			tc.mock(repo)
+
+			err := usecase.CheckRelay(context.Background())
+			assert.Equal(t, tc.expectedErr, err != nil)
+		})
+	}
+}
+
+func TestWebhookUsecase_Redeliver(t *testing.T) {
+	testCases := []struct {
+		name         string
+		owner        int32
+		status       string
+		expectUpdate bool
+		expectedErr  errors.ErrorType
+	}{
+		{name: "should queue a dead delivery again", owner: 1, status: StatusDead, expectUpdate: true},
+		{name: "should queue a succeeded delivery again", owner: 1, status: StatusSucceeded, expectUpdate: true},
+		{name: "should reject a pending delivery", owner: 1, status: StatusPending, expectedErr: errors.BadRequest},
+		{name: "should hide the deliveries of another user", owner: 9, expectedErr: errors.NotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			usecase, repo := newTestUsecase(t)
+
+			repo.EXPECT().GetWebhookSubscriptionByID(gomock.Any(), int32(2)).
+				Return(postgres.WebhookSubscription{ID: 2, UserID: tc.owner}, nil)
+			if tc.owner == 1 {
+				repo.EXPECT().GetWebhookDelivery(gomock.Any(), postgres.GetWebhookDeliveryParams{ID: 7, SubscriptionID: 2}).
+					Return(postgres.WebhookDelivery{ID: 7, SubscriptionID: 2, Status: tc.status, Attempts: 3, LastError: "unexpected status 500"}, nil)
+			}
+			if tc.expectUpdate {
+				repo.EXPECT().UpdateWebhookDeliveryAttempt(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, arg postgres.UpdateWebhookDeliveryAttemptParams) error {
+						assert.Equal(t, StatusPending, arg.Status)
+						assert.Equal(t, int32(0), arg.Attempts)
+						// the last outcome stays in the log until the next attempt
+						assert.Equal(t, "unexpected status 500", arg.LastError)
+						return nil
+					})
+			}
+
+			delivery, err := usecase.Redeliver(context.Background(), 1, 2, 7)
+			if tc.expectedErr != 0 {
+				assert.Equal(t, tc.expectedErr, errors.GetType(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, StatusPending, delivery.Status)
+		})
+	}
+}
+
+func TestWebhookUsecase_EnqueueTx(t *testing.T) {
+	usecase, repo := newTestUsecase(t)
+
+	event, err := stream.NewEvent(stream.EventTransactionCreated, 4, stream.TransactionCreatedData{TransactionID: 9, Type: "credit", Currency: "IDR", Amount: 50})
+	require.NoError(t, err)
+
+	repo.EXPECT().CreateWebhookDeliveries(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, arg postgres.CreateWebhookDeliveriesParams) (int64, error) {
+			assert.Equal(t, int32(4), arg.UserID)
+			assert.Equal(t, string(stream.EventTransactionCreated), arg.EventType)
+
+			var payload Payload
+			require.NoError(t, json.Unmarshal(arg.Payload, &payload))
+			assert.Equal(t, arg.EventID, payload.ID)
+			assert.Equal(t, stream.EventTransactionCreated, payload.Type)
+			assert.JSONEq(t, `{"transaction_id":9,"type":"credit","currency":"IDR","amount":50}`, string(payload.Data))
+			return 2, nil
+		})
+
+	require.NoError(t, usecase.EnqueueTx(context.Background(), nil, []stream.Event{event}))
+}
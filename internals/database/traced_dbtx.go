@@ -0,0 +1,115 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	goerrors "errors"
+	"regexp"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const defaultStatementName = "sql.query"
+
+// sqlc prefixes every generated statement with "-- name: <QueryName> :<kind>"
+var sqlcNameRe = regexp.MustCompile(`^--\s*name:\s*(\w+)`)
+
+// DBTX mirrors the interface sqlc generates, so anything accepted
+// by postgres.New can be wrapped here
+type DBTX interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+type tracedDBTX struct {
+	db     DBTX
+	tracer trace.Tracer
+}
+
+// NewTracedDBTX wraps db so every statement becomes a child span
+// of the caller span, named after the sqlc query
+func NewTracedDBTX(db DBTX, tracer trace.Tracer) DBTX {
+	if tracer == nil {
+		tracer = noop.NewTracerProvider().Tracer("")
+	}
+
+	return &tracedDBTX{
+		db:     db,
+		tracer: tracer,
+	}
+}
+
+func (t *tracedDBTX) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := t.start(ctx, query)
+	defer span.End()
+
+	result, err := t.db.ExecContext(ctx, query, args...)
+	recordError(span, err)
+	return result, err
+}
+
+func (t *tracedDBTX) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	ctx, span := t.start(ctx, query)
+	defer span.End()
+
+	stmt, err := t.db.PrepareContext(ctx, query)
+	recordError(span, err)
+	return stmt, err
+}
+
+func (t *tracedDBTX) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, span := t.start(ctx, query)
+	defer span.End()
+
+	rows, err := t.db.QueryContext(ctx, query, args...)
+	recordError(span, err)
+	return rows, err
+}
+
+func (t *tracedDBTX) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	ctx, span := t.start(ctx, query)
+	defer span.End()
+
+	row := t.db.QueryRowContext(ctx, query, args...)
+	recordError(span, row.Err())
+	return row
+}
+
+func (t *tracedDBTX) start(ctx context.Context, query string) (context.Context, trace.Span) {
+	name := StatementName(query)
+	return t.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemNamePostgreSQL,
+			semconv.DBOperationName(name),
+			semconv.DBQueryText(query),
+		),
+	)
+}
+
+// StatementName returns the sqlc query name of a statement,
+// or a generic name for hand written queries
+func StatementName(query string) string {
+	matches := sqlcNameRe.FindStringSubmatch(query)
+	if len(matches) < 2 {
+		return defaultStatementName
+	}
+	return matches[1]
+}
+
+// no rows is an expected outcome for lookups, don't flag it as a failed span
+func recordError(span trace.Span, err error) {
+	if err == nil || goerrors.Is(err, sql.ErrNoRows) {
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	span.SetAttributes(attribute.Bool("error", true))
+}
@@ -0,0 +1,31 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracedDBTX_SpanNamedAfterStatement(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := tracesdk.NewTracerProvider(tracesdk.WithSpanProcessor(recorder)).Tracer("test")
+
+	query := "-- name: UpdateUserBalanceByID :exec\nUPDATE users SET balance = $2 WHERE id = $1"
+	mock.ExpectExec("UPDATE users").WithArgs(1, 100.0).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	_, err = NewTracedDBTX(db, tracer).ExecContext(context.Background(), query, 1, 100.0)
+	assert.NoError(t, err)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "UpdateUserBalanceByID", spans[0].Name())
+	assert.Equal(t, defaultStatementName, StatementName("SELECT 1"))
+}
@@ -2,12 +2,10 @@ package errors
 
 import (
 	"fmt"
-	log_color "kc-ewallet/internals/helpers/color"
 	"net/http"
 	"runtime"
 	"strconv"
 
-	"github.com/getsentry/sentry-go"
 	"github.com/pkg/errors"
 )
 
@@ -41,49 +39,55 @@ type AppError struct {
 	originalError error
 	fields        map[string]ErrorMessages
 	stackTrace    []string
+	// report is set when the error must be reported, see Report
+	report *reportState
+	// code is the catalog code set with WithCode
+	code Code
+	// cause is the error NewWithUserMsg was given, logged but never shown to the user
+	cause error
 }
 
-// New creates a new AppError with formatted message
+// New creates a new AppError with formatted message,
+// it is reported when responded if the report filter allows its type
 func (errorType ErrorType) New(msg string, args ...interface{}) error {
-	log_color.PrintRed(fmt.Sprintf(msg, args...))
-	shouldReport := shouldReport(errorType)
 	appError := AppError{errorType: errorType, originalError: fmt.Errorf(msg, args...), stackTrace: []string{msg}}
-	if shouldReport {
-		appError.Report()
+	if shouldReport(errorType) {
+		appError.report = newReportState(captureStackTrace())
 	}
 
 	return appError
 }
 
-// NewWithUserMsg creates a new error with custom formatted user message
+// NewWithUserMsg creates a new error with custom formatted user message, err is kept as
+// its cause for the logs
 func (errorType ErrorType) NewWithUserMsg(err error, userMsg string, args ...interface{}) error {
 	appError := errorType.New("Error: %v", err)
 
-	return Msg(appError, userMsg, args...)
+	withMsg := Msg(appError, userMsg, args...).(AppError)
+	withMsg.cause = err
+	return withMsg
 }
 
-// NewAndReport creates a new AppError with formatted message and report
+// NewAndReport creates a new AppError with formatted message that is always reported
 func (errorType ErrorType) NewAndReport(msg string, args ...interface{}) error {
-	log_color.PrintRed(fmt.Sprintf(msg, args...))
 	appError := AppError{errorType: errorType, originalError: fmt.Errorf(msg, args...), stackTrace: []string{msg}}
-	appError.Report()
+	appError.report = newReportState(captureStackTrace())
 
 	return appError
 }
 
-func shouldReport(errorType ErrorType) bool {
-	statusCodeStr := strconv.Itoa(int(errorType))[:3] // Get first 3 digits
+// statusType returns the http status part of errorType, e.g. 422 for 42201
+func statusType(errorType ErrorType) ErrorType {
+	statusCodeStr := strconv.Itoa(int(errorType))
+	if len(statusCodeStr) > 3 {
+		statusCodeStr = statusCodeStr[:3] // Get first 3 digits
+	}
 	statusCode, err := strconv.Atoi(statusCodeStr)
 	if err != nil {
-		return true
+		return errorType
 	}
 
-	switch ErrorType(statusCode) {
-	case Unauthorized, Forbidden, NotFound, UnprocessableEntity, TooManyRequests:
-		return false
-	default:
-		return true
-	}
+	return ErrorType(statusCode)
 }
 
 // Error returns the mssage of a AppError
@@ -91,35 +95,27 @@ func (error AppError) Error() string {
 	return error.originalError.Error()
 }
 
-func (error AppError) Report() {
-	log_color.PrintRed("========== Error Stack Strace ==========")
+// captureStackTrace must be called directly by the error constructor,
+// it skips itself and the constructor and keeps the next 5 callers
+func captureStackTrace() []string {
 	var stackTrace []string
 	for i := 4; i < 9; i++ { // Skip 4 function, Get last 5 error trace
 		file, line, fnName := TraceCaller(i)
-		traceMsg := fmt.Sprintf("%s:%d@%s", file, line, fnName)
-		log_color.PrintYellow(traceMsg)
-		stackTrace = append(stackTrace, traceMsg)
+		stackTrace = append(stackTrace, fmt.Sprintf("%s:%d@%s", file, line, fnName))
 	}
-
-	sentry.ConfigureScope(func(scope *sentry.Scope) {
-		scope.SetExtra("stack_trace", stackTrace)
-	})
-	sentry.CaptureException(error)
+	return stackTrace
 }
 
-// New creates a no type error with formatted message and report to sentry
+// New creates a no type error with formatted message that is always reported
 func New(msg string, args ...interface{}) error {
-	log_color.PrintRed(fmt.Sprintf(msg, args...))
 	err := AppError{errorType: NoType, originalError: errors.New(fmt.Sprintf(msg, args...)), stackTrace: []string{msg}}
-
-	err.Report()
+	err.report = newReportState(captureStackTrace())
 
 	return err
 }
 
 // NewAndDontReport creates a new AppError and don't report it
 func NewAndDontReport(msg string, args ...interface{}) error {
-	log_color.PrintRed(fmt.Sprintf(msg, args...))
 	err := AppError{errorType: NoType, originalError: errors.New(fmt.Sprintf(msg, args...)), stackTrace: []string{msg}}
 
 	return err
@@ -135,6 +131,9 @@ func Msg(err error, msg string, args ...interface{}) error {
 			originalError: errors.New(formattedMsg),
 			fields:        appError.fields,
 			stackTrace:    append([]string{errorMsg}, appError.stackTrace...),
+			report:        appError.report,
+			code:          appError.code,
+			cause:         appError.cause,
 		}
 	}
 
@@ -150,6 +149,9 @@ func Wrap(err error, msg string, args ...interface{}) error {
 			originalError: wrappedError,
 			fields:        appError.fields,
 			stackTrace:    appError.stackTrace,
+			report:        appError.report,
+			code:          appError.code,
+			cause:         appError.cause,
 		}
 	}
 
@@ -165,13 +167,22 @@ func Cause(err error) error {
 func AddStackTrace(err error, msg string) error {
 	if appError, ok := err.(AppError); ok {
 		stackTrace := append([]string{msg}, appError.stackTrace...)
-		return AppError{errorType: appError.errorType, originalError: appError.originalError, fields: appError.fields, stackTrace: stackTrace}
+		return AppError{errorType: appError.errorType, originalError: appError.originalError, fields: appError.fields, stackTrace: stackTrace, report: appError.report, code: appError.code, cause: appError.cause}
 	}
 
 	stackTrace := append([]string{msg}, getOriginalErrorStackTrace(err)...)
 	return AppError{errorType: NoType, originalError: err, stackTrace: stackTrace}
 }
 
+// CauseOf returns the error an AppError was created from with NewWithUserMsg, nil when
+// there is none
+func CauseOf(err error) error {
+	if appError, ok := err.(AppError); ok {
+		return appError.cause
+	}
+	return nil
+}
+
 // GetStackTrace returns the error stack trace
 func GetStackTrace(err error) []string {
 	if appError, ok := err.(AppError); ok {
@@ -250,13 +261,6 @@ func IsNotFound(err error) bool {
 	return Is(err, NotFound)
 }
 
-func SetExtra(key string, value interface{}) {
-	// @TODO: Need to export to an interface!
-	sentry.ConfigureScope(func(scope *sentry.Scope) {
-		scope.SetExtra(key, value)
-	})
-}
-
 func TraceCaller(skip int) (file string, line int, fnName string) {
 	pc := make([]uintptr, 10) // at least 1 entry needed
 	runtime.Callers(skip, pc)
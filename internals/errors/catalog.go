@@ -0,0 +1,399 @@
+package errors
+
+import (
+	"fmt"
+	"kc-ewallet/internals/helpers/language"
+)
+
+// Code identifies an error in the catalog, clients switch on it instead of the message
+type Code string
+
+const (
+	CodeInternal               Code = "ER001"
+	CodeBadRequest             Code = "ER002"
+	CodeValidation             Code = "ER003"
+	CodeNotFound               Code = "ER004"
+	CodeForbidden              Code = "ER005"
+	CodeUnauthorized           Code = "ER006"
+	CodeTooManyRequests        Code = "ER007"
+	CodeSuspiciousActivity     Code = "ER008"
+	CodeServiceUnavailable     Code = "ER009"
+	CodeUserNotFound           Code = "ER010"
+	CodeUsernameTaken          Code = "ER011"
+	CodeInvalidCredentials     Code = "ER012"
+	CodeInsufficientFunds      Code = "ER013"
+	CodeActivityMarked         Code = "ER014"
+	CodeInvalidSignature       Code = "ER015"
+	CodeOrderAlreadyPaid       Code = "ER016"
+	CodeQuoteExpired           Code = "ER017"
+	CodeQuoteChanged           Code = "ER018"
+	CodeInvalidQuote           Code = "ER019"
+	CodeUnsupportedCurrency    Code = "ER020"
+	CodeWalletNotFound         Code = "ER021"
+	CodeInvalidAmountPrecision Code = "ER022"
+	CodeWalletExists           Code = "ER023"
+	CodeRateUnavailable        Code = "ER024"
+	CodePocketNotFound         Code = "ER025"
+	CodePocketExists           Code = "ER026"
+	CodeSavingsGoalNotFound    Code = "ER027"
+	CodeSavingsGoalExists      Code = "ER028"
+	CodeRecipientNotFound      Code = "ER029"
+	CodeScheduleNotFound       Code = "ER030"
+	CodePaymentRequestNotFound Code = "ER031"
+	CodePaymentRequestResolved Code = "ER032"
+	CodeBillNotFound           Code = "ER033"
+	CodeBillSharePaid          Code = "ER034"
+)
+
+// Redirect codes tell clients which screen to send the user to
+const (
+	RedirectNone            int8 = 0
+	RedirectLogin           int8 = 1
+	RedirectCustomerService int8 = 2
+)
+
+type CatalogEntry struct {
+	Code         Code
+	Type         ErrorType
+	RedirectCode int8
+	Messages     map[language.Language]string
+}
+
+// Message returns the message in lang, falling back to the default language
+func (e CatalogEntry) Message(lang language.Language) string {
+	if message, ok := e.Messages[lang]; ok {
+		return message
+	}
+	return e.Messages[language.Default]
+}
+
+var catalog = map[Code]CatalogEntry{
+	CodeInternal: {
+		Type: InternalServer,
+		Messages: map[language.Language]string{
+			language.English:    "Internal server error.",
+			language.Indonesian: "Terjadi kesalahan pada server.",
+		},
+	},
+	CodeBadRequest: {
+		Type: BadRequest,
+		Messages: map[language.Language]string{
+			language.English:    "Bad request.",
+			language.Indonesian: "Permintaan tidak valid.",
+		},
+	},
+	CodeValidation: {
+		Type: Validation,
+		Messages: map[language.Language]string{
+			language.English:    "Unprocessable entity error.",
+			language.Indonesian: "Data yang dikirim tidak valid.",
+		},
+	},
+	CodeNotFound: {
+		Type: NotFound,
+		Messages: map[language.Language]string{
+			language.English:    "Resource not found.",
+			language.Indonesian: "Data tidak ditemukan.",
+		},
+	},
+	CodeForbidden: {
+		Type: Forbidden,
+		Messages: map[language.Language]string{
+			language.English:    "You don't have access to this resource.",
+			language.Indonesian: "Anda tidak memiliki akses ke data ini.",
+		},
+	},
+	CodeUnauthorized: {
+		Type:         Unauthorized,
+		RedirectCode: RedirectLogin,
+		Messages: map[language.Language]string{
+			language.English:    "Please log in again.",
+			language.Indonesian: "Harap login kembali.",
+		},
+	},
+	CodeTooManyRequests: {
+		Type: TooManyRequests,
+		Messages: map[language.Language]string{
+			language.English:    "Too many requests, please try again later.",
+			language.Indonesian: "Terlalu banyak permintaan, silakan coba lagi nanti.",
+		},
+	},
+	CodeSuspiciousActivity: {
+		Type:         TooManyRequests,
+		RedirectCode: RedirectCustomerService,
+		Messages: map[language.Language]string{
+			language.English:    "Unusual activity detected, please contact Customer Service or try again later.",
+			language.Indonesian: "Aktivitas Anda terdeteksi tidak wajar, hubungi tim Customer Service atau coba lagi nanti.",
+		},
+	},
+	CodeActivityMarked: {
+		Type: TooManyRequests,
+		Messages: map[language.Language]string{
+			language.English:    "Unusual activity detected, please try again later.",
+			language.Indonesian: "Aktivitas Anda terdeteksi tidak wajar, mohon coba lagi nanti.",
+		},
+	},
+	CodeServiceUnavailable: {
+		Type: ServiceUnavailable,
+		Messages: map[language.Language]string{
+			language.English:    "Service is temporarily unavailable, please try again later.",
+			language.Indonesian: "Layanan sedang tidak tersedia, silakan coba lagi nanti.",
+		},
+	},
+	CodeUserNotFound: {
+		Type: NotFound,
+		Messages: map[language.Language]string{
+			language.English:    "User not found.",
+			language.Indonesian: "Pengguna tidak ditemukan.",
+		},
+	},
+	CodeUsernameTaken: {
+		Type: BadRequest,
+		Messages: map[language.Language]string{
+			language.English:    "Username already exists.",
+			language.Indonesian: "Username sudah digunakan.",
+		},
+	},
+	CodeInvalidCredentials: {
+		Type: Unauthorized,
+		Messages: map[language.Language]string{
+			language.English:    "Invalid username or password.",
+			language.Indonesian: "Username atau password salah.",
+		},
+	},
+	CodeInsufficientFunds: {
+		Type: BadRequest,
+		Messages: map[language.Language]string{
+			language.English:    "Insufficient funds.",
+			language.Indonesian: "Saldo tidak mencukupi.",
+		},
+	},
+	CodeInvalidSignature: {
+		Type: Unauthorized,
+		Messages: map[language.Language]string{
+			language.English:    "Invalid request signature.",
+			language.Indonesian: "Tanda tangan permintaan tidak valid.",
+		},
+	},
+	CodeOrderAlreadyPaid: {
+		Type: BadRequest,
+		Messages: map[language.Language]string{
+			language.English:    "This order has already been paid.",
+			language.Indonesian: "Pesanan ini sudah dibayar.",
+		},
+	},
+	CodeQuoteExpired: {
+		Type: BadRequest,
+		Messages: map[language.Language]string{
+			language.English:    "The quote has expired, please request a new one.",
+			language.Indonesian: "Penawaran sudah kedaluwarsa, silakan minta penawaran baru.",
+		},
+	},
+	CodeQuoteChanged: {
+		Type: BadRequest,
+		Messages: map[language.Language]string{
+			language.English:    "The conditions of the quote have changed, please request a new one.",
+			language.Indonesian: "Kondisi penawaran sudah berubah, silakan minta penawaran baru.",
+		},
+	},
+	CodeInvalidQuote: {
+		Type: BadRequest,
+		Messages: map[language.Language]string{
+			language.English:    "The quote is invalid or has already been used.",
+			language.Indonesian: "Penawaran tidak valid atau sudah digunakan.",
+		},
+	},
+	CodeUnsupportedCurrency: {
+		Type: BadRequest,
+		Messages: map[language.Language]string{
+			language.English:    "This currency is not supported.",
+			language.Indonesian: "Mata uang ini tidak didukung.",
+		},
+	},
+	CodeWalletNotFound: {
+		Type: NotFound,
+		Messages: map[language.Language]string{
+			language.English:    "You don't have a wallet in this currency.",
+			language.Indonesian: "Anda tidak memiliki dompet dalam mata uang ini.",
+		},
+	},
+	CodeInvalidAmountPrecision: {
+		Type: BadRequest,
+		Messages: map[language.Language]string{
+			language.English:    "The amount has more decimals than the currency allows.",
+			language.Indonesian: "Jumlah memiliki desimal lebih banyak dari yang diizinkan mata uang.",
+		},
+	},
+	CodeWalletExists: {
+		Type: BadRequest,
+		Messages: map[language.Language]string{
+			language.English:    "You already have a wallet in this currency.",
+			language.Indonesian: "Anda sudah memiliki dompet dalam mata uang ini.",
+		},
+	},
+	CodeRateUnavailable: {
+		Type: BadRequest,
+		Messages: map[language.Language]string{
+			language.English:    "Exchange between these currencies is not available right now.",
+			language.Indonesian: "Penukaran antara mata uang ini sedang tidak tersedia.",
+		},
+	},
+	CodePocketNotFound: {
+		Type: NotFound,
+		Messages: map[language.Language]string{
+			language.English:    "The pocket was not found.",
+			language.Indonesian: "Kantong tidak ditemukan.",
+		},
+	},
+	CodePocketExists: {
+		Type: BadRequest,
+		Messages: map[language.Language]string{
+			language.English:    "You already have a pocket with this name in this currency.",
+			language.Indonesian: "Anda sudah memiliki kantong dengan nama ini dalam mata uang ini.",
+		},
+	},
+	CodeSavingsGoalNotFound: {
+		Type: NotFound,
+		Messages: map[language.Language]string{
+			language.English:    "The savings goal was not found.",
+			language.Indonesian: "Target tabungan tidak ditemukan.",
+		},
+	},
+	CodeSavingsGoalExists: {
+		Type: BadRequest,
+		Messages: map[language.Language]string{
+			language.English:    "This pocket already has a savings goal.",
+			language.Indonesian: "Kantong ini sudah memiliki target tabungan.",
+		},
+	},
+	CodeRecipientNotFound: {
+		Type: NotFound,
+		Messages: map[language.Language]string{
+			language.English:    "The recipient has no wallet in this currency.",
+			language.Indonesian: "Penerima tidak memiliki dompet dalam mata uang ini.",
+		},
+	},
+	CodeScheduleNotFound: {
+		Type: NotFound,
+		Messages: map[language.Language]string{
+			language.English:    "The scheduled transfer was not found.",
+			language.Indonesian: "Transfer terjadwal tidak ditemukan.",
+		},
+	},
+	CodePaymentRequestNotFound: {
+		Type: NotFound,
+		Messages: map[language.Language]string{
+			language.English:    "The payment request was not found.",
+			language.Indonesian: "Permintaan pembayaran tidak ditemukan.",
+		},
+	},
+	CodePaymentRequestResolved: {
+		Type: BadRequest,
+		Messages: map[language.Language]string{
+			language.English:    "The payment request is no longer pending.",
+			language.Indonesian: "Permintaan pembayaran sudah tidak menunggu lagi.",
+		},
+	},
+	CodeBillNotFound: {
+		Type: NotFound,
+		Messages: map[language.Language]string{
+			language.English:    "The bill was not found.",
+			language.Indonesian: "Tagihan tidak ditemukan.",
+		},
+	},
+	CodeBillSharePaid: {
+		Type: BadRequest,
+		Messages: map[language.Language]string{
+			language.English:    "Your share of the bill is already paid.",
+			language.Indonesian: "Bagian tagihan Anda sudah dibayar.",
+		},
+	},
+}
+
+// typeCodes is the catalog entry used for an AppError created without a code
+var typeCodes = map[ErrorType]Code{
+	InternalServer:     CodeInternal,
+	BadRequest:         CodeBadRequest,
+	Validation:         CodeValidation,
+	NotFound:           CodeNotFound,
+	Forbidden:          CodeForbidden,
+	Unauthorized:       CodeUnauthorized,
+	TooManyRequests:    CodeTooManyRequests,
+	ServiceUnavailable: CodeServiceUnavailable,
+}
+
+func init() {
+	for code, entry := range catalog {
+		entry.Code = code
+		catalog[code] = entry
+	}
+}
+
+// Lookup returns the catalog entry of code
+func Lookup(code Code) (CatalogEntry, bool) {
+	entry, ok := catalog[code]
+	return entry, ok
+}
+
+// FromCatalog builds the ExtError of a catalog code, it panics on an unknown
+// code since ExtErrors are declared as package variables
+func FromCatalog(code Code) *CustomError {
+	entry, ok := catalog[code]
+	if !ok {
+		panic(fmt.Sprintf("error code %s is not in the catalog", code))
+	}
+
+	return newExtError(ExtErrorArg{
+		Code:         int(statusType(entry.Type)),
+		ErrCode:      string(code),
+		RedirectCode: entry.RedirectCode,
+		Err:          entry.Message(language.English),
+		IdMessage:    entry.Message(language.Indonesian),
+		EnMessage:    entry.Message(language.English),
+	})
+}
+
+// WithCode attaches a catalog code to an AppError, its catalog message becomes the user message
+func WithCode(err error, code Code) error {
+	appError, ok := err.(AppError)
+	if !ok {
+		appError = AppError{errorType: NoType, originalError: err, stackTrace: getOriginalErrorStackTrace(err)}
+	}
+	if entry, ok := catalog[code]; ok && appError.errorType == NoType {
+		appError.errorType = entry.Type
+	}
+
+	appError.code = code
+	return appError
+}
+
+// HasCode reports whether err carries an explicit catalog code
+func HasCode(err error) bool {
+	switch v := err.(type) {
+	case AppError:
+		return v.code != ""
+	case ExtError:
+		return v.GetErrCode() != ""
+	default:
+		return false
+	}
+}
+
+// CatalogEntryOf returns the entry of the error code, or the default entry of its type
+func CatalogEntryOf(err error) CatalogEntry {
+	var code Code
+	switch v := err.(type) {
+	case AppError:
+		code = v.code
+	case ExtError:
+		code = Code(v.GetErrCode())
+	}
+
+	if entry, ok := catalog[code]; ok {
+		return entry
+	}
+	if code, ok := typeCodes[statusType(GetType(err))]; ok {
+		return catalog[code]
+	}
+	return catalog[CodeInternal]
+}
@@ -0,0 +1,82 @@
+package errors
+
+import (
+	"kc-ewallet/internals/helpers/language"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalog_Complete(t *testing.T) {
+	for code, entry := range catalog {
+		assert.Equal(t, code, entry.Code)
+		assert.NotEmpty(t, entry.Messages[language.English], "english message of %s", code)
+		assert.NotEmpty(t, entry.Messages[language.Indonesian], "indonesian message of %s", code)
+	}
+	for errorType, code := range typeCodes {
+		_, ok := Lookup(code)
+		assert.True(t, ok, "default code of type %d", errorType)
+	}
+}
+
+func TestFromCatalog(t *testing.T) {
+	err := FromCatalog(CodeUnauthorized)
+
+	assert.Equal(t, string(CodeUnauthorized), err.GetErrCode())
+	assert.Equal(t, RedirectLogin, err.GetRedirectCode())
+	assert.Equal(t, "Harap login kembali.", err.GetIdMessage())
+	assert.Equal(t, 401, err.GetCode())
+
+	assert.Panics(t, func() { FromCatalog("ER999") })
+}
+
+func TestCatalogEntryOf(t *testing.T) {
+	testCases := []struct {
+		name         string
+		err          error
+		expectedCode Code
+		hasCode      bool
+	}{
+		{
+			name:         "coded app error",
+			err:          WithCode(NotFound.New("user not found"), CodeUserNotFound),
+			expectedCode: CodeUserNotFound,
+			hasCode:      true,
+		},
+		{
+			name:         "uncoded app error uses its type",
+			err:          BadRequest.New("bad input"),
+			expectedCode: CodeBadRequest,
+		},
+		{
+			name:         "plain error gets the code type",
+			err:          WithCode(assertError("no money"), CodeInsufficientFunds),
+			expectedCode: CodeInsufficientFunds,
+			hasCode:      true,
+		},
+		{
+			name:         "catalog ext error",
+			err:          FromCatalog(CodeSuspiciousActivity),
+			expectedCode: CodeSuspiciousActivity,
+			hasCode:      true,
+		},
+		{
+			name:         "unknown error is internal",
+			err:          assertError("boom"),
+			expectedCode: CodeInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedCode, CatalogEntryOf(tc.err).Code)
+			assert.Equal(t, tc.hasCode, HasCode(tc.err))
+		})
+	}
+
+	assert.Equal(t, BadRequest, GetType(WithCode(assertError("no money"), CodeInsufficientFunds)))
+}
+
+type assertError string
+
+func (e assertError) Error() string { return string(e) }
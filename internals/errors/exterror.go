@@ -70,7 +70,8 @@ func (c *CustomError) GetErrCode() string {
 	return c.errCode
 }
 
-func NewExtError(arg ExtErrorArg) *CustomError {
+// newExtError is unexported, ExtErrors are declared from the catalog with FromCatalog
+func newExtError(arg ExtErrorArg) *CustomError {
 	return &CustomError{
 		code:         arg.Code,
 		errCode:      arg.ErrCode,
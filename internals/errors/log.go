@@ -0,0 +1,28 @@
+package errors
+
+import (
+	"context"
+	"kc-ewallet/internals/helpers/logging"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+// Log writes a handled error to the context logger, so it carries the request id, with
+// its cause and stack trace. Server errors are logged as errors, the others as warnings
+func Log(ctx context.Context, err error) {
+	fields := []zap.Field{
+		zap.Error(err),
+		zap.Strings("stack_trace", GetStackTrace(err)),
+	}
+	if cause := CauseOf(err); cause != nil {
+		fields = append(fields, zap.NamedError("cause", cause))
+	}
+
+	logger := logging.NewFromContext(ctx)
+	if statusType(GetType(err)) >= http.StatusInternalServerError {
+		logger.Error("request failed", fields...)
+		return
+	}
+	logger.Warn("request failed", fields...)
+}
@@ -0,0 +1,58 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"kc-ewallet/internals/helpers/logging"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLog(t *testing.T) {
+	testCases := []struct {
+		name          string
+		err           error
+		expectedLevel zapcore.Level
+		expectedCause string
+	}{
+		{
+			name:          "server error is logged as an error with its cause",
+			err:           WithCode(InternalServer.NewWithUserMsg(fmt.Errorf("dial tcp: connection refused"), "failed to get wallet"), CodeInternal),
+			expectedLevel: zapcore.ErrorLevel,
+			expectedCause: "dial tcp: connection refused",
+		},
+		{
+			name:          "client error is logged as a warning",
+			err:           BadRequest.NewWithUserMsg(nil, "Insufficient funds"),
+			expectedLevel: zapcore.WarnLevel,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.DebugLevel)
+			restore := zap.ReplaceGlobals(zap.New(core))
+			defer restore()
+
+			Log(logging.WithRequestID(context.Background(), "request-1"), tc.err)
+
+			require.Equal(t, 1, logs.Len())
+			entry := logs.All()[0]
+			assert.Equal(t, tc.expectedLevel, entry.Level)
+
+			fields := entry.ContextMap()
+			assert.Equal(t, "request-1", fields[logging.RequestIDLogKey])
+			assert.Equal(t, tc.err.Error(), fields["error"])
+			if tc.expectedCause == "" {
+				assert.NotContains(t, fields, "cause")
+				return
+			}
+			assert.Equal(t, tc.expectedCause, fields["cause"])
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package errors
+
+import (
+	"context"
+	"sync"
+)
+
+// ErrorReporter sends reportable errors to a tracker, implementations must be safe for concurrent use
+type ErrorReporter interface {
+	Report(ctx context.Context, err error, extras map[string]interface{})
+}
+
+// ReportFilter decides which error types are reported
+type ReportFilter func(errorType ErrorType) bool
+
+var (
+	reporterMu   sync.RWMutex
+	reporter     ErrorReporter = NewLogReporter()
+	reportFilter ReportFilter  = IgnoreTypes(Unauthorized, Forbidden, NotFound, UnprocessableEntity, TooManyRequests)
+)
+
+// SetReporter replaces the reporter used by Report, set it once at startup
+func SetReporter(r ErrorReporter) {
+	reporterMu.Lock()
+	defer reporterMu.Unlock()
+
+	reporter = r
+}
+
+// SetReportFilter replaces which error types created with ErrorType.New are reported
+func SetReportFilter(filter ReportFilter) {
+	reporterMu.Lock()
+	defer reporterMu.Unlock()
+
+	reportFilter = filter
+}
+
+// IgnoreTypes reports every error type except the given ones,
+// extended types such as 42201 are matched on their first 3 digits
+func IgnoreTypes(types ...ErrorType) ReportFilter {
+	ignored := make(map[ErrorType]bool, len(types))
+	for _, t := range types {
+		ignored[t] = true
+	}
+
+	return func(errorType ErrorType) bool {
+		return !ignored[statusType(errorType)]
+	}
+}
+
+func shouldReport(errorType ErrorType) bool {
+	reporterMu.RLock()
+	defer reporterMu.RUnlock()
+
+	return reportFilter(errorType)
+}
+
+// Report sends err to the reporter with the request scope carried on ctx,
+// only errors marked reportable when created are sent, and each one only once
+func Report(ctx context.Context, err error) {
+	appError, ok := err.(AppError)
+	if !ok || appError.report == nil {
+		return
+	}
+	if !appError.report.markReported() {
+		return
+	}
+
+	extras := map[string]interface{}{
+		"stack_trace": appError.report.stackTrace,
+	}
+
+	reporterMu.RLock()
+	r := reporter
+	reporterMu.RUnlock()
+
+	r.Report(ctx, appError, extras)
+}
+
+// reportState is shared by every copy of an AppError, so wrapping with Msg
+// or Wrap keeps the original creation stack and reports the error once
+type reportState struct {
+	once       sync.Once
+	stackTrace []string
+}
+
+func newReportState(stackTrace []string) *reportState {
+	return &reportState{stackTrace: stackTrace}
+}
+
+func (r *reportState) markReported() bool {
+	reported := false
+	r.once.Do(func() {
+		reported = true
+	})
+	return reported
+}
@@ -0,0 +1,25 @@
+package errors
+
+import (
+	"context"
+	"kc-ewallet/internals/helpers/logging"
+
+	"go.uber.org/zap"
+)
+
+type logReporter struct{}
+
+// NewLogReporter writes reported errors to the context logger, used when no tracker is configured
+func NewLogReporter() ErrorReporter {
+	return logReporter{}
+}
+
+func (logReporter) Report(ctx context.Context, err error, extras map[string]interface{}) {
+	data := ScopeFromContext(ctx).Data()
+	logging.NewFromContext(ctx).Error("reported error",
+		zap.Error(err),
+		zap.Any("extras", extras),
+		zap.Any("scope_extras", data.Extras),
+		zap.Any("scope_tags", data.Tags),
+	)
+}
@@ -0,0 +1,48 @@
+package errors
+
+import (
+	"context"
+	"sync"
+)
+
+// Reported is one error captured by MemoryReporter
+type Reported struct {
+	Err    error
+	Extras map[string]interface{}
+	Scope  ScopeData
+}
+
+// MemoryReporter keeps reported errors in memory for tests
+type MemoryReporter struct {
+	mu      sync.Mutex
+	reports []Reported
+}
+
+func NewMemoryReporter() *MemoryReporter {
+	return &MemoryReporter{}
+}
+
+func (m *MemoryReporter) Report(ctx context.Context, err error, extras map[string]interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.reports = append(m.reports, Reported{
+		Err:    err,
+		Extras: extras,
+		Scope:  ScopeFromContext(ctx).Data(),
+	})
+}
+
+func (m *MemoryReporter) Reports() []Reported {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Reported(nil), m.reports...)
+}
+
+func (m *MemoryReporter) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.reports = nil
+}
@@ -0,0 +1,39 @@
+package errors
+
+import (
+	"context"
+
+	"github.com/getsentry/sentry-go"
+)
+
+type sentryReporter struct {
+	hub *sentry.Hub
+}
+
+// NewSentryReporter captures errors through a clone of hub per report, or the
+// hub carried on ctx, so scope data never leaks between concurrent requests
+func NewSentryReporter(hub *sentry.Hub) ErrorReporter {
+	if hub == nil {
+		hub = sentry.CurrentHub()
+	}
+
+	return &sentryReporter{hub: hub}
+}
+
+func (r *sentryReporter) Report(ctx context.Context, err error, extras map[string]interface{}) {
+	hub := sentry.GetHubFromContext(ctx)
+	if hub == nil {
+		hub = r.hub.Clone()
+	}
+
+	data := ScopeFromContext(ctx).Data()
+	hub.WithScope(func(scope *sentry.Scope) {
+		scope.SetTags(data.Tags)
+		scope.SetExtras(data.Extras)
+		scope.SetExtras(extras)
+		if data.UserID != "" {
+			scope.SetUser(sentry.User{ID: data.UserID})
+		}
+		hub.CaptureException(err)
+	})
+}
@@ -0,0 +1,113 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func useMemoryReporter(t *testing.T) *MemoryReporter {
+	memory := NewMemoryReporter()
+	SetReporter(memory)
+	t.Cleanup(func() {
+		SetReporter(NewLogReporter())
+		SetReportFilter(IgnoreTypes(Unauthorized, Forbidden, NotFound, UnprocessableEntity, TooManyRequests))
+	})
+	return memory
+}
+
+func TestReport(t *testing.T) {
+	testCases := []struct {
+		name          string
+		err           func() error
+		expectedCount int
+	}{
+		{
+			name:          "internal server error is reported",
+			err:           func() error { return InternalServer.New("database is down") },
+			expectedCount: 1,
+		},
+		{
+			name:          "not found is filtered",
+			err:           func() error { return NotFound.New("user not found") },
+			expectedCount: 0,
+		},
+		{
+			name:          "extended type is filtered on its status",
+			err:           func() error { return DefaultAppError.New("invalid pin") },
+			expectedCount: 0,
+		},
+		{
+			name:          "wrapped error keeps its report mark",
+			err:           func() error { return InternalServer.NewWithUserMsg(fmt.Errorf("timeout"), "failed to get user") },
+			expectedCount: 1,
+		},
+		{
+			name:          "not reported error",
+			err:           func() error { return NewAndDontReport("broken pipe") },
+			expectedCount: 0,
+		},
+		{
+			name:          "plain error",
+			err:           func() error { return fmt.Errorf("plain") },
+			expectedCount: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			memory := useMemoryReporter(t)
+
+			err := tc.err()
+			Report(context.Background(), err)
+			Report(context.Background(), err)
+
+			assert.Len(t, memory.Reports(), tc.expectedCount)
+		})
+	}
+}
+
+func TestReport_Filter(t *testing.T) {
+	memory := useMemoryReporter(t)
+	SetReportFilter(IgnoreTypes(InternalServer))
+
+	Report(context.Background(), InternalServer.New("ignored"))
+	Report(context.Background(), NotFound.New("reported"))
+
+	reports := memory.Reports()
+	assert.Len(t, reports, 1)
+	assert.Equal(t, "reported", reports[0].Err.Error())
+	assert.NotEmpty(t, reports[0].Extras["stack_trace"])
+}
+
+func TestReport_ScopePerRequest(t *testing.T) {
+	memory := useMemoryReporter(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			ctx := NewScopeContext(context.Background())
+			scope := ScopeFromContext(ctx)
+			scope.SetTag("request_id", fmt.Sprintf("request-%d", i))
+			scope.SetExtra("password", "secret")
+
+			Report(ctx, InternalServer.New("request %d failed", i))
+		}(i)
+	}
+	wg.Wait()
+
+	reports := memory.Reports()
+	assert.Len(t, reports, 10)
+	for _, report := range reports {
+		var i int
+		fmt.Sscanf(report.Err.Error(), "request %d failed", &i)
+		assert.Equal(t, fmt.Sprintf("request-%d", i), report.Scope.Tags["request_id"])
+		assert.Equal(t, "[REDACTED]", report.Scope.Extras["password"])
+	}
+}
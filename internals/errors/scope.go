@@ -0,0 +1,96 @@
+package errors
+
+import (
+	"context"
+	"kc-ewallet/internals/helpers/redact"
+	"sync"
+)
+
+// Scope is the per-request data attached to reported errors,
+// it lives on the request context so concurrent requests never share it
+type Scope struct {
+	mu     sync.Mutex
+	tags   map[string]string
+	extras map[string]interface{}
+	userID string
+}
+
+type scopeKey struct{}
+
+// NewScopeContext returns ctx carrying a fresh, empty scope
+func NewScopeContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, scopeKey{}, &Scope{
+		tags:   map[string]string{},
+		extras: map[string]interface{}{},
+	})
+}
+
+// ScopeFromContext returns the request scope, nil when ctx has none
+func ScopeFromContext(ctx context.Context) *Scope {
+	scope, _ := ctx.Value(scopeKey{}).(*Scope)
+	return scope
+}
+
+// SetExtra sets a redacted extra on the scope carried by ctx, no-op without one
+func SetExtra(ctx context.Context, key string, value interface{}) {
+	if scope := ScopeFromContext(ctx); scope != nil {
+		scope.SetExtra(key, value)
+	}
+}
+
+func (s *Scope) SetTag(key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tags[key] = value
+}
+
+// SetExtra stores value with credentials such as password or token masked
+func (s *Scope) SetExtra(key string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if redact.IsSensitive(key) {
+		s.extras[key] = redact.Mask
+		return
+	}
+	s.extras[key] = redact.Value(value)
+}
+
+func (s *Scope) SetUser(userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.userID = userID
+}
+
+// ScopeData is a copy of a scope taken at report time
+type ScopeData struct {
+	Tags   map[string]string
+	Extras map[string]interface{}
+	UserID string
+}
+
+// Data copies the scope, nil safe so reporters can call it on ScopeFromContext directly
+func (s *Scope) Data() ScopeData {
+	data := ScopeData{
+		Tags:   map[string]string{},
+		Extras: map[string]interface{}{},
+	}
+	if s == nil {
+		return data
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, value := range s.tags {
+		data.Tags[key] = value
+	}
+	for key, value := range s.extras {
+		data.Extras[key] = value
+	}
+	data.UserID = s.userID
+
+	return data
+}
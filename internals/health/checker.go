@@ -0,0 +1,29 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// SQLChecker pings the database pool
+func SQLChecker(db *sql.DB) CheckerFunc {
+	return func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	}
+}
+
+// RedisChecker borrows a connection from the pool and sends PING
+func RedisChecker(pool *redis.Pool) CheckerFunc {
+	return func(ctx context.Context) error {
+		conn, err := pool.GetContext(ctx)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		_, err = redis.DoContext(conn, ctx, "PING")
+		return err
+	}
+}
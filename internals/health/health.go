@@ -0,0 +1,234 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"kc-ewallet/internals/helpers/logging"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+type Criticality string
+
+const (
+	// Critical checks make the service not ready when they fail
+	Critical Criticality = "critical"
+	// NonCritical checks only degrade the report
+	NonCritical Criticality = "non_critical"
+
+	StatusUp       = "up"
+	StatusDown     = "down"
+	StatusReady    = "ready"
+	StatusDegraded = "degraded"
+	StatusNotReady = "not_ready"
+	StatusDraining = "draining"
+
+	DefaultCheckTimeout = 2 * time.Second
+	DefaultCacheTTL     = 5 * time.Second
+
+	// the report is public, the raw check error is only logged since it may carry DSNs and hostnames
+	messageCheckFailed   = "check failed"
+	messageCheckTimedOut = "check timed out"
+)
+
+// CheckerFunc returns nil when the dependency is healthy
+type CheckerFunc func(ctx context.Context) error
+
+type check struct {
+	name        string
+	checker     CheckerFunc
+	timeout     time.Duration
+	criticality Criticality
+}
+
+type CheckResult struct {
+	Status      string      `json:"status"`
+	Criticality Criticality `json:"criticality"`
+	Message     string      `json:"message,omitempty"`
+	LatencyMs   int64       `json:"latency_ms"`
+}
+
+type Report struct {
+	Status    string                 `json:"status"`
+	CheckedAt time.Time              `json:"checked_at"`
+	Checks    map[string]CheckResult `json:"checks"`
+}
+
+// IsReady reports whether every critical check passed and the service is not shutting down
+func (r Report) IsReady() bool {
+	return r.Status != StatusNotReady && r.Status != StatusDraining
+}
+
+type Registry struct {
+	mu       sync.Mutex
+	checks   []check
+	cacheTTL time.Duration
+	last     *Report
+	draining bool
+	// version changes with the registered checks, a report run on older checks is not cached
+	version int
+	// refresh collapses the checks of concurrent probes missing the cache into one run
+	refresh singleflight.Group
+}
+
+func NewRegistry(cacheTTL time.Duration) *Registry {
+	if cacheTTL <= 0 {
+		cacheTTL = DefaultCacheTTL
+	}
+
+	return &Registry{
+		cacheTTL: cacheTTL,
+	}
+}
+
+// Register adds a named checker, a zero timeout falls back to DefaultCheckTimeout
+func (r *Registry) Register(name string, criticality Criticality, timeout time.Duration, checker CheckerFunc) {
+	if timeout <= 0 {
+		timeout = DefaultCheckTimeout
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.checks = append(r.checks, check{
+		name:        name,
+		checker:     checker,
+		timeout:     timeout,
+		criticality: criticality,
+	})
+	r.version++
+	r.last = nil
+}
+
+// SetDraining fails readiness right away, without waiting for the cache to expire
+func (r *Registry) SetDraining(draining bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.draining = draining
+}
+
+// Report runs every check in parallel, results are cached for the registry TTL
+// so frequent probes don't hammer the dependencies. The lock is only held to read
+// the checks and store the report, a slow check must not block concurrent probes.
+// Concurrent probes missing the cache share one run, detached from their context so
+// a probe that disconnects or times out can't cache its cancellation as failed checks
+func (r *Registry) Report(ctx context.Context) Report {
+	r.mu.Lock()
+	if r.draining {
+		r.mu.Unlock()
+		return drainingReport()
+	}
+	if r.last != nil && time.Since(r.last.CheckedAt) < r.cacheTTL {
+		last := *r.last
+		r.mu.Unlock()
+		return last
+	}
+	r.mu.Unlock()
+
+	report, _, _ := r.refresh.Do("report", func() (interface{}, error) {
+		return r.runChecks(context.WithoutCancel(ctx)), nil
+	})
+	return report.(Report)
+}
+
+func (r *Registry) runChecks(ctx context.Context) Report {
+	r.mu.Lock()
+	checks := make([]check, len(r.checks))
+	copy(checks, r.checks)
+	version := r.version
+	r.mu.Unlock()
+
+	report := Report{
+		Status:    StatusReady,
+		CheckedAt: time.Now(),
+		Checks:    make(map[string]CheckResult, len(checks)),
+	}
+
+	var (
+		wg      sync.WaitGroup
+		results = make([]CheckResult, len(checks))
+	)
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c check) {
+			defer wg.Done()
+			results[i] = run(ctx, c)
+		}(i, c)
+	}
+	wg.Wait()
+
+	for i, c := range checks {
+		result := results[i]
+		report.Checks[c.name] = result
+
+		if result.Status == StatusUp {
+			continue
+		}
+		if c.criticality == Critical {
+			report.Status = StatusNotReady
+		} else if report.Status == StatusReady {
+			report.Status = StatusDegraded
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// draining may have started while the checks ran
+	if r.draining {
+		return drainingReport()
+	}
+	// a concurrent probe may have stored a fresher report in the meantime
+	if version == r.version && (r.last == nil || r.last.CheckedAt.Before(report.CheckedAt)) {
+		r.last = &report
+	}
+	return report
+}
+
+func drainingReport() Report {
+	return Report{
+		Status:    StatusDraining,
+		CheckedAt: time.Now(),
+		Checks:    map[string]CheckResult{},
+	}
+}
+
+func run(ctx context.Context, c check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var (
+		start  = time.Now()
+		result = CheckResult{Status: StatusUp, Criticality: c.criticality}
+		done   = make(chan error, 1)
+	)
+
+	// checkers that ignore the context must not block the probe past its timeout
+	go func() {
+		done <- c.checker(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		logging.NewFromContext(ctx).Warn("health check failed", zap.String("check", c.name), zap.Error(err))
+
+		result.Status = StatusDown
+		result.Message = messageCheckFailed
+		if errors.Is(err, context.DeadlineExceeded) {
+			result.Message = messageCheckTimedOut
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,171 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Report(t *testing.T) {
+	testCases := []struct {
+		name           string
+		register       func(r *Registry)
+		expectedStatus string
+	}{
+		{
+			name: "ready when every check passes",
+			register: func(r *Registry) {
+				r.Register("postgres", Critical, 0, func(ctx context.Context) error { return nil })
+			},
+			expectedStatus: StatusReady,
+		},
+		{
+			name: "degraded when non critical check fails",
+			register: func(r *Registry) {
+				r.Register("postgres", Critical, 0, func(ctx context.Context) error { return nil })
+				r.Register("relay", NonCritical, 0, func(ctx context.Context) error { return errors.New("down") })
+			},
+			expectedStatus: StatusDegraded,
+		},
+		{
+			name: "not ready when critical check times out",
+			register: func(r *Registry) {
+				r.Register("redis", Critical, 10*time.Millisecond, func(ctx context.Context) error {
+					time.Sleep(time.Second)
+					return nil
+				})
+			},
+			expectedStatus: StatusNotReady,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			registry := NewRegistry(time.Minute)
+			tc.register(registry)
+
+			report := registry.Report(context.Background())
+			assert.Equal(t, tc.expectedStatus, report.Status)
+			assert.Equal(t, tc.expectedStatus != StatusNotReady, report.IsReady())
+		})
+	}
+}
+
+func TestRegistry_ReportIsCached(t *testing.T) {
+	var calls int32
+	registry := NewRegistry(time.Minute)
+	registry.Register("postgres", Critical, 0, func(ctx context.Context) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+
+	registry.Report(context.Background())
+	registry.Report(context.Background())
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRegistry_SetDraining(t *testing.T) {
+	registry := NewRegistry(time.Minute)
+	registry.Register("postgres", Critical, 0, func(ctx context.Context) error { return nil })
+
+	assert.True(t, registry.Report(context.Background()).IsReady())
+
+	registry.SetDraining(true)
+
+	report := registry.Report(context.Background())
+	assert.Equal(t, StatusDraining, report.Status)
+	assert.False(t, report.IsReady())
+}
+
+func TestRegistry_SlowCheckDoesNotBlockProbes(t *testing.T) {
+	release := make(chan struct{})
+	registry := NewRegistry(time.Minute)
+	registry.Register("postgres", Critical, time.Second, func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+
+	go registry.Report(context.Background())
+	// let the first probe start its checks
+	time.Sleep(20 * time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		registry.SetDraining(true)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(200 * time.Millisecond):
+		t.Fatal("draining blocked behind a running check")
+	}
+	assert.Equal(t, StatusDraining, registry.Report(context.Background()).Status)
+	close(release)
+}
+
+func TestRegistry_CancelledProbeIsNotCached(t *testing.T) {
+	registry := NewRegistry(time.Minute)
+	registry.Register("postgres", Critical, time.Second, func(ctx context.Context) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(20 * time.Millisecond):
+			return nil
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.Equal(t, StatusReady, registry.Report(ctx).Status)
+	assert.Equal(t, StatusReady, registry.Report(context.Background()).Status)
+}
+
+func TestRegistry_ConcurrentProbesShareOneRun(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	registry := NewRegistry(time.Minute)
+	registry.Register("postgres", Critical, time.Second, func(ctx context.Context) error {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, StatusReady, registry.Report(context.Background()).Status)
+		}()
+	}
+	// let every probe join the running check
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRegistry_ReportHidesCheckErrors(t *testing.T) {
+	registry := NewRegistry(time.Minute)
+	registry.Register("postgres", Critical, 0, func(ctx context.Context) error {
+		return errors.New("dial tcp db.internal:5432: password authentication failed for user ewallet")
+	})
+	registry.Register("redis", NonCritical, 10*time.Millisecond, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	report := registry.Report(context.Background())
+
+	assert.Equal(t, CheckResult{Status: StatusDown, Criticality: Critical, Message: messageCheckFailed, LatencyMs: report.Checks["postgres"].LatencyMs}, report.Checks["postgres"])
+	assert.Equal(t, messageCheckTimedOut, report.Checks["redis"].Message)
+}
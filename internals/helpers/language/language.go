@@ -0,0 +1,66 @@
+package language
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Language string
+
+const (
+	English    Language = "en"
+	Indonesian Language = "id"
+
+	Default = English
+)
+
+var supported = map[string]Language{
+	"en": English,
+	"id": Indonesian,
+	// "in" is the deprecated ISO 639 code still sent by older android clients
+	"in": Indonesian,
+}
+
+// FromAcceptLanguage picks the supported language with the highest quality
+// from an Accept-Language header, e.g. "id-ID,id;q=0.9,en;q=0.8", falling back to Default
+func FromAcceptLanguage(header string) Language {
+	type candidate struct {
+		language Language
+		quality  float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+
+		language, ok := supported[primary]
+		if !ok {
+			continue
+		}
+
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality <= 0 {
+			continue
+		}
+
+		candidates = append(candidates, candidate{language: language, quality: quality})
+	}
+
+	if len(candidates) == 0 {
+		return Default
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+	return candidates[0].language
+}
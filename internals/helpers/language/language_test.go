@@ -0,0 +1,30 @@
+package language
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromAcceptLanguage(t *testing.T) {
+	testCases := []struct {
+		name     string
+		header   string
+		expected Language
+	}{
+		{name: "empty header falls back to default", header: "", expected: Default},
+		{name: "region subtag is ignored", header: "id-ID", expected: Indonesian},
+		{name: "deprecated indonesian code", header: "in", expected: Indonesian},
+		{name: "highest quality wins", header: "en;q=0.5,id;q=0.9", expected: Indonesian},
+		{name: "unsupported languages are skipped", header: "fr-FR,de;q=0.9,id;q=0.1", expected: Indonesian},
+		{name: "zero quality is not acceptable", header: "id;q=0,en;q=0.1", expected: English},
+		{name: "invalid quality is skipped", header: "id;q=abc,en;q=0.3", expected: English},
+		{name: "nothing supported falls back to default", header: "ja,ko", expected: Default},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, FromAcceptLanguage(tc.header))
+		})
+	}
+}
@@ -6,8 +6,12 @@ import (
 	"os"
 	"time"
 
+	"kc-ewallet/internals/helpers/operation"
+	"kc-ewallet/internals/helpers/redact"
+
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	lumberjack "gopkg.in/natefinch/lumberjack.v2"
@@ -16,6 +20,9 @@ import (
 var (
 	LogFileMaxSize int    = 500 // in megabytes
 	LogFilePath    string = ""  // path to log file, set as early as possible
+
+	// Level is shared by every logger built with New, changing it takes effect at runtime
+	Level zap.AtomicLevel = zap.NewAtomicLevel()
 )
 
 const (
@@ -28,10 +35,18 @@ const (
 	StacktraceKey   string = "stacktrace"
 	ErrorsKey       string = "errors"
 	RequestIDLogKey string = "request_id"
+	TraceIDLogKey   string = "trace_id"
+	SpanIDLogKey    string = "span_id"
+	UserIDLogKey    string = "user_id"
+	OperationLogKey string = "operation"
 )
 
 type RequestIDKey struct{}
 
+type userIDKey struct{}
+
+type fieldsKey struct{}
+
 func NewCustomEncoderConfig() zapcore.Encoder {
 	encoderConfig := zap.NewProductionEncoderConfig()
 	encoderConfig.TimeKey = TimeKey
@@ -62,11 +77,65 @@ func GetRequestIDFromContext(ctx context.Context) string {
 	return uuid.New().String()
 }
 
+// WithRequestID carries the request id for every log written with the returned context
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, RequestIDKey{}, requestID)
+}
+
+// WithUserID carries the authenticated user for every log written with the returned context
+func WithUserID(ctx context.Context, userID int32) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// WithFields carries extra fields for every log written with the returned context
+func WithFields(ctx context.Context, fields ...zap.Field) context.Context {
+	existing, _ := ctx.Value(fieldsKey{}).([]zap.Field)
+	merged := make([]zap.Field, 0, len(existing)+len(fields))
+	merged = append(merged, existing...)
+	merged = append(merged, fields...)
+	return context.WithValue(ctx, fieldsKey{}, merged)
+}
+
+// NewFromContext returns the global logger annotated with the request id,
+// trace and span ids, user id, operation name and fields carried on ctx
 func NewFromContext(ctx context.Context) *zap.Logger {
-	requestID := GetRequestIDFromContext(ctx)
-	return zap.L().WithOptions(zap.Fields(
-		zap.String(RequestIDLogKey, requestID),
-	))
+	c := ctx
+	if gc, ok := ctx.(*gin.Context); ok {
+		c = gc.Request.Context()
+	}
+
+	fields := []zap.Field{
+		zap.String(RequestIDLogKey, GetRequestIDFromContext(c)),
+	}
+
+	if spanContext := trace.SpanContextFromContext(c); spanContext.IsValid() {
+		fields = append(fields,
+			zap.String(TraceIDLogKey, spanContext.TraceID().String()),
+			zap.String(SpanIDLogKey, spanContext.SpanID().String()),
+		)
+	}
+	if userID, ok := c.Value(userIDKey{}).(int32); ok {
+		fields = append(fields, zap.Int32(UserIDLogKey, userID))
+	}
+	if operationName := operation.GetOperationName(c); operationName != "" {
+		fields = append(fields, zap.String(OperationLogKey, operationName))
+	}
+	if extra, ok := c.Value(fieldsKey{}).([]zap.Field); ok {
+		fields = append(fields, extra...)
+	}
+
+	return zap.L().With(fields...)
+}
+
+// SetLevel changes the level of every logger built with New, e.g. "debug" or "warn"
+func SetLevel(level string) error {
+	parsed, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	Level.SetLevel(parsed)
+	return nil
 }
 
 func New() *zap.Logger {
@@ -80,11 +149,12 @@ func New() *zap.Logger {
 		logOutput = zapcore.NewMultiWriteSyncer(os.Stdout, logFile)
 	}
 
-	core := zapcore.NewCore(
+	// sensitive fields are masked before reaching stdout or the log file
+	core := redact.NewZapCore(zapcore.NewCore(
 		NewCustomEncoderConfig(),
 		logOutput,
-		zapcore.InfoLevel,
-	)
+		Level,
+	))
 
 	// logger configured to give timestamp, caller, request id, and stacktrace (on error)
 	host, err := os.Hostname()
@@ -0,0 +1,52 @@
+package logging
+
+import (
+	"context"
+	"testing"
+
+	"kc-ewallet/internals/helpers/operation"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestNewFromContext(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	restore := zap.ReplaceGlobals(zap.New(core))
+	defer restore()
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1},
+		SpanID:  trace.SpanID{2},
+	})
+
+	ctx := WithRequestID(context.Background(), "request-1")
+	ctx = WithUserID(ctx, 7)
+	ctx = operation.SetOperationName(ctx, "/api/v1/transactions/credit")
+	ctx = trace.ContextWithSpanContext(ctx, spanContext)
+	ctx = WithFields(ctx, zap.String("transaction_type", "credit"))
+
+	NewFromContext(ctx).Error("failed")
+
+	fields := logs.All()[0].ContextMap()
+	assert.Equal(t, "request-1", fields[RequestIDLogKey])
+	assert.Equal(t, int32(7), fields[UserIDLogKey])
+	assert.Equal(t, "/api/v1/transactions/credit", fields[OperationLogKey])
+	assert.Equal(t, spanContext.TraceID().String(), fields[TraceIDLogKey])
+	assert.Equal(t, spanContext.SpanID().String(), fields[SpanIDLogKey])
+	assert.Equal(t, "credit", fields["transaction_type"])
+}
+
+func TestSetLevel(t *testing.T) {
+	defer Level.SetLevel(zapcore.InfoLevel)
+
+	assert.NoError(t, SetLevel("debug"))
+	assert.Equal(t, zapcore.DebugLevel, Level.Level())
+	assert.Equal(t, "DEBUG", SlogLeveler{}.Level().String())
+
+	assert.Error(t, SetLevel("verbose"))
+	assert.Equal(t, zapcore.DebugLevel, Level.Level())
+}
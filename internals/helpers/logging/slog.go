@@ -0,0 +1,23 @@
+package logging
+
+import (
+	"log/slog"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// SlogLeveler keeps slog handlers on the same runtime level as zap
+type SlogLeveler struct{}
+
+func (SlogLeveler) Level() slog.Level {
+	switch level := Level.Level(); {
+	case level <= zapcore.DebugLevel:
+		return slog.LevelDebug
+	case level == zapcore.InfoLevel:
+		return slog.LevelInfo
+	case level == zapcore.WarnLevel:
+		return slog.LevelWarn
+	default:
+		return slog.LevelError
+	}
+}
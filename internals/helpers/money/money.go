@@ -0,0 +1,34 @@
+package money
+
+import "math"
+
+// Round rounds the amount half away from zero to the minor units of its currency
+func Round(amount float64, minorUnits int16) float64 {
+	scale := math.Pow10(int(minorUnits))
+	return math.Round(amount*scale) / scale
+}
+
+// HasPrecision reports whether the amount fits the minor units of its currency,
+// 10.005 doesn't fit a currency with 2 minor units
+func HasPrecision(amount float64, minorUnits int16) bool {
+	scale := math.Pow10(int(minorUnits))
+	// tolerate the binary representation error of the decimal input
+	return math.Abs(amount*scale-math.Round(amount*scale)) < 1e-6
+}
+
+// Split divides the amount into parts as equal as its minor units allow, the minor
+// units left over go one each to the first parts so they always add up to the amount
+func Split(amount float64, parts int, minorUnits int16) []float64 {
+	scale := math.Pow10(int(minorUnits))
+	total := int64(math.Round(amount * scale))
+
+	shares := make([]float64, parts)
+	for i := range shares {
+		share := total / int64(parts)
+		if int64(i) < total%int64(parts) {
+			share++
+		}
+		shares[i] = float64(share) / scale
+	}
+	return shares
+}
@@ -0,0 +1,28 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRound(t *testing.T) {
+	assert.Equal(t, 10.01, Round(10.005, 2))
+	assert.Equal(t, 1235.0, Round(1234.5, 0))
+	assert.Equal(t, 0.123, Round(0.1234, 3))
+}
+
+func TestHasPrecision(t *testing.T) {
+	assert.True(t, HasPrecision(10.25, 2))
+	assert.True(t, HasPrecision(0.1+0.2, 2))
+	assert.False(t, HasPrecision(10.005, 2))
+	assert.True(t, HasPrecision(1500, 0))
+	assert.False(t, HasPrecision(1500.5, 0))
+	assert.True(t, HasPrecision(1.125, 3))
+}
+
+func TestSplit(t *testing.T) {
+	assert.Equal(t, []float64{33.34, 33.33, 33.33}, Split(100, 3, 2))
+	assert.Equal(t, []float64{500, 500}, Split(1000, 2, 0))
+	assert.Equal(t, []float64{3, 2, 2}, Split(7, 3, 0))
+}
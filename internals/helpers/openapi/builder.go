@@ -0,0 +1,239 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Route documents one registered gin route, Request and Response are
+// example values whose types are reflected into schemas
+type Route struct {
+	Method      string
+	Path        string
+	Summary     string
+	Description string
+	Tag         string
+	// Secured routes require the bearer access token
+	Secured bool
+	// Security names a scheme added with Builder.SecurityScheme, used instead of the bearer token
+	Security string
+	// Query is a struct whose `form` tags are the query parameters
+	Query interface{}
+	// Request is the JSON request body
+	Request interface{}
+	// Response is the success body, ResponseType defaults to JSON
+	Response     interface{}
+	ResponseType string
+	// Errors documents the error body, usually response.ErrorResponse
+	Errors interface{}
+}
+
+func (r Route) key() string {
+	return r.Method + " " + r.Path
+}
+
+// Builder collects route documentation and renders it against the routes
+// actually registered on the router
+type Builder struct {
+	info    Info
+	tags    []Tag
+	schemes map[string]SecurityScheme
+	routes  map[string]Route
+}
+
+func NewBuilder(info Info) *Builder {
+	return &Builder{
+		info: info,
+		schemes: map[string]SecurityScheme{
+			BearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+		},
+		routes: map[string]Route{},
+	}
+}
+
+// Tag describes a group of operations
+func (b *Builder) Tag(name, description string) *Builder {
+	b.tags = append(b.tags, Tag{Name: name, Description: description})
+	return b
+}
+
+// SecurityScheme describes an authentication routes can name in Route.Security
+func (b *Builder) SecurityScheme(name string, scheme SecurityScheme) *Builder {
+	b.schemes[name] = scheme
+	return b
+}
+
+// Document adds route documentation, documenting a route twice panics
+// since it is always a copy paste mistake
+func (b *Builder) Document(routes ...Route) *Builder {
+	for _, route := range routes {
+		if _, ok := b.routes[route.key()]; ok {
+			panic(fmt.Sprintf("route %s is documented twice", route.key()))
+		}
+		b.routes[route.key()] = route
+	}
+	return b
+}
+
+// Build renders the document of the registered routes. Routes registered without
+// documentation are returned as undocumented, documentation without a registered
+// route is returned as stale, neither ends up in the document
+func (b *Builder) Build(registered gin.RoutesInfo) (document *Document, undocumented gin.RoutesInfo, stale []Route) {
+	document = &Document{
+		OpenAPI: Version,
+		Info:    b.info,
+		Tags:    b.tags,
+		Paths:   map[string]map[string]Operation{},
+		Components: Components{
+			SecuritySchemes: b.schemes,
+		},
+	}
+
+	matched := map[string]bool{}
+	for _, info := range registered {
+		route, ok := b.routes[info.Method+" "+info.Path]
+		if !ok {
+			undocumented = append(undocumented, info)
+			continue
+		}
+		matched[route.key()] = true
+
+		path := ginPathToOpenAPI(route.Path)
+		if document.Paths[path] == nil {
+			document.Paths[path] = map[string]Operation{}
+		}
+		document.Paths[path][strings.ToLower(route.Method)] = b.operation(route, info)
+	}
+
+	for key, route := range b.routes {
+		if !matched[key] {
+			stale = append(stale, route)
+		}
+	}
+	sort.Slice(stale, func(i, j int) bool { return stale[i].key() < stale[j].key() })
+
+	return document, undocumented, stale
+}
+
+func (b *Builder) operation(route Route, info gin.RouteInfo) Operation {
+	operation := Operation{
+		OperationID: operationID(info.Handler),
+		Summary:     route.Summary,
+		Description: route.Description,
+		Parameters:  pathParameters(route.Path),
+		Responses:   map[string]Response{},
+	}
+	if route.Tag != "" {
+		operation.Tags = []string{route.Tag}
+	}
+	switch {
+	case route.Security != "":
+		operation.Security = []map[string][]string{{route.Security: {}}}
+	case route.Secured:
+		operation.Security = []map[string][]string{{BearerAuth: {}}}
+	}
+	if route.Query != nil {
+		operation.Parameters = append(operation.Parameters, queryParameters(route.Query)...)
+	}
+	if route.Request != nil {
+		operation.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{JSONContentType: {Schema: SchemaOf(route.Request)}},
+		}
+	}
+
+	success := Response{Description: http.StatusText(http.StatusOK)}
+	if route.Response != nil || route.ResponseType != "" {
+		contentType := route.ResponseType
+		if contentType == "" {
+			contentType = JSONContentType
+		}
+		var schema *Schema
+		if contentType == JSONContentType {
+			schema = SchemaOf(route.Response)
+		} else {
+			schema = &Schema{Type: "string"}
+		}
+		success.Content = map[string]MediaType{contentType: {Schema: schema}}
+	}
+	operation.Responses[fmt.Sprint(http.StatusOK)] = success
+
+	if route.Errors != nil {
+		operation.Responses["default"] = Response{
+			Description: "Error",
+			Content:     map[string]MediaType{JSONContentType: {Schema: SchemaOf(route.Errors)}},
+		}
+	}
+
+	return operation
+}
+
+// ginPathToOpenAPI converts gin parameters, /users/:id and /files/*path, into /users/{id} and /files/{path}
+func ginPathToOpenAPI(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func pathParameters(path string) []Parameter {
+	var parameters []Parameter
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			parameters = append(parameters, Parameter{
+				Name:     segment[1:],
+				In:       "path",
+				Required: true,
+				Schema:   &Schema{Type: "string"},
+			})
+		}
+	}
+	return parameters
+}
+
+func queryParameters(query interface{}) []Parameter {
+	t := reflect.TypeOf(query)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var parameters []Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("form"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		schema := schemaOf(reflect.Value{}, field.Type, map[reflect.Type]bool{})
+		parameters = append(parameters, Parameter{
+			Name:     name,
+			In:       "query",
+			Required: applyBinding(schema, field.Tag.Get("binding")),
+			Schema:   schema,
+		})
+	}
+	return parameters
+}
+
+// operationID derives the id from the handler name, e.g.
+// kc-ewallet/protocols/http/controller.(*UserController).Login-fm becomes Login
+func operationID(handler string) string {
+	name := strings.TrimSuffix(handler, "-fm")
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	if strings.HasPrefix(name, "func") {
+		// anonymous handlers have no meaningful name
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,102 @@
+package openapi
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type base struct {
+	Status string `json:"status"`
+}
+
+type envelope struct {
+	base
+	Data interface{} `json:"data"`
+}
+
+type item struct {
+	ID        int32      `json:"id" binding:"required"`
+	Amount    float64    `json:"amount" binding:"required,gt=0,lte=1000"`
+	Currency  string     `json:"currency" binding:"oneof=IDR USD"`
+	Email     string     `json:"email,omitempty" binding:"email"`
+	Tags      []string   `json:"tags" binding:"max=3"`
+	DeletedAt *time.Time `json:"deleted_at"`
+	Secret    string     `json:"secret" redact:"true"`
+	Ignored   string     `json:"-"`
+	internal  string
+}
+
+func TestSchemaOf(t *testing.T) {
+	schema := SchemaOf(envelope{Data: []item{}})
+
+	assert.Equal(t, "object", schema.Type)
+	assert.Contains(t, schema.Properties, "status", "embedded fields are flattened")
+
+	data := schema.Properties["data"]
+	assert.Equal(t, "array", data.Type)
+
+	element := data.Items
+	assert.ElementsMatch(t, []string{"id", "amount"}, element.Required)
+	assert.NotContains(t, element.Properties, "Ignored")
+	assert.NotContains(t, element.Properties, "internal")
+
+	assert.Equal(t, "int32", element.Properties["id"].Format)
+	assert.Equal(t, 0.0, *element.Properties["amount"].Minimum)
+	assert.True(t, element.Properties["amount"].ExclusiveMinimum)
+	assert.Equal(t, 1000.0, *element.Properties["amount"].Maximum)
+	assert.Equal(t, []interface{}{"IDR", "USD"}, element.Properties["currency"].Enum)
+	assert.Equal(t, "email", element.Properties["email"].Format)
+	assert.Equal(t, 3, *element.Properties["tags"].MaxItems)
+	assert.Equal(t, "date-time", element.Properties["deleted_at"].Format)
+	assert.True(t, element.Properties["deleted_at"].Nullable)
+	assert.True(t, element.Properties["secret"].WriteOnly)
+}
+
+type pageQuery struct {
+	Page  int    `form:"page" binding:"required,min=1"`
+	Order string `form:"order"`
+}
+
+func TestBuilder_Build(t *testing.T) {
+	builder := NewBuilder(Info{Title: "test", Version: "1"}).
+		SecurityScheme("apiKey", SecurityScheme{Type: "apiKey", Name: "X-Api-Key", In: "header"}).
+		Document(
+			Route{Method: http.MethodGet, Path: "/items/:id", Query: pageQuery{}, Response: item{}, Secured: true},
+			Route{Method: http.MethodDelete, Path: "/items/:id"},
+			Route{Method: http.MethodGet, Path: "/items", Security: "apiKey"},
+		)
+
+	document, undocumented, stale := builder.Build(gin.RoutesInfo{
+		{Method: http.MethodGet, Path: "/items/:id", Handler: "kc-ewallet/controller.(*ItemController).GetItem-fm"},
+		{Method: http.MethodGet, Path: "/items"},
+		{Method: http.MethodPost, Path: "/items"},
+	})
+
+	assert.Len(t, undocumented, 1)
+	assert.Equal(t, "/items", undocumented[0].Path)
+	assert.Len(t, stale, 1)
+	assert.Equal(t, http.MethodDelete, stale[0].Method)
+
+	operation := document.Paths["/items/{id}"]["get"]
+	assert.Equal(t, "GetItem", operation.OperationID)
+	assert.Equal(t, []Parameter{
+		{Name: "id", In: "path", Required: true, Schema: &Schema{Type: "string"}},
+		{Name: "page", In: "query", Required: true, Schema: &Schema{Type: "integer", Format: "int64", Minimum: float64Ptr(1)}},
+		{Name: "order", In: "query", Schema: &Schema{Type: "string"}},
+	}, operation.Parameters)
+	assert.Equal(t, []map[string][]string{{BearerAuth: {}}}, operation.Security)
+
+	assert.Equal(t, []map[string][]string{{"apiKey": {}}}, document.Paths["/items"]["get"].Security)
+	assert.Contains(t, document.Components.SecuritySchemes, "apiKey")
+	assert.Contains(t, document.Components.SecuritySchemes, BearerAuth)
+
+	assert.Panics(t, func() {
+		builder.Document(Route{Method: http.MethodGet, Path: "/items/:id"})
+	})
+}
+
+func float64Ptr(f float64) *float64 { return &f }
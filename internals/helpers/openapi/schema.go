@@ -0,0 +1,248 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	rawJSONType   = reflect.TypeOf(json.RawMessage{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// SchemaOf reflects the schema of v from its json tags, validation rules are
+// read from the gin `binding` tags so the documented constraints are the enforced ones.
+// Interface fields are described by the value they hold, which lets an envelope
+// such as response.StandardResponse document its data
+func SchemaOf(v interface{}) *Schema {
+	if v == nil {
+		return &Schema{}
+	}
+	return schemaOf(reflect.ValueOf(v), reflect.TypeOf(v), map[reflect.Type]bool{})
+}
+
+func schemaOf(value reflect.Value, t reflect.Type, seen map[reflect.Type]bool) *Schema {
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+	if t == rawJSONType {
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		var elem reflect.Value
+		if value.IsValid() && !value.IsNil() {
+			elem = value.Elem()
+		}
+		schema := schemaOf(elem, t.Elem(), seen)
+		schema.Nullable = true
+		return schema
+	case reflect.Interface:
+		if value.IsValid() && !value.IsNil() {
+			return schemaOf(value.Elem(), value.Elem().Type(), seen)
+		}
+		return &Schema{}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		var elem reflect.Value
+		if value.IsValid() && value.Len() > 0 {
+			elem = value.Index(0)
+		}
+		return &Schema{Type: "array", Items: schemaOf(elem, t.Elem(), seen)}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: schemaOf(reflect.Value{}, t.Elem(), seen)}
+	case reflect.Struct:
+		if t.Implements(marshalerType) || reflect.PtrTo(t).Implements(marshalerType) {
+			// custom marshalers (null.String, decimal...) are documented by their underlying value
+			return marshalerSchema(t)
+		}
+		if seen[t] {
+			// recursive types are cut at the second visit
+			return &Schema{Type: "object"}
+		}
+		seen[t] = true
+		defer delete(seen, t)
+
+		schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+		structFields(value, t, schema, seen)
+		return schema
+	default:
+		return &Schema{}
+	}
+}
+
+func structFields(value reflect.Value, t reflect.Type, schema *Schema, seen map[reflect.Type]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		var fieldValue reflect.Value
+		if value.IsValid() {
+			fieldValue = value.Field(i)
+		}
+
+		name, omitted := jsonName(field)
+		if omitted {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+				if fieldValue.IsValid() && !fieldValue.IsNil() {
+					fieldValue = fieldValue.Elem()
+				} else {
+					fieldValue = reflect.Value{}
+				}
+			}
+			if embedded.Kind() == reflect.Struct {
+				structFields(fieldValue, embedded, schema, seen)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := schemaOf(fieldValue, field.Type, seen)
+		if field.Tag.Get("redact") == "true" {
+			property.WriteOnly = true
+		}
+		if applyBinding(property, field.Tag.Get("binding")) {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = property
+	}
+}
+
+// jsonName returns the json name of a field, omitted is true for `json:"-"`
+func jsonName(field reflect.StructField) (name string, omitted bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	name, _, _ = strings.Cut(tag, ",")
+	return name, false
+}
+
+// applyBinding maps the validator rules onto the schema and reports whether the field is required
+func applyBinding(schema *Schema, binding string) (required bool) {
+	if binding == "" {
+		return false
+	}
+
+	for _, rule := range strings.Split(binding, ",") {
+		tag, param, _ := strings.Cut(rule, "=")
+		switch tag {
+		case "required":
+			required = true
+		case "min", "gte":
+			setLowerBound(schema, param, false)
+		case "gt":
+			setLowerBound(schema, param, true)
+		case "max", "lte":
+			setUpperBound(schema, param, false)
+		case "lt":
+			setUpperBound(schema, param, true)
+		case "len":
+			setLowerBound(schema, param, false)
+			setUpperBound(schema, param, false)
+		case "oneof":
+			for _, option := range strings.Fields(param) {
+				schema.Enum = append(schema.Enum, option)
+			}
+		case "email":
+			schema.Format = "email"
+		case "uuid", "uuid3", "uuid4", "uuid5":
+			schema.Format = "uuid"
+		case "url":
+			schema.Format = "uri"
+		case "datetime":
+			schema.Format = "date-time"
+		}
+	}
+
+	return required
+}
+
+func setLowerBound(schema *Schema, param string, exclusive bool) {
+	switch schema.Type {
+	case "string":
+		if n, err := strconv.Atoi(param); err == nil {
+			schema.MinLength = &n
+		}
+	case "array":
+		if n, err := strconv.Atoi(param); err == nil {
+			schema.MinItems = &n
+		}
+	case "integer", "number":
+		if n, err := strconv.ParseFloat(param, 64); err == nil {
+			schema.Minimum = &n
+			schema.ExclusiveMinimum = exclusive
+		}
+	}
+}
+
+func setUpperBound(schema *Schema, param string, exclusive bool) {
+	switch schema.Type {
+	case "string":
+		if n, err := strconv.Atoi(param); err == nil {
+			schema.MaxLength = &n
+		}
+	case "array":
+		if n, err := strconv.Atoi(param); err == nil {
+			schema.MaxItems = &n
+		}
+	case "integer", "number":
+		if n, err := strconv.ParseFloat(param, 64); err == nil {
+			schema.Maximum = &n
+			schema.ExclusiveMaximum = exclusive
+		}
+	}
+}
+
+// marshalerSchema describes a json.Marshaler struct by marshaling its zero value
+func marshalerSchema(t reflect.Type) *Schema {
+	raw, err := json.Marshal(reflect.New(t).Interface())
+	if err != nil {
+		return &Schema{}
+	}
+
+	var zero interface{}
+	if err := json.Unmarshal(raw, &zero); err != nil {
+		return &Schema{}
+	}
+
+	switch zero.(type) {
+	case string:
+		return &Schema{Type: "string"}
+	case float64:
+		return &Schema{Type: "number"}
+	case bool:
+		return &Schema{Type: "boolean"}
+	default:
+		// null.* types marshal their zero value as null
+		return &Schema{Nullable: true}
+	}
+}
@@ -0,0 +1,261 @@
+package redact
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// Mask replaces every redacted value
+const Mask = "[REDACTED]"
+
+// TagName marks a struct field as sensitive regardless of its name, e.g. `redact:"true"`
+const TagName = "redact"
+
+// DefaultKeys is the field denylist, keys are matched per word so
+// "new_password", "accessToken" and "X-Api-Key" are all redacted
+var DefaultKeys = []string{
+	"password",
+	"passwd",
+	"pin",
+	"token",
+	"otp",
+	"secret",
+	"apikey",
+	"authorization",
+	"cookie",
+}
+
+var sensitiveKeys = toSet(DefaultKeys)
+
+func toSet(keys []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		set[strings.ToLower(key)] = struct{}{}
+	}
+	return set
+}
+
+// IsSensitive reports whether a field, header or query key is on the denylist
+func IsSensitive(key string) bool {
+	words := splitWords(key)
+	for i, word := range words {
+		if _, ok := sensitiveKeys[word]; ok {
+			return true
+		}
+		// adjacent words cover split keys like "api_key"
+		if i > 0 {
+			if _, ok := sensitiveKeys[words[i-1]+word]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// splitWords lowercases key and splits it on separators and camelCase boundaries
+func splitWords(key string) []string {
+	var (
+		words []string
+		word  []rune
+		prev  rune
+	)
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = word[:0]
+		}
+	}
+
+	for _, r := range key {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && unicode.IsLower(prev):
+			flush()
+			word = append(word, unicode.ToLower(r))
+		default:
+			word = append(word, unicode.ToLower(r))
+		}
+		prev = r
+	}
+	flush()
+
+	return words
+}
+
+// Map returns a copy of m with sensitive keys masked, nested maps and slices included
+func Map(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+
+	redacted := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		if IsSensitive(key) {
+			redacted[key] = Mask
+			continue
+		}
+		redacted[key] = Value(value)
+	}
+	return redacted
+}
+
+// JSON decodes a request body and masks its sensitive fields,
+// bodies that are not a JSON object are dropped instead of logged raw
+func JSON(body []byte) map[string]interface{} {
+	var params map[string]interface{}
+	if err := json.Unmarshal(body, &params); err != nil {
+		return nil
+	}
+	return Map(params)
+}
+
+// Headers returns a copy of header with sensitive headers such as Authorization and Cookie masked
+func Headers(header http.Header) http.Header {
+	redacted := make(http.Header, len(header))
+	for key, values := range header {
+		if IsSensitive(key) {
+			redacted[key] = []string{Mask}
+			continue
+		}
+		redacted[key] = append([]string(nil), values...)
+	}
+	return redacted
+}
+
+// Query returns a copy of values with sensitive parameters masked
+func Query(values url.Values) url.Values {
+	redacted := make(url.Values, len(values))
+	for key, value := range values {
+		if IsSensitive(key) {
+			redacted[key] = []string{Mask}
+			continue
+		}
+		redacted[key] = append([]string(nil), value...)
+	}
+	return redacted
+}
+
+// RawQuery masks sensitive parameters of an encoded query string,
+// a query that fails to parse is dropped
+func RawQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return ""
+	}
+	return Query(values).Encode()
+}
+
+// Value masks sensitive fields of maps and structs, structs are converted to
+// maps keyed by their json name and fields tagged `redact:"true"` are masked
+func Value(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return Map(v)
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, item := range v {
+			redacted[i] = Value(item)
+		}
+		return redacted
+	case http.Header:
+		return Headers(v)
+	case url.Values:
+		return Query(v)
+	}
+
+	return reflectValue(reflect.ValueOf(value))
+}
+
+func reflectValue(rv reflect.Value) interface{} {
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		return reflectStruct(rv)
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return rv.Interface()
+		}
+		redacted := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			key := iter.Key().String()
+			if IsSensitive(key) {
+				redacted[key] = Mask
+				continue
+			}
+			redacted[key] = reflectValue(iter.Value())
+		}
+		return redacted
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return nil
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return rv.Interface()
+		}
+		redacted := make([]interface{}, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			redacted[i] = reflectValue(rv.Index(i))
+		}
+		return redacted
+	}
+
+	if !rv.CanInterface() {
+		return nil
+	}
+	return rv.Interface()
+}
+
+func reflectStruct(rv reflect.Value) interface{} {
+	rt := rv.Type()
+
+	// opaque structs such as time.Time are logged as is
+	if _, ok := rv.Interface().(json.Marshaler); ok {
+		return rv.Interface()
+	}
+
+	redacted := make(map[string]interface{}, rt.NumField())
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			jsonName := strings.Split(tag, ",")[0]
+			if jsonName == "-" {
+				continue
+			}
+			if jsonName != "" {
+				name = jsonName
+			}
+		}
+
+		if field.Tag.Get(TagName) == "true" || IsSensitive(name) {
+			redacted[name] = Mask
+			continue
+		}
+		redacted[name] = reflectValue(rv.Field(i))
+	}
+	return redacted
+}
@@ -0,0 +1,94 @@
+package redact
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestIsSensitive(t *testing.T) {
+	testCases := []struct {
+		key      string
+		expected bool
+	}{
+		{key: "password", expected: true},
+		{key: "new_password", expected: true},
+		{key: "accessToken", expected: true},
+		{key: "X-Api-Key", expected: true},
+		{key: "Authorization", expected: true},
+		{key: "Set-Cookie", expected: true},
+		{key: "pin", expected: true},
+		{key: "shipping", expected: false},
+		{key: "username", expected: false},
+		{key: "amount", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsSensitive(tc.key))
+		})
+	}
+}
+
+func TestJSON(t *testing.T) {
+	params := JSON([]byte(`{"username":"john","password":"secret123","device":{"otp":"123456","os":"ios"}}`))
+
+	assert.Equal(t, map[string]interface{}{
+		"username": "john",
+		"password": Mask,
+		"device": map[string]interface{}{
+			"otp": Mask,
+			"os":  "ios",
+		},
+	}, params)
+	assert.Nil(t, JSON([]byte("password=secret123")))
+}
+
+func TestValue_StructTag(t *testing.T) {
+	type request struct {
+		Username string `json:"username"`
+		Secret   string `json:"phrase" redact:"true"`
+		Ignored  string `json:"-"`
+	}
+
+	redacted := Value(&request{Username: "john", Secret: "open sesame", Ignored: "x"})
+
+	assert.Equal(t, map[string]interface{}{
+		"username": "john",
+		"phrase":   Mask,
+	}, redacted)
+}
+
+func TestHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer abc")
+	header.Set("Cookie", "session=abc")
+	header.Set("Content-Type", "application/json")
+
+	redacted := Headers(header)
+
+	assert.Equal(t, Mask, redacted.Get("Authorization"))
+	assert.Equal(t, Mask, redacted.Get("Cookie"))
+	assert.Equal(t, "application/json", redacted.Get("Content-Type"))
+	assert.Equal(t, "Bearer abc", header.Get("Authorization"))
+}
+
+func TestRawQuery(t *testing.T) {
+	assert.Equal(t, "page=1&token=%5BREDACTED%5D", RawQuery("token=abc&page=1"))
+}
+
+func TestZapCore(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(NewZapCore(core)).With(zap.String("pin", "123456"))
+
+	logger.Info("login", zap.String("password", "secret123"), zap.Any("body", map[string]interface{}{"token": "abc", "username": "john"}))
+
+	fields := logs.All()[0].ContextMap()
+	assert.Equal(t, Mask, fields["pin"])
+	assert.Equal(t, Mask, fields["password"])
+	assert.Equal(t, map[string]interface{}{"token": Mask, "username": "john"}, fields["body"])
+}
@@ -0,0 +1,53 @@
+package redact
+
+import (
+	"encoding/json"
+
+	"github.com/getsentry/sentry-go"
+)
+
+// SentryBeforeSend is a sentry.ClientOptions BeforeSend scrubbing the
+// request and extras of an event before it leaves the process
+func SentryBeforeSend(event *sentry.Event, hint *sentry.EventHint) *sentry.Event {
+	if event.Request != nil {
+		headers := make(map[string]string, len(event.Request.Headers))
+		for key, value := range event.Request.Headers {
+			if IsSensitive(key) {
+				value = Mask
+			}
+			headers[key] = value
+		}
+		event.Request.Headers = headers
+		event.Request.QueryString = RawQuery(event.Request.QueryString)
+		if event.Request.Cookies != "" {
+			event.Request.Cookies = Mask
+		}
+		if event.Request.Data != "" {
+			event.Request.Data = redactData(event.Request.Data)
+		}
+	}
+
+	for key, value := range event.Extra {
+		if IsSensitive(key) {
+			event.Extra[key] = Mask
+			continue
+		}
+		event.Extra[key] = Value(value)
+	}
+
+	return event
+}
+
+// redactData masks a JSON body field by field, anything else is masked whole
+func redactData(data string) string {
+	params := JSON([]byte(data))
+	if params == nil {
+		return Mask
+	}
+
+	redacted, err := json.Marshal(params)
+	if err != nil {
+		return Mask
+	}
+	return string(redacted)
+}
@@ -0,0 +1,14 @@
+package redact
+
+import "log/slog"
+
+// SlogReplaceAttr is a slog.HandlerOptions ReplaceAttr masking sensitive attributes
+func SlogReplaceAttr(groups []string, attr slog.Attr) slog.Attr {
+	if IsSensitive(attr.Key) {
+		return slog.String(attr.Key, Mask)
+	}
+	if attr.Value.Kind() == slog.KindAny {
+		return slog.Any(attr.Key, Value(attr.Value.Any()))
+	}
+	return attr
+}
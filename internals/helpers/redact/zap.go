@@ -0,0 +1,46 @@
+package redact
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type zapCore struct {
+	zapcore.Core
+}
+
+// NewZapCore wraps core so sensitive fields are masked before they are encoded
+func NewZapCore(core zapcore.Core) zapcore.Core {
+	return &zapCore{Core: core}
+}
+
+func (c *zapCore) With(fields []zapcore.Field) zapcore.Core {
+	return &zapCore{Core: c.Core.With(ZapFields(fields))}
+}
+
+func (c *zapCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *zapCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(entry, ZapFields(fields))
+}
+
+// ZapFields returns fields with sensitive keys masked and reflected values redacted
+func ZapFields(fields []zapcore.Field) []zapcore.Field {
+	redacted := make([]zapcore.Field, len(fields))
+	for i, field := range fields {
+		switch {
+		case IsSensitive(field.Key):
+			redacted[i] = zap.String(field.Key, Mask)
+		case field.Type == zapcore.ReflectType:
+			redacted[i] = zap.Any(field.Key, Value(field.Interface))
+		default:
+			redacted[i] = field
+		}
+	}
+	return redacted
+}
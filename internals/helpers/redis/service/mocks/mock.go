@@ -0,0 +1,205 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRedisServiceInterface is a mock of RedisServiceInterface interface.
+type MockRedisServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRedisServiceInterfaceMockRecorder
+}
+
+// MockRedisServiceInterfaceMockRecorder is the mock recorder for MockRedisServiceInterface.
+type MockRedisServiceInterfaceMockRecorder struct {
+	mock *MockRedisServiceInterface
+}
+
+// NewMockRedisServiceInterface creates a new mock instance.
+func NewMockRedisServiceInterface(ctrl *gomock.Controller) *MockRedisServiceInterface {
+	mock := &MockRedisServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockRedisServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRedisServiceInterface) EXPECT() *MockRedisServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Acquire mocks base method.
+func (m *MockRedisServiceInterface) Acquire(key string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Acquire", key)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Acquire indicates an expected call of Acquire.
+func (mr *MockRedisServiceInterfaceMockRecorder) Acquire(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Acquire", reflect.TypeOf((*MockRedisServiceInterface)(nil).Acquire), key)
+}
+
+// Delete mocks base method.
+func (m *MockRedisServiceInterface) Delete(key string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", key)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRedisServiceInterfaceMockRecorder) Delete(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRedisServiceInterface)(nil).Delete), key)
+}
+
+// Exists mocks base method.
+func (m *MockRedisServiceInterface) Exists(key string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", key)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockRedisServiceInterfaceMockRecorder) Exists(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockRedisServiceInterface)(nil).Exists), key)
+}
+
+// Get mocks base method.
+func (m *MockRedisServiceInterface) Get(key string, data interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", key, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRedisServiceInterfaceMockRecorder) Get(key, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRedisServiceInterface)(nil).Get), key, data)
+}
+
+// Hget mocks base method.
+func (m *MockRedisServiceInterface) Hget(key, field string) (*string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hget", key, field)
+	ret0, _ := ret[0].(*string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hget indicates an expected call of Hget.
+func (mr *MockRedisServiceInterfaceMockRecorder) Hget(key, field interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hget", reflect.TypeOf((*MockRedisServiceInterface)(nil).Hget), key, field)
+}
+
+// Hset mocks base method.
+func (m *MockRedisServiceInterface) Hset(key, field, value string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hset", key, field, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Hset indicates an expected call of Hset.
+func (mr *MockRedisServiceInterfaceMockRecorder) Hset(key, field, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hset", reflect.TypeOf((*MockRedisServiceInterface)(nil).Hset), key, field, value)
+}
+
+// HsetWithExpiry mocks base method.
+func (m *MockRedisServiceInterface) HsetWithExpiry(key, field, value string, time int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HsetWithExpiry", key, field, value, time)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HsetWithExpiry indicates an expected call of HsetWithExpiry.
+func (mr *MockRedisServiceInterfaceMockRecorder) HsetWithExpiry(key, field, value, time interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HsetWithExpiry", reflect.TypeOf((*MockRedisServiceInterface)(nil).HsetWithExpiry), key, field, value, time)
+}
+
+// Release mocks base method.
+func (m *MockRedisServiceInterface) Release(key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockRedisServiceInterfaceMockRecorder) Release(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockRedisServiceInterface)(nil).Release), key)
+}
+
+// Set mocks base method.
+func (m *MockRedisServiceInterface) Set(key string, data interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", key, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockRedisServiceInterfaceMockRecorder) Set(key, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockRedisServiceInterface)(nil).Set), key, data)
+}
+
+// SetWithExpiry mocks base method.
+func (m *MockRedisServiceInterface) SetWithExpiry(key string, data interface{}, time int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWithExpiry", key, data, time)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetWithExpiry indicates an expected call of SetWithExpiry.
+func (mr *MockRedisServiceInterfaceMockRecorder) SetWithExpiry(key, data, time interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWithExpiry", reflect.TypeOf((*MockRedisServiceInterface)(nil).SetWithExpiry), key, data, time)
+}
+
+// SetWithLock mocks base method.
+func (m *MockRedisServiceInterface) SetWithLock(key string, data interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWithLock", key, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetWithLock indicates an expected call of SetWithLock.
+func (mr *MockRedisServiceInterfaceMockRecorder) SetWithLock(key, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWithLock", reflect.TypeOf((*MockRedisServiceInterface)(nil).SetWithLock), key, data)
+}
+
+// SetnxWithExpiry mocks base method.
+func (m *MockRedisServiceInterface) SetnxWithExpiry(key string, data interface{}, time int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetnxWithExpiry", key, data, time)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetnxWithExpiry indicates an expected call of SetnxWithExpiry.
+func (mr *MockRedisServiceInterfaceMockRecorder) SetnxWithExpiry(key, data, time interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetnxWithExpiry", reflect.TypeOf((*MockRedisServiceInterface)(nil).SetnxWithExpiry), key, data, time)
+}
@@ -9,6 +9,7 @@ import (
 
 type Auth struct {
 	UserID               int32
+	MerchantID           int32
 	AccessToken          string
 	IsUsingInternalToken bool
 	IsLoggedIn           bool
@@ -43,6 +44,7 @@ func initAuth(c *gin.Context, r *Request) {
 	actor, err := middleware.NewActorFromContext(c)
 	if err == nil {
 		r.Auth.UserID = actor.UserID
+		r.Auth.MerchantID = actor.MerchantID
 	}
 
 	r.Auth.IsLoggedIn = false
@@ -0,0 +1,177 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	DefaultDrainTimeout = 15 * time.Second
+	DefaultStopTimeout  = 5 * time.Second
+)
+
+type LifecycleConfiguration struct {
+	// DrainTimeout bounds the stop of components registered with Drain, e.g. in flight http requests
+	DrainTimeout time.Duration
+	// ReadinessGracePeriod is the wait between failing readiness and draining,
+	// so load balancers stop routing new requests first
+	ReadinessGracePeriod time.Duration
+	// StopTimeout is the default deadline of every other component
+	StopTimeout time.Duration
+}
+
+// Component is anything with a start/stop lifecycle, Start is optional
+// for components that are already running when registered
+type Component struct {
+	Name        string
+	Start       func(ctx context.Context) error
+	Stop        func(ctx context.Context) error
+	StopTimeout time.Duration
+	Drain       bool
+}
+
+// Lifecycle starts components in registration order and stops them in reverse
+type Lifecycle struct {
+	mu         sync.Mutex
+	config     LifecycleConfiguration
+	components []Component
+	started    []Component
+	drainHooks []func()
+}
+
+func NewLifecycle(config LifecycleConfiguration) *Lifecycle {
+	if config.DrainTimeout <= 0 {
+		config.DrainTimeout = DefaultDrainTimeout
+	}
+	if config.StopTimeout <= 0 {
+		config.StopTimeout = DefaultStopTimeout
+	}
+
+	return &Lifecycle{
+		config: config,
+	}
+}
+
+func (l *Lifecycle) Register(component Component) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.components = append(l.components, component)
+}
+
+// OnDrain registers a hook called before any component is stopped, e.g. failing readiness
+func (l *Lifecycle) OnDrain(hook func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.drainHooks = append(l.drainHooks, hook)
+}
+
+// Start starts every component in order, if one fails the already started ones are stopped
+func (l *Lifecycle) Start(ctx context.Context) error {
+	l.mu.Lock()
+	components := l.components
+	l.mu.Unlock()
+
+	for _, component := range components {
+		if component.Start != nil {
+			if err := component.Start(ctx); err != nil {
+				l.Stop()
+				return fmt.Errorf("failed to start %s: %w", component.Name, err)
+			}
+		}
+
+		l.mu.Lock()
+		l.started = append(l.started, component)
+		l.mu.Unlock()
+	}
+
+	return nil
+}
+
+// Run starts the components and blocks until SIGINT/SIGTERM or ctx is done, then stops them
+func (l *Lifecycle) Run(ctx context.Context) error {
+	if err := l.Start(ctx); err != nil {
+		return err
+	}
+
+	// The server will listen to the SIGINT and SIGTERM
+	// SIGINT will listen to CTRL-C.
+	// SIGTERM will be caught if kill command executed.
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(quit)
+
+	select {
+	case <-quit:
+	case <-ctx.Done():
+	}
+
+	l.Stop()
+	return nil
+}
+
+// Stop runs the drain hooks, waits the readiness grace period
+// then stops the started components in reverse order
+func (l *Lifecycle) Stop() {
+	l.mu.Lock()
+	hooks := l.drainHooks
+	started := l.started
+	l.started = nil
+	l.mu.Unlock()
+
+	log.Println("Shutdown Server ...")
+	for _, hook := range hooks {
+		hook()
+	}
+
+	if l.config.ReadinessGracePeriod > 0 && len(started) > 0 {
+		time.Sleep(l.config.ReadinessGracePeriod)
+	}
+
+	for i := len(started) - 1; i >= 0; i-- {
+		component := started[i]
+		if component.Stop == nil {
+			continue
+		}
+
+		if err := l.stop(component); err != nil {
+			log.Printf("failed to stop %s: %v", component.Name, err)
+			continue
+		}
+		log.Printf("%s stopped", component.Name)
+	}
+	log.Println("Server exiting")
+}
+
+// stop enforces the component deadline even if Stop ignores its context
+func (l *Lifecycle) stop(component Component) error {
+	timeout := component.StopTimeout
+	if timeout <= 0 {
+		timeout = l.config.StopTimeout
+	}
+	if component.Drain {
+		timeout = l.config.DrainTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- component.Stop(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLifecycle_Stop(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		calls []string
+	)
+	record := func(call string) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, call)
+	}
+	component := func(name string) Component {
+		return Component{
+			Name:  name,
+			Start: func(ctx context.Context) error { record("start " + name); return nil },
+			Stop:  func(ctx context.Context) error { record("stop " + name); return nil },
+		}
+	}
+
+	lifecycle := NewLifecycle(LifecycleConfiguration{})
+	lifecycle.Register(component("postgres"))
+	lifecycle.Register(component("redis"))
+	lifecycle.Register(component("http"))
+	lifecycle.OnDrain(func() { record("drain") })
+
+	assert.NoError(t, lifecycle.Start(context.Background()))
+	lifecycle.Stop()
+
+	assert.Equal(t, []string{
+		"start postgres", "start redis", "start http",
+		"drain",
+		"stop http", "stop redis", "stop postgres",
+	}, calls)
+}
+
+func TestLifecycle_StartFailure(t *testing.T) {
+	var stopped bool
+
+	lifecycle := NewLifecycle(LifecycleConfiguration{})
+	lifecycle.Register(Component{
+		Name: "postgres",
+		Stop: func(ctx context.Context) error { stopped = true; return nil },
+	})
+	lifecycle.Register(Component{
+		Name:  "http",
+		Start: func(ctx context.Context) error { return errors.New("address already in use") },
+	})
+
+	err := lifecycle.Start(context.Background())
+
+	assert.ErrorContains(t, err, "failed to start http")
+	assert.True(t, stopped)
+}
+
+func TestLifecycle_StopDeadline(t *testing.T) {
+	var stopped bool
+
+	lifecycle := NewLifecycle(LifecycleConfiguration{
+		DrainTimeout: 20 * time.Millisecond,
+		StopTimeout:  time.Second,
+	})
+	lifecycle.Register(Component{Name: "postgres", Stop: func(ctx context.Context) error { stopped = true; return nil }})
+	lifecycle.Register(Component{
+		Name: "http",
+		// ignores its context, the lifecycle must not wait for it
+		Stop:  func(ctx context.Context) error { time.Sleep(time.Second); return nil },
+		Drain: true,
+	})
+
+	assert.NoError(t, lifecycle.Start(context.Background()))
+
+	start := time.Now()
+	lifecycle.Stop()
+
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.True(t, stopped)
+}
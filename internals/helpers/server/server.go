@@ -4,11 +4,10 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
-	"os/signal"
 	"strconv"
-	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -23,10 +22,32 @@ type RESTServerConfiguration struct {
 type RESTServer struct {
 	router http.Handler
 	config *RESTServerConfiguration
+	srv    *http.Server
 }
 
-// Serve serves the http requests to http server
+// Serve serves the http requests to http server and blocks until
+// SIGINT/SIGTERM, then drains in flight requests
 func (hs *RESTServer) Serve() {
+	lifecycle := NewLifecycle(LifecycleConfiguration{})
+	lifecycle.Register(hs.Component())
+
+	if err := lifecycle.Run(context.Background()); err != nil {
+		log.Fatalf("listen: %s\n", err)
+	}
+}
+
+// Component returns the server as a lifecycle component, stopping it
+// drains in flight requests within the lifecycle drain timeout
+func (hs *RESTServer) Component() Component {
+	return Component{
+		Name:  "http server",
+		Start: hs.start,
+		Stop:  hs.stop,
+		Drain: true,
+	}
+}
+
+func (hs *RESTServer) start(ctx context.Context) error {
 	port := hs.config.Port
 	if os.Getenv("SERVER_PORT") != "" {
 		parsedPort, err := strconv.Atoi(os.Getenv("SERVER_PORT"))
@@ -37,42 +58,34 @@ func (hs *RESTServer) Serve() {
 
 	address := fmt.Sprintf("%s:%d", hs.config.Domain, port)
 
-	srv := &http.Server{
+	hs.srv = &http.Server{
 		ReadTimeout:       1 * time.Minute,
 		ReadHeaderTimeout: 20 * time.Second,
 		Addr:              address,
 		Handler:           hs.router,
 	}
 
+	// bind synchronously so a busy port fails the start
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+
 	go func() {
 		// service connections
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		if err := hs.srv.Serve(listener); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("listen: %s\n", err)
 		}
 	}()
 
-	// Wait for interrupt signal to gracefully shutdown the server with
-	// a timeout of 5 seconds.
-	quit := make(chan os.Signal, 1)
-
-	// The server will listen to the SIGINT and SIGTERM
-	// SIGINT will listen to CTRL-C.
-	// SIGTERM will be caught if kill command executed.
-	//
-	// See:
-	// - https://en.wikipedia.org/wiki/Unix_signal
-	// - https://www.quora.com/What-is-the-difference-between-the-SIGINT-and-SIGTERM-signals-in-Linux
-	// - http://programmergamer.blogspot.co.id/2013/05/clarification-on-sigint-sigterm-sigkill.html
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-	log.Println("Shutdown Server ...")
-
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	if err := srv.Shutdown(ctx); err != nil {
-		log.Fatal("Server Shutdown:", err)
+	return nil
+}
+
+func (hs *RESTServer) stop(ctx context.Context) error {
+	if hs.srv == nil {
+		return nil
 	}
-	log.Println("Server exiting")
+	return hs.srv.Shutdown(ctx)
 }
 
 // InitRESTServer initialize REST server & setup routes
@@ -4,17 +4,15 @@ import (
 	"context"
 
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
-	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/exporters/prometheus"
 	metricsdk "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
 )
 
-func NewExporter(ctx context.Context, enable bool, endpoint string) (metricsdk.Exporter, error) {
-	if !enable {
-		return stdoutmetric.New()
-	}
-
+// NewExporter pushes the metrics to the collector at endpoint, only built when
+// metrics are enabled
+func NewExporter(ctx context.Context, endpoint string) (metricsdk.Exporter, error) {
 	// since service it's not exposed, it's ok
 	// @TODO: use TLS for this kind of connection
 	return otlpmetricgrpc.New(ctx,
@@ -23,7 +21,16 @@ func NewExporter(ctx context.Context, enable bool, endpoint string) (metricsdk.E
 	)
 }
 
-func NewMeterProvider(exp metricsdk.Exporter, appName string) (*metricsdk.MeterProvider, error) {
+// NewPrometheusReader returns a pull based reader, its metrics are served
+// by promhttp.Handler() from the default prometheus registry
+func NewPrometheusReader() (metricsdk.Reader, error) {
+	return prometheus.New()
+}
+
+// NewMeterProvider pushes metrics periodically to exp, extraReaders
+// (e.g. prometheus) are attached alongside. A nil exp pushes nothing and
+// without readers the recorded metrics are dropped
+func NewMeterProvider(exp metricsdk.Exporter, appName string, extraReaders ...metricsdk.Reader) (*metricsdk.MeterProvider, error) {
 	r, err := resource.Merge(
 		resource.Default(),
 		resource.NewWithAttributes(
@@ -36,9 +43,13 @@ func NewMeterProvider(exp metricsdk.Exporter, appName string) (*metricsdk.MeterP
 		return nil, err
 	}
 
-	return metricsdk.NewMeterProvider(
-		metricsdk.WithResource(r),
-		metricsdk.WithReader(metricsdk.NewPeriodicReader(exp)),
-	), nil
+	opts := []metricsdk.Option{metricsdk.WithResource(r)}
+	if exp != nil {
+		opts = append(opts, metricsdk.WithReader(metricsdk.NewPeriodicReader(exp)))
+	}
+	for _, reader := range extraReaders {
+		opts = append(opts, metricsdk.WithReader(reader))
+	}
 
+	return metricsdk.NewMeterProvider(opts...), nil
 }
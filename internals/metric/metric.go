@@ -1,17 +1,49 @@
 package metric
 
 import (
+	"context"
 	"fmt"
+	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	metricsdk "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+const (
+	OutcomeSuccess           = "success"
+	OutcomeInsufficientFunds = "insufficient_funds"
+	OutcomeNotFound          = "not_found"
+	OutcomeError             = "error"
+
+	LoginReasonUserNotFound    = "user_not_found"
+	LoginReasonInvalidPassword = "invalid_password"
+	LoginReasonError           = "error"
+
+	RateLimitReasonExceeded = "exceeded"
+	RateLimitReasonMarked   = "violation_marked"
 )
 
 //go:generate mockgen -destination=mocks/mock_metric.go -source=metric.go Metric
 type Metric interface {
-	// Add metrics implementations here
+	// RecordTransaction counts a transaction attempt and records its amount
+	RecordTransaction(ctx context.Context, transactionType, outcome string, amount float64)
+	RecordInsufficientFunds(ctx context.Context, transactionType string)
+	// RecordLockWait records how long we waited for the user row lock (GetUserByIDLock)
+	RecordLockWait(ctx context.Context, duration time.Duration)
+	RecordLoginSuccess(ctx context.Context)
+	RecordLoginFailure(ctx context.Context, reason string)
+	RecordRateLimitRejection(ctx context.Context, handler, reason string)
 }
+
 type metric struct {
-	// Add add metric dependencies here
+	transactionCount        metricsdk.Int64Counter
+	transactionAmount       metricsdk.Float64Histogram
+	insufficientFundsCount  metricsdk.Int64Counter
+	lockWaitTime            metricsdk.Float64Histogram
+	loginSuccessCount       metricsdk.Int64Counter
+	loginFailureCount       metricsdk.Int64Counter
+	rateLimitRejectionCount metricsdk.Int64Counter
 }
 
 func NewMetric(meter metricsdk.Meter) (*metric, error) {
@@ -19,5 +51,105 @@ func NewMetric(meter metricsdk.Meter) (*metric, error) {
 		return nil, fmt.Errorf("meter should not be nil")
 	}
 
-	return &metric{}, nil
+	var (
+		m   metric
+		err error
+	)
+
+	m.transactionCount, err = meter.Int64Counter("wallet_transaction_count",
+		metricsdk.WithDescription("number of wallet transactions by type and outcome"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("transaction count meter error: %w", err)
+	}
+
+	m.transactionAmount, err = meter.Float64Histogram("wallet_transaction_amount",
+		metricsdk.WithDescription("amount of wallet transactions by type and outcome"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("transaction amount meter error: %w", err)
+	}
+
+	m.insufficientFundsCount, err = meter.Int64Counter("wallet_insufficient_funds_count",
+		metricsdk.WithDescription("number of transactions rejected for insufficient funds"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("insufficient funds meter error: %w", err)
+	}
+
+	m.lockWaitTime, err = meter.Float64Histogram("wallet_user_lock_wait",
+		metricsdk.WithDescription("time spent waiting for the user row lock"),
+		metricsdk.WithUnit("ms"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("lock wait meter error: %w", err)
+	}
+
+	m.loginSuccessCount, err = meter.Int64Counter("auth_login_success_count",
+		metricsdk.WithDescription("number of successful logins"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("login success meter error: %w", err)
+	}
+
+	m.loginFailureCount, err = meter.Int64Counter("auth_login_failure_count",
+		metricsdk.WithDescription("number of failed logins by reason"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("login failure meter error: %w", err)
+	}
+
+	m.rateLimitRejectionCount, err = meter.Int64Counter("http_rate_limit_rejection_count",
+		metricsdk.WithDescription("number of requests rejected by the rate limiter"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("rate limit meter error: %w", err)
+	}
+
+	return &m, nil
+}
+
+// NewNoopMetric returns metrics that record nothing, used when no meter is configured
+func NewNoopMetric() *metric {
+	m, _ := NewMetric(noop.NewMeterProvider().Meter(""))
+	return m
+}
+
+func (m *metric) RecordTransaction(ctx context.Context, transactionType, outcome string, amount float64) {
+	attrs := metricsdk.WithAttributes(
+		attribute.String("type", transactionType),
+		attribute.String("outcome", outcome),
+	)
+
+	m.transactionCount.Add(ctx, 1, attrs)
+	m.transactionAmount.Record(ctx, amount, attrs)
+}
+
+func (m *metric) RecordInsufficientFunds(ctx context.Context, transactionType string) {
+	m.insufficientFundsCount.Add(ctx, 1,
+		metricsdk.WithAttributes(attribute.String("type", transactionType)),
+	)
+}
+
+func (m *metric) RecordLockWait(ctx context.Context, duration time.Duration) {
+	m.lockWaitTime.Record(ctx, float64(duration.Microseconds())/1000)
+}
+
+func (m *metric) RecordLoginSuccess(ctx context.Context) {
+	m.loginSuccessCount.Add(ctx, 1)
+}
+
+func (m *metric) RecordLoginFailure(ctx context.Context, reason string) {
+	m.loginFailureCount.Add(ctx, 1,
+		metricsdk.WithAttributes(attribute.String("reason", reason)),
+	)
+}
+
+func (m *metric) RecordRateLimitRejection(ctx context.Context, handler, reason string) {
+	m.rateLimitRejectionCount.Add(ctx, 1,
+		metricsdk.WithAttributes(
+			attribute.String("handler", handler),
+			attribute.String("reason", reason),
+		),
+	)
 }
@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: metric.go
+
+// Package mock_metric is a generated GoMock package.
+package mock_metric
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMetric is a mock of Metric interface.
+type MockMetric struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricMockRecorder
+}
+
+// MockMetricMockRecorder is the mock recorder for MockMetric.
+type MockMetricMockRecorder struct {
+	mock *MockMetric
+}
+
+// NewMockMetric creates a new mock instance.
+func NewMockMetric(ctrl *gomock.Controller) *MockMetric {
+	mock := &MockMetric{ctrl: ctrl}
+	mock.recorder = &MockMetricMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetric) EXPECT() *MockMetricMockRecorder {
+	return m.recorder
+}
+
+// RecordInsufficientFunds mocks base method.
+func (m *MockMetric) RecordInsufficientFunds(ctx context.Context, transactionType string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordInsufficientFunds", ctx, transactionType)
+}
+
+// RecordInsufficientFunds indicates an expected call of RecordInsufficientFunds.
+func (mr *MockMetricMockRecorder) RecordInsufficientFunds(ctx, transactionType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordInsufficientFunds", reflect.TypeOf((*MockMetric)(nil).RecordInsufficientFunds), ctx, transactionType)
+}
+
+// RecordLockWait mocks base method.
+func (m *MockMetric) RecordLockWait(ctx context.Context, duration time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordLockWait", ctx, duration)
+}
+
+// RecordLockWait indicates an expected call of RecordLockWait.
+func (mr *MockMetricMockRecorder) RecordLockWait(ctx, duration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLockWait", reflect.TypeOf((*MockMetric)(nil).RecordLockWait), ctx, duration)
+}
+
+// RecordLoginFailure mocks base method.
+func (m *MockMetric) RecordLoginFailure(ctx context.Context, reason string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordLoginFailure", ctx, reason)
+}
+
+// RecordLoginFailure indicates an expected call of RecordLoginFailure.
+func (mr *MockMetricMockRecorder) RecordLoginFailure(ctx, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLoginFailure", reflect.TypeOf((*MockMetric)(nil).RecordLoginFailure), ctx, reason)
+}
+
+// RecordLoginSuccess mocks base method.
+func (m *MockMetric) RecordLoginSuccess(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordLoginSuccess", ctx)
+}
+
+// RecordLoginSuccess indicates an expected call of RecordLoginSuccess.
+func (mr *MockMetricMockRecorder) RecordLoginSuccess(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLoginSuccess", reflect.TypeOf((*MockMetric)(nil).RecordLoginSuccess), ctx)
+}
+
+// RecordRateLimitRejection mocks base method.
+func (m *MockMetric) RecordRateLimitRejection(ctx context.Context, handler, reason string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordRateLimitRejection", ctx, handler, reason)
+}
+
+// RecordRateLimitRejection indicates an expected call of RecordRateLimitRejection.
+func (mr *MockMetricMockRecorder) RecordRateLimitRejection(ctx, handler, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordRateLimitRejection", reflect.TypeOf((*MockMetric)(nil).RecordRateLimitRejection), ctx, handler, reason)
+}
+
+// RecordTransaction mocks base method.
+func (m *MockMetric) RecordTransaction(ctx context.Context, transactionType, outcome string, amount float64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordTransaction", ctx, transactionType, outcome, amount)
+}
+
+// RecordTransaction indicates an expected call of RecordTransaction.
+func (mr *MockMetricMockRecorder) RecordTransaction(ctx, transactionType, outcome, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordTransaction", reflect.TypeOf((*MockMetric)(nil).RecordTransaction), ctx, transactionType, outcome, amount)
+}
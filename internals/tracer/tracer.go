@@ -4,17 +4,14 @@ import (
 	"context"
 
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
-	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	"go.opentelemetry.io/otel/sdk/resource"
 	tracesdk "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
 )
 
-func NewExporter(ctx context.Context, enable bool, endpoint string) (tracesdk.SpanExporter, error) {
-	if !enable {
-		return stdouttrace.New()
-	}
-
+// NewExporter exports the spans to the collector at endpoint, only built when tracing
+// is enabled. Disabled, no provider is installed and the global noop one stays
+func NewExporter(ctx context.Context, endpoint string) (tracesdk.SpanExporter, error) {
 	// since service it's not exposed, it's ok
 	// @TODO: use TLS for this kind of connection
 	return otlptracegrpc.New(ctx,
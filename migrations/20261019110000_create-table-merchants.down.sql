@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS merchant_api_keys;
+DROP TABLE IF EXISTS merchants;
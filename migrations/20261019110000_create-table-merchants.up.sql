@@ -0,0 +1,20 @@
+CREATE TABLE merchants (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE merchant_api_keys (
+    id SERIAL PRIMARY KEY,
+    merchant_id INTEGER NOT NULL REFERENCES merchants(id),
+    -- public, sent with every signed request
+    key_id VARCHAR(64) NOT NULL UNIQUE,
+    -- base64 AES-256-GCM of the secret under MERCHANT_SECRET_ENCRYPTION_KEY, the
+    -- plain secret is only shown when the key is issued
+    secret_ciphertext TEXT NOT NULL,
+    -- null until the key is rotated or revoked
+    expires_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_merchant_api_keys_merchant_id ON merchant_api_keys(merchant_id);
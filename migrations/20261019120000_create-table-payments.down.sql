@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS payments;
+DROP TABLE IF EXISTS settlements;
+
+DELETE FROM transactions WHERE type IN ('payment', 'settlement');
+ALTER TABLE transactions DROP CONSTRAINT transactions_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_type_check CHECK (type IN ('credit', 'debit'));
+ALTER TABLE transactions ALTER COLUMN type TYPE VARCHAR(10);
+
+ALTER TABLE merchants DROP COLUMN IF EXISTS user_id;
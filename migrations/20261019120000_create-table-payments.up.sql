@@ -0,0 +1,46 @@
+-- the wallet account settlements are credited to, null for merchants created before
+-- wallets were linked, they can't take payments until one is set
+ALTER TABLE merchants ADD COLUMN user_id INTEGER UNIQUE REFERENCES users(id);
+
+ALTER TABLE transactions ALTER COLUMN type TYPE VARCHAR(20);
+ALTER TABLE transactions DROP CONSTRAINT transactions_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_type_check CHECK (type IN ('credit', 'debit', 'payment', 'settlement'));
+
+CREATE TABLE settlements (
+    id SERIAL PRIMARY KEY,
+    merchant_id INTEGER NOT NULL REFERENCES merchants(id),
+    -- the day whose payments are settled, a merchant is settled once per day
+    settlement_date DATE NOT NULL,
+    -- the currency of the settled payments, each is settled on its own
+    currency CHAR(3) NOT NULL,
+    payment_count INTEGER NOT NULL DEFAULT 0,
+    gross_amount DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    fee_amount DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    net_amount DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    -- the credit of the net amount to the merchant wallet
+    transaction_id INTEGER REFERENCES transactions(id),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (merchant_id, settlement_date, currency)
+);
+
+CREATE TABLE payments (
+    id SERIAL PRIMARY KEY,
+    merchant_id INTEGER NOT NULL REFERENCES merchants(id),
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    currency CHAR(3) NOT NULL,
+    amount DECIMAL(15, 2) NOT NULL,
+    order_reference VARCHAR(64) NOT NULL,
+    metadata JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'captured' CHECK (status IN ('captured', 'settled')),
+    -- the debit of the payer
+    transaction_id INTEGER NOT NULL REFERENCES transactions(id),
+    settlement_id INTEGER REFERENCES settlements(id),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    -- an order is paid once
+    UNIQUE (merchant_id, order_reference)
+);
+
+CREATE INDEX idx_payments_user_id ON payments(user_id);
+CREATE INDEX idx_payments_settlement_id ON payments(settlement_id);
+-- the settlement job only scans payments waiting to be settled
+CREATE INDEX idx_payments_captured ON payments(merchant_id, currency, created_at) WHERE status = 'captured';
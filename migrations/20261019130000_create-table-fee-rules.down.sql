@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS transaction_fees;
+DROP TABLE IF EXISTS fee_rules;
+
+DELETE FROM transactions WHERE type IN ('fee', 'fee_revenue');
+ALTER TABLE transactions DROP CONSTRAINT transactions_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_type_check CHECK (type IN ('credit', 'debit', 'payment', 'settlement'));
+
+ALTER TABLE users DROP COLUMN IF EXISTS tier;
@@ -0,0 +1,42 @@
+-- fee rules can target a tier, every user starts in the standard one
+ALTER TABLE users ADD COLUMN tier VARCHAR(20) NOT NULL DEFAULT 'standard';
+
+ALTER TABLE transactions DROP CONSTRAINT transactions_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_type_check
+    CHECK (type IN ('credit', 'debit', 'payment', 'settlement', 'fee', 'fee_revenue'));
+
+CREATE TABLE fee_rules (
+    id SERIAL PRIMARY KEY,
+    transaction_type VARCHAR(20) NOT NULL,
+    -- null matches every channel or tier, a rule naming one wins over it
+    channel VARCHAR(20),
+    user_tier VARCHAR(20),
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('flat', 'percentage', 'tiered')),
+    flat_amount DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    percent DECIMAL(7, 4) NOT NULL DEFAULT 0,
+    -- [{"up_to": 100, "flat": 1, "percent": 0}, {"up_to": null, ...}], the first tier the amount fits applies
+    tiers JSONB NOT NULL DEFAULT '[]',
+    min_fee DECIMAL(15, 2),
+    max_fee DECIMAL(15, 2),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- one active rule per key, a new rule replaces the previous one
+CREATE UNIQUE INDEX idx_fee_rules_active_key ON fee_rules(transaction_type, COALESCE(channel, ''), COALESCE(user_tier, ''))
+    WHERE active;
+
+CREATE TABLE transaction_fees (
+    id SERIAL PRIMARY KEY,
+    -- the transaction the fee was charged for
+    transaction_id INTEGER NOT NULL REFERENCES transactions(id),
+    -- null for a fee set by configuration rather than a rule, e.g. the settlement fee
+    fee_rule_id INTEGER REFERENCES fee_rules(id),
+    amount DECIMAL(15, 2) NOT NULL,
+    -- the fee row of the user and the matching row of the revenue account
+    charge_transaction_id INTEGER NOT NULL REFERENCES transactions(id),
+    revenue_transaction_id INTEGER NOT NULL REFERENCES transactions(id),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_transaction_fees_transaction_id ON transaction_fees(transaction_id);
@@ -0,0 +1,33 @@
+-- only the rupiah rules and balances fit the previous schema
+DELETE FROM transaction_fees WHERE fee_rule_id IN (SELECT id FROM fee_rules WHERE currency <> 'IDR');
+DELETE FROM fee_rules WHERE currency <> 'IDR';
+DROP INDEX idx_fee_rules_active_key;
+CREATE UNIQUE INDEX idx_fee_rules_active_key ON fee_rules(transaction_type, COALESCE(channel, ''), COALESCE(user_tier, ''))
+    WHERE active;
+ALTER TABLE fee_rules DROP COLUMN currency;
+ALTER TABLE fee_rules ALTER COLUMN flat_amount TYPE DECIMAL(15, 2);
+ALTER TABLE fee_rules ALTER COLUMN min_fee TYPE DECIMAL(15, 2);
+ALTER TABLE fee_rules ALTER COLUMN max_fee TYPE DECIMAL(15, 2);
+ALTER TABLE transaction_fees ALTER COLUMN amount TYPE DECIMAL(15, 2);
+
+ALTER TABLE users ADD COLUMN balance DECIMAL(15, 2) NOT NULL DEFAULT 0;
+UPDATE users SET balance = wallets.balance
+FROM wallets
+WHERE wallets.user_id = users.id AND wallets.currency = 'IDR';
+
+ALTER TABLE payments DROP CONSTRAINT payments_currency_fkey;
+ALTER TABLE settlements DROP CONSTRAINT settlements_currency_fkey;
+DELETE FROM payments WHERE currency <> 'IDR';
+DELETE FROM settlements WHERE currency <> 'IDR';
+ALTER TABLE payments ALTER COLUMN amount TYPE DECIMAL(15, 2);
+ALTER TABLE settlements ALTER COLUMN gross_amount TYPE DECIMAL(15, 2);
+ALTER TABLE settlements ALTER COLUMN fee_amount TYPE DECIMAL(15, 2);
+ALTER TABLE settlements ALTER COLUMN net_amount TYPE DECIMAL(15, 2);
+
+DELETE FROM transaction_fees WHERE transaction_id IN (SELECT id FROM transactions WHERE currency <> 'IDR');
+DELETE FROM transactions WHERE currency <> 'IDR';
+ALTER TABLE transactions DROP COLUMN currency;
+ALTER TABLE transactions ALTER COLUMN amount TYPE DECIMAL(15, 2);
+
+DROP TABLE IF EXISTS wallets;
+DROP TABLE IF EXISTS currencies;
@@ -0,0 +1,53 @@
+CREATE TABLE currencies (
+    -- ISO 4217 code
+    code CHAR(3) PRIMARY KEY,
+    -- digits after the decimal point, 0 for JPY and 3 for KWD
+    minor_units SMALLINT NOT NULL CHECK (minor_units BETWEEN 0 AND 4)
+);
+
+INSERT INTO currencies (code, minor_units) VALUES
+    ('IDR', 2),
+    ('USD', 2),
+    ('EUR', 2),
+    ('SGD', 2),
+    ('JPY', 0),
+    ('KWD', 3);
+
+CREATE TABLE wallets (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    currency CHAR(3) NOT NULL REFERENCES currencies(code),
+    -- wide enough for every currency, amounts are rounded to the minor units of theirs
+    balance DECIMAL(19, 4) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, currency)
+);
+
+-- every balance so far was in rupiah
+INSERT INTO wallets (user_id, currency, balance, created_at)
+SELECT id, 'IDR', balance, created_at FROM users;
+
+ALTER TABLE users DROP COLUMN balance;
+
+ALTER TABLE transactions ALTER COLUMN amount TYPE DECIMAL(19, 4);
+ALTER TABLE transactions ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'IDR' REFERENCES currencies(code);
+ALTER TABLE transactions ALTER COLUMN currency DROP DEFAULT;
+
+ALTER TABLE payments ALTER COLUMN amount TYPE DECIMAL(19, 4);
+ALTER TABLE payments ADD FOREIGN KEY (currency) REFERENCES currencies(code);
+ALTER TABLE settlements ALTER COLUMN gross_amount TYPE DECIMAL(19, 4);
+ALTER TABLE settlements ALTER COLUMN fee_amount TYPE DECIMAL(19, 4);
+ALTER TABLE settlements ALTER COLUMN net_amount TYPE DECIMAL(19, 4);
+ALTER TABLE settlements ADD FOREIGN KEY (currency) REFERENCES currencies(code);
+
+-- flat amounts and caps only make sense in one currency, a rule is charged on its own
+ALTER TABLE fee_rules ALTER COLUMN flat_amount TYPE DECIMAL(19, 4);
+ALTER TABLE fee_rules ALTER COLUMN min_fee TYPE DECIMAL(19, 4);
+ALTER TABLE fee_rules ALTER COLUMN max_fee TYPE DECIMAL(19, 4);
+ALTER TABLE fee_rules ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'IDR' REFERENCES currencies(code);
+ALTER TABLE fee_rules ALTER COLUMN currency DROP DEFAULT;
+ALTER TABLE transaction_fees ALTER COLUMN amount TYPE DECIMAL(19, 4);
+
+DROP INDEX idx_fee_rules_active_key;
+CREATE UNIQUE INDEX idx_fee_rules_active_key ON fee_rules(transaction_type, currency, COALESCE(channel, ''), COALESCE(user_tier, ''))
+    WHERE active;
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS exchanges;
+
+DELETE FROM transactions WHERE type IN ('exchange_out', 'exchange_in');
+ALTER TABLE transactions DROP CONSTRAINT transactions_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_type_check
+    CHECK (type IN ('credit', 'debit', 'payment', 'settlement', 'fee', 'fee_revenue'));
@@ -0,0 +1,24 @@
+ALTER TABLE transactions DROP CONSTRAINT transactions_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_type_check
+    CHECK (type IN ('credit', 'debit', 'payment', 'settlement', 'fee', 'fee_revenue', 'exchange_out', 'exchange_in'));
+
+CREATE TABLE exchanges (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    -- the nonce of the executed quote, a quote is executed once
+    quote_nonce VARCHAR(64) NOT NULL UNIQUE,
+    from_currency CHAR(3) NOT NULL REFERENCES currencies(code),
+    to_currency CHAR(3) NOT NULL REFERENCES currencies(code),
+    from_amount DECIMAL(19, 4) NOT NULL,
+    to_amount DECIMAL(19, 4) NOT NULL,
+    -- units of to_currency bought by one unit of from_currency, mid_rate is the one of the rate source
+    rate DECIMAL(24, 10) NOT NULL,
+    mid_rate DECIMAL(24, 10) NOT NULL,
+    spread_percent DECIMAL(7, 4) NOT NULL,
+    debit_transaction_id INTEGER NOT NULL REFERENCES transactions(id),
+    credit_transaction_id INTEGER NOT NULL REFERENCES transactions(id),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (from_currency <> to_currency)
+);
+
+CREATE INDEX idx_exchanges_user_id ON exchanges(user_id, created_at DESC);
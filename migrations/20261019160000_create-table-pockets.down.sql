@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS pocket_entries;
+DROP TABLE IF EXISTS pockets;
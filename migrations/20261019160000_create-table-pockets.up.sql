@@ -0,0 +1,32 @@
+-- a pocket sets part of a wallet balance aside, the wallet balance stays the total and
+-- only what no pocket holds can be spent
+CREATE TABLE pockets (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    currency CHAR(3) NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    balance DECIMAL(19, 4) NOT NULL DEFAULT 0 CHECK (balance >= 0),
+    -- the default pocket of a wallet receives its credits
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id, currency) REFERENCES wallets(user_id, currency),
+    UNIQUE (user_id, currency, name)
+);
+
+CREATE UNIQUE INDEX idx_pockets_default ON pockets(user_id, currency) WHERE is_default;
+
+CREATE TABLE pocket_entries (
+    id SERIAL PRIMARY KEY,
+    pocket_id INTEGER NOT NULL REFERENCES pockets(id),
+    type VARCHAR(20) NOT NULL CHECK (type IN ('credit', 'move_in', 'move_out')),
+    amount DECIMAL(19, 4) NOT NULL CHECK (amount > 0),
+    -- the pocket balance after the entry
+    balance DECIMAL(19, 4) NOT NULL,
+    -- the credit transaction a default pocket received
+    transaction_id INTEGER REFERENCES transactions(id),
+    -- the other side of a move, NULL for the unallocated balance of the wallet
+    counterpart_pocket_id INTEGER REFERENCES pockets(id),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_pocket_entries_pocket_id ON pocket_entries(pocket_id, id DESC);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS savings_goals;
+
+DELETE FROM pocket_entries WHERE type IN ('round_up', 'sweep');
+ALTER TABLE pocket_entries DROP CONSTRAINT pocket_entries_type_check;
+ALTER TABLE pocket_entries ADD CONSTRAINT pocket_entries_type_check
+    CHECK (type IN ('credit', 'move_in', 'move_out'));
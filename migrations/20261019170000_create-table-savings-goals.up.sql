@@ -0,0 +1,25 @@
+ALTER TABLE pocket_entries DROP CONSTRAINT pocket_entries_type_check;
+ALTER TABLE pocket_entries ADD CONSTRAINT pocket_entries_type_check
+    CHECK (type IN ('credit', 'move_in', 'move_out', 'round_up', 'sweep'));
+
+-- a savings goal saves into a pocket until its balance reaches the target, the rules
+-- move money from the unallocated balance of the wallet into it
+CREATE TABLE savings_goals (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    pocket_id INTEGER NOT NULL UNIQUE REFERENCES pockets(id),
+    target_amount DECIMAL(19, 4) NOT NULL CHECK (target_amount > 0),
+    target_date DATE NOT NULL,
+    -- each debit of the wallet is rounded up to a multiple of it and the change is saved
+    round_up_to DECIMAL(19, 4) CHECK (round_up_to > 0),
+    -- sweep_amount is saved every sweep_frequency, next_sweep_at is when it is due
+    sweep_amount DECIMAL(19, 4) CHECK (sweep_amount > 0),
+    sweep_frequency VARCHAR(10) CHECK (sweep_frequency IN ('daily', 'weekly', 'monthly')),
+    next_sweep_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((sweep_amount IS NULL) = (sweep_frequency IS NULL)),
+    CHECK ((sweep_frequency IS NULL) = (next_sweep_at IS NULL))
+);
+
+CREATE INDEX idx_savings_goals_user_id ON savings_goals(user_id);
+CREATE INDEX idx_savings_goals_next_sweep_at ON savings_goals(next_sweep_at) WHERE next_sweep_at IS NOT NULL;
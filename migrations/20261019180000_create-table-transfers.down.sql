@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS transfers;
+
+DELETE FROM transactions WHERE type IN ('transfer_out', 'transfer_in');
+ALTER TABLE transactions DROP CONSTRAINT transactions_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_type_check
+    CHECK (type IN ('credit', 'debit', 'payment', 'settlement', 'fee', 'fee_revenue', 'exchange_out', 'exchange_in'));
@@ -0,0 +1,21 @@
+ALTER TABLE transactions DROP CONSTRAINT transactions_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_type_check
+    CHECK (type IN ('credit', 'debit', 'payment', 'settlement', 'fee', 'fee_revenue', 'exchange_out', 'exchange_in', 'transfer_out', 'transfer_in'));
+
+CREATE TABLE transfers (
+    id SERIAL PRIMARY KEY,
+    from_user_id INTEGER NOT NULL REFERENCES users(id),
+    to_user_id INTEGER NOT NULL REFERENCES users(id),
+    currency CHAR(3) NOT NULL REFERENCES currencies(code),
+    amount DECIMAL(19, 4) NOT NULL CHECK (amount > 0),
+    note VARCHAR(140) NOT NULL DEFAULT '',
+    -- set by a caller that may retry the same transfer, a key is transferred once
+    idempotency_key VARCHAR(100) UNIQUE,
+    debit_transaction_id INTEGER NOT NULL REFERENCES transactions(id),
+    credit_transaction_id INTEGER NOT NULL REFERENCES transactions(id),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (from_user_id <> to_user_id)
+);
+
+CREATE INDEX idx_transfers_from_user_id ON transfers(from_user_id, created_at DESC);
+CREATE INDEX idx_transfers_to_user_id ON transfers(to_user_id, created_at DESC);
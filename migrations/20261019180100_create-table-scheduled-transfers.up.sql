@@ -0,0 +1,43 @@
+-- a scheduled transfer pays another user once at start_at, or repeatedly following
+-- recurrence until end_at or max_occurrences
+CREATE TABLE scheduled_transfers (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    to_user_id INTEGER NOT NULL REFERENCES users(id),
+    currency CHAR(3) NOT NULL REFERENCES currencies(code),
+    amount DECIMAL(19, 4) NOT NULL CHECK (amount > 0),
+    note VARCHAR(140) NOT NULL DEFAULT '',
+    -- an RFC 5545 RRULE counted from start_at, NULL for a one-off transfer
+    recurrence VARCHAR(255),
+    start_at TIMESTAMP NOT NULL,
+    end_at TIMESTAMP,
+    max_occurrences INTEGER CHECK (max_occurrences > 0),
+    -- retry tries an occurrence the balance can't cover again later, skip moves on to the next one
+    on_insufficient_funds VARCHAR(10) NOT NULL CHECK (on_insufficient_funds IN ('retry', 'skip')),
+    status VARCHAR(10) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'completed', 'cancelled', 'failed')),
+    -- the pending occurrence and its failed attempts, occurrence_count is the finished ones
+    occurrence_at TIMESTAMP,
+    occurrence_count INTEGER NOT NULL DEFAULT 0,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    -- when the worker picks it up, pushed ahead while a worker holds it
+    next_run_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (user_id <> to_user_id)
+);
+
+CREATE INDEX idx_scheduled_transfers_user_id ON scheduled_transfers(user_id, id DESC);
+CREATE INDEX idx_scheduled_transfers_next_run_at ON scheduled_transfers(next_run_at) WHERE status = 'active';
+
+-- each attempt of an occurrence
+CREATE TABLE scheduled_transfer_runs (
+    id SERIAL PRIMARY KEY,
+    scheduled_transfer_id INTEGER NOT NULL REFERENCES scheduled_transfers(id),
+    occurrence_at TIMESTAMP NOT NULL,
+    attempt INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('succeeded', 'insufficient_funds', 'failed')),
+    transfer_id INTEGER REFERENCES transfers(id),
+    error VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_scheduled_transfer_runs_scheduled_transfer_id ON scheduled_transfer_runs(scheduled_transfer_id, id DESC);
@@ -0,0 +1,20 @@
+-- a payment request asks payer_id to transfer the amount to requester_id before expires_at
+CREATE TABLE payment_requests (
+    id SERIAL PRIMARY KEY,
+    requester_id INTEGER NOT NULL REFERENCES users(id),
+    payer_id INTEGER NOT NULL REFERENCES users(id),
+    currency CHAR(3) NOT NULL REFERENCES currencies(code),
+    amount DECIMAL(19, 4) NOT NULL CHECK (amount > 0),
+    note VARCHAR(140) NOT NULL DEFAULT '',
+    status VARCHAR(10) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'paid', 'declined', 'expired', 'cancelled')),
+    expires_at TIMESTAMP NOT NULL,
+    -- the transfer that paid it
+    transfer_id INTEGER REFERENCES transfers(id),
+    resolved_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (requester_id <> payer_id)
+);
+
+CREATE INDEX idx_payment_requests_requester_id ON payment_requests(requester_id, id DESC);
+CREATE INDEX idx_payment_requests_payer_id ON payment_requests(payer_id, id DESC);
+CREATE INDEX idx_payment_requests_expires_at ON payment_requests(expires_at) WHERE status = 'pending';
@@ -0,0 +1,33 @@
+-- a bill splits a group expense paid by creator_id, each participant owes the creator its share
+CREATE TABLE bills (
+    id SERIAL PRIMARY KEY,
+    creator_id INTEGER NOT NULL REFERENCES users(id),
+    title VARCHAR(140) NOT NULL,
+    currency CHAR(3) NOT NULL REFERENCES currencies(code),
+    total DECIMAL(19, 4) NOT NULL CHECK (total > 0),
+    split VARCHAR(10) NOT NULL CHECK (split IN ('equal', 'custom')),
+    -- the shares paid and still owed, the part of the creator is in neither
+    paid_amount DECIMAL(19, 4) NOT NULL DEFAULT 0,
+    outstanding_amount DECIMAL(19, 4) NOT NULL CHECK (outstanding_amount >= 0),
+    status VARCHAR(10) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'settled')),
+    settled_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_bills_creator_id ON bills(creator_id, id DESC);
+
+CREATE TABLE bill_shares (
+    id SERIAL PRIMARY KEY,
+    bill_id INTEGER NOT NULL REFERENCES bills(id),
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    amount DECIMAL(19, 4) NOT NULL CHECK (amount > 0),
+    status VARCHAR(10) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'paid')),
+    -- the transfer that settled it
+    transfer_id INTEGER REFERENCES transfers(id),
+    paid_at TIMESTAMP,
+    reminded_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (bill_id, user_id)
+);
+
+CREATE INDEX idx_bill_shares_user_id ON bill_shares(user_id, id DESC);
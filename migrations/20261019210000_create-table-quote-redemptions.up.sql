@@ -0,0 +1,11 @@
+-- the executed transaction quotes, a quote is executed once. The row commits with the
+-- quoted transaction, so an execution rolled back leaves the quote usable until it expires
+CREATE TABLE quote_redemptions (
+    nonce VARCHAR(64) PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    -- past it the quote can't be executed anyway and the row can be pruned
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_quote_redemptions_expires_at ON quote_redemptions(expires_at);
@@ -0,0 +1,68 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const migrationsDir = "./migrations"
+
+// StateChecker reports an error when the schema is dirty or behind the migration files,
+// it is registered as a readiness check
+func StateChecker(db *sql.DB) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		var (
+			version int64
+			dirty   bool
+		)
+
+		err := db.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+		if err != nil {
+			return fmt.Errorf("failed to read migration state: %w", err)
+		}
+
+		if dirty {
+			return fmt.Errorf("migration %d is dirty", version)
+		}
+
+		latest, err := latestVersion(migrationsDir)
+		if err != nil {
+			return err
+		}
+		if version < latest {
+			return fmt.Errorf("schema at version %d, latest migration is %d", version, latest)
+		}
+
+		return nil
+	}
+}
+
+// latestVersion returns the highest "<version>_<name>.up.sql" prefix in dir
+func latestVersion(dir string) (int64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read migrations dir: %w", err)
+	}
+
+	var latest int64
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".up.sql") {
+			continue
+		}
+
+		version, err := strconv.ParseInt(strings.SplitN(name, "_", 2)[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		if version > latest {
+			latest = version
+		}
+	}
+
+	return latest, nil
+}
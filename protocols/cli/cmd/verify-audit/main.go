@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"kc-ewallet/configurations"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/usecase/audit"
+	"kc-ewallet/internals/database"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+)
+
+// verify-audit walks the audit_events hash chain and exits non zero on the first broken link
+func main() {
+	// Load environment variables from .env file
+	godotenv.Load()
+
+	postgresWriter, err := database.NewPostgresWriter(configurations.NewDatabaseWriter())
+	if err != nil {
+		log.Fatalf("failed to connect to postgres writer: %v", err)
+	}
+	defer postgresWriter.GetDB().Close()
+
+	auditUsecase := audit.NewAuditUsecase(postgresWriter.GetDB(), postgres.New(postgresWriter.GetDB()), nil)
+
+	result, err := auditUsecase.Verify(context.Background())
+	if err != nil {
+		log.Fatalf("failed to verify audit chain: %v", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(result)
+
+	if !result.Valid {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,241 @@
+package grpc
+
+import (
+	"context"
+	"kc-ewallet/domains/usecase/audit"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/internals/helpers/logging"
+	"kc-ewallet/internals/helpers/operation"
+	"kc-ewallet/internals/helpers/redact"
+	"kc-ewallet/protocols/http/middleware"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	jwtHelper "kc-ewallet/internals/helpers/jwt"
+)
+
+// RequestIDMetadata is the metadata form of middleware.RequestIDHeader
+const RequestIDMetadata = "x-request-id"
+
+// RequestID reuses the x-request-id metadata or generates one, it is echoed in the
+// response header and carried by the logger, audit and error report scope
+func RequestID() gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		requestID := firstValue(md, RequestIDMetadata)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+		_ = gogrpc.SetHeader(ctx, metadata.Pairs(RequestIDMetadata, requestID))
+
+		ctx = logging.WithRequestID(ctx, requestID)
+		ctx = operation.SetOperationName(ctx, info.FullMethod)
+
+		client := audit.Client{UserAgent: firstValue(md, "user-agent")}
+		if p, ok := peer.FromContext(ctx); ok {
+			client.IP = p.Addr.String()
+		}
+		ctx = audit.WithClient(ctx, client)
+
+		ctx = errors.NewScopeContext(ctx)
+		scope := errors.ScopeFromContext(ctx)
+		scope.SetExtra("method", info.FullMethod)
+		scope.SetExtra("metadata", redact.Headers(http.Header(md)))
+		scope.SetExtra("request", redact.Value(req))
+		scope.SetTag("request_id", requestID)
+
+		return handler(ctx, req)
+	}
+}
+
+// Tracing starts a server span per call, continuing the trace propagated in the metadata
+func Tracing(tracer trace.Tracer) gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (interface{}, error) {
+		if tracer == nil {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+
+		ctx, span := tracer.Start(ctx, info.FullMethod,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("operation", info.FullMethod),
+				attribute.String("rpc.system", "grpc"),
+				attribute.String(middleware.RequestIDHeader, logging.GetRequestIDFromContext(ctx)),
+			),
+		)
+		defer span.End()
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			span.SetStatus(otelcodes.Error, err.Error())
+			span.SetAttributes(attribute.String("rpc.grpc.status_code", status.Code(err).String()))
+		}
+		return resp, err
+	}
+}
+
+// Logging logs every call with its gRPC code and latency, like StructuredLogFormatter
+func Logging() gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		fields := []zap.Field{
+			zap.String("method", info.FullMethod),
+			zap.String("code", status.Code(err).String()),
+			zap.Duration("latency", time.Since(start)),
+		}
+		logger := logging.NewFromContext(ctx)
+		if err != nil {
+			logger.Error("grpc request", append(fields, zap.Error(err))...)
+		} else {
+			logger.Info("grpc request", fields...)
+		}
+		return resp, err
+	}
+}
+
+// ErrorMapping converts the AppErrors returned by handlers into gRPC status errors, and
+// logs and reports them like response.RespondError does
+func ErrorMapping() gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		errors.Log(ctx, err)
+		errors.Report(ctx, err)
+		return resp, Status(ctx, err)
+	}
+}
+
+// Recovery turns a panicking handler into an internal error instead of crashing the server
+func Recovery() gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logging.NewFromContext(ctx).Error("grpc panic recovered",
+					zap.Any("panic", r),
+					zap.ByteString("stack", debug.Stack()),
+				)
+				err = errors.InternalServer.NewAndReport("panic: %v", r)
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// AuthorizeToken verifies the bearer token of every method except the public ones and
+// puts the middleware.Actor in the context, like middleware.AuthorizeToken
+func AuthorizeToken(secret string, tokenHelper jwtHelper.IJWTHelper, publicMethods map[string]bool) gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (interface{}, error) {
+		if publicMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		tokenString, err := tokenHelper.GetToken(ctx)
+		if err != nil || tokenString == "" {
+			return nil, middleware.ErrUnauthorized
+		}
+
+		claims, err := jwtHelper.VerifyHMACToken(tokenString, secret, jwt.SigningMethodHS256)
+		if err != nil {
+			return nil, err
+		}
+
+		var actor middleware.Actor
+		if err := actor.SetActorFromClaims(claims); err != nil {
+			return nil, err
+		}
+		actor.OriginToken = tokenString
+
+		ctx = context.WithValue(ctx, middleware.ActorKey{}, actor)
+		ctx = logging.WithUserID(ctx, actor.UserID)
+		ctx = audit.WithActor(ctx, audit.Actor{UserID: actor.UserID, Role: actor.Role})
+		if scope := errors.ScopeFromContext(ctx); scope != nil {
+			scope.SetUser(strconv.Itoa(int(actor.UserID)))
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// RateLimit runs the rate limiter for the methods in handlers, keyed by the handler name
+// of their REST route and the peer IP so gRPC and HTTP calls share one bucket per client.
+// A nil limiter lets every call through
+func RateLimit(limiter *middleware.RateLimiter, handlers map[string]string) gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (interface{}, error) {
+		handlerName, ok := handlers[info.FullMethod]
+		if !ok || limiter == nil {
+			return handler(ctx, req)
+		}
+
+		if err := limiter.Check(ctx, handlerName, peerIP(ctx)); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// peerIP is the address of the caller without its port, like gin's ClientIP
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// metadataCarrier adapts incoming metadata to the otel propagator
+type metadataCarrier metadata.MD
+
+func (m metadataCarrier) Get(key string) string {
+	return firstValue(metadata.MD(m), key)
+}
+
+func (m metadataCarrier) Set(key, value string) {
+	metadata.MD(m).Set(key, value)
+}
+
+func (m metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,409 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: ewallet/v1/transaction.proto
+
+package ewalletv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateTransactionRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Amount float64                `protobuf:"fixed64,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	// currency is the ISO 4217 code of the wallet
+	Currency string `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	// quote_id executes with the terms of a quote of the same type and amount
+	QuoteId       string `protobuf:"bytes,3,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTransactionRequest) Reset() {
+	*x = CreateTransactionRequest{}
+	mi := &file_ewallet_v1_transaction_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTransactionRequest) ProtoMessage() {}
+
+func (x *CreateTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ewallet_v1_transaction_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTransactionRequest.ProtoReflect.Descriptor instead.
+func (*CreateTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_ewallet_v1_transaction_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateTransactionRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CreateTransactionRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreateTransactionRequest) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
+type CreateTransactionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId int32                  `protobuf:"varint,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	NewBalance    float64                `protobuf:"fixed64,2,opt,name=new_balance,json=newBalance,proto3" json:"new_balance,omitempty"`
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTransactionResponse) Reset() {
+	*x = CreateTransactionResponse{}
+	mi := &file_ewallet_v1_transaction_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTransactionResponse) ProtoMessage() {}
+
+func (x *CreateTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ewallet_v1_transaction_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTransactionResponse.ProtoReflect.Descriptor instead.
+func (*CreateTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_ewallet_v1_transaction_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateTransactionResponse) GetTransactionId() int32 {
+	if x != nil {
+		return x.TransactionId
+	}
+	return 0
+}
+
+func (x *CreateTransactionResponse) GetNewBalance() float64 {
+	if x != nil {
+		return x.NewBalance
+	}
+	return 0
+}
+
+func (x *CreateTransactionResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type QuoteTransactionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// type is credit, debit, payment or transfer
+	Type   string  `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Amount float64 `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	// currency defaults to the default currency
+	Currency string `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	// to_user_id is the recipient of a transfer
+	ToUserId      int32 `protobuf:"varint,4,opt,name=to_user_id,json=toUserId,proto3" json:"to_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuoteTransactionRequest) Reset() {
+	*x = QuoteTransactionRequest{}
+	mi := &file_ewallet_v1_transaction_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteTransactionRequest) ProtoMessage() {}
+
+func (x *QuoteTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ewallet_v1_transaction_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteTransactionRequest.ProtoReflect.Descriptor instead.
+func (*QuoteTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_ewallet_v1_transaction_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *QuoteTransactionRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *QuoteTransactionRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *QuoteTransactionRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *QuoteTransactionRequest) GetToUserId() int32 {
+	if x != nil {
+		return x.ToUserId
+	}
+	return 0
+}
+
+type QuoteTransactionResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Type             string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Currency         string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Amount           float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Fee              float64                `protobuf:"fixed64,4,opt,name=fee,proto3" json:"fee,omitempty"`
+	Balance          float64                `protobuf:"fixed64,5,opt,name=balance,proto3" json:"balance,omitempty"`
+	ResultingBalance float64                `protobuf:"fixed64,6,opt,name=resulting_balance,json=resultingBalance,proto3" json:"resulting_balance,omitempty"`
+	// quote_id and expires_at, in unix seconds, are only set when quotes are configured
+	QuoteId       string `protobuf:"bytes,7,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	ExpiresAt     int64  `protobuf:"varint,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuoteTransactionResponse) Reset() {
+	*x = QuoteTransactionResponse{}
+	mi := &file_ewallet_v1_transaction_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteTransactionResponse) ProtoMessage() {}
+
+func (x *QuoteTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ewallet_v1_transaction_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteTransactionResponse.ProtoReflect.Descriptor instead.
+func (*QuoteTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_ewallet_v1_transaction_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *QuoteTransactionResponse) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *QuoteTransactionResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *QuoteTransactionResponse) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *QuoteTransactionResponse) GetFee() float64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *QuoteTransactionResponse) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *QuoteTransactionResponse) GetResultingBalance() float64 {
+	if x != nil {
+		return x.ResultingBalance
+	}
+	return 0
+}
+
+func (x *QuoteTransactionResponse) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
+func (x *QuoteTransactionResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+var File_ewallet_v1_transaction_proto protoreflect.FileDescriptor
+
+const file_ewallet_v1_transaction_proto_rawDesc = "" +
+	"\n" +
+	"\x1cewallet/v1/transaction.proto\x12\n" +
+	"ewallet.v1\"i\n" +
+	"\x18CreateTransactionRequest\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12\x19\n" +
+	"\bquote_id\x18\x03 \x01(\tR\aquoteId\"\x7f\n" +
+	"\x19CreateTransactionResponse\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\x05R\rtransactionId\x12\x1f\n" +
+	"\vnew_balance\x18\x02 \x01(\x01R\n" +
+	"newBalance\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\"\x7f\n" +
+	"\x17QuoteTransactionRequest\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x1c\n" +
+	"\n" +
+	"to_user_id\x18\x04 \x01(\x05R\btoUserId\"\xf5\x01\n" +
+	"\x18QuoteTransactionResponse\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12\x10\n" +
+	"\x03fee\x18\x04 \x01(\x01R\x03fee\x12\x18\n" +
+	"\abalance\x18\x05 \x01(\x01R\abalance\x12+\n" +
+	"\x11resulting_balance\x18\x06 \x01(\x01R\x10resultingBalance\x12\x19\n" +
+	"\bquote_id\x18\a \x01(\tR\aquoteId\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\b \x01(\x03R\texpiresAt2\xc2\x02\n" +
+	"\x12TransactionService\x12f\n" +
+	"\x17CreateCreditTransaction\x12$.ewallet.v1.CreateTransactionRequest\x1a%.ewallet.v1.CreateTransactionResponse\x12e\n" +
+	"\x16CreateDebitTransaction\x12$.ewallet.v1.CreateTransactionRequest\x1a%.ewallet.v1.CreateTransactionResponse\x12]\n" +
+	"\x10QuoteTransaction\x12#.ewallet.v1.QuoteTransactionRequest\x1a$.ewallet.v1.QuoteTransactionResponseB6Z4kc-ewallet/protocols/grpc/proto/ewallet/v1;ewalletv1b\x06proto3"
+
+var (
+	file_ewallet_v1_transaction_proto_rawDescOnce sync.Once
+	file_ewallet_v1_transaction_proto_rawDescData []byte
+)
+
+func file_ewallet_v1_transaction_proto_rawDescGZIP() []byte {
+	file_ewallet_v1_transaction_proto_rawDescOnce.Do(func() {
+		file_ewallet_v1_transaction_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_ewallet_v1_transaction_proto_rawDesc), len(file_ewallet_v1_transaction_proto_rawDesc)))
+	})
+	return file_ewallet_v1_transaction_proto_rawDescData
+}
+
+var file_ewallet_v1_transaction_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_ewallet_v1_transaction_proto_goTypes = []any{
+	(*CreateTransactionRequest)(nil),  // 0: ewallet.v1.CreateTransactionRequest
+	(*CreateTransactionResponse)(nil), // 1: ewallet.v1.CreateTransactionResponse
+	(*QuoteTransactionRequest)(nil),   // 2: ewallet.v1.QuoteTransactionRequest
+	(*QuoteTransactionResponse)(nil),  // 3: ewallet.v1.QuoteTransactionResponse
+}
+var file_ewallet_v1_transaction_proto_depIdxs = []int32{
+	0, // 0: ewallet.v1.TransactionService.CreateCreditTransaction:input_type -> ewallet.v1.CreateTransactionRequest
+	0, // 1: ewallet.v1.TransactionService.CreateDebitTransaction:input_type -> ewallet.v1.CreateTransactionRequest
+	2, // 2: ewallet.v1.TransactionService.QuoteTransaction:input_type -> ewallet.v1.QuoteTransactionRequest
+	1, // 3: ewallet.v1.TransactionService.CreateCreditTransaction:output_type -> ewallet.v1.CreateTransactionResponse
+	1, // 4: ewallet.v1.TransactionService.CreateDebitTransaction:output_type -> ewallet.v1.CreateTransactionResponse
+	3, // 5: ewallet.v1.TransactionService.QuoteTransaction:output_type -> ewallet.v1.QuoteTransactionResponse
+	3, // [3:6] is the sub-list for method output_type
+	0, // [0:3] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_ewallet_v1_transaction_proto_init() }
+func file_ewallet_v1_transaction_proto_init() {
+	if File_ewallet_v1_transaction_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ewallet_v1_transaction_proto_rawDesc), len(file_ewallet_v1_transaction_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_ewallet_v1_transaction_proto_goTypes,
+		DependencyIndexes: file_ewallet_v1_transaction_proto_depIdxs,
+		MessageInfos:      file_ewallet_v1_transaction_proto_msgTypes,
+	}.Build()
+	File_ewallet_v1_transaction_proto = out.File
+	file_ewallet_v1_transaction_proto_goTypes = nil
+	file_ewallet_v1_transaction_proto_depIdxs = nil
+}
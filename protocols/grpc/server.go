@@ -0,0 +1,131 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/internals/helpers/server"
+	"kc-ewallet/protocols/http/middleware"
+	"log"
+	"net"
+
+	ewalletv1 "kc-ewallet/protocols/grpc/proto/ewallet/v1"
+	"kc-ewallet/protocols/grpc/service"
+
+	jwtHelper "kc-ewallet/internals/helpers/jwt"
+
+	"go.opentelemetry.io/otel/trace"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+type ServerConfiguration struct {
+	Port          int
+	Domain        string
+	JWTSigningKey string
+	TokenHelper   jwtHelper.IJWTHelper
+	Tracer        trace.Tracer
+	RateLimiter   *middleware.RateLimiter
+}
+
+// PublicMethods are callable without an access token, like the routes
+// left out of middleware.RegisterHandlers on the HTTP server
+var PublicMethods = map[string]bool{
+	ewalletv1.UserService_RegisterUser_FullMethodName: true,
+	ewalletv1.UserService_Login_FullMethodName:        true,
+	healthpb.Health_Check_FullMethodName:              true,
+	healthpb.Health_Watch_FullMethodName:              true,
+}
+
+// RateLimitedMethods maps the rate limited methods to the handler names of their
+// REST routes in middleware.CheckRateLimit
+var RateLimitedMethods = map[string]string{
+	ewalletv1.TransactionService_CreateCreditTransaction_FullMethodName: "CreateCreditTransaction",
+	ewalletv1.TransactionService_CreateDebitTransaction_FullMethodName:  "CreateDebitTransaction",
+	ewalletv1.TransactionService_QuoteTransaction_FullMethodName:        "QuoteTransaction",
+}
+
+// Server serves the user and transaction services over gRPC next to the REST server
+type Server struct {
+	config *ServerConfiguration
+	srv    *gogrpc.Server
+	health *health.Server
+}
+
+// NewServer registers the services on a gRPC server, interceptors run in order:
+// request id, tracing, logging, error mapping, recovery, auth then rate limit
+func NewServer(config *ServerConfiguration, userUsecase usecase.IUserUsecase, walletUsecase usecase.IWalletUsecase, transactionUsecase usecase.ITransactionUsecase) *Server {
+	srv := gogrpc.NewServer(
+		gogrpc.ChainUnaryInterceptor(
+			RequestID(),
+			Tracing(config.Tracer),
+			Logging(),
+			ErrorMapping(),
+			Recovery(),
+			AuthorizeToken(config.JWTSigningKey, config.TokenHelper, PublicMethods),
+			RateLimit(config.RateLimiter, RateLimitedMethods),
+		),
+	)
+
+	ewalletv1.RegisterUserServiceServer(srv, service.NewUserService(userUsecase, walletUsecase))
+	ewalletv1.RegisterTransactionServiceServer(srv, service.NewTransactionService(transactionUsecase))
+
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(srv, healthServer)
+	reflection.Register(srv)
+
+	return &Server{
+		config: config,
+		srv:    srv,
+		health: healthServer,
+	}
+}
+
+// Component returns the server as a lifecycle component, stopping it
+// waits for in flight calls within the lifecycle drain timeout
+func (s *Server) Component() server.Component {
+	return server.Component{
+		Name:  "grpc server",
+		Start: s.start,
+		Stop:  s.stop,
+		Drain: true,
+	}
+}
+
+func (s *Server) start(ctx context.Context) error {
+	address := fmt.Sprintf("%s:%d", s.config.Domain, s.config.Port)
+
+	// bind synchronously so a busy port fails the start
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		if err := s.srv.Serve(listener); err != nil && err != gogrpc.ErrServerStopped {
+			log.Fatalf("grpc listen: %s\n", err)
+		}
+	}()
+
+	return nil
+}
+
+func (s *Server) stop(ctx context.Context) error {
+	s.health.Shutdown()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.srv.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.srv.Stop()
+		return ctx.Err()
+	}
+}
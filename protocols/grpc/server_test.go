@@ -0,0 +1,289 @@
+package grpc
+
+import (
+	"context"
+	mock_configuration "kc-ewallet/configurations/mocks"
+	"kc-ewallet/constants"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/usecase"
+	mock_usecase "kc-ewallet/domains/usecase/mocks"
+	"kc-ewallet/internals/errors"
+	mock_service "kc-ewallet/internals/helpers/redis/service/mocks"
+	"kc-ewallet/protocols/http/middleware"
+	"kc-ewallet/protocols/http/request"
+	"net"
+	"testing"
+	"time"
+
+	ewalletv1 "kc-ewallet/protocols/grpc/proto/ewallet/v1"
+
+	jwtHelper "kc-ewallet/internals/helpers/jwt"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testSigningKey = "secret"
+
+type testServer struct {
+	users        *mock_usecase.MockIUserUsecase
+	wallets      *mock_usecase.MockIWalletUsecase
+	transactions *mock_usecase.MockITransactionUsecase
+	conn         *gogrpc.ClientConn
+}
+
+func newTestServer(t *testing.T) *testServer {
+	ctrl := gomock.NewController(t)
+
+	jwtConfig := mock_configuration.NewMockIJWTConfiguration(ctrl)
+	jwtConfig.EXPECT().GetExpireInMinute().AnyTimes()
+	jwtConfig.EXPECT().GetSigningKey().Return(testSigningKey).AnyTimes()
+	jwtConfig.EXPECT().GetIssuer().AnyTimes()
+
+	ts := &testServer{
+		users:        mock_usecase.NewMockIUserUsecase(ctrl),
+		wallets:      mock_usecase.NewMockIWalletUsecase(ctrl),
+		transactions: mock_usecase.NewMockITransactionUsecase(ctrl),
+	}
+	srv := NewServer(&ServerConfiguration{
+		JWTSigningKey: testSigningKey,
+		TokenHelper:   jwtHelper.NewJWTHelper(jwtConfig),
+	}, ts.users, ts.wallets, ts.transactions)
+
+	listener := bufconn.Listen(1 << 20)
+	go srv.srv.Serve(listener)
+	t.Cleanup(srv.srv.Stop)
+
+	conn, err := gogrpc.NewClient("passthrough:///bufnet",
+		gogrpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		gogrpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	ts.conn = conn
+
+	return ts
+}
+
+func signToken(t *testing.T, userID int32) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id":    userID,
+		"role":       "customer",
+		"role_group": "customer",
+		"exp":        time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte(testSigningKey))
+	require.NoError(t, err)
+	return token
+}
+
+func errorInfo(t *testing.T, err error) *errdetails.ErrorInfo {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info
+		}
+	}
+	t.Fatalf("no ErrorInfo in %v", err)
+	return nil
+}
+
+func TestServer_Login(t *testing.T) {
+	ts := newTestServer(t)
+	client := ewalletv1.NewUserServiceClient(ts.conn)
+
+	ts.users.EXPECT().
+		Login(gomock.Any(), request.LoginRequest{Username: "luffy", Password: "secret"}).
+		Return("token", &postgres.User{ID: 1, Username: "luffy"}, nil)
+
+	var header metadata.MD
+	resp, err := client.Login(context.Background(), &ewalletv1.LoginRequest{Username: "luffy", Password: "secret"}, gogrpc.Header(&header))
+	require.NoError(t, err)
+
+	assert.Equal(t, "token", resp.GetAccessToken())
+	assert.Equal(t, int32(1), resp.GetUser().GetId())
+	assert.Equal(t, "luffy", resp.GetUser().GetUsername())
+	assert.NotEmpty(t, header.Get(RequestIDMetadata))
+}
+
+func TestServer_Validation(t *testing.T) {
+	ts := newTestServer(t)
+	client := ewalletv1.NewUserServiceClient(ts.conn)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), AcceptLanguageMetadata, "id")
+	_, err := client.RegisterUser(ctx, &ewalletv1.RegisterUserRequest{Username: "zoro", Password: "123"})
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "Data yang dikirim tidak valid.", st.Message())
+	assert.Equal(t, string(errors.CodeValidation), errorInfo(t, err).GetReason())
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			violations = badRequest.GetFieldViolations()
+		}
+	}
+	require.Len(t, violations, 1)
+	assert.Equal(t, "Password", violations[0].GetField())
+	assert.Equal(t, "Password minimal 6", violations[0].GetDescription())
+}
+
+func TestServer_Authorization(t *testing.T) {
+	ts := newTestServer(t)
+	client := ewalletv1.NewUserServiceClient(ts.conn)
+
+	_, err := client.GetUser(context.Background(), &ewalletv1.GetUserRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, string(errors.CodeUnauthorized), errorInfo(t, err).GetReason())
+	assert.Equal(t, "1", errorInfo(t, err).GetMetadata()["redirect_code"])
+
+	ts.users.EXPECT().GetUserByID(gomock.Any(), int32(7)).Return(&postgres.User{ID: 7, Username: "nami"}, nil)
+	ts.wallets.EXPECT().ListWallets(gomock.Any(), int32(7)).Return([]postgres.Wallet{{ID: 2, UserID: 7, Currency: "IDR", Balance: 100}}, nil)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+signToken(t, 7))
+	resp, err := client.GetUser(ctx, &ewalletv1.GetUserRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "nami", resp.GetUser().GetUsername())
+	require.Len(t, resp.GetUser().GetWallets(), 1)
+	assert.Equal(t, 100.0, resp.GetUser().GetWallets()[0].GetBalance())
+}
+
+func TestServer_ErrorMapping(t *testing.T) {
+	ts := newTestServer(t)
+	client := ewalletv1.NewTransactionServiceClient(ts.conn)
+	ctx := metadata.AppendToOutgoingContext(context.Background(),
+		"authorization", "Bearer "+signToken(t, 3),
+		AcceptLanguageMetadata, "id-ID",
+	)
+
+	testCases := []struct {
+		name         string
+		err          error
+		expectedCode codes.Code
+		expectedMsg  string
+		expectedErr  errors.Code
+	}{
+		{
+			name:         "catalog coded error",
+			err:          errors.WithCode(errors.BadRequest.New("Insufficient funds"), errors.CodeInsufficientFunds),
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Saldo tidak mencukupi.",
+			expectedErr:  errors.CodeInsufficientFunds,
+		},
+		{
+			name:         "not found",
+			err:          errors.NotFound.New("user not found"),
+			expectedCode: codes.NotFound,
+			expectedMsg:  "Data tidak ditemukan.",
+			expectedErr:  errors.CodeNotFound,
+		},
+		{
+			name:         "internal",
+			err:          errors.InternalServer.New("failed to update balance"),
+			expectedCode: codes.Internal,
+			expectedErr:  errors.CodeInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts.transactions.EXPECT().
+				CreateDebitTransaction(gomock.Any(), request.CreateDebitTransactionRequest{UserID: 3, Amount: 10, Currency: "IDR", Channel: constants.ChannelGRPC}).
+				Return(int32(0), 0.0, tc.err)
+
+			_, err := client.CreateDebitTransaction(ctx, &ewalletv1.CreateTransactionRequest{Amount: 10, Currency: "IDR"})
+
+			st := status.Convert(err)
+			assert.Equal(t, tc.expectedCode, st.Code())
+			if tc.expectedMsg != "" {
+				assert.Equal(t, tc.expectedMsg, st.Message())
+			}
+			assert.Equal(t, string(tc.expectedErr), errorInfo(t, err).GetReason())
+		})
+	}
+}
+
+func TestServer_QuotedDebit(t *testing.T) {
+	ts := newTestServer(t)
+	client := ewalletv1.NewTransactionServiceClient(ts.conn)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+signToken(t, 3))
+	expiresAt := time.Now().Add(30 * time.Second).Truncate(time.Second)
+
+	ts.transactions.EXPECT().
+		QuoteTransaction(gomock.Any(), request.CreateTransactionQuoteRequest{UserID: 3, Type: constants.TransactionTypeDebit, Amount: 10, Currency: "IDR", Channel: constants.ChannelGRPC}).
+		Return(usecase.TransactionQuote{Type: constants.TransactionTypeDebit, Currency: "IDR", Amount: 10, Fee: 1, Balance: 100, ResultingBalance: 89, QuoteID: "quote", ExpiresAt: expiresAt}, nil)
+	ts.transactions.EXPECT().
+		CreateDebitTransaction(gomock.Any(), request.CreateDebitTransactionRequest{UserID: 3, Amount: 10, Currency: "IDR", QuoteID: "quote", Channel: constants.ChannelGRPC}).
+		Return(int32(9), 89.0, nil)
+
+	quote, err := client.QuoteTransaction(ctx, &ewalletv1.QuoteTransactionRequest{Type: constants.TransactionTypeDebit, Amount: 10, Currency: "IDR"})
+	require.NoError(t, err)
+	assert.Equal(t, 89.0, quote.GetResultingBalance())
+	assert.Equal(t, expiresAt.Unix(), quote.GetExpiresAt())
+
+	resp, err := client.CreateDebitTransaction(ctx, &ewalletv1.CreateTransactionRequest{Amount: 10, Currency: "IDR", QuoteId: quote.GetQuoteId()})
+	require.NoError(t, err)
+	assert.Equal(t, 89.0, resp.GetNewBalance())
+}
+
+func TestRateLimit(t *testing.T) {
+	testCases := []struct {
+		name     string
+		method   string
+		tokens   string
+		wantErr  error
+		wantCall bool
+	}{
+		{name: "should let a transaction through while the bucket has room", method: ewalletv1.TransactionService_CreateDebitTransaction_FullMethodName, wantCall: true},
+		{name: "should reject a transaction once the bucket is full", method: ewalletv1.TransactionService_CreateDebitTransaction_FullMethodName, tokens: "1", wantErr: middleware.ErrSuspiciousActivity},
+		{name: "should skip methods that aren't rate limited", method: ewalletv1.UserService_GetUser_FullMethodName, wantCall: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			redis := mock_service.NewMockRedisServiceInterface(ctrl)
+
+			// keyed like middleware.CheckRateLimit: REST handler name and the peer IP without its port
+			key := "rate-limiter:CreateDebitTransaction:10.0.0.1"
+			violationKey := "violation-checker:CreateDebitTransaction:10.0.0.1"
+			if _, limited := RateLimitedMethods[tc.method]; limited {
+				lastLeak := ""
+				if tc.tokens != "" {
+					lastLeak = time.Now().Format(time.RFC3339Nano)
+				}
+				redis.EXPECT().Hget(key, "lastLeak").Return(&lastLeak, nil)
+				redis.EXPECT().Hget(key, "tokens").Return(&tc.tokens, nil)
+				if tc.wantErr == nil {
+					redis.EXPECT().HsetWithExpiry(key, gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
+				} else {
+					redis.EXPECT().Get(violationKey, gomock.Any()).Return(nil)
+					redis.EXPECT().SetWithExpiry(violationKey, 1, gomock.Any()).Return(nil)
+				}
+				redis.EXPECT().Get(violationKey+":marked", gomock.Any()).Return(assert.AnError)
+			}
+
+			ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 51234}})
+			called := false
+			_, err := RateLimit(middleware.NewRateLimiter(redis, []string{}), RateLimitedMethods)(ctx, nil, &gogrpc.UnaryServerInfo{FullMethod: tc.method},
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					called = true
+					return nil, nil
+				})
+
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCall, called)
+		})
+	}
+}
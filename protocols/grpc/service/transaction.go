@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"kc-ewallet/constants"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/protocols/http/middleware"
+	"kc-ewallet/protocols/http/request"
+
+	ewalletv1 "kc-ewallet/protocols/grpc/proto/ewallet/v1"
+)
+
+type TransactionService struct {
+	ewalletv1.UnimplementedTransactionServiceServer
+	usecase usecase.ITransactionUsecase
+}
+
+func NewTransactionService(usecase usecase.ITransactionUsecase) *TransactionService {
+	return &TransactionService{
+		usecase: usecase,
+	}
+}
+
+func (s *TransactionService) CreateCreditTransaction(ctx context.Context, req *ewalletv1.CreateTransactionRequest) (*ewalletv1.CreateTransactionResponse, error) {
+	actor, err := middleware.NewActorFromContext(ctx)
+	if err != nil {
+		return nil, middleware.ErrUnauthorized
+	}
+
+	body := request.CreateCreditTransactionRequest{
+		UserID:   actor.UserID,
+		Amount:   req.GetAmount(),
+		Currency: req.GetCurrency(),
+		QuoteID:  req.GetQuoteId(),
+		Channel:  constants.ChannelGRPC,
+	}
+	if err := validate(ctx, body); err != nil {
+		return nil, err
+	}
+
+	transactionID, newBalance, err := s.usecase.CreateCreditTransaction(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ewalletv1.CreateTransactionResponse{TransactionId: transactionID, NewBalance: newBalance, Currency: body.Currency}, nil
+}
+
+func (s *TransactionService) CreateDebitTransaction(ctx context.Context, req *ewalletv1.CreateTransactionRequest) (*ewalletv1.CreateTransactionResponse, error) {
+	actor, err := middleware.NewActorFromContext(ctx)
+	if err != nil {
+		return nil, middleware.ErrUnauthorized
+	}
+
+	body := request.CreateDebitTransactionRequest{
+		UserID:   actor.UserID,
+		Amount:   req.GetAmount(),
+		Currency: req.GetCurrency(),
+		QuoteID:  req.GetQuoteId(),
+		Channel:  constants.ChannelGRPC,
+	}
+	if err := validate(ctx, body); err != nil {
+		return nil, err
+	}
+
+	transactionID, newBalance, err := s.usecase.CreateDebitTransaction(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ewalletv1.CreateTransactionResponse{TransactionId: transactionID, NewBalance: newBalance, Currency: body.Currency}, nil
+}
+
+func (s *TransactionService) QuoteTransaction(ctx context.Context, req *ewalletv1.QuoteTransactionRequest) (*ewalletv1.QuoteTransactionResponse, error) {
+	actor, err := middleware.NewActorFromContext(ctx)
+	if err != nil {
+		return nil, middleware.ErrUnauthorized
+	}
+
+	body := request.CreateTransactionQuoteRequest{
+		UserID:   actor.UserID,
+		Type:     req.GetType(),
+		Amount:   req.GetAmount(),
+		Currency: req.GetCurrency(),
+		ToUserID: req.GetToUserId(),
+		Channel:  constants.ChannelGRPC,
+	}
+	if err := validate(ctx, body); err != nil {
+		return nil, err
+	}
+
+	quote, err := s.usecase.QuoteTransaction(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ewalletv1.QuoteTransactionResponse{
+		Type:             quote.Type,
+		Currency:         quote.Currency,
+		Amount:           quote.Amount,
+		Fee:              quote.Fee,
+		Balance:          quote.Balance,
+		ResultingBalance: quote.ResultingBalance,
+		QuoteId:          quote.QuoteID,
+	}
+	if quote.QuoteID != "" {
+		res.ExpiresAt = quote.ExpiresAt.Unix()
+	}
+	return res, nil
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/protocols/http/middleware"
+	"kc-ewallet/protocols/http/request"
+
+	ewalletv1 "kc-ewallet/protocols/grpc/proto/ewallet/v1"
+)
+
+type UserService struct {
+	ewalletv1.UnimplementedUserServiceServer
+	usecase usecase.IUserUsecase
+	wallets usecase.IWalletUsecase
+}
+
+func NewUserService(usecase usecase.IUserUsecase, walletUsecase usecase.IWalletUsecase) *UserService {
+	return &UserService{
+		usecase: usecase,
+		wallets: walletUsecase,
+	}
+}
+
+func (s *UserService) RegisterUser(ctx context.Context, req *ewalletv1.RegisterUserRequest) (*ewalletv1.RegisterUserResponse, error) {
+	body := request.RegisterUserRequest{
+		Username: req.GetUsername(),
+		Password: req.GetPassword(),
+	}
+	if err := validate(ctx, body); err != nil {
+		return nil, err
+	}
+
+	if err := s.usecase.CreateUser(ctx, body); err != nil {
+		return nil, err
+	}
+
+	return &ewalletv1.RegisterUserResponse{}, nil
+}
+
+func (s *UserService) Login(ctx context.Context, req *ewalletv1.LoginRequest) (*ewalletv1.LoginResponse, error) {
+	body := request.LoginRequest{
+		Username: req.GetUsername(),
+		Password: req.GetPassword(),
+	}
+	if err := validate(ctx, body); err != nil {
+		return nil, err
+	}
+
+	accessToken, user, err := s.usecase.Login(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ewalletv1.LoginResponse{
+		AccessToken: accessToken,
+		User:        newUser(*user),
+	}, nil
+}
+
+func (s *UserService) GetUser(ctx context.Context, req *ewalletv1.GetUserRequest) (*ewalletv1.GetUserResponse, error) {
+	actor, err := middleware.NewActorFromContext(ctx)
+	if err != nil {
+		return nil, middleware.ErrUnauthorized
+	}
+
+	user, err := s.usecase.GetUserByID(ctx, actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	wallets, err := s.wallets.ListWallets(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := newUser(*user)
+	for _, wallet := range wallets {
+		res.Wallets = append(res.Wallets, &ewalletv1.Wallet{
+			Id:       wallet.ID,
+			Currency: wallet.Currency,
+			Balance:  wallet.Balance,
+		})
+	}
+	return &ewalletv1.GetUserResponse{User: res}, nil
+}
+
+func newUser(user postgres.User) *ewalletv1.User {
+	return &ewalletv1.User{
+		Id:       user.ID,
+		Username: user.Username,
+	}
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/internals/helpers/language"
+	"kc-ewallet/protocols/http/middleware"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/grpc/metadata"
+)
+
+// validate runs the same `binding` rules gin applies to the HTTP request structs
+func validate(ctx context.Context, request interface{}) error {
+	err := binding.Validator.ValidateStruct(request)
+	if err == nil {
+		return nil
+	}
+
+	validationErrs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return errors.BadRequest.New(err.Error())
+	}
+
+	lang := language.Default
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("accept-language"); len(values) > 0 {
+			lang = language.FromAcceptLanguage(values[0])
+		}
+	}
+	return middleware.NewValidationError(validationErrs, lang)
+}
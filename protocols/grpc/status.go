@@ -0,0 +1,129 @@
+package grpc
+
+import (
+	"context"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/internals/helpers/language"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain is the ErrorInfo domain of the catalog codes
+const ErrorDomain = "kc-ewallet"
+
+// AcceptLanguageMetadata picks the language of status messages, like the Accept-Language header
+const AcceptLanguageMetadata = "accept-language"
+
+// statusCodes maps the http status of an errors.ErrorType to its gRPC code
+var statusCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusUnprocessableEntity: codes.InvalidArgument,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+	http.StatusInternalServerError: codes.Internal,
+}
+
+// Code returns the gRPC code of err from its errors.ErrorType
+func Code(err error) codes.Code {
+	if code, ok := statusCodes[httpStatus(err)]; ok {
+		return code
+	}
+	return codes.Unknown
+}
+
+// httpStatus follows response.RespondError, extended errors carry their own status
+func httpStatus(err error) int {
+	errType := errors.GetType(err)
+	if extError, ok := err.(errors.ExtError); ok && errType == errors.ExtendedError {
+		return extError.GetCode()
+	}
+
+	statusCode, _ := strconv.Atoi(strconv.Itoa(int(errType))[:3])
+	return statusCode
+}
+
+// Status converts err into a gRPC status error. The message and details come from the
+// error catalog in the language of the accept-language metadata, like response.RespondError
+func Status(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(interface{ GRPCStatus() *status.Status }); ok {
+		return err
+	}
+
+	var (
+		lang    = languageFromContext(ctx)
+		entry   = errors.CatalogEntryOf(err)
+		message string
+	)
+	switch {
+	case errors.HasCode(err):
+		message = entry.Message(lang)
+		if extError, ok := err.(errors.ExtError); ok {
+			message = extError.GetEnMessage()
+			if lang == language.Indonesian {
+				message = extError.GetIdMessage()
+			}
+		}
+	case lang == language.English && err.Error() != "":
+		// uncatalogued messages are written in english by the usecases
+		message = err.Error()
+	default:
+		message = entry.Message(lang)
+	}
+
+	redirectCode := entry.RedirectCode
+	if extError, ok := err.(errors.ExtError); ok {
+		redirectCode = extError.GetRedirectCode()
+	}
+
+	st := status.New(Code(err), message)
+	withDetails, detailErr := st.WithDetails(
+		&errdetails.ErrorInfo{
+			Reason:   string(entry.Code),
+			Domain:   ErrorDomain,
+			Metadata: map[string]string{"redirect_code": strconv.Itoa(int(redirectCode))},
+		},
+		&errdetails.LocalizedMessage{Locale: string(lang), Message: message},
+	)
+	if detailErr == nil {
+		st = withDetails
+	}
+
+	if fields := errors.GetFields(err); len(fields) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, field := range slices.Sorted(maps.Keys(fields)) {
+			for _, description := range fields[field] {
+				badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+					Field:       field,
+					Description: description,
+				})
+			}
+		}
+		if withDetails, detailErr := st.WithDetails(badRequest); detailErr == nil {
+			st = withDetails
+		}
+	}
+
+	return st.Err()
+}
+
+func languageFromContext(ctx context.Context) language.Language {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(AcceptLanguageMetadata)
+	if len(values) == 0 {
+		return language.Default
+	}
+	return language.FromAcceptLanguage(values[0])
+}
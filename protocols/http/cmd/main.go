@@ -1,26 +1,56 @@
 package main
 
 import (
+	"context"
 	"io"
 	"kc-ewallet/configurations"
-	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/repository"
+	"kc-ewallet/domains/repository/cache"
+	"kc-ewallet/domains/repository/fx"
+	"kc-ewallet/domains/repository/stream"
+	"kc-ewallet/domains/usecase/audit"
+	"kc-ewallet/domains/usecase/bill"
+	"kc-ewallet/domains/usecase/fee"
+	"kc-ewallet/domains/usecase/merchant"
+	"kc-ewallet/domains/usecase/paymentrequest"
+	"kc-ewallet/domains/usecase/pocket"
+	"kc-ewallet/domains/usecase/realtime"
+	"kc-ewallet/domains/usecase/savings"
+	"kc-ewallet/domains/usecase/schedule"
 	"kc-ewallet/domains/usecase/transaction"
 	"kc-ewallet/domains/usecase/user"
+	"kc-ewallet/domains/usecase/wallet"
+	"kc-ewallet/domains/usecase/webhook"
 	"kc-ewallet/internals/database"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/internals/health"
+	jwtHelper "kc-ewallet/internals/helpers/jwt"
 	"kc-ewallet/internals/helpers/logging"
 	rate_limit "kc-ewallet/internals/helpers/rate_limiter"
+	"kc-ewallet/internals/helpers/redact"
+	"kc-ewallet/internals/helpers/redis"
 	"kc-ewallet/internals/helpers/server"
+	"kc-ewallet/internals/metric"
+	"kc-ewallet/internals/tracer"
 	"kc-ewallet/migrations"
+	grpcserver "kc-ewallet/protocols/grpc"
 	"kc-ewallet/protocols/http/controller"
+	"kc-ewallet/protocols/http/middleware"
 	"kc-ewallet/protocols/http/routes"
 	"log"
+	"log/slog"
 	"os"
 	"strconv"
+	"time"
 
+	"github.com/getsentry/sentry-go"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
+	metricsdk "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 	"go.uber.org/zap"
 )
 
@@ -36,58 +66,87 @@ func main() {
 	databaseConfiguration := configurations.NewDatabaseWriter()
 	jwtConfiguration := configurations.NewJWTConfiguration()
 	redisConfiguration := configurations.NewRedisConfiguration()
+	errorReporterConfiguration := configurations.NewErrorReporterConfiguration()
+	realtimeConfiguration := configurations.NewRealtimeConfiguration()
+	webhookConfiguration := configurations.NewWebhookConfiguration()
+	merchantConfiguration := configurations.NewMerchantConfiguration()
+	settlementConfiguration := configurations.NewSettlementConfiguration()
+	feeConfiguration := configurations.NewFeeConfiguration()
+	quoteConfiguration := configurations.NewQuoteConfiguration()
+	fxConfiguration := configurations.NewFXConfiguration()
+	savingsConfiguration := configurations.NewSavingsConfiguration()
+	scheduleConfiguration := configurations.NewScheduleConfiguration()
+	paymentRequestConfiguration := configurations.NewPaymentRequestConfiguration()
+	billConfiguration := configurations.NewBillConfiguration()
+
+	if err := logging.SetLevel(appConfiguration.GetLogLevel()); err != nil {
+		log.Printf("invalid log level %q, keep %s: %v", appConfiguration.GetLogLevel(), logging.Level, err)
+	}
 
 	// Initialize helpers
 	// _ := jwt.NewJWTHelper(jwtConfiguration)
 
+	// Initialize error reporter
+	if err := setupErrorReporter(errorReporterConfiguration, appConfiguration); err != nil {
+		log.Fatalf("create error reporter failed: %v", err)
+	}
+	log.Printf("error reporter: %s \n", errorReporterConfiguration.GetReporter())
+
 	// Set OpenTelemetry propagator to W3C TraceContext for proper traceparent extraction
 	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
 
-	// Initialize tracer
-	// traceExp, err := tracer.NewExporter(context.Background(), appConfiguration.GetEnableTracer(), appConfiguration.GetOtelCollector())
-	// if err != nil {
-	// 	log.Fatal("create trace exporter failed: %w", err)
-	// }
-	// tp, err := tracer.NewTraceProvider(traceExp, appConfiguration.GetAppName())
-	// if err != nil {
-	// 	log.Fatal("create trace provider failed: %w", err)
-	// }
-	// defer func() {
-	// 	ctxWithTimeout, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	// 	defer cancel()
-
-	// 	_ = tp.Shutdown(ctxWithTimeout)
-	// }()
-	// otel.SetTracerProvider(tp)
-	// _ = tp.Tracer(appConfiguration.GetAppName())
-
-	// Initialize metric
-	// metricExp, err := metric.NewExporter(context.Background(), appConfiguration.GetEnableMetric(), appConfiguration.GetOtelCollector())
-	// if err != nil {
-	// 	log.Fatal("create metric exporter failed: %w", err)
-	// }
-
-	// mp, err := metric.NewMeterProvider(metricExp, appConfiguration.GetAppName())
-	// if err != nil {
-	// 	log.Fatal("create metric provider failed: %w", err)
-	// }
-	// defer func() {
-	// 	ctxWithTimeout, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	// 	defer cancel()
-
-	// 	_ = mp.Shutdown(ctxWithTimeout)
-	// }()
-
-	// otel.SetMeterProvider(mp)
-	// meter := mp.Meter(appConfiguration.GetAppName())
-
-	// _, err = metric.NewMetric(meter)
-	// if err != nil {
-	// 	log.Fatal("create new metrics failed: %w", err)
-	// }
+	// Initialize tracer, disabled keeps the global noop provider so no span is recorded
+	var appTracer trace.Tracer = noop.NewTracerProvider().Tracer(appConfiguration.GetAppName())
+	stopTracer := func(context.Context) error { return nil }
+	if appConfiguration.GetEnableTracer() {
+		traceExp, err := tracer.NewExporter(context.Background(), appConfiguration.GetOtelCollector())
+		if err != nil {
+			log.Fatalf("create trace exporter failed: %v", err)
+		}
+		tp, err := tracer.NewTraceProvider(traceExp, appConfiguration.GetAppName())
+		if err != nil {
+			log.Fatalf("create trace provider failed: %v", err)
+		}
+		otel.SetTracerProvider(tp)
+		appTracer = tp.Tracer(appConfiguration.GetAppName())
+		stopTracer = tp.Shutdown
+	}
+
+	// Initialize metric, disabled pushes nothing and only the prometheus reader (if any) collects
+	var metricExp metricsdk.Exporter
+	if appConfiguration.GetEnableMetric() {
+		var err error
+		metricExp, err = metric.NewExporter(context.Background(), appConfiguration.GetOtelCollector())
+		if err != nil {
+			log.Fatalf("create metric exporter failed: %v", err)
+		}
+	}
+
+	var metricReaders []metricsdk.Reader
+	if appConfiguration.GetEnablePrometheus() {
+		promReader, err := metric.NewPrometheusReader()
+		if err != nil {
+			log.Fatalf("create prometheus reader failed: %v", err)
+		}
+		metricReaders = append(metricReaders, promReader)
+	}
+
+	mp, err := metric.NewMeterProvider(metricExp, appConfiguration.GetAppName(), metricReaders...)
+	if err != nil {
+		log.Fatalf("create metric provider failed: %v", err)
+	}
+
+	otel.SetMeterProvider(mp)
+	meter := mp.Meter(appConfiguration.GetAppName())
+
+	appMetric, err := metric.NewMetric(meter)
+	if err != nil {
+		log.Fatalf("create new metrics failed: %v", err)
+	}
 
 	log.Printf("trace available: %v at: %s \n", appConfiguration.GetEnableTracer(), appConfiguration.GetOtelCollector())
 	log.Printf("metric available: %v at: %s \n", appConfiguration.GetEnableMetric(), appConfiguration.GetOtelCollector())
+	log.Printf("prometheus available: %v at: %s \n", appConfiguration.GetEnablePrometheus(), routes.Metrics)
 
 	// Initialize redis
 	rate_limit.InitCoreRedis(redisConfiguration) // for rate limiter
@@ -106,22 +165,96 @@ func main() {
 	}
 
 	// Initialize repositories
-	postgresRepo := postgres.New(postgresWriter.GetDB())
+	postgresRepo := repository.NewTracedRepository(postgresWriter.GetDB(), appTracer)
+	userCache := cache.NewUserCache(rate_limit.NewCacheService(), cache.DefaultUserTTL, appTracer)
+	eventStream := stream.NewRedisEventStream(redis.RedisPool, realtimeConfiguration.GetReplaySize(), appTracer)
+	nonceCache := cache.NewNonceCache(redis.RedisPool, appTracer)
 
 	// Initialize usecases
-	userUsecase := user.NewUserUsecase(postgresWriter.GetDB(), postgresRepo, jwtConfiguration, nil)
-	transactionUsecase := transaction.NewTransactionUsecase(postgresWriter.GetDB(), postgresRepo, nil)
+	auditUsecase := audit.NewAuditUsecase(postgresWriter.GetDB(), postgresRepo, appTracer)
+	userUsecase := user.NewUserUsecase(postgresWriter.GetDB(), postgresRepo, userCache, jwtConfiguration, appTracer, appMetric, auditUsecase)
+	walletUsecase := wallet.NewWalletUsecase(postgresRepo, userCache, auditUsecase, appTracer)
+	pocketUsecase := pocket.NewPocketUsecase(postgresWriter.GetDB(), postgresRepo, userCache, auditUsecase, appTracer)
+	savingsUsecase := savings.NewSavingsUsecase(postgresWriter.GetDB(), postgresRepo, userCache, savingsConfiguration, auditUsecase, appTracer)
+	webhookUsecase := webhook.NewWebhookUsecase(postgresRepo, webhookConfiguration, auditUsecase, appTracer)
+	feeUsecase := fee.NewFeeUsecase(postgresWriter.GetDB(), postgresRepo, feeConfiguration, auditUsecase, appTracer)
+	transactionDependencies := transaction.Dependencies{
+		DB:         postgresWriter.GetDB(),
+		Repository: postgresRepo,
+		Trace:      appTracer,
+		Metric:     appMetric,
+		Audit:      auditUsecase,
+		Events:     eventStream,
+		Cache:      userCache,
+		Webhook:    webhookUsecase,
+		Fees:       feeUsecase,
+		Quote:      quoteConfiguration,
+		Savings:    savingsUsecase,
+	}
+	transactionUsecase := transaction.NewTransactionUsecase(transactionDependencies)
+	exchangeUsecase := transaction.NewExchangeUsecase(transactionDependencies, fx.NewStaticRateSource(fxConfiguration.GetRates()), fxConfiguration)
+	scheduleUsecase := schedule.NewScheduleUsecase(postgresWriter.GetDB(), postgresRepo, scheduleConfiguration, transactionUsecase, auditUsecase, appTracer)
+	paymentRequestUsecase := paymentrequest.NewPaymentRequestUsecase(postgresRepo, paymentRequestConfiguration, transactionUsecase, auditUsecase, appTracer)
+	billUsecase := bill.NewBillUsecase(postgresWriter.GetDB(), postgresRepo, billConfiguration, transactionUsecase, eventStream, auditUsecase, appTracer)
+	realtimeUsecase := realtime.NewRealtimeUsecase(eventStream, realtimeConfiguration, appTracer)
+	merchantUsecase := merchant.NewMerchantUsecase(postgresWriter.GetDB(), postgresRepo, nonceCache, merchantConfiguration, auditUsecase, appTracer)
+	settlementUsecase := transaction.NewSettlementUsecase(transactionDependencies, settlementConfiguration)
 
 	// Initialize controllers
-	userController := controller.NewUserController(userUsecase)
+	userController := controller.NewUserController(userUsecase, pocketUsecase)
+	walletController := controller.NewWalletController(walletUsecase)
+	pocketController := controller.NewPocketController(pocketUsecase)
+	savingsController := controller.NewSavingsController(savingsUsecase)
+	transferController := controller.NewTransferController(transactionUsecase)
+	scheduleController := controller.NewScheduleController(scheduleUsecase)
+	paymentRequestController := controller.NewPaymentRequestController(paymentRequestUsecase)
+	billController := controller.NewBillController(billUsecase)
 	transactionController := controller.NewTransactionController(transactionUsecase)
+	exchangeController := controller.NewExchangeController(exchangeUsecase)
+	eventController := controller.NewEventController(realtimeUsecase, realtimeConfiguration.GetHeartbeatInterval())
+	webhookController := controller.NewWebhookController(webhookUsecase)
+	merchantController := controller.NewMerchantController(merchantUsecase)
+	paymentController := controller.NewPaymentController(transactionUsecase)
+	settlementController := controller.NewSettlementController(settlementUsecase)
+	feeController := controller.NewFeeController(feeUsecase)
 
 	// Initialize router with middleware
-	router := routes.InitRouter(appConfiguration, nil)
+	router := routes.InitRouter(appConfiguration, appTracer)
+
+	// Register readiness checks
+	healthRegistry := health.NewRegistry(health.DefaultCacheTTL)
+	healthRegistry.Register("postgres", health.Critical, 2*time.Second, health.SQLChecker(postgresWriter.GetDB()))
+	healthRegistry.Register("redis", health.Critical, time.Second, health.RedisChecker(redis.RedisPool))
+	healthRegistry.Register("migration", health.Critical, 2*time.Second, migrations.StateChecker(postgresWriter.GetDB()))
+	// the relay only delays the webhooks, the payments keep working without it
+	healthRegistry.Register("webhook relay", health.NonCritical, 2*time.Second, webhookUsecase.CheckRelay)
 
 	// Register routes
-	routes.RegisterUserRoutes(router, jwtConfiguration.GetSigningKey(), userController)
-	routes.RegisterTransactionRoutes(router, jwtConfiguration.GetSigningKey(), transactionController)
+	routes.RegisterRoutes(router, routes.Dependencies{
+		AppName:          appConfiguration.GetAppName(),
+		JWTSigningKey:    jwtConfiguration.GetSigningKey(),
+		EnablePrometheus: appConfiguration.GetEnablePrometheus(),
+		Metric:           appMetric,
+		Audit:            auditUsecase,
+		Merchant:         merchantUsecase,
+		Health:           healthRegistry,
+		User:             userController,
+		Wallet:           walletController,
+		Pocket:           pocketController,
+		Savings:          savingsController,
+		Transfer:         transferController,
+		Schedule:         scheduleController,
+		PaymentRequest:   paymentRequestController,
+		Bill:             billController,
+		Transaction:      transactionController,
+		Exchange:         exchangeController,
+		Event:            eventController,
+		Webhook:          webhookController,
+		MerchantAPI:      merchantController,
+		Payment:          paymentController,
+		Settlement:       settlementController,
+		Fee:              feeController,
+	})
 
 	// Create and start the server
 	port, err := strconv.Atoi(appConfiguration.GetPort())
@@ -134,8 +267,90 @@ func main() {
 		Domain: getDomain(appConfiguration),
 	}, router)
 
+	grpcPort, err := strconv.Atoi(appConfiguration.GetGRPCPort())
+	if err != nil {
+		log.Fatalf("invalid grpc port -> %+v", err)
+	}
+
+	grpcServer := grpcserver.NewServer(&grpcserver.ServerConfiguration{
+		Port:          grpcPort,
+		Domain:        getDomain(appConfiguration),
+		JWTSigningKey: jwtConfiguration.GetSigningKey(),
+		TokenHelper:   jwtHelper.NewJWTHelper(jwtConfiguration),
+		Tracer:        appTracer,
+		RateLimiter:   middleware.NewRateLimiter(rate_limit.NewCacheService(), []string{}).WithMetric(appMetric).WithAudit(auditUsecase),
+	}, userUsecase, walletUsecase, transactionUsecase)
+
+	// Components stop in reverse: grpc and http servers drain first, telemetry flushes last
+	lifecycle := server.NewLifecycle(server.LifecycleConfiguration{
+		DrainTimeout:         appConfiguration.GetShutdownDrainTimeout(),
+		ReadinessGracePeriod: appConfiguration.GetShutdownReadinessGrace(),
+		StopTimeout:          10 * time.Second,
+	})
+	if sentry.CurrentHub().Client() != nil {
+		lifecycle.Register(server.Component{Name: "sentry", Stop: func(ctx context.Context) error {
+			sentry.Flush(2 * time.Second)
+			return nil
+		}})
+	}
+	lifecycle.Register(server.Component{Name: "trace provider", Stop: stopTracer})
+	lifecycle.Register(server.Component{Name: "meter provider", Stop: mp.Shutdown})
+	lifecycle.Register(server.Component{Name: "postgres", Stop: func(ctx context.Context) error {
+		return postgresWriter.GetDB().Close()
+	}})
+	lifecycle.Register(server.Component{Name: "redis", Stop: func(ctx context.Context) error {
+		return redis.RedisPool.Close()
+	}})
+	lifecycle.Register(backgroundComponent("realtime listener", realtimeUsecase.Run))
+	lifecycle.Register(backgroundComponent("webhook worker", webhookUsecase.Run))
+	lifecycle.Register(backgroundComponent("settlement job", settlementUsecase.Run))
+	lifecycle.Register(backgroundComponent("savings sweep job", savingsUsecase.Run))
+	lifecycle.Register(backgroundComponent("scheduled transfer worker", scheduleUsecase.Run))
+	lifecycle.Register(backgroundComponent("payment request expiry sweeper", paymentRequestUsecase.Run))
+	lifecycle.Register(restServer.Component())
+	lifecycle.Register(grpcServer.Component())
+	lifecycle.OnDrain(func() {
+		healthRegistry.SetDraining(true)
+	})
+	// open event streams would otherwise hold the http server until the drain timeout
+	lifecycle.OnDrain(realtimeUsecase.Close)
+
 	log.Printf("Starting http server on port %s...", appConfiguration.GetPort())
-	restServer.Serve()
+	log.Printf("Starting grpc server on port %s...", appConfiguration.GetGRPCPort())
+	if err := lifecycle.Run(context.Background()); err != nil {
+		log.Fatalf("listen: %s\n", err)
+	}
+}
+
+// backgroundComponent runs a worker loop until it is stopped, registered after the
+// stores it uses so it stops before them
+func backgroundComponent(name string, run func(ctx context.Context)) server.Component {
+	var (
+		cancel  context.CancelFunc
+		stopped = make(chan struct{})
+	)
+
+	return server.Component{
+		Name: name,
+		Start: func(ctx context.Context) error {
+			var runCtx context.Context
+			runCtx, cancel = context.WithCancel(context.Background())
+			go func() {
+				defer close(stopped)
+				run(runCtx)
+			}()
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			cancel()
+			select {
+			case <-stopped:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	}
 }
 
 func getDomain(appConfiguration configurations.IAppConfiguration) string {
@@ -147,11 +362,39 @@ func getDomain(appConfiguration configurations.IAppConfiguration) string {
 	return domain
 }
 
+func setupErrorReporter(config configurations.IErrorReporterConfiguration, appConfiguration configurations.IAppConfiguration) error {
+	var ignoredTypes []errors.ErrorType
+	for _, ignoredType := range config.GetIgnoredTypes() {
+		ignoredTypes = append(ignoredTypes, errors.ErrorType(ignoredType))
+	}
+	errors.SetReportFilter(errors.IgnoreTypes(ignoredTypes...))
+
+	if config.GetReporter() != configurations.ErrorReporterSentry {
+		errors.SetReporter(errors.NewLogReporter())
+		return nil
+	}
+
+	if err := sentry.Init(sentry.ClientOptions{
+		Dsn:         config.GetSentryDSN(),
+		Environment: appConfiguration.GetEnv(),
+		BeforeSend:  redact.SentryBeforeSend,
+	}); err != nil {
+		return err
+	}
+	errors.SetReporter(errors.NewSentryReporter(nil))
+
+	return nil
+}
+
 func setupLogger() {
 	logging.LogFilePath = "logs/gin.log"
 	logger := logging.New()
 
 	zap.ReplaceGlobals(logger)
+	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		Level:       logging.SlogLeveler{},
+		ReplaceAttr: redact.SlogReplaceAttr,
+	})))
 
 	gin.DefaultWriter = io.Discard
 	gin.DefaultErrorWriter = io.Discard
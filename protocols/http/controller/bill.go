@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/internals/helpers/pagination"
+	requesthelper "kc-ewallet/internals/helpers/request"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BillController struct {
+	usecase usecase.IBillUsecase
+}
+
+func NewBillController(usecase usecase.IBillUsecase) *BillController {
+	return &BillController{
+		usecase: usecase,
+	}
+}
+
+func (ctl *BillController) CreateBill(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	body := request.CreateBillRequest{
+		UserID: reqHelper.Auth.UserID,
+	}
+	if err := reqHelper.SetPostParams(&body); err != nil {
+		return
+	}
+
+	detail, err := ctl.usecase.CreateBill(ctx.Request.Context(), body)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewBillDetailResponse(detail), "success")
+}
+
+func (ctl *BillController) ListBills(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	var query request.ListBillsQuery
+	if err := reqHelper.SetQueryParams(&query); err != nil {
+		return
+	}
+
+	page := pagination.GetPaginationConfig(query.Page, query.Limit)
+	bills, total, err := ctl.usecase.ListBills(ctx.Request.Context(), request.ListBillsRequest{
+		UserID: reqHelper.Auth.UserID,
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	})
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccessWithPaginator(ctx,
+		response.NewBillsResponse(bills),
+		pagination.BuildPaginator(int(total), page.Limit, page.Offset),
+		"success",
+	)
+}
+
+func (ctl *BillController) ListOwedBillShares(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	var query request.ListOwedBillSharesQuery
+	if err := reqHelper.SetQueryParams(&query); err != nil {
+		return
+	}
+
+	page := pagination.GetPaginationConfig(query.Page, query.Limit)
+	shares, total, err := ctl.usecase.ListOwedShares(ctx.Request.Context(), request.ListOwedBillSharesRequest{
+		UserID: reqHelper.Auth.UserID,
+		Status: query.Status,
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	})
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccessWithPaginator(ctx,
+		response.NewOwedBillSharesResponse(shares),
+		pagination.BuildPaginator(int(total), page.Limit, page.Offset),
+		"success",
+	)
+}
+
+func (ctl *BillController) GetBill(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	var uri request.BillURI
+	if err := reqHelper.SetURIParams(&uri); err != nil {
+		return
+	}
+
+	detail, err := ctl.usecase.GetBill(ctx.Request.Context(), reqHelper.Auth.UserID, uri.ID)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewBillDetailResponse(detail), "success")
+}
+
+func (ctl *BillController) PayBillShare(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	var uri request.BillURI
+	if err := reqHelper.SetURIParams(&uri); err != nil {
+		return
+	}
+
+	result, err := ctl.usecase.PayShare(ctx.Request.Context(), reqHelper.Auth.UserID, uri.ID)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewTransferResponse(result), "success")
+}
+
+func (ctl *BillController) RemindBillParticipants(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	var uri request.BillURI
+	if err := reqHelper.SetURIParams(&uri); err != nil {
+		return
+	}
+
+	shares, err := ctl.usecase.Remind(ctx.Request.Context(), reqHelper.Auth.UserID, uri.ID)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewBillSharesResponse(shares), "success")
+}
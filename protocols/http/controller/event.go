@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"kc-ewallet/domains/usecase"
+	requesthelper "kc-ewallet/internals/helpers/request"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+	"net/http"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+// LastEventIDHeader is sent by EventSource when it reconnects
+const LastEventIDHeader = "Last-Event-ID"
+
+type EventController struct {
+	usecase   usecase.IRealtimeUsecase
+	heartbeat time.Duration
+}
+
+func NewEventController(usecase usecase.IRealtimeUsecase, heartbeat time.Duration) *EventController {
+	return &EventController{
+		usecase:   usecase,
+		heartbeat: heartbeat,
+	}
+}
+
+// StreamEvents pushes the events of the logged in user as Server-Sent Events
+// until the client disconnects or the server shuts down
+func (ctl *EventController) StreamEvents(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	query := request.StreamEventsQuery{}
+	if err := reqHelper.SetQueryParams(&query); err != nil {
+		return
+	}
+
+	lastEventID := ctx.GetHeader(LastEventIDHeader)
+	if lastEventID == "" {
+		lastEventID = query.LastEventID
+	}
+
+	subscription, err := ctl.usecase.Subscribe(ctx.Request.Context(), reqHelper.Auth.UserID, lastEventID)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+	defer subscription.Close()
+
+	ctx.Header("Content-Type", sse.ContentType)
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no") // nginx would buffer the stream
+	ctx.Status(http.StatusOK)
+	ctx.Writer.Flush()
+
+	heartbeat := time.NewTicker(ctl.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case <-heartbeat.C:
+			// a comment line, ignored by EventSource
+			if _, err := ctx.Writer.WriteString(": heartbeat\n\n"); err != nil {
+				return
+			}
+			ctx.Writer.Flush()
+		case event, ok := <-subscription.Events():
+			if !ok {
+				return
+			}
+			if err := sse.Encode(ctx.Writer, sse.Event{
+				Id:    event.ID,
+				Event: string(event.Type),
+				Data:  event,
+			}); err != nil {
+				return
+			}
+			ctx.Writer.Flush()
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"kc-ewallet/domains/usecase"
+	requesthelper "kc-ewallet/internals/helpers/request"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExchangeController struct {
+	usecase usecase.IExchangeUsecase
+}
+
+func NewExchangeController(usecase usecase.IExchangeUsecase) *ExchangeController {
+	return &ExchangeController{
+		usecase: usecase,
+	}
+}
+
+func (ctl *ExchangeController) QuoteExchange(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	body := request.CreateExchangeQuoteRequest{
+		UserID: reqHelper.Auth.UserID,
+	}
+	if err := reqHelper.SetPostParams(&body); err != nil {
+		return
+	}
+
+	quote, err := ctl.usecase.QuoteExchange(ctx.Request.Context(), body)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewExchangeQuoteResponse(quote), "success")
+}
+
+func (ctl *ExchangeController) CreateExchange(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	body := request.CreateExchangeRequest{
+		UserID: reqHelper.Auth.UserID,
+	}
+	if err := reqHelper.SetPostParams(&body); err != nil {
+		return
+	}
+
+	result, err := ctl.usecase.Exchange(ctx.Request.Context(), body)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewExchangeResponse(result), "success")
+}
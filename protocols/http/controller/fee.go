@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"kc-ewallet/domains/usecase"
+	requesthelper "kc-ewallet/internals/helpers/request"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FeeController struct {
+	usecase usecase.IFeeUsecase
+}
+
+func NewFeeController(usecase usecase.IFeeUsecase) *FeeController {
+	return &FeeController{
+		usecase: usecase,
+	}
+}
+
+func (ctl *FeeController) CreateFeeRule(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	var body request.CreateFeeRuleRequest
+	if err := reqHelper.SetPostParams(&body); err != nil {
+		return
+	}
+
+	rule, err := ctl.usecase.CreateRule(ctx.Request.Context(), body)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewFeeRuleResponse(rule), "success")
+}
+
+func (ctl *FeeController) ListFeeRules(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	var query request.ListFeeRulesQuery
+	if err := reqHelper.SetQueryParams(&query); err != nil {
+		return
+	}
+
+	rules, err := ctl.usecase.ListRules(ctx.Request.Context(), !query.All)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewFeeRulesResponse(rules), "success")
+}
+
+func (ctl *FeeController) DeactivateFeeRule(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	var uri request.FeeRuleURI
+	if err := reqHelper.SetURIParams(&uri); err != nil {
+		return
+	}
+
+	if err := ctl.usecase.DeactivateRule(ctx.Request.Context(), uri.ID); err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, nil, "success")
+}
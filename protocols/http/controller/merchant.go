@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"kc-ewallet/domains/usecase"
+	requesthelper "kc-ewallet/internals/helpers/request"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MerchantController struct {
+	usecase usecase.IMerchantUsecase
+}
+
+func NewMerchantController(usecase usecase.IMerchantUsecase) *MerchantController {
+	return &MerchantController{
+		usecase: usecase,
+	}
+}
+
+func (ctl *MerchantController) CreateMerchant(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	var body request.CreateMerchantRequest
+	if err := reqHelper.SetPostParams(&body); err != nil {
+		return
+	}
+
+	merchant, key, err := ctl.usecase.CreateMerchant(ctx.Request.Context(), body)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewCreateMerchantResponse(merchant, key), "success")
+}
+
+func (ctl *MerchantController) ListMerchantKeys(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	var uri request.MerchantURI
+	if err := reqHelper.SetURIParams(&uri); err != nil {
+		return
+	}
+
+	keys, err := ctl.usecase.ListKeys(ctx.Request.Context(), uri.ID)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewMerchantKeysResponse(keys), "success")
+}
+
+func (ctl *MerchantController) RotateMerchantKey(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	var uri request.MerchantURI
+	if err := reqHelper.SetURIParams(&uri); err != nil {
+		return
+	}
+
+	key, err := ctl.usecase.RotateKey(ctx.Request.Context(), uri.ID)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewIssuedMerchantKeyResponse(key), "success")
+}
+
+func (ctl *MerchantController) RevokeMerchantKey(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	var uri request.MerchantKeyURI
+	if err := reqHelper.SetURIParams(&uri); err != nil {
+		return
+	}
+
+	if err := ctl.usecase.RevokeKey(ctx.Request.Context(), uri.ID, uri.KeyID); err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, nil, "success")
+}
+
+// GetMerchantProfile is called by the merchant with a signed request
+func (ctl *MerchantController) GetMerchantProfile(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	merchant, err := ctl.usecase.GetMerchant(ctx.Request.Context(), reqHelper.Auth.MerchantID)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewMerchantResponse(merchant), "success")
+}
@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"kc-ewallet/constants"
+	"kc-ewallet/domains/usecase"
+	requesthelper "kc-ewallet/internals/helpers/request"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaymentController struct {
+	usecase usecase.ITransactionUsecase
+}
+
+func NewPaymentController(usecase usecase.ITransactionUsecase) *PaymentController {
+	return &PaymentController{
+		usecase: usecase,
+	}
+}
+
+func (ctl *PaymentController) CreatePayment(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	body := request.CreatePaymentRequest{
+		UserID:  reqHelper.Auth.UserID,
+		Channel: constants.ChannelApp,
+	}
+	if err := reqHelper.SetPostParams(&body); err != nil {
+		return
+	}
+
+	payment, newBalance, err := ctl.usecase.CreatePayment(ctx.Request.Context(), body)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewCreatePaymentResponse(payment, newBalance), "success")
+}
@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/internals/helpers/pagination"
+	requesthelper "kc-ewallet/internals/helpers/request"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaymentRequestController struct {
+	usecase usecase.IPaymentRequestUsecase
+}
+
+func NewPaymentRequestController(usecase usecase.IPaymentRequestUsecase) *PaymentRequestController {
+	return &PaymentRequestController{
+		usecase: usecase,
+	}
+}
+
+func (ctl *PaymentRequestController) CreatePaymentRequest(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	body := request.CreatePaymentRequestRequest{
+		UserID: reqHelper.Auth.UserID,
+	}
+	if err := reqHelper.SetPostParams(&body); err != nil {
+		return
+	}
+
+	paymentRequest, err := ctl.usecase.CreateRequest(ctx.Request.Context(), body)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewPaymentRequestResponse(paymentRequest), "success")
+}
+
+func (ctl *PaymentRequestController) ListIncomingPaymentRequests(ctx *gin.Context) {
+	ctl.listPaymentRequests(ctx, true)
+}
+
+func (ctl *PaymentRequestController) ListOutgoingPaymentRequests(ctx *gin.Context) {
+	ctl.listPaymentRequests(ctx, false)
+}
+
+func (ctl *PaymentRequestController) listPaymentRequests(ctx *gin.Context, incoming bool) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	var query request.ListPaymentRequestsQuery
+	if err := reqHelper.SetQueryParams(&query); err != nil {
+		return
+	}
+
+	page := pagination.GetPaginationConfig(query.Page, query.Limit)
+	paymentRequests, total, err := ctl.usecase.ListRequests(ctx.Request.Context(), request.ListPaymentRequestsRequest{
+		UserID:   reqHelper.Auth.UserID,
+		Incoming: incoming,
+		Status:   query.Status,
+		Limit:    page.Limit,
+		Offset:   page.Offset,
+	})
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccessWithPaginator(ctx,
+		response.NewPaymentRequestsResponse(paymentRequests),
+		pagination.BuildPaginator(int(total), page.Limit, page.Offset),
+		"success",
+	)
+}
+
+func (ctl *PaymentRequestController) GetPaymentRequest(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	var uri request.PaymentRequestURI
+	if err := reqHelper.SetURIParams(&uri); err != nil {
+		return
+	}
+
+	paymentRequest, err := ctl.usecase.GetRequest(ctx.Request.Context(), reqHelper.Auth.UserID, uri.ID)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewPaymentRequestResponse(paymentRequest), "success")
+}
+
+func (ctl *PaymentRequestController) PayPaymentRequest(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	var uri request.PaymentRequestURI
+	if err := reqHelper.SetURIParams(&uri); err != nil {
+		return
+	}
+
+	result, err := ctl.usecase.Pay(ctx.Request.Context(), reqHelper.Auth.UserID, uri.ID)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewTransferResponse(result), "success")
+}
+
+func (ctl *PaymentRequestController) DeclinePaymentRequest(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	var uri request.PaymentRequestURI
+	if err := reqHelper.SetURIParams(&uri); err != nil {
+		return
+	}
+
+	paymentRequest, err := ctl.usecase.Decline(ctx.Request.Context(), reqHelper.Auth.UserID, uri.ID)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewPaymentRequestResponse(paymentRequest), "success")
+}
+
+func (ctl *PaymentRequestController) CancelPaymentRequest(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	var uri request.PaymentRequestURI
+	if err := reqHelper.SetURIParams(&uri); err != nil {
+		return
+	}
+
+	paymentRequest, err := ctl.usecase.Cancel(ctx.Request.Context(), reqHelper.Auth.UserID, uri.ID)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewPaymentRequestResponse(paymentRequest), "success")
+}
@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/internals/helpers/pagination"
+	requesthelper "kc-ewallet/internals/helpers/request"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PocketController struct {
+	usecase usecase.IPocketUsecase
+}
+
+func NewPocketController(usecase usecase.IPocketUsecase) *PocketController {
+	return &PocketController{
+		usecase: usecase,
+	}
+}
+
+func (ctl *PocketController) CreatePocket(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	body := request.CreatePocketRequest{
+		UserID: reqHelper.Auth.UserID,
+	}
+	if err := reqHelper.SetPostParams(&body); err != nil {
+		return
+	}
+
+	pocket, err := ctl.usecase.CreatePocket(ctx.Request.Context(), body)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewPocketResponse(pocket), "success")
+}
+
+func (ctl *PocketController) ListPockets(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	balances, err := ctl.usecase.ListBalances(ctx.Request.Context(), reqHelper.Auth.UserID)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewWalletBalancesResponse(balances), "success")
+}
+
+func (ctl *PocketController) MovePocketMoney(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	body := request.MovePocketMoneyRequest{
+		UserID: reqHelper.Auth.UserID,
+	}
+	if err := reqHelper.SetPostParams(&body); err != nil {
+		return
+	}
+
+	balance, err := ctl.usecase.MoveMoney(ctx.Request.Context(), body)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewWalletBalanceResponse(balance), "success")
+}
+
+func (ctl *PocketController) SetDefaultPocket(ctx *gin.Context) {
+	ctl.setDefaultPocket(ctx, true)
+}
+
+func (ctl *PocketController) UnsetDefaultPocket(ctx *gin.Context) {
+	ctl.setDefaultPocket(ctx, false)
+}
+
+func (ctl *PocketController) setDefaultPocket(ctx *gin.Context, isDefault bool) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	var uri request.PocketURI
+	if err := reqHelper.SetURIParams(&uri); err != nil {
+		return
+	}
+
+	pocket, err := ctl.usecase.SetDefaultPocket(ctx.Request.Context(), reqHelper.Auth.UserID, uri.ID, isDefault)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewPocketResponse(pocket), "success")
+}
+
+func (ctl *PocketController) ListPocketEntries(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	var uri request.PocketURI
+	if err := reqHelper.SetURIParams(&uri); err != nil {
+		return
+	}
+	var query request.ListPocketEntriesQuery
+	if err := reqHelper.SetQueryParams(&query); err != nil {
+		return
+	}
+
+	page := pagination.GetPaginationConfig(query.Page, query.Limit)
+	entries, total, err := ctl.usecase.ListEntries(ctx.Request.Context(), request.ListPocketEntriesRequest{
+		UserID:   reqHelper.Auth.UserID,
+		PocketID: uri.ID,
+		Limit:    page.Limit,
+		Offset:   page.Offset,
+	})
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccessWithPaginator(ctx,
+		response.NewPocketEntriesResponse(entries),
+		pagination.BuildPaginator(int(total), page.Limit, page.Offset),
+		"success",
+	)
+}
@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"kc-ewallet/domains/usecase"
+	requesthelper "kc-ewallet/internals/helpers/request"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SavingsController struct {
+	usecase usecase.ISavingsUsecase
+}
+
+func NewSavingsController(usecase usecase.ISavingsUsecase) *SavingsController {
+	return &SavingsController{
+		usecase: usecase,
+	}
+}
+
+func (ctl *SavingsController) CreateSavingsGoal(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	body := request.CreateSavingsGoalRequest{
+		UserID: reqHelper.Auth.UserID,
+	}
+	if err := reqHelper.SetPostParams(&body); err != nil {
+		return
+	}
+
+	progress, err := ctl.usecase.CreateGoal(ctx.Request.Context(), body)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewSavingsGoalResponse(progress), "success")
+}
+
+func (ctl *SavingsController) ListSavingsGoals(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	progress, err := ctl.usecase.ListGoals(ctx.Request.Context(), reqHelper.Auth.UserID)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewSavingsGoalsResponse(progress), "success")
+}
+
+func (ctl *SavingsController) GetSavingsGoal(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	var uri request.SavingsGoalURI
+	if err := reqHelper.SetURIParams(&uri); err != nil {
+		return
+	}
+
+	progress, err := ctl.usecase.GetGoal(ctx.Request.Context(), reqHelper.Auth.UserID, uri.ID)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewSavingsGoalResponse(progress), "success")
+}
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/internals/helpers/pagination"
+	requesthelper "kc-ewallet/internals/helpers/request"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ScheduleController struct {
+	usecase usecase.IScheduleUsecase
+}
+
+func NewScheduleController(usecase usecase.IScheduleUsecase) *ScheduleController {
+	return &ScheduleController{
+		usecase: usecase,
+	}
+}
+
+func (ctl *ScheduleController) CreateScheduledTransfer(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	body := request.CreateScheduleRequest{
+		UserID: reqHelper.Auth.UserID,
+	}
+	if err := reqHelper.SetPostParams(&body); err != nil {
+		return
+	}
+
+	schedule, err := ctl.usecase.CreateSchedule(ctx.Request.Context(), body)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewScheduledTransferResponse(schedule), "success")
+}
+
+func (ctl *ScheduleController) ListScheduledTransfers(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	schedules, err := ctl.usecase.ListSchedules(ctx.Request.Context(), reqHelper.Auth.UserID)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewScheduledTransfersResponse(schedules), "success")
+}
+
+func (ctl *ScheduleController) GetScheduledTransfer(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	var uri request.ScheduleURI
+	if err := reqHelper.SetURIParams(&uri); err != nil {
+		return
+	}
+
+	schedule, err := ctl.usecase.GetSchedule(ctx.Request.Context(), reqHelper.Auth.UserID, uri.ID)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewScheduledTransferResponse(schedule), "success")
+}
+
+func (ctl *ScheduleController) CancelScheduledTransfer(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	var uri request.ScheduleURI
+	if err := reqHelper.SetURIParams(&uri); err != nil {
+		return
+	}
+
+	schedule, err := ctl.usecase.CancelSchedule(ctx.Request.Context(), reqHelper.Auth.UserID, uri.ID)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewScheduledTransferResponse(schedule), "success")
+}
+
+func (ctl *ScheduleController) ListScheduledTransferRuns(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	var uri request.ScheduleURI
+	if err := reqHelper.SetURIParams(&uri); err != nil {
+		return
+	}
+	var query request.ListScheduleRunsQuery
+	if err := reqHelper.SetQueryParams(&query); err != nil {
+		return
+	}
+
+	page := pagination.GetPaginationConfig(query.Page, query.Limit)
+	runs, total, err := ctl.usecase.ListRuns(ctx.Request.Context(), request.ListScheduleRunsRequest{
+		UserID:     reqHelper.Auth.UserID,
+		ScheduleID: uri.ID,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+	})
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccessWithPaginator(ctx,
+		response.NewScheduledTransferRunsResponse(runs),
+		pagination.BuildPaginator(int(total), page.Limit, page.Offset),
+		"success",
+	)
+}
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/internals/helpers/pagination"
+	requesthelper "kc-ewallet/internals/helpers/request"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SettlementController is called by the merchant with signed requests
+type SettlementController struct {
+	usecase usecase.ISettlementUsecase
+}
+
+func NewSettlementController(usecase usecase.ISettlementUsecase) *SettlementController {
+	return &SettlementController{
+		usecase: usecase,
+	}
+}
+
+func (ctl *SettlementController) ListSettlements(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	var query request.ListSettlementsQuery
+	if err := reqHelper.SetQueryParams(&query); err != nil {
+		return
+	}
+
+	page := pagination.GetPaginationConfig(query.Page, query.Limit)
+	settlements, total, err := ctl.usecase.ListSettlements(ctx.Request.Context(), request.ListSettlementsRequest{
+		MerchantID: reqHelper.Auth.MerchantID,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+	})
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccessWithPaginator(ctx,
+		response.NewSettlementsResponse(settlements),
+		pagination.BuildPaginator(int(total), page.Limit, page.Offset),
+		"success",
+	)
+}
+
+// GetSettlementReport downloads the settled payments as csv
+func (ctl *SettlementController) GetSettlementReport(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	var uri request.SettlementURI
+	if err := reqHelper.SetURIParams(&uri); err != nil {
+		return
+	}
+
+	settlement, payments, err := ctl.usecase.GetSettlementReport(ctx.Request.Context(), reqHelper.Auth.MerchantID, uri.ID)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	report, err := response.NewSettlementReportCSV(settlement, payments)
+	if err != nil {
+		response.RespondError(ctx, errors.InternalServer.NewWithUserMsg(err, "failed to build settlement report"))
+		return
+	}
+
+	ctx.Header("Content-Disposition", `attachment; filename="`+response.SettlementReportFilename(settlement)+`"`)
+	ctx.Data(http.StatusOK, "text/csv; charset=utf-8", report)
+}
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"kc-ewallet/constants"
 	"kc-ewallet/domains/usecase"
 	requesthelper "kc-ewallet/internals/helpers/request"
 	"kc-ewallet/protocols/http/request"
@@ -23,7 +24,8 @@ func (ctl *TransactionController) CreateCreditTransaction(ctx *gin.Context) {
 	reqHelper := requesthelper.InitRequest(ctx)
 
 	body := request.CreateCreditTransactionRequest{
-		UserID: reqHelper.Auth.UserID,
+		UserID:  reqHelper.Auth.UserID,
+		Channel: constants.ChannelApp,
 	}
 	if err := reqHelper.SetPostParams(&body); err != nil {
 		return
@@ -35,14 +37,15 @@ func (ctl *TransactionController) CreateCreditTransaction(ctx *gin.Context) {
 		return
 	}
 
-	response.RespondSuccess(ctx, response.NewCreateCreditTransactionResponse(transactionID, newBalance), "success")
+	response.RespondSuccess(ctx, response.NewCreateCreditTransactionResponse(transactionID, body.Currency, newBalance), "success")
 }
 
 func (ctl *TransactionController) CreateDebitTransaction(ctx *gin.Context) {
 	reqHelper := requesthelper.InitRequest(ctx)
 
 	body := request.CreateDebitTransactionRequest{
-		UserID: reqHelper.Auth.UserID,
+		UserID:  reqHelper.Auth.UserID,
+		Channel: constants.ChannelApp,
 	}
 	if err := reqHelper.SetPostParams(&body); err != nil {
 		return
@@ -54,5 +57,25 @@ func (ctl *TransactionController) CreateDebitTransaction(ctx *gin.Context) {
 		return
 	}
 
-	response.RespondSuccess(ctx, response.NewCreateDebitTransactionResponse(transactionID, newBalance), "success")
+	response.RespondSuccess(ctx, response.NewCreateDebitTransactionResponse(transactionID, body.Currency, newBalance), "success")
+}
+
+func (ctl *TransactionController) QuoteTransaction(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	body := request.CreateTransactionQuoteRequest{
+		UserID:  reqHelper.Auth.UserID,
+		Channel: constants.ChannelApp,
+	}
+	if err := reqHelper.SetPostParams(&body); err != nil {
+		return
+	}
+
+	quote, err := ctl.usecase.QuoteTransaction(ctx.Request.Context(), body)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewTransactionQuoteResponse(quote), "success")
 }
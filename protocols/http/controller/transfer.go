@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"kc-ewallet/constants"
+	"kc-ewallet/domains/usecase"
+	requesthelper "kc-ewallet/internals/helpers/request"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TransferController struct {
+	usecase usecase.ITransferUsecase
+}
+
+func NewTransferController(usecase usecase.ITransferUsecase) *TransferController {
+	return &TransferController{
+		usecase: usecase,
+	}
+}
+
+func (ctl *TransferController) CreateTransfer(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	body := request.CreateTransferRequest{
+		UserID:  reqHelper.Auth.UserID,
+		Channel: constants.ChannelApp,
+	}
+	if err := reqHelper.SetPostParams(&body); err != nil {
+		return
+	}
+
+	result, err := ctl.usecase.Transfer(ctx.Request.Context(), body)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewTransferResponse(result), "success")
+}
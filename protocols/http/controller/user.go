@@ -11,11 +11,13 @@ import (
 
 type UserController struct {
 	usecase usecase.IUserUsecase
+	pockets usecase.IPocketUsecase
 }
 
-func NewUserController(usecase usecase.IUserUsecase) *UserController {
+func NewUserController(usecase usecase.IUserUsecase, pocketUsecase usecase.IPocketUsecase) *UserController {
 	return &UserController{
 		usecase: usecase,
+		pockets: pocketUsecase,
 	}
 }
 
@@ -63,6 +65,14 @@ func (ctl *UserController) GetUserByID(ctx *gin.Context) {
 		return
 	}
 
+	// balances are cached apart from the profile, every balance writer drops them
+	balances, err := ctl.pockets.ListBalances(ctx.Request.Context(), user.ID)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
 	res := response.NewGetUserByIDResponse(*user)
+	res.Wallets = response.NewWalletBalancesResponse(balances)
 	response.RespondSuccess(ctx, res, "success")
 }
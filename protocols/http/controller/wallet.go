@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"kc-ewallet/domains/usecase"
+	requesthelper "kc-ewallet/internals/helpers/request"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WalletController struct {
+	usecase usecase.IWalletUsecase
+}
+
+func NewWalletController(usecase usecase.IWalletUsecase) *WalletController {
+	return &WalletController{
+		usecase: usecase,
+	}
+}
+
+func (ctl *WalletController) OpenWallet(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	body := request.OpenWalletRequest{
+		UserID: reqHelper.Auth.UserID,
+	}
+	if err := reqHelper.SetPostParams(&body); err != nil {
+		return
+	}
+
+	wallet, err := ctl.usecase.OpenWallet(ctx.Request.Context(), body)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewWalletResponse(wallet), "success")
+}
+
+func (ctl *WalletController) ListWallets(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	wallets, err := ctl.usecase.ListWallets(ctx.Request.Context(), reqHelper.Auth.UserID)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewWalletsResponse(wallets), "success")
+}
+
+func (ctl *WalletController) ListCurrencies(ctx *gin.Context) {
+	currencies, err := ctl.usecase.ListCurrencies(ctx.Request.Context())
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewCurrenciesResponse(currencies), "success")
+}
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/internals/helpers/pagination"
+	requesthelper "kc-ewallet/internals/helpers/request"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookController struct {
+	usecase usecase.IWebhookUsecase
+}
+
+func NewWebhookController(usecase usecase.IWebhookUsecase) *WebhookController {
+	return &WebhookController{
+		usecase: usecase,
+	}
+}
+
+func (ctl *WebhookController) CreateWebhook(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	body := request.CreateWebhookRequest{
+		UserID: reqHelper.Auth.UserID,
+	}
+	if err := reqHelper.SetPostParams(&body); err != nil {
+		return
+	}
+
+	subscription, err := ctl.usecase.CreateSubscription(ctx.Request.Context(), body)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewCreateWebhookResponse(subscription), "success")
+}
+
+func (ctl *WebhookController) ListWebhooks(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	subscriptions, err := ctl.usecase.ListSubscriptions(ctx.Request.Context(), reqHelper.Auth.UserID)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewWebhooksResponse(subscriptions), "success")
+}
+
+func (ctl *WebhookController) DeleteWebhook(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	var uri request.WebhookURI
+	if err := reqHelper.SetURIParams(&uri); err != nil {
+		return
+	}
+
+	if err := ctl.usecase.DeleteSubscription(ctx.Request.Context(), reqHelper.Auth.UserID, uri.ID); err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, nil, "success")
+}
+
+func (ctl *WebhookController) ListWebhookDeliveries(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	var uri request.WebhookURI
+	if err := reqHelper.SetURIParams(&uri); err != nil {
+		return
+	}
+	var query request.ListWebhookDeliveriesQuery
+	if err := reqHelper.SetQueryParams(&query); err != nil {
+		return
+	}
+
+	page := pagination.GetPaginationConfig(query.Page, query.Limit)
+	deliveries, total, err := ctl.usecase.ListDeliveries(ctx.Request.Context(), request.ListWebhookDeliveriesRequest{
+		UserID:         reqHelper.Auth.UserID,
+		SubscriptionID: uri.ID,
+		Status:         query.Status,
+		Limit:          page.Limit,
+		Offset:         page.Offset,
+	})
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccessWithPaginator(ctx,
+		response.NewWebhookDeliveriesResponse(deliveries),
+		pagination.BuildPaginator(int(total), page.Limit, page.Offset),
+		"success",
+	)
+}
+
+func (ctl *WebhookController) RedeliverWebhook(ctx *gin.Context) {
+	reqHelper := requesthelper.InitRequest(ctx)
+
+	var uri request.WebhookDeliveryURI
+	if err := reqHelper.SetURIParams(&uri); err != nil {
+		return
+	}
+
+	delivery, err := ctl.usecase.Redeliver(ctx.Request.Context(), reqHelper.Auth.UserID, uri.ID, uri.DeliveryID)
+	if err != nil {
+		response.RespondError(ctx, err)
+		return
+	}
+
+	response.RespondSuccess(ctx, response.NewWebhookDeliveryResponse(delivery), "success")
+}
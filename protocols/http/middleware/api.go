@@ -2,40 +2,38 @@ package middleware
 
 import (
 	"bytes"
-	"encoding/json"
 	"io"
 
-	"github.com/getsentry/sentry-go"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/internals/helpers/redact"
+
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 )
 
-// API middleware for API
+// API middleware for API, it attaches a per-request error report scope
+// so concurrent requests never share reported data
 func API() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		sentry.ConfigureScope(func(scope *sentry.Scope) {
-			scope.SetExtra("method", c.Request.Method)
-			scope.SetExtra("url", c.Request.URL.String())
-			scope.SetExtra("user_agent", c.Request.UserAgent())
-			scope.SetExtra("content_type", c.ContentType())
-			scope.SetExtra("query_params", c.Request.URL.Query())
-			scope.SetTag("request_id", uuid.New().String())
-			scope.SetExtra("json_response", nil)
-			scope.SetUser(sentry.User{ID: ""})
-			scope.SetExtra("body_params", nil)
-		})
+		ctx := errors.NewScopeContext(c.Request.Context())
+		scope := errors.ScopeFromContext(ctx)
+
+		scope.SetExtra("method", c.Request.Method)
+		scope.SetExtra("url", c.Request.URL.Path)
+		scope.SetExtra("user_agent", c.Request.UserAgent())
+		scope.SetExtra("content_type", c.ContentType())
+		scope.SetExtra("query_params", redact.Query(c.Request.URL.Query()))
+		scope.SetExtra("headers", redact.Headers(c.Request.Header))
+		scope.SetTag("request_id", GetRequestIDFromContext(ctx))
 
 		bodyBs, _ := io.ReadAll(c.Request.Body)
 		c.Request.Body = io.NopCloser(bytes.NewBuffer(bodyBs))
 
 		if bodyBs != nil {
-			var bodyParams map[string]interface{}
-			_ = json.Unmarshal(bodyBs, &bodyParams)
-			sentry.ConfigureScope(func(scope *sentry.Scope) {
-				scope.SetExtra("body_params", bodyParams)
-			})
+			// credentials such as password and pin never reach the reporter
+			scope.SetExtra("body_params", redact.JSON(bodyBs))
 		}
 
+		c.Request = c.Request.WithContext(ctx)
 		c.Next()
 	}
 }
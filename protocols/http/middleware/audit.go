@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"kc-ewallet/domains/usecase/audit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditContext carries the client ip and user agent for audit events recorded down the request
+func AuditContext() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(audit.WithClient(c.Request.Context(), audit.Client{
+			IP:        c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+		}))
+		c.Next()
+	}
+}
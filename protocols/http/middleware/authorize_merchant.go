@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/domains/usecase/audit"
+	"kc-ewallet/domains/usecase/merchant"
+	"kc-ewallet/internals/errors"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxSignedBodySize bounds the body read into memory to verify its hash
+const maxSignedBodySize = 1 << 20
+
+var (
+	ErrSignedBodyTooLarge = errors.BadRequest.New("request body is too large to verify")
+)
+
+// AuthorizeMerchantSignature verifies the HMAC signature of a merchant request, see
+// merchant.Sign, and sets an Actor with the merchant role and the merchant id
+func AuthorizeMerchantSignature(merchantUsecase usecase.IMerchantUsecase, opts ...middlewareOptionFn) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		opt := defaultMiddlewareOption()
+		for _, o := range opts {
+			o(opt)
+		}
+
+		if strings.Contains(c.FullPath(), "private") {
+			return
+		}
+
+		handlerName := getHandlerNameFromGinContext(c.HandlerNames())
+
+		registered := true
+
+		if opt.registerHandlers != nil {
+			registered = opt.registerHandlers[handlerName]
+		}
+
+		if opt.excludedHandlers != nil {
+			if opt.excludedHandlers[handlerName] {
+				registered = false
+			}
+		}
+
+		if !registered {
+			return
+		}
+
+		var body []byte
+		if c.Request.Body != nil {
+			var err error
+			body, err = io.ReadAll(io.LimitReader(c.Request.Body, maxSignedBodySize+1))
+			if err != nil {
+				response.RespondError(c, errors.BadRequest.NewWithUserMsg(err, "failed to read request body"))
+				c.Abort()
+				return
+			}
+			if len(body) > maxSignedBodySize {
+				response.RespondError(c, ErrSignedBodyTooLarge)
+				c.Abort()
+				return
+			}
+			// the handler binds the same body again
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		key, err := merchantUsecase.Authenticate(c.Request.Context(), request.MerchantSignature{
+			KeyID:     c.GetHeader(merchant.KeyIDHeader),
+			Timestamp: c.GetHeader(merchant.TimestampHeader),
+			Nonce:     c.GetHeader(merchant.NonceHeader),
+			Signature: c.GetHeader(merchant.SignatureHeader),
+			Method:    c.Request.Method,
+			Path:      c.Request.URL.RequestURI(),
+			Body:      body,
+		})
+		if err != nil {
+			response.RespondError(c, err)
+			c.Abort()
+			return
+		}
+
+		actor := Actor{
+			Role:       Merchant,
+			Platform:   Merchant,
+			RoleGroup:  Merchant,
+			MerchantID: key.MerchantID,
+		}
+		actor.SetToContext(c)
+
+		ctxRequestWithActor := context.WithValue(c.Request.Context(), ActorKey{}, actor)
+		ctxRequestWithActor = audit.WithActor(ctxRequestWithActor, audit.Actor{UserID: key.MerchantID, Role: Merchant})
+		if scope := errors.ScopeFromContext(ctxRequestWithActor); scope != nil {
+			scope.SetUser(Merchant + ":" + strconv.Itoa(int(key.MerchantID)))
+		}
+		c.Request = c.Request.WithContext(ctxRequestWithActor)
+	}
+}
@@ -2,9 +2,11 @@ package middleware
 
 import (
 	"context"
+	"kc-ewallet/domains/usecase/audit"
 	"kc-ewallet/internals/errors"
+	"kc-ewallet/internals/helpers/logging"
 	"kc-ewallet/protocols/http/response"
-	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -22,18 +24,14 @@ const (
 	RelationshipManager    string = "relationship_manager"
 	CreditAnalyst          string = "credit_analyst"
 	FieldOfficer           string = "field_officer"
+	Merchant               string = "merchant"
 	BearerScheme           string = "Bearer"
 	AccessTokenCookieName  string = "access_token"
 	RefreshTokenCookieName string = "refresh_token"
 )
 
 var (
-	ErrUnauthorized = errors.NewExtError(errors.ExtErrorArg{
-		Code:      http.StatusUnauthorized,
-		IdMessage: "Harap login kembali",
-		ErrCode:   "ER006",
-		Err:       "invalid token",
-	})
+	ErrUnauthorized = errors.FromCatalog(errors.CodeUnauthorized)
 )
 
 func AuthorizeToken(secret string, opts ...middlewareOptionFn) gin.HandlerFunc {
@@ -94,6 +92,11 @@ func AuthorizeToken(secret string, opts ...middlewareOptionFn) gin.HandlerFunc {
 			actor.SetToContext(c)
 
 			ctxRequestWithActor := context.WithValue(c.Request.Context(), ActorKey{}, actor)
+			ctxRequestWithActor = logging.WithUserID(ctxRequestWithActor, actor.UserID)
+			ctxRequestWithActor = audit.WithActor(ctxRequestWithActor, audit.Actor{UserID: actor.UserID, Role: actor.Role})
+			if scope := errors.ScopeFromContext(ctxRequestWithActor); scope != nil {
+				scope.SetUser(strconv.Itoa(int(actor.UserID)))
+			}
 			c.Request = c.Request.WithContext(ctxRequestWithActor)
 		}
 	}
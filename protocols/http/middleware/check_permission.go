@@ -13,6 +13,9 @@ type PagePermission string
 const (
 	UserPage        PagePermission = "user"
 	TransactionPage PagePermission = "transaction"
+	LogLevelPage    PagePermission = "log_level"
+	MerchantPage    PagePermission = "merchant"
+	FeePage         PagePermission = "fee"
 )
 
 var (
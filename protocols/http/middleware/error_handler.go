@@ -3,6 +3,7 @@ package middleware
 import (
 	"fmt"
 	"kc-ewallet/internals/errors"
+	"kc-ewallet/internals/helpers/language"
 	"kc-ewallet/protocols/http/response"
 	"log"
 	"net"
@@ -37,54 +38,133 @@ func lowerCaseFirst(s string) string {
 	return ""
 }
 
-func fieldErrorToText(e validator.FieldError) string {
-	switch e.Tag() {
-	case "required":
-		return fmt.Sprintf("%s is required", e.Field())
-	case "required_without":
-		fields := strings.Split(strings.ToLower(e.Param()), " ")
-		return fmt.Sprintf("%s is required if %s is not present", e.Field(), strings.Join(fields, " or "))
-	case "required_without_all":
-		fields := strings.Split(strings.ToLower(e.Param()), " ")
-		return fmt.Sprintf("%s is required if %s is not present", e.Field(), strings.Join(fields, " and "))
-	case "required_with":
+// validationMessages are formatted with the field name and the tag parameter
+var validationMessages = map[string]map[language.Language]string{
+	"required": {
+		language.English:    "%[1]s is required",
+		language.Indonesian: "%[1]s wajib diisi",
+	},
+	"required_without": {
+		language.English:    "%[1]s is required if %[2]s is not present",
+		language.Indonesian: "%[1]s wajib diisi jika %[2]s tidak diisi",
+	},
+	"required_without_all": {
+		language.English:    "%[1]s is required if %[2]s is not present",
+		language.Indonesian: "%[1]s wajib diisi jika %[2]s tidak diisi",
+	},
+	"required_with": {
+		language.English:    "%[1]s is required if %[2]s is present",
+		language.Indonesian: "%[1]s wajib diisi jika %[2]s diisi",
+	},
+	"required_with_all": {
+		language.English:    "%[1]s is required if %[2]s is present",
+		language.Indonesian: "%[1]s wajib diisi jika %[2]s diisi",
+	},
+	"max": {
+		language.English:    "%[1]s cannot be longer than or equal to %[2]s",
+		language.Indonesian: "%[1]s tidak boleh lebih dari %[2]s",
+	},
+	"min": {
+		language.English:    "%[1]s must be longer than or equal to %[2]s",
+		language.Indonesian: "%[1]s minimal %[2]s",
+	},
+	"email": {
+		language.English:    "Invalid email format",
+		language.Indonesian: "Format email tidak valid",
+	},
+	"len": {
+		language.English:    "%[1]s must be %[2]s characters long",
+		language.Indonesian: "%[1]s harus %[2]s karakter",
+	},
+	"uuid": {
+		language.English:    "%[1]s must be in UUID format",
+		language.Indonesian: "%[1]s harus dalam format UUID",
+	},
+	"datetime": {
+		language.English:    "%[1]s must be in a valid datetime format (%[2]s)",
+		language.Indonesian: "%[1]s harus dalam format tanggal yang valid (%[2]s)",
+	},
+	"startswith": {
+		language.English:    "%[1]s should starts with %[2]s",
+		language.Indonesian: "%[1]s harus diawali dengan %[2]s",
+	},
+	"endswith": {
+		language.English:    "%[1]s should ends with %[2]s",
+		language.Indonesian: "%[1]s harus diakhiri dengan %[2]s",
+	},
+	"http_url": {
+		language.English:    "%[1]s must be an http or https url",
+		language.Indonesian: "%[1]s harus berupa url http atau https",
+	},
+	"oneof": {
+		language.English:    "%[1]s must be one of %[2]s",
+		language.Indonesian: "%[1]s harus salah satu dari %[2]s",
+	},
+	"default": {
+		language.English:    "%[1]s is not valid",
+		language.Indonesian: "%[1]s tidak valid",
+	},
+}
+
+var conjunctions = map[string]map[language.Language]string{
+	"or": {
+		language.English:    "or",
+		language.Indonesian: "atau",
+	},
+	"and": {
+		language.English:    "and",
+		language.Indonesian: "dan",
+	},
+}
+
+func fieldErrorToText(e validator.FieldError, lang language.Language) string {
+	tag := e.Tag()
+	param := e.Param()
+
+	switch tag {
+	case "required_without", "required_with":
 		fields := strings.Split(strings.ToLower(e.Param()), " ")
-		return fmt.Sprintf("%s is required if %s is present", e.Field(), strings.Join(fields, " or "))
-	case "required_with_all":
+		param = strings.Join(fields, " "+conjunctions["or"][lang]+" ")
+	case "required_without_all", "required_with_all":
 		fields := strings.Split(strings.ToLower(e.Param()), " ")
-		return fmt.Sprintf("%s is required if %s is present", e.Field(), strings.Join(fields, " and "))
-	case "max":
-		return fmt.Sprintf("%s cannot be longer than or equal to %s", e.Field(), e.Param())
-	case "min":
-		return fmt.Sprintf("%s must be longer than or equal to %s", e.Field(), e.Param())
-	case "email":
-		return "Invalid email format"
-	case "len":
-		return fmt.Sprintf("%s must be %s characters long", e.Field(), e.Param())
-	case "uuid", "uuid3", "uuid4", "uuid5":
-		return fmt.Sprintf("%s must be in UUID format", e.Field())
-	case "datetime":
-		return fmt.Sprintf("%s must be in a valid datetime format (%s)", e.Field(), e.Param())
-	case "startswith":
-		return fmt.Sprintf("%s should starts with %s", e.Field(), e.Param())
-	case "endswith":
-		return fmt.Sprintf("%s should ends with %s", e.Field(), e.Param())
+		param = strings.Join(fields, " "+conjunctions["and"][lang]+" ")
+	case "uuid3", "uuid4", "uuid5":
+		tag = "uuid"
+	}
+
+	messages, ok := validationMessages[tag]
+	if !ok {
+		messages = validationMessages["default"]
+	}
+	message, ok := messages[lang]
+	if !ok {
+		message = messages[language.Default]
+	}
+
+	if !strings.Contains(message, "%[2]s") {
+		return fmt.Sprintf(message, e.Field())
 	}
-	return fmt.Sprintf("%s is not valid", e.Field())
+	return fmt.Sprintf(message, e.Field(), param)
 }
 
 func handleValidationError(e *gin.Error, c *gin.Context) {
 	validationErrs := e.Err.(validator.ValidationErrors)
+	lang := language.FromAcceptLanguage(c.GetHeader(response.AcceptLanguageHeader))
+
+	response.RespondError(c, NewValidationError(validationErrs, lang))
+}
+
+// NewValidationError converts binding errors into a Validation AppError with
+// a localized message per field, the gRPC server shares it with HandleError
+func NewValidationError(validationErrs validator.ValidationErrors, lang language.Language) error {
 	err := errors.Validation.New("Validation error")
 	var errMessage string
 	for _, validationErr := range validationErrs {
-		errMessage = fieldErrorToText(validationErr)
+		errMessage = fieldErrorToText(validationErr, lang)
 
 		err = errors.AddFieldError(err, validationErr.Field(), errMessage)
 	}
-	err = errors.Msg(err, errMessage) // replace err message to the latest error message
-
-	response.RespondError(c, err)
+	return errors.Msg(err, errMessage) // replace err message to the latest error message
 }
 
 // HandleError Middleware for handling error
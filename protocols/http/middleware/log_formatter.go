@@ -5,6 +5,8 @@ import (
 	"slices"
 	"time"
 
+	"kc-ewallet/internals/helpers/redact"
+
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -12,6 +14,7 @@ import (
 
 const Healthz string = "/healthz"
 const Readyz string = "/readyz"
+const Metrics string = "/metrics"
 
 func LogFormatter() gin.HandlerFunc {
 	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
@@ -32,19 +35,18 @@ func LogFormatter() gin.HandlerFunc {
 	})
 }
 
-var ignoredPath = []string{Healthz, Readyz}
+var ignoredPath = []string{Healthz, Readyz, Metrics}
 
 func StructuredLogFormatter() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var (
 			start     time.Time = time.Now()
 			path      string    = c.FullPath()
-			query     string    = c.Request.URL.RawQuery
+			query     string    = redact.RawQuery(c.Request.URL.RawQuery)
 			method    string    = c.Request.Method
 			userAgent string    = c.Request.UserAgent()
 			clientIP  string    = c.ClientIP()
 			status    int       = c.Writer.Status()
-			requestID string    = GetRequestIDFromContext(c)
 		)
 
 		if slices.Contains(ignoredPath, path) {
@@ -66,15 +68,16 @@ func StructuredLogFormatter() gin.HandlerFunc {
 			zap.String("user-agent", userAgent),
 			zap.Duration("latency", latency),
 			zap.String("time", end.UTC().Format(time.RFC3339)),
-			zap.String("request_id", requestID),
 		}
 
+		// request id, trace and user are read from the request context
+		logger := NewFromContext(c.Request.Context())
 		if len(c.Errors) > 0 {
 			for _, e := range c.Errors.Errors() {
-				zap.L().Error(e, fields...)
+				logger.Error(e, fields...)
 			}
 		} else {
-			zap.L().Info(path, fields...)
+			logger.Info(path, fields...)
 		}
 	}
 }
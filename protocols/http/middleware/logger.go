@@ -4,6 +4,8 @@ import (
 	"log/slog"
 	"time"
 
+	"kc-ewallet/internals/helpers/redact"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -13,13 +15,13 @@ func SlogMiddleware() gin.HandlerFunc {
 		// Start timer
 		start := time.Now()
 		path := c.Request.URL.Path
-		raw := c.Request.URL.RawQuery
+		raw := redact.RawQuery(c.Request.URL.RawQuery)
 
 		// Process request
 		c.Next()
 
 		// Skip logging for health check endpoints
-		if path == "/healthz" || path == "/readyz" || path == "/health" {
+		if path == "/healthz" || path == "/readyz" || path == "/health" || path == "/metrics" {
 			return
 		}
 
@@ -21,6 +21,8 @@ type Actor struct {
 	PermissionPage []string
 	OriginToken    string
 	CompanyID      uuid.UUID
+	// MerchantID is set instead of UserID for a signed merchant request
+	MerchantID int32
 }
 
 func (a *Actor) IsPermit(page PagePermission) bool {
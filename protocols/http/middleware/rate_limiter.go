@@ -1,23 +1,40 @@
 package middleware
 
 import (
+	"context"
 	"fmt"
 	"slices"
 	"strconv"
 	"strings"
 	"time"
 
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/domains/usecase/audit"
 	"kc-ewallet/internals/errors"
 	log_color "kc-ewallet/internals/helpers/color"
+	"kc-ewallet/internals/helpers/logging"
 	redis_service "kc-ewallet/internals/helpers/redis/service"
+	"kc-ewallet/internals/metric"
 	"kc-ewallet/protocols/http/response"
 
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
 )
 
 const (
 	keyPrefix          = "rate-limiter:%s:%s"
 	violationKeyPrefix = "violation-checker:%s:%s"
+
+	rateLimiterTracerName = "kc-ewallet/rate-limiter"
+)
+
+var (
+	ErrSuspiciousActivity = errors.FromCatalog(errors.CodeSuspiciousActivity)
+	ErrActivityMarked     = errors.FromCatalog(errors.CodeActivityMarked)
 )
 
 type RateLimiterInterface interface {
@@ -31,6 +48,8 @@ type RateLimiter struct {
 	capacity   float64  // Maximum number of tokens the bucket can hold
 	timeWindow float64  // since elapsed time is on seconds, enable option to set window time. Default is 1 as 1 second
 	allowedIPs []string // whitelisted IPs will not go through IP rate limiter checkinng
+	metric     metric.Metric
+	audit      usecase.IAuditUsecase
 }
 
 // This module will return Rate Limiter with 1 request per second limit
@@ -54,6 +73,41 @@ func NewRateLimiterWithOption(redis redis_service.RedisServiceInterface, rate, c
 	}
 }
 
+// WithMetric records every rejected request into appMetric
+func (r *RateLimiter) WithMetric(appMetric metric.Metric) *RateLimiter {
+	r.metric = appMetric
+	return r
+}
+
+// WithAudit records an audit event whenever an entity gets marked as anomalous
+func (r *RateLimiter) WithAudit(auditUsecase usecase.IAuditUsecase) *RateLimiter {
+	r.audit = auditUsecase
+	return r
+}
+
+func (r *RateLimiter) recordMarked(ctx context.Context, handler, velocity string) {
+	if r.audit == nil {
+		return
+	}
+
+	if err := r.audit.Record(ctx, audit.Event{
+		Type: audit.EventRateLimitMarked,
+		Metadata: map[string]interface{}{
+			"handler":  handler,
+			"velocity": velocity,
+		},
+	}); err != nil {
+		logging.NewFromContext(ctx).Error("failed to record rate limit mark", zap.Error(err))
+	}
+}
+
+func (r *RateLimiter) recordRejection(ctx context.Context, handler, reason string) {
+	if r.metric == nil {
+		return
+	}
+	r.metric.RecordRateLimitRejection(ctx, handler, reason)
+}
+
 // AllowRequest will check based on handler name and velocity key
 // velocity key can be used as IP, DeviceID, etc
 func (r *RateLimiter) AllowRequest(handler, velocity string) bool {
@@ -134,8 +188,9 @@ func (r *RateLimiter) AllowRequest(handler, velocity string) bool {
 }
 
 // IncrViolationCount is a mini bot detection based on velocity frequency anomaly
-// if violation have been done 10 times under one minute, mark that entity
-func (r *RateLimiter) IncrViolationCount(handler, velocity string) {
+// if violation have been done 10 times under one minute, mark that entity.
+// It reports whether this call newly marked the entity
+func (r *RateLimiter) IncrViolationCount(handler, velocity string) bool {
 	var (
 		key        = fmt.Sprintf(violationKeyPrefix, handler, velocity)
 		anomalyKey = fmt.Sprintf("%s:marked", key)
@@ -146,11 +201,14 @@ func (r *RateLimiter) IncrViolationCount(handler, velocity string) {
 	r.redis.Get(key, &violationLimitCount)
 
 	if violationLimitCount >= 10 {
+		alreadyMarked := r.IsViolationMarked(handler, velocity)
 		r.redis.SetWithExpiry(anomalyKey, true, int(24*time.Hour))
-		return
+		return !alreadyMarked
 	} else {
 		r.redis.SetWithExpiry(key, violationLimitCount+1, int(1*time.Minute))
 	}
+
+	return false
 }
 
 // IsViolationMarked is a mini bot detection based on velocity frequency anomaly
@@ -171,6 +229,40 @@ func (r *RateLimiter) IsViolationMarked(handler, velocity string) bool {
 	return violationMarked
 }
 
+// Check takes a token for handler keyed by velocity, it returns ErrSuspiciousActivity
+// when the bucket is empty and ErrActivityMarked when the velocity has been marked.
+// CheckRateLimit and the gRPC interceptor share it so both drain the same buckets
+func (r *RateLimiter) Check(ctx context.Context, handler, velocity string) error {
+	// the limiter talks to redis without a context, so wrap its calls in one span ended
+	// before the rejection is recorded and the rest of the chain runs
+	_, span := otel.Tracer(rateLimiterTracerName).Start(ctx, "redis.rate_limiter",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemNameRedis,
+			attribute.String("handler", handler),
+		),
+	)
+	allowed := r.AllowRequest(handler, velocity)
+	newlyMarked := !allowed && r.IncrViolationCount(handler, velocity)
+	marked := r.IsViolationMarked(handler, velocity)
+	span.End()
+
+	if !allowed {
+		if newlyMarked {
+			r.recordMarked(ctx, handler, velocity)
+		}
+		r.recordRejection(ctx, handler, metric.RateLimitReasonExceeded)
+		return ErrSuspiciousActivity
+	}
+
+	if marked {
+		r.recordRejection(ctx, handler, metric.RateLimitReasonMarked)
+		return ErrActivityMarked
+	}
+
+	return nil
+}
+
 func CheckRateLimit(limiter *RateLimiter, opts ...middlewareOptionFn) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		opt := defaultMiddlewareOption()
@@ -200,14 +292,8 @@ func CheckRateLimit(limiter *RateLimiter, opts ...middlewareOptionFn) gin.Handle
 			return
 		}
 
-		if !limiter.AllowRequest(handlerName, c.ClientIP()) {
-			limiter.IncrViolationCount(handlerName, c.ClientIP())
-			response.RespondError(c, errors.TooManyRequests.New("Aktivitas Anda terdeteksi tidak wajar, hubungi tim Customer Service atau coba lagi nanti"))
-			c.Abort()
-		}
-
-		if limiter.IsViolationMarked(handlerName, c.ClientIP()) {
-			response.RespondError(c, errors.TooManyRequests.New("Aktivitas Anda terdeteksi tidak wajar, mohon coba lagi nanti"))
+		if err := limiter.Check(c.Request.Context(), handlerName, c.ClientIP()); err != nil {
+			response.RespondError(c, err)
 			c.Abort()
 		}
 	}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"kc-ewallet/internals/helpers/logging"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -11,11 +12,9 @@ import (
 
 const (
 	RequestIDHeader string = "X-Request-ID"
-	RequestIDLogKey string = "request_id"
+	RequestIDLogKey string = logging.RequestIDLogKey
 )
 
-type requestIDKey struct{}
-
 func RequestIDInjector() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		requestID := c.GetHeader(strings.ToLower(RequestIDHeader))
@@ -24,7 +23,7 @@ func RequestIDInjector() gin.HandlerFunc {
 			c.Request.Header[RequestIDHeader] = []string{requestID}
 		}
 		c.Request = c.Request.WithContext(
-			context.WithValue(c.Request.Context(), requestIDKey{}, requestID),
+			logging.WithRequestID(c.Request.Context(), requestID),
 		)
 		c.Header(RequestIDHeader, requestID)
 		c.Next()
@@ -36,22 +35,9 @@ func RequestIDInjector() gin.HandlerFunc {
 // there will be some zero request id due to async communication
 // will assign new one if found no request id
 func GetRequestIDFromContext(ctx context.Context) string {
-	c := ctx
-	if gc, ok := ctx.(*gin.Context); ok {
-		c = gc.Request.Context()
-	}
-
-	requestID, _ := c.Value(requestIDKey{}).(string)
-	if requestID != "" {
-		return requestID
-	}
-
-	return uuid.New().String()
+	return logging.GetRequestIDFromContext(ctx)
 }
 
 func NewFromContext(ctx context.Context) *zap.Logger {
-	requestID := GetRequestIDFromContext(ctx)
-	return zap.L().WithOptions(zap.Fields(
-		zap.String(RequestIDLogKey, requestID),
-	))
+	return logging.NewFromContext(ctx)
 }
@@ -0,0 +1,48 @@
+package request
+
+// CreateBillRequest splits Total among the user and the participants. An equal split
+// divides it between all of them, a custom split owes each participant its Amount and
+// leaves the rest to the user
+type CreateBillRequest struct {
+	UserID       int32             `json:"-" binding:"required"`
+	Title        string            `json:"title" binding:"required,max=140"`
+	Currency     string            `json:"currency" binding:"required,iso4217"`
+	Total        float64           `json:"total" binding:"required,gt=0"`
+	Split        string            `json:"split" binding:"required,oneof=equal custom"`
+	Participants []BillParticipant `json:"participants" binding:"required,min=1,dive"`
+}
+
+type BillParticipant struct {
+	UserID int32 `json:"user_id" binding:"required,gt=0"`
+	// Amount is the share of a custom split, left out of an equal one
+	Amount float64 `json:"amount" binding:"omitempty,gt=0"`
+}
+
+type BillURI struct {
+	ID int32 `uri:"id" binding:"required,gt=0"`
+}
+
+type ListBillsQuery struct {
+	Page  int `form:"page" binding:"omitempty,min=1"`
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type ListBillsRequest struct {
+	UserID int32
+	Limit  int
+	Offset int
+}
+
+type ListOwedBillSharesQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending paid"`
+	Page   int    `form:"page" binding:"omitempty,min=1"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// ListOwedBillSharesRequest lists the shares the user owes in the bills of others
+type ListOwedBillSharesRequest struct {
+	UserID int32
+	Status string
+	Limit  int
+	Offset int
+}
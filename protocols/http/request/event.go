@@ -0,0 +1,6 @@
+package request
+
+type StreamEventsQuery struct {
+	// LastEventID resumes the stream for clients that can't send the Last-Event-ID header
+	LastEventID string `form:"last_event_id"`
+}
@@ -0,0 +1,16 @@
+package request
+
+// CreateExchangeQuoteRequest prices selling Amount of From for To, between two
+// wallets of the user
+type CreateExchangeQuoteRequest struct {
+	UserID int32   `json:"-" binding:"required"`
+	From   string  `json:"from" binding:"required,iso4217"`
+	To     string  `json:"to" binding:"required,iso4217,nefield=From"`
+	Amount float64 `json:"amount" binding:"required,gt=0"`
+}
+
+// CreateExchangeRequest executes an exchange quote, a quote is executed once
+type CreateExchangeRequest struct {
+	UserID  int32  `json:"-" binding:"required"`
+	QuoteID string `json:"quote_id" binding:"required,max=512"`
+}
@@ -0,0 +1,33 @@
+package request
+
+type CreateFeeRuleRequest struct {
+	TransactionType string `json:"transaction_type" binding:"required,oneof=credit debit payment transfer"`
+	// Currency is matched exactly, the fee amounts are in it
+	Currency string `json:"currency" binding:"required,iso4217"`
+	// Channel and UserTier narrow the rule, empty matches every channel or tier
+	Channel    string  `json:"channel" binding:"omitempty,oneof=app grpc"`
+	UserTier   string  `json:"user_tier" binding:"omitempty,max=20"`
+	Kind       string  `json:"kind" binding:"required,oneof=flat percentage tiered"`
+	FlatAmount float64 `json:"flat_amount" binding:"gte=0"`
+	Percent    float64 `json:"percent" binding:"gte=0,lte=100"`
+	// Tiers are ordered by UpTo, only the last one may leave it empty
+	Tiers []FeeTier `json:"tiers" binding:"omitempty,dive"`
+	// MinFee and MaxFee clamp the computed fee
+	MinFee *float64 `json:"min_fee" binding:"omitempty,gte=0"`
+	MaxFee *float64 `json:"max_fee" binding:"omitempty,gte=0"`
+}
+
+type FeeTier struct {
+	UpTo    *float64 `json:"up_to" binding:"omitempty,gt=0"`
+	Flat    float64  `json:"flat" binding:"gte=0"`
+	Percent float64  `json:"percent" binding:"gte=0,lte=100"`
+}
+
+type FeeRuleURI struct {
+	ID int32 `uri:"id" binding:"required,gt=0"`
+}
+
+type ListFeeRulesQuery struct {
+	// All includes the replaced and deactivated rules
+	All bool `form:"all"`
+}
@@ -0,0 +1,28 @@
+package request
+
+type CreateMerchantRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+	// UserID is the wallet account the merchant payments are settled to
+	UserID int32 `json:"user_id" binding:"required,gt=0"`
+}
+
+type MerchantURI struct {
+	ID int32 `uri:"id" binding:"required,gt=0"`
+}
+
+type MerchantKeyURI struct {
+	ID    int32  `uri:"id" binding:"required,gt=0"`
+	KeyID string `uri:"key_id" binding:"required,max=64"`
+}
+
+// MerchantSignature is what a signed merchant request is verified from,
+// Path is the request uri including the query string
+type MerchantSignature struct {
+	KeyID     string
+	Timestamp string
+	Nonce     string
+	Signature string
+	Method    string
+	Path      string
+	Body      []byte
+}
@@ -0,0 +1,14 @@
+package request
+
+type CreatePaymentRequest struct {
+	UserID     int32   `json:"-" binding:"required"`
+	MerchantID int32   `json:"merchant_id" binding:"required,gt=0"`
+	Amount     float64 `json:"amount" binding:"required,gt=0"`
+	// OrderReference is the merchant's id of the order, an order is paid once
+	OrderReference string `json:"order_reference" binding:"required,max=64"`
+	// Metadata is kept with the payment and shown in the settlement report
+	Metadata map[string]interface{} `json:"metadata"`
+	// QuoteID executes with the terms of a payment quote of the same amount
+	QuoteID string `json:"quote_id" binding:"omitempty,max=512"`
+	Channel string `json:"-"`
+}
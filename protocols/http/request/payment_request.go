@@ -0,0 +1,34 @@
+package request
+
+import "time"
+
+// CreatePaymentRequestRequest asks PayerID to transfer Amount to the user
+type CreatePaymentRequestRequest struct {
+	UserID   int32   `json:"-" binding:"required"`
+	PayerID  int32   `json:"payer_id" binding:"required,gt=0"`
+	Currency string  `json:"currency" binding:"required,iso4217"`
+	Amount   float64 `json:"amount" binding:"required,gt=0"`
+	Note     string  `json:"note" binding:"max=140"`
+	// ExpiresAt is when the request can no longer be paid, RFC 3339. The default expiry applies when unset
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type PaymentRequestURI struct {
+	ID int32 `uri:"id" binding:"required,gt=0"`
+}
+
+type ListPaymentRequestsQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending paid declined expired cancelled"`
+	Page   int    `form:"page" binding:"omitempty,min=1"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// ListPaymentRequestsRequest lists the requests to pay of the user when Incoming, the
+// requests it made otherwise
+type ListPaymentRequestsRequest struct {
+	UserID   int32
+	Incoming bool
+	Status   string
+	Limit    int
+	Offset   int
+}
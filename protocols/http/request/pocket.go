@@ -0,0 +1,34 @@
+package request
+
+type CreatePocketRequest struct {
+	UserID   int32  `json:"-" binding:"required"`
+	Currency string `json:"currency" binding:"required,iso4217"`
+	Name     string `json:"name" binding:"required,max=50"`
+	// IsDefault makes the pocket receive the credits of its wallet
+	IsDefault bool `json:"is_default"`
+}
+
+// MovePocketMoneyRequest moves money between two pockets of a wallet, a missing pocket
+// id is the unallocated balance of the wallet
+type MovePocketMoneyRequest struct {
+	UserID       int32   `json:"-" binding:"required"`
+	FromPocketID int32   `json:"from_pocket_id" binding:"omitempty,gt=0"`
+	ToPocketID   int32   `json:"to_pocket_id" binding:"omitempty,gt=0"`
+	Amount       float64 `json:"amount" binding:"required,gt=0"`
+}
+
+type PocketURI struct {
+	ID int32 `uri:"id" binding:"required,gt=0"`
+}
+
+type ListPocketEntriesQuery struct {
+	Page  int `form:"page" binding:"omitempty,min=1"`
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type ListPocketEntriesRequest struct {
+	UserID   int32
+	PocketID int32
+	Limit    int
+	Offset   int
+}
@@ -0,0 +1,20 @@
+package request
+
+// CreateSavingsGoalRequest saves into a pocket until its balance reaches TargetAmount,
+// the rules are optional and a goal without any is only filled by moves
+type CreateSavingsGoalRequest struct {
+	UserID       int32   `json:"-" binding:"required"`
+	PocketID     int32   `json:"pocket_id" binding:"required,gt=0"`
+	TargetAmount float64 `json:"target_amount" binding:"required,gt=0"`
+	// TargetDate is a day after today, YYYY-MM-DD
+	TargetDate string `json:"target_date" binding:"required,datetime=2006-01-02"`
+	// RoundUpTo rounds each debit of the wallet up to a multiple of it and saves the change
+	RoundUpTo float64 `json:"round_up_to" binding:"omitempty,gt=0"`
+	// SweepAmount is saved every SweepFrequency, starting one period after the goal is created
+	SweepAmount    float64 `json:"sweep_amount" binding:"required_with=SweepFrequency,omitempty,gt=0"`
+	SweepFrequency string  `json:"sweep_frequency" binding:"required_with=SweepAmount,omitempty,oneof=daily weekly monthly"`
+}
+
+type SavingsGoalURI struct {
+	ID int32 `uri:"id" binding:"required,gt=0"`
+}
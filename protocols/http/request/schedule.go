@@ -0,0 +1,38 @@
+package request
+
+import "time"
+
+// CreateScheduleRequest transfers Amount to ToUserID at StartAt, and again at every
+// occurrence of Recurrence when it is set
+type CreateScheduleRequest struct {
+	UserID   int32   `json:"-" binding:"required"`
+	ToUserID int32   `json:"to_user_id" binding:"required,gt=0"`
+	Currency string  `json:"currency" binding:"required,iso4217"`
+	Amount   float64 `json:"amount" binding:"required,gt=0"`
+	Note     string  `json:"note" binding:"max=140"`
+	// StartAt is the first occurrence, RFC 3339 in the future
+	StartAt time.Time `json:"start_at" binding:"required"`
+	// Recurrence is an RFC 5545 RRULE, e.g. FREQ=MONTHLY;BYMONTHDAY=1, empty for a one-off transfer
+	Recurrence string `json:"recurrence" binding:"max=255"`
+	// EndAt and MaxOccurrences bound a recurring transfer, the first reached ends it
+	EndAt          *time.Time `json:"end_at"`
+	MaxOccurrences int32      `json:"max_occurrences" binding:"omitempty,gt=0"`
+	// OnInsufficientFunds retries the occurrence later or skips to the next one, retry by default
+	OnInsufficientFunds string `json:"on_insufficient_funds" binding:"omitempty,oneof=retry skip"`
+}
+
+type ScheduleURI struct {
+	ID int32 `uri:"id" binding:"required,gt=0"`
+}
+
+type ListScheduleRunsQuery struct {
+	Page  int `form:"page" binding:"omitempty,min=1"`
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type ListScheduleRunsRequest struct {
+	UserID     int32
+	ScheduleID int32
+	Limit      int
+	Offset     int
+}
@@ -0,0 +1,16 @@
+package request
+
+type SettlementURI struct {
+	ID int32 `uri:"id" binding:"required,gt=0"`
+}
+
+type ListSettlementsQuery struct {
+	Page  int `form:"page" binding:"omitempty,min=1"`
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type ListSettlementsRequest struct {
+	MerchantID int32
+	Limit      int
+	Offset     int
+}
@@ -3,9 +3,34 @@ package request
 type CreateCreditTransactionRequest struct {
 	UserID int32   `json:"user_id" binding:"required"`
 	Amount float64 `json:"amount" binding:"required,gt=0"`
+	// Currency picks the wallet, the amount can't have more decimals than the currency
+	Currency string `json:"currency" binding:"required,iso4217"`
+	// QuoteID executes with the terms of a quote, it must be for the same type and amount
+	QuoteID string `json:"quote_id" binding:"omitempty,max=512"`
+	// Channel is set by the transport the request came in through
+	Channel string `json:"-"`
 }
 
 type CreateDebitTransactionRequest struct {
 	UserID int32   `json:"user_id" binding:"required"`
 	Amount float64 `json:"amount" binding:"required,gt=0"`
+	// Currency picks the wallet, the amount can't have more decimals than the currency
+	Currency string `json:"currency" binding:"required,iso4217"`
+	// QuoteID executes with the terms of a quote, it must be for the same type and amount
+	QuoteID string `json:"quote_id" binding:"omitempty,max=512"`
+	// Channel is set by the transport the request came in through
+	Channel string `json:"-"`
+}
+
+// CreateTransactionQuoteRequest previews the fee and resulting balance of a transaction,
+// the returned quote id locks them for a short while
+type CreateTransactionQuoteRequest struct {
+	UserID int32   `json:"-" binding:"required"`
+	Type   string  `json:"type" binding:"required,oneof=credit debit payment transfer"`
+	Amount float64 `json:"amount" binding:"required,gt=0"`
+	// Currency defaults to the default currency, payments are always in it
+	Currency string `json:"currency" binding:"omitempty,iso4217"`
+	// ToUserID is the recipient of a transfer, the quote is only valid for it
+	ToUserID int32  `json:"to_user_id" binding:"required_if=Type transfer,gte=0"`
+	Channel  string `json:"-"`
 }
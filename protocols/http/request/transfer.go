@@ -0,0 +1,19 @@
+package request
+
+type CreateTransferRequest struct {
+	UserID   int32   `json:"-" binding:"required"`
+	ToUserID int32   `json:"to_user_id" binding:"required,gt=0"`
+	Currency string  `json:"currency" binding:"required,iso4217"`
+	Amount   float64 `json:"amount" binding:"required,gt=0"`
+	Note     string  `json:"note" binding:"max=140"`
+	// QuoteID executes with the terms of a transfer quote, it must be for the same recipient and amount
+	QuoteID string `json:"quote_id" binding:"omitempty,max=512"`
+	// Channel is set by the transport the request came in through
+	Channel string `json:"-"`
+	// IdempotencyKey is set by callers that may retry, e.g. a scheduled transfer run
+	IdempotencyKey string `json:"-"`
+	// PaymentRequestID is the payment request the transfer pays, it must still be pending
+	PaymentRequestID int32 `json:"-"`
+	// BillID is the bill whose share of the user the transfer pays, ToUserID is its creator
+	BillID int32 `json:"-"`
+}
@@ -2,12 +2,12 @@ package request
 
 type RegisterUserRequest struct {
 	Username string `json:"username" binding:"required"`
-	Password string `json:"password" binding:"required,min=6"`
+	Password string `json:"password" binding:"required,min=6" redact:"true"`
 }
 
 type LoginRequest struct {
 	Username string `json:"username" binding:"required"`
-	Password string `json:"password" binding:"required,min=6"`
+	Password string `json:"password" binding:"required,min=6" redact:"true"`
 }
 
 type UserIDURI struct {
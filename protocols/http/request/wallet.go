@@ -0,0 +1,6 @@
+package request
+
+type OpenWalletRequest struct {
+	UserID   int32  `json:"-" binding:"required"`
+	Currency string `json:"currency" binding:"required,iso4217"`
+}
@@ -0,0 +1,31 @@
+package request
+
+type CreateWebhookRequest struct {
+	UserID int32  `json:"-" binding:"required"`
+	URL    string `json:"url" binding:"required,http_url,max=2048"`
+	// EventTypes filters the delivered events, empty subscribes to every type
+	EventTypes []string `json:"event_types" binding:"omitempty,dive,oneof=BalanceChanged TransactionCreated"`
+}
+
+type WebhookURI struct {
+	ID int32 `uri:"id" binding:"required,gt=0"`
+}
+
+type WebhookDeliveryURI struct {
+	ID         int32 `uri:"id" binding:"required,gt=0"`
+	DeliveryID int64 `uri:"delivery_id" binding:"required,gt=0"`
+}
+
+type ListWebhookDeliveriesQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending succeeded dead"`
+	Page   int    `form:"page" binding:"omitempty,min=1"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type ListWebhookDeliveriesRequest struct {
+	UserID         int32
+	SubscriptionID int32
+	Status         string
+	Limit          int
+	Offset         int
+}
@@ -0,0 +1,127 @@
+package response
+
+import (
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/usecase"
+	"time"
+)
+
+// BillResponse is a bill, shares are only listed on a single bill
+type BillResponse struct {
+	ID                int32               `json:"id"`
+	CreatorID         int32               `json:"creator_id"`
+	Title             string              `json:"title"`
+	Currency          string              `json:"currency"`
+	Total             float64             `json:"total"`
+	Split             string              `json:"split"`
+	PaidAmount        float64             `json:"paid_amount"`
+	OutstandingAmount float64             `json:"outstanding_amount"`
+	Status            string              `json:"status"`
+	SettledAt         *time.Time          `json:"settled_at,omitempty"`
+	Shares            []BillShareResponse `json:"shares,omitempty"`
+	CreatedAt         time.Time           `json:"created_at"`
+}
+
+type BillShareResponse struct {
+	ID         int32      `json:"id"`
+	UserID     int32      `json:"user_id"`
+	Amount     float64    `json:"amount"`
+	Status     string     `json:"status"`
+	TransferID *int32     `json:"transfer_id,omitempty"`
+	PaidAt     *time.Time `json:"paid_at,omitempty"`
+	RemindedAt *time.Time `json:"reminded_at,omitempty"`
+}
+
+// OwedBillShareResponse is a share the user owes with the bill it belongs to
+type OwedBillShareResponse struct {
+	ID         int32      `json:"id"`
+	BillID     int32      `json:"bill_id"`
+	CreatorID  int32      `json:"creator_id"`
+	Title      string     `json:"title"`
+	Currency   string     `json:"currency"`
+	Amount     float64    `json:"amount"`
+	Status     string     `json:"status"`
+	TransferID *int32     `json:"transfer_id,omitempty"`
+	PaidAt     *time.Time `json:"paid_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func NewBillResponse(bill postgres.Bill) BillResponse {
+	res := BillResponse{
+		ID:                bill.ID,
+		CreatorID:         bill.CreatorID,
+		Title:             bill.Title,
+		Currency:          bill.Currency,
+		Total:             bill.Total,
+		Split:             bill.Split,
+		PaidAmount:        bill.PaidAmount,
+		OutstandingAmount: bill.OutstandingAmount,
+		Status:            bill.Status,
+		CreatedAt:         bill.CreatedAt,
+	}
+	if bill.SettledAt.Valid {
+		res.SettledAt = &bill.SettledAt.Time
+	}
+	return res
+}
+
+func NewBillDetailResponse(detail usecase.BillDetail) BillResponse {
+	res := NewBillResponse(detail.Bill)
+	res.Shares = NewBillSharesResponse(detail.Shares)
+	return res
+}
+
+func NewBillsResponse(bills []postgres.Bill) []BillResponse {
+	res := make([]BillResponse, 0, len(bills))
+	for _, bill := range bills {
+		res = append(res, NewBillResponse(bill))
+	}
+	return res
+}
+
+func NewBillSharesResponse(shares []postgres.BillShare) []BillShareResponse {
+	res := make([]BillShareResponse, 0, len(shares))
+	for _, share := range shares {
+		item := BillShareResponse{
+			ID:     share.ID,
+			UserID: share.UserID,
+			Amount: share.Amount,
+			Status: share.Status,
+		}
+		if share.TransferID.Valid {
+			item.TransferID = &share.TransferID.Int32
+		}
+		if share.PaidAt.Valid {
+			item.PaidAt = &share.PaidAt.Time
+		}
+		if share.RemindedAt.Valid {
+			item.RemindedAt = &share.RemindedAt.Time
+		}
+		res = append(res, item)
+	}
+	return res
+}
+
+func NewOwedBillSharesResponse(shares []postgres.ListBillSharesByUserIDRow) []OwedBillShareResponse {
+	res := make([]OwedBillShareResponse, 0, len(shares))
+	for _, share := range shares {
+		item := OwedBillShareResponse{
+			ID:        share.ID,
+			BillID:    share.BillID,
+			CreatorID: share.CreatorID,
+			Title:     share.Title,
+			Currency:  share.Currency,
+			Amount:    share.Amount,
+			Status:    share.Status,
+			CreatedAt: share.CreatedAt,
+		}
+		if share.TransferID.Valid {
+			item.TransferID = &share.TransferID.Int32
+		}
+		if share.PaidAt.Valid {
+			item.PaidAt = &share.PaidAt.Time
+		}
+		res = append(res, item)
+	}
+	return res
+}
@@ -0,0 +1,63 @@
+package response
+
+import (
+	"kc-ewallet/domains/usecase"
+	"time"
+)
+
+// ExchangeQuoteResponse prices an exchange, nothing is executed until the quote id is sent
+type ExchangeQuoteResponse struct {
+	From          string    `json:"from"`
+	To            string    `json:"to"`
+	FromAmount    float64   `json:"from_amount"`
+	ToAmount      float64   `json:"to_amount"`
+	Rate          float64   `json:"rate"`
+	MidRate       float64   `json:"mid_rate"`
+	SpreadPercent float64   `json:"spread_percent"`
+	QuoteID       string    `json:"quote_id"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+type ExchangeResponse struct {
+	ID                  int32     `json:"id"`
+	From                string    `json:"from"`
+	To                  string    `json:"to"`
+	FromAmount          float64   `json:"from_amount"`
+	ToAmount            float64   `json:"to_amount"`
+	Rate                float64   `json:"rate"`
+	DebitTransactionID  int32     `json:"debit_transaction_id"`
+	CreditTransactionID int32     `json:"credit_transaction_id"`
+	FromBalance         float64   `json:"from_balance"`
+	ToBalance           float64   `json:"to_balance"`
+	CreatedAt           time.Time `json:"created_at"`
+}
+
+func NewExchangeQuoteResponse(quote usecase.ExchangeQuote) ExchangeQuoteResponse {
+	return ExchangeQuoteResponse{
+		From:          quote.From,
+		To:            quote.To,
+		FromAmount:    quote.FromAmount,
+		ToAmount:      quote.ToAmount,
+		Rate:          quote.Rate,
+		MidRate:       quote.MidRate,
+		SpreadPercent: quote.SpreadPercent,
+		QuoteID:       quote.QuoteID,
+		ExpiresAt:     quote.ExpiresAt,
+	}
+}
+
+func NewExchangeResponse(result usecase.ExchangeResult) ExchangeResponse {
+	return ExchangeResponse{
+		ID:                  result.Exchange.ID,
+		From:                result.Exchange.FromCurrency,
+		To:                  result.Exchange.ToCurrency,
+		FromAmount:          result.Exchange.FromAmount,
+		ToAmount:            result.Exchange.ToAmount,
+		Rate:                result.Exchange.Rate,
+		DebitTransactionID:  result.Exchange.DebitTransactionID,
+		CreditTransactionID: result.Exchange.CreditTransactionID,
+		FromBalance:         result.FromBalance,
+		ToBalance:           result.ToBalance,
+		CreatedAt:           result.Exchange.CreatedAt,
+	}
+}
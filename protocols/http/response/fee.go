@@ -0,0 +1,66 @@
+package response
+
+import (
+	"encoding/json"
+	"kc-ewallet/domains/repository/postgres"
+	"time"
+)
+
+type FeeTierResponse struct {
+	UpTo    *float64 `json:"up_to"`
+	Flat    float64  `json:"flat"`
+	Percent float64  `json:"percent"`
+}
+
+type FeeRuleResponse struct {
+	ID              int32             `json:"id"`
+	TransactionType string            `json:"transaction_type"`
+	Currency        string            `json:"currency"`
+	Channel         *string           `json:"channel"`
+	UserTier        *string           `json:"user_tier"`
+	Kind            string            `json:"kind"`
+	FlatAmount      float64           `json:"flat_amount"`
+	Percent         float64           `json:"percent"`
+	Tiers           []FeeTierResponse `json:"tiers"`
+	MinFee          *float64          `json:"min_fee"`
+	MaxFee          *float64          `json:"max_fee"`
+	Active          bool              `json:"active"`
+	CreatedAt       time.Time         `json:"created_at"`
+}
+
+func NewFeeRuleResponse(rule postgres.FeeRule) FeeRuleResponse {
+	res := FeeRuleResponse{
+		ID:              rule.ID,
+		TransactionType: rule.TransactionType,
+		Currency:        rule.Currency,
+		Kind:            rule.Kind,
+		FlatAmount:      rule.FlatAmount,
+		Percent:         rule.Percent,
+		Tiers:           []FeeTierResponse{},
+		Active:          rule.Active,
+		CreatedAt:       rule.CreatedAt,
+	}
+	if rule.Channel.Valid {
+		res.Channel = &rule.Channel.String
+	}
+	if rule.UserTier.Valid {
+		res.UserTier = &rule.UserTier.String
+	}
+	if rule.MinFee.Valid {
+		res.MinFee = &rule.MinFee.Float64
+	}
+	if rule.MaxFee.Valid {
+		res.MaxFee = &rule.MaxFee.Float64
+	}
+	// the tiers were validated on creation, a broken row is shown without them
+	_ = json.Unmarshal(rule.Tiers, &res.Tiers)
+	return res
+}
+
+func NewFeeRulesResponse(rules []postgres.FeeRule) []FeeRuleResponse {
+	res := make([]FeeRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		res = append(res, NewFeeRuleResponse(rule))
+	}
+	return res
+}
@@ -3,6 +3,7 @@ package response
 import (
 	"fmt"
 	"kc-ewallet/internals/errors"
+	"kc-ewallet/internals/helpers/language"
 	"kc-ewallet/internals/helpers/pagination"
 	"net/http"
 	"strconv"
@@ -10,6 +11,11 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	AcceptLanguageHeader  string = "Accept-Language"
+	ContentLanguageHeader string = "Content-Language"
+)
+
 type BaseResponse struct {
 	Status  string `json:"status"`
 	Message string `json:"message"`
@@ -96,50 +102,60 @@ func RespondSuccessWithPaginator(c *gin.Context, data interface{}, paginator *pa
 	c.JSON(http.StatusOK, response)
 }
 
-// RespondError respond error
+// RespondError respond error, the message and error detail come from the
+// error catalog in the language picked from the Accept-Language header
 func RespondError(c *gin.Context, err error) {
 	var (
-		defaultMessage, errorCode string
-		errDetail                 ErrorDetail
+		lang      = language.FromAcceptLanguage(c.GetHeader(AcceptLanguageHeader))
+		entry     = errors.CatalogEntryOf(err)
+		errorCode string
+		message   string
+		errDetail = ErrorDetail{
+			IdMessage:    entry.Message(language.Indonesian),
+			EnMessage:    entry.Message(language.English),
+			Code:         string(entry.Code),
+			RedirectCode: entry.RedirectCode,
+		}
 	)
 
 	errType := errors.GetType(err)
-	switch errType {
-	case errors.Unauthorized:
-		defaultMessage = "Unauthorized."
-	case errors.NotFound:
-		defaultMessage = "Resource not found."
-	case errors.UnprocessableEntity:
-		defaultMessage = "Unprocessable entity error."
-	case errors.BadRequest:
-		defaultMessage = "Bad request."
-	case errors.ExtendedError:
-		extError, ok := err.(errors.ExtError)
-		if ok {
-			errDetail = ErrorDetail{
-				IdMessage:    extError.GetIdMessage(),
-				EnMessage:    extError.GetEnMessage(),
-				Code:         extError.GetErrCode(),
-				RedirectCode: extError.GetRedirectCode(),
-			}
-			errorCode = fmt.Sprintf("%d", extError.GetCode())
+	if extError, ok := err.(errors.ExtError); ok && errType == errors.ExtendedError {
+		errDetail = ErrorDetail{
+			IdMessage:    extError.GetIdMessage(),
+			EnMessage:    extError.GetEnMessage(),
+			Code:         extError.GetErrCode(),
+			RedirectCode: extError.GetRedirectCode(),
 		}
-	default:
-		defaultMessage = "Internal server error."
+		errorCode = fmt.Sprintf("%d", extError.GetCode())
 	}
 
-	message := err.Error()
-	if message == "" {
-		message = defaultMessage
+	switch {
+	case errors.HasCode(err):
+		message = errDetail.EnMessage
+		if lang == language.Indonesian {
+			message = errDetail.IdMessage
+		}
+	case lang == language.English && err.Error() != "":
+		// uncatalogued messages are written in english by the usecases
+		message = err.Error()
+	default:
+		message = entry.Message(lang)
 	}
+
 	if errorCode == "" {
 		// Get error code from err type value
 		errorCode = strconv.Itoa(int(errType))
 	}
 	statusCode := getStatusCode(errorCode)
 
+	// logged with the request id where it's handled, not where it's built
+	errors.Log(c.Request.Context(), err)
+	// report with the request scope, errors not marked reportable are skipped
+	errors.Report(c.Request.Context(), err)
+
 	// Attach error to context for logging and send response
 	c.Error(err)
+	c.Header(ContentLanguageHeader, string(lang))
 	c.JSON(statusCode, BuildErrorResponse(err, errorCode, message).WithDetail(errDetail))
 }
 
@@ -0,0 +1,77 @@
+package response
+
+import (
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/usecase"
+	"time"
+)
+
+type MerchantResponse struct {
+	ID        int32     `json:"id"`
+	Name      string    `json:"name"`
+	UserID    *int32    `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// MerchantKeyResponse never carries the secret or its ciphertext
+type MerchantKeyResponse struct {
+	KeyID     string     `json:"key_id"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// IssuedMerchantKeyResponse is the only response with the secret, it can't be read again
+type IssuedMerchantKeyResponse struct {
+	MerchantKeyResponse
+	Secret string `json:"secret"`
+}
+
+type CreateMerchantResponse struct {
+	MerchantResponse
+	Key IssuedMerchantKeyResponse `json:"key"`
+}
+
+func NewMerchantResponse(merchant postgres.Merchant) MerchantResponse {
+	res := MerchantResponse{
+		ID:        merchant.ID,
+		Name:      merchant.Name,
+		CreatedAt: merchant.CreatedAt,
+	}
+	if merchant.UserID.Valid {
+		res.UserID = &merchant.UserID.Int32
+	}
+	return res
+}
+
+func NewMerchantKeyResponse(key postgres.MerchantApiKey) MerchantKeyResponse {
+	res := MerchantKeyResponse{
+		KeyID:     key.KeyID,
+		CreatedAt: key.CreatedAt,
+	}
+	if key.ExpiresAt.Valid {
+		res.ExpiresAt = &key.ExpiresAt.Time
+	}
+	return res
+}
+
+func NewMerchantKeysResponse(keys []postgres.MerchantApiKey) []MerchantKeyResponse {
+	res := make([]MerchantKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		res = append(res, NewMerchantKeyResponse(key))
+	}
+	return res
+}
+
+func NewIssuedMerchantKeyResponse(key usecase.IssuedMerchantKey) IssuedMerchantKeyResponse {
+	return IssuedMerchantKeyResponse{
+		MerchantKeyResponse: NewMerchantKeyResponse(key.MerchantApiKey),
+		Secret:              key.Secret,
+	}
+}
+
+func NewCreateMerchantResponse(merchant postgres.Merchant, key usecase.IssuedMerchantKey) CreateMerchantResponse {
+	return CreateMerchantResponse{
+		MerchantResponse: NewMerchantResponse(merchant),
+		Key:              NewIssuedMerchantKeyResponse(key),
+	}
+}
@@ -0,0 +1,45 @@
+package response
+
+import (
+	"encoding/json"
+	"kc-ewallet/domains/repository/postgres"
+	"time"
+)
+
+type PaymentResponse struct {
+	ID             int32           `json:"id"`
+	MerchantID     int32           `json:"merchant_id"`
+	Currency       string          `json:"currency"`
+	Amount         float64         `json:"amount"`
+	OrderReference string          `json:"order_reference"`
+	Metadata       json.RawMessage `json:"metadata"`
+	Status         string          `json:"status"`
+	TransactionID  int32           `json:"transaction_id"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+type CreatePaymentResponse struct {
+	PaymentResponse
+	NewBalance float64 `json:"new_balance"`
+}
+
+func NewPaymentResponse(payment postgres.Payment) PaymentResponse {
+	return PaymentResponse{
+		ID:             payment.ID,
+		MerchantID:     payment.MerchantID,
+		Currency:       payment.Currency,
+		Amount:         payment.Amount,
+		OrderReference: payment.OrderReference,
+		Metadata:       payment.Metadata,
+		Status:         payment.Status,
+		TransactionID:  payment.TransactionID,
+		CreatedAt:      payment.CreatedAt,
+	}
+}
+
+func NewCreatePaymentResponse(payment postgres.Payment, newBalance float64) CreatePaymentResponse {
+	return CreatePaymentResponse{
+		PaymentResponse: NewPaymentResponse(payment),
+		NewBalance:      newBalance,
+	}
+}
@@ -0,0 +1,50 @@
+package response
+
+import (
+	"kc-ewallet/domains/repository/postgres"
+	"time"
+)
+
+// PaymentRequestResponse is a payment request, transfer_id is only set once it is paid
+type PaymentRequestResponse struct {
+	ID          int32      `json:"id"`
+	RequesterID int32      `json:"requester_id"`
+	PayerID     int32      `json:"payer_id"`
+	Currency    string     `json:"currency"`
+	Amount      float64    `json:"amount"`
+	Note        string     `json:"note"`
+	Status      string     `json:"status"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	TransferID  *int32     `json:"transfer_id,omitempty"`
+	ResolvedAt  *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+func NewPaymentRequestResponse(paymentRequest postgres.PaymentRequest) PaymentRequestResponse {
+	res := PaymentRequestResponse{
+		ID:          paymentRequest.ID,
+		RequesterID: paymentRequest.RequesterID,
+		PayerID:     paymentRequest.PayerID,
+		Currency:    paymentRequest.Currency,
+		Amount:      paymentRequest.Amount,
+		Note:        paymentRequest.Note,
+		Status:      paymentRequest.Status,
+		ExpiresAt:   paymentRequest.ExpiresAt,
+		CreatedAt:   paymentRequest.CreatedAt,
+	}
+	if paymentRequest.TransferID.Valid {
+		res.TransferID = &paymentRequest.TransferID.Int32
+	}
+	if paymentRequest.ResolvedAt.Valid {
+		res.ResolvedAt = &paymentRequest.ResolvedAt.Time
+	}
+	return res
+}
+
+func NewPaymentRequestsResponse(paymentRequests []postgres.PaymentRequest) []PaymentRequestResponse {
+	res := make([]PaymentRequestResponse, 0, len(paymentRequests))
+	for _, paymentRequest := range paymentRequests {
+		res = append(res, NewPaymentRequestResponse(paymentRequest))
+	}
+	return res
+}
@@ -0,0 +1,88 @@
+package response
+
+import (
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/usecase"
+	"time"
+)
+
+type PocketResponse struct {
+	ID        int32     `json:"id"`
+	Currency  string    `json:"currency"`
+	Name      string    `json:"name"`
+	Balance   float64   `json:"balance"`
+	IsDefault bool      `json:"is_default"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WalletBalanceResponse is a wallet with its balance split into its pockets, the
+// unallocated rest is what can be spent
+type WalletBalanceResponse struct {
+	WalletResponse
+	Unallocated float64          `json:"unallocated"`
+	Pockets     []PocketResponse `json:"pockets"`
+}
+
+// PocketEntryResponse is a line of the history of a pocket, a move without
+// counterpart_pocket_id is from or to the unallocated balance
+type PocketEntryResponse struct {
+	ID                  int32     `json:"id"`
+	Type                string    `json:"type"`
+	Amount              float64   `json:"amount"`
+	Balance             float64   `json:"balance"`
+	TransactionID       *int32    `json:"transaction_id,omitempty"`
+	CounterpartPocketID *int32    `json:"counterpart_pocket_id,omitempty"`
+	CreatedAt           time.Time `json:"created_at"`
+}
+
+func NewPocketResponse(pocket postgres.Pocket) PocketResponse {
+	return PocketResponse{
+		ID:        pocket.ID,
+		Currency:  pocket.Currency,
+		Name:      pocket.Name,
+		Balance:   pocket.Balance,
+		IsDefault: pocket.IsDefault,
+		CreatedAt: pocket.CreatedAt,
+	}
+}
+
+func NewWalletBalanceResponse(balance usecase.WalletBalance) WalletBalanceResponse {
+	res := WalletBalanceResponse{
+		WalletResponse: NewWalletResponse(balance.Wallet),
+		Unallocated:    balance.Unallocated,
+		Pockets:        make([]PocketResponse, 0, len(balance.Pockets)),
+	}
+	for _, pocket := range balance.Pockets {
+		res.Pockets = append(res.Pockets, NewPocketResponse(pocket))
+	}
+	return res
+}
+
+func NewWalletBalancesResponse(balances []usecase.WalletBalance) []WalletBalanceResponse {
+	res := make([]WalletBalanceResponse, 0, len(balances))
+	for _, balance := range balances {
+		res = append(res, NewWalletBalanceResponse(balance))
+	}
+	return res
+}
+
+func NewPocketEntriesResponse(entries []postgres.PocketEntry) []PocketEntryResponse {
+	res := make([]PocketEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		item := PocketEntryResponse{
+			ID:        entry.ID,
+			Type:      entry.Type,
+			Amount:    entry.Amount,
+			Balance:   entry.Balance,
+			CreatedAt: entry.CreatedAt,
+		}
+		if entry.TransactionID.Valid {
+			item.TransactionID = &entry.TransactionID.Int32
+		}
+		if entry.CounterpartPocketID.Valid {
+			item.CounterpartPocketID = &entry.CounterpartPocketID.Int32
+		}
+		res = append(res, item)
+	}
+	return res
+}
@@ -0,0 +1,58 @@
+package response
+
+import (
+	"kc-ewallet/domains/usecase"
+	"time"
+)
+
+// SavingsGoalResponse is a goal with the progress of its pocket, the rules it has
+// none of are left out
+type SavingsGoalResponse struct {
+	ID             int32          `json:"id"`
+	Pocket         PocketResponse `json:"pocket"`
+	TargetAmount   float64        `json:"target_amount"`
+	TargetDate     string         `json:"target_date"`
+	RoundUpTo      *float64       `json:"round_up_to,omitempty"`
+	SweepAmount    *float64       `json:"sweep_amount,omitempty"`
+	SweepFrequency string         `json:"sweep_frequency,omitempty"`
+	NextSweepAt    *time.Time     `json:"next_sweep_at,omitempty"`
+	Remaining      float64        `json:"remaining"`
+	Percent        float64        `json:"percent"`
+	DaysLeft       int            `json:"days_left"`
+	Reached        bool           `json:"reached"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
+
+func NewSavingsGoalResponse(progress usecase.SavingsProgress) SavingsGoalResponse {
+	goal := progress.Goal
+	res := SavingsGoalResponse{
+		ID:             goal.ID,
+		Pocket:         NewPocketResponse(progress.Pocket),
+		TargetAmount:   goal.TargetAmount,
+		TargetDate:     goal.TargetDate.Format(time.DateOnly),
+		SweepFrequency: goal.SweepFrequency.String,
+		Remaining:      progress.Remaining,
+		Percent:        progress.Percent,
+		DaysLeft:       progress.DaysLeft,
+		Reached:        progress.Reached,
+		CreatedAt:      goal.CreatedAt,
+	}
+	if goal.RoundUpTo.Valid {
+		res.RoundUpTo = &goal.RoundUpTo.Float64
+	}
+	if goal.SweepAmount.Valid {
+		res.SweepAmount = &goal.SweepAmount.Float64
+	}
+	if goal.NextSweepAt.Valid {
+		res.NextSweepAt = &goal.NextSweepAt.Time
+	}
+	return res
+}
+
+func NewSavingsGoalsResponse(progress []usecase.SavingsProgress) []SavingsGoalResponse {
+	res := make([]SavingsGoalResponse, 0, len(progress))
+	for _, item := range progress {
+		res = append(res, NewSavingsGoalResponse(item))
+	}
+	return res
+}
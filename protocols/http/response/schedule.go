@@ -0,0 +1,88 @@
+package response
+
+import (
+	"kc-ewallet/domains/repository/postgres"
+	"time"
+)
+
+// ScheduledTransferResponse is a scheduled transfer, next_run_at is left out once it
+// is no longer active
+type ScheduledTransferResponse struct {
+	ID                  int32      `json:"id"`
+	ToUserID            int32      `json:"to_user_id"`
+	Currency            string     `json:"currency"`
+	Amount              float64    `json:"amount"`
+	Note                string     `json:"note"`
+	StartAt             time.Time  `json:"start_at"`
+	Recurrence          string     `json:"recurrence,omitempty"`
+	EndAt               *time.Time `json:"end_at,omitempty"`
+	MaxOccurrences      *int32     `json:"max_occurrences,omitempty"`
+	OnInsufficientFunds string     `json:"on_insufficient_funds"`
+	Status              string     `json:"status"`
+	OccurrenceCount     int32      `json:"occurrence_count"`
+	NextRunAt           *time.Time `json:"next_run_at,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+}
+
+type ScheduledTransferRunResponse struct {
+	ID           int32     `json:"id"`
+	OccurrenceAt time.Time `json:"occurrence_at"`
+	Attempt      int32     `json:"attempt"`
+	Status       string    `json:"status"`
+	TransferID   *int32    `json:"transfer_id,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func NewScheduledTransferResponse(schedule postgres.ScheduledTransfer) ScheduledTransferResponse {
+	res := ScheduledTransferResponse{
+		ID:                  schedule.ID,
+		ToUserID:            schedule.ToUserID,
+		Currency:            schedule.Currency,
+		Amount:              schedule.Amount,
+		Note:                schedule.Note,
+		StartAt:             schedule.StartAt,
+		Recurrence:          schedule.Recurrence.String,
+		OnInsufficientFunds: schedule.OnInsufficientFunds,
+		Status:              schedule.Status,
+		OccurrenceCount:     schedule.OccurrenceCount,
+		CreatedAt:           schedule.CreatedAt,
+	}
+	if schedule.EndAt.Valid {
+		res.EndAt = &schedule.EndAt.Time
+	}
+	if schedule.MaxOccurrences.Valid {
+		res.MaxOccurrences = &schedule.MaxOccurrences.Int32
+	}
+	if schedule.NextRunAt.Valid {
+		res.NextRunAt = &schedule.NextRunAt.Time
+	}
+	return res
+}
+
+func NewScheduledTransfersResponse(schedules []postgres.ScheduledTransfer) []ScheduledTransferResponse {
+	res := make([]ScheduledTransferResponse, 0, len(schedules))
+	for _, schedule := range schedules {
+		res = append(res, NewScheduledTransferResponse(schedule))
+	}
+	return res
+}
+
+func NewScheduledTransferRunsResponse(runs []postgres.ScheduledTransferRun) []ScheduledTransferRunResponse {
+	res := make([]ScheduledTransferRunResponse, 0, len(runs))
+	for _, run := range runs {
+		item := ScheduledTransferRunResponse{
+			ID:           run.ID,
+			OccurrenceAt: run.OccurrenceAt,
+			Attempt:      run.Attempt,
+			Status:       run.Status,
+			Error:        run.Error,
+			CreatedAt:    run.CreatedAt,
+		}
+		if run.TransferID.Valid {
+			item.TransferID = &run.TransferID.Int32
+		}
+		res = append(res, item)
+	}
+	return res
+}
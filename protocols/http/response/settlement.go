@@ -0,0 +1,84 @@
+package response
+
+import (
+	"bytes"
+	"encoding/csv"
+	"kc-ewallet/domains/repository/postgres"
+	"strconv"
+	"time"
+)
+
+type SettlementResponse struct {
+	ID             int32   `json:"id"`
+	SettlementDate string  `json:"settlement_date"`
+	Currency       string  `json:"currency"`
+	PaymentCount   int32   `json:"payment_count"`
+	GrossAmount    float64 `json:"gross_amount"`
+	FeeAmount      float64 `json:"fee_amount"`
+	NetAmount      float64 `json:"net_amount"`
+	// TransactionID is the credit of the net amount to the merchant wallet
+	TransactionID *int32    `json:"transaction_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func NewSettlementResponse(settlement postgres.Settlement) SettlementResponse {
+	res := SettlementResponse{
+		ID:             settlement.ID,
+		SettlementDate: settlement.SettlementDate.Format(time.DateOnly),
+		Currency:       settlement.Currency,
+		PaymentCount:   settlement.PaymentCount,
+		GrossAmount:    settlement.GrossAmount,
+		FeeAmount:      settlement.FeeAmount,
+		NetAmount:      settlement.NetAmount,
+		CreatedAt:      settlement.CreatedAt,
+	}
+	if settlement.TransactionID.Valid {
+		res.TransactionID = &settlement.TransactionID.Int32
+	}
+	return res
+}
+
+func NewSettlementsResponse(settlements []postgres.Settlement) []SettlementResponse {
+	res := make([]SettlementResponse, 0, len(settlements))
+	for _, settlement := range settlements {
+		res = append(res, NewSettlementResponse(settlement))
+	}
+	return res
+}
+
+// SettlementReportFilename names the csv report of a settlement when it is downloaded
+func SettlementReportFilename(settlement postgres.Settlement) string {
+	return "settlement-" + settlement.SettlementDate.Format(time.DateOnly) + "-" + strconv.Itoa(int(settlement.ID)) + ".csv"
+}
+
+// NewSettlementReportCSV writes one row per settled payment followed by the totals
+// rows, the merchant reconciles it with its orders by the order reference
+func NewSettlementReportCSV(settlement postgres.Settlement, payments []postgres.Payment) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	records := [][]string{{"payment_id", "order_reference", "amount", "captured_at", "metadata"}}
+	for _, payment := range payments {
+		records = append(records, []string{
+			strconv.Itoa(int(payment.ID)),
+			payment.OrderReference,
+			formatAmount(payment.Amount),
+			payment.CreatedAt.UTC().Format(time.RFC3339),
+			string(payment.Metadata),
+		})
+	}
+	records = append(records,
+		[]string{"gross", "", formatAmount(settlement.GrossAmount), "", ""},
+		[]string{"fee", "", formatAmount(settlement.FeeAmount), "", ""},
+		[]string{"net", "", formatAmount(settlement.NetAmount), "", ""},
+	)
+
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
@@ -1,25 +1,63 @@
 package response
 
+import (
+	"kc-ewallet/domains/usecase"
+	"time"
+)
+
 type CreateCreditTransactionResponse struct {
 	TransactionID int32   `json:"transaction_id"`
+	Currency      string  `json:"currency"`
 	NewBalance    float64 `json:"new_balance"`
 }
 
 type CreateDebitTransactionResponse struct {
 	TransactionID int32   `json:"transaction_id"`
+	Currency      string  `json:"currency"`
 	NewBalance    float64 `json:"new_balance"`
 }
 
-func NewCreateCreditTransactionResponse(transactionID int32, newBalance float64) CreateCreditTransactionResponse {
+// TransactionQuoteResponse previews a transaction, nothing is executed until the
+// quote id is sent with the transaction
+type TransactionQuoteResponse struct {
+	Type             string     `json:"type"`
+	Currency         string     `json:"currency"`
+	Amount           float64    `json:"amount"`
+	Fee              float64    `json:"fee"`
+	Balance          float64    `json:"balance"`
+	ResultingBalance float64    `json:"resulting_balance"`
+	QuoteID          string     `json:"quote_id,omitempty"`
+	ExpiresAt        *time.Time `json:"expires_at,omitempty"`
+}
+
+func NewCreateCreditTransactionResponse(transactionID int32, currency string, newBalance float64) CreateCreditTransactionResponse {
 	return CreateCreditTransactionResponse{
 		TransactionID: transactionID,
+		Currency:      currency,
 		NewBalance:    newBalance,
 	}
 }
 
-func NewCreateDebitTransactionResponse(transactionID int32, newBalance float64) CreateDebitTransactionResponse {
+func NewCreateDebitTransactionResponse(transactionID int32, currency string, newBalance float64) CreateDebitTransactionResponse {
 	return CreateDebitTransactionResponse{
 		TransactionID: transactionID,
+		Currency:      currency,
 		NewBalance:    newBalance,
 	}
 }
+
+func NewTransactionQuoteResponse(quote usecase.TransactionQuote) TransactionQuoteResponse {
+	res := TransactionQuoteResponse{
+		Type:             quote.Type,
+		Currency:         quote.Currency,
+		Amount:           quote.Amount,
+		Fee:              quote.Fee,
+		Balance:          quote.Balance,
+		ResultingBalance: quote.ResultingBalance,
+	}
+	if quote.QuoteID != "" {
+		res.QuoteID = quote.QuoteID
+		res.ExpiresAt = &quote.ExpiresAt
+	}
+	return res
+}
@@ -0,0 +1,33 @@
+package response
+
+import (
+	"kc-ewallet/domains/usecase"
+	"time"
+)
+
+type TransferResponse struct {
+	ID                  int32     `json:"id"`
+	ToUserID            int32     `json:"to_user_id"`
+	Currency            string    `json:"currency"`
+	Amount              float64   `json:"amount"`
+	Note                string    `json:"note"`
+	DebitTransactionID  int32     `json:"debit_transaction_id"`
+	CreditTransactionID int32     `json:"credit_transaction_id"`
+	Balance             float64   `json:"balance"`
+	CreatedAt           time.Time `json:"created_at"`
+}
+
+func NewTransferResponse(result usecase.TransferResult) TransferResponse {
+	transfer := result.Transfer
+	return TransferResponse{
+		ID:                  transfer.ID,
+		ToUserID:            transfer.ToUserID,
+		Currency:            transfer.Currency,
+		Amount:              transfer.Amount,
+		Note:                transfer.Note,
+		DebitTransactionID:  transfer.DebitTransactionID,
+		CreditTransactionID: transfer.CreditTransactionID,
+		Balance:             result.Balance,
+		CreatedAt:           transfer.CreatedAt,
+	}
+}
@@ -2,10 +2,11 @@ package response
 
 import "kc-ewallet/domains/repository/postgres"
 
+// GetUserByIDResponse lists the wallets with their total balance and its per-pocket breakdown
 type GetUserByIDResponse struct {
-	ID       int32   `json:"id"`
-	Username string  `json:"username"`
-	Balance  float64 `json:"balance,omitempty"`
+	ID       int32                   `json:"id"`
+	Username string                  `json:"username"`
+	Wallets  []WalletBalanceResponse `json:"wallets,omitempty"`
 }
 
 type LoginResponse struct {
@@ -17,7 +18,6 @@ func NewGetUserByIDResponse(user postgres.User) GetUserByIDResponse {
 	return GetUserByIDResponse{
 		ID:       user.ID,
 		Username: user.Username,
-		Balance:  user.Balance,
 	}
 }
 
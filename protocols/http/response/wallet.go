@@ -0,0 +1,43 @@
+package response
+
+import (
+	"kc-ewallet/domains/repository/postgres"
+	"time"
+)
+
+type WalletResponse struct {
+	ID        int32     `json:"id"`
+	Currency  string    `json:"currency"`
+	Balance   float64   `json:"balance"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CurrencyResponse struct {
+	Code       string `json:"code"`
+	MinorUnits int16  `json:"minor_units"`
+}
+
+func NewWalletResponse(wallet postgres.Wallet) WalletResponse {
+	return WalletResponse{
+		ID:        wallet.ID,
+		Currency:  wallet.Currency,
+		Balance:   wallet.Balance,
+		CreatedAt: wallet.CreatedAt,
+	}
+}
+
+func NewWalletsResponse(wallets []postgres.Wallet) []WalletResponse {
+	res := make([]WalletResponse, 0, len(wallets))
+	for _, wallet := range wallets {
+		res = append(res, NewWalletResponse(wallet))
+	}
+	return res
+}
+
+func NewCurrenciesResponse(currencies []postgres.Currency) []CurrencyResponse {
+	res := make([]CurrencyResponse, 0, len(currencies))
+	for _, currency := range currencies {
+		res = append(res, CurrencyResponse{Code: currency.Code, MinorUnits: currency.MinorUnits})
+	}
+	return res
+}
@@ -0,0 +1,91 @@
+package response
+
+import (
+	"encoding/json"
+	"kc-ewallet/domains/repository/postgres"
+	"kc-ewallet/domains/usecase/webhook"
+	"time"
+)
+
+type WebhookResponse struct {
+	ID         int32     `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// CreateWebhookResponse is the only response with the secret, it can't be read again
+type CreateWebhookResponse struct {
+	WebhookResponse
+	Secret string `json:"secret"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             int64           `json:"id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Status         string          `json:"status"`
+	Attempts       int32           `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at"`
+	ResponseStatus *int32          `json:"response_status"`
+	LastError      string          `json:"last_error,omitempty"`
+	Payload        json.RawMessage `json:"payload"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+func NewWebhookResponse(subscription postgres.WebhookSubscription) WebhookResponse {
+	return WebhookResponse{
+		ID:         subscription.ID,
+		URL:        subscription.Url,
+		EventTypes: subscription.EventTypes,
+		CreatedAt:  subscription.CreatedAt,
+	}
+}
+
+func NewCreateWebhookResponse(subscription postgres.WebhookSubscription) CreateWebhookResponse {
+	return CreateWebhookResponse{
+		WebhookResponse: NewWebhookResponse(subscription),
+		Secret:          subscription.Secret,
+	}
+}
+
+func NewWebhooksResponse(subscriptions []postgres.WebhookSubscription) []WebhookResponse {
+	res := make([]WebhookResponse, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		res = append(res, NewWebhookResponse(subscription))
+	}
+	return res
+}
+
+func NewWebhookDeliveryResponse(delivery postgres.WebhookDelivery) WebhookDeliveryResponse {
+	res := WebhookDeliveryResponse{
+		ID:        delivery.ID,
+		EventID:   delivery.EventID.String(),
+		EventType: delivery.EventType,
+		Status:    delivery.Status,
+		Attempts:  delivery.Attempts,
+		LastError: delivery.LastError,
+		Payload:   delivery.Payload,
+		CreatedAt: delivery.CreatedAt,
+	}
+	// only a pending delivery has a next attempt
+	if delivery.Status == webhook.StatusPending {
+		res.NextAttemptAt = &delivery.NextAttemptAt
+	}
+	if delivery.LastAttemptAt.Valid {
+		res.LastAttemptAt = &delivery.LastAttemptAt.Time
+	}
+	if delivery.ResponseStatus.Valid {
+		res.ResponseStatus = &delivery.ResponseStatus.Int32
+	}
+	return res
+}
+
+func NewWebhookDeliveriesResponse(deliveries []postgres.WebhookDelivery) []WebhookDeliveryResponse {
+	res := make([]WebhookDeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		res = append(res, NewWebhookDeliveryResponse(delivery))
+	}
+	return res
+}
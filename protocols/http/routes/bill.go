@@ -0,0 +1,132 @@
+package routes
+
+import (
+	"kc-ewallet/constants"
+	"kc-ewallet/domains/repository/stream"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/internals/helpers/openapi"
+	rate_limit "kc-ewallet/internals/helpers/rate_limiter"
+	"kc-ewallet/internals/metric"
+	"kc-ewallet/protocols/http/controller"
+	"kc-ewallet/protocols/http/middleware"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterBillRoutes(router *gin.Engine, jwtSigningKey string, appMetric metric.Metric, auditUsecase usecase.IAuditUsecase, ctrl *controller.BillController) {
+	v1RouterGroup := router.Group(constants.ApiV1BasePath)
+	v1RouterGroup.Use(
+		middleware.AuthorizeToken(
+			jwtSigningKey,
+			middleware.RegisterHandlers(
+				map[string]bool{
+					"CreateBill":             true,
+					"ListBills":              true,
+					"ListOwedBillShares":     true,
+					"GetBill":                true,
+					"PayBillShare":           true,
+					"RemindBillParticipants": true,
+				},
+			),
+		),
+		middleware.CheckRateLimit(
+			middleware.NewRateLimiter(rate_limit.NewCacheService(), []string{}).WithMetric(appMetric).WithAudit(auditUsecase),
+			middleware.RegisterHandlers(
+				map[string]bool{
+					"CreateBill":             true,
+					"PayBillShare":           true,
+					"RemindBillParticipants": true,
+				},
+			),
+		),
+	)
+
+	BillV1Routes(v1RouterGroup, ctrl)
+}
+
+func BillV1Routes(v1Router *gin.RouterGroup, ctrl *controller.BillController) {
+	routes := v1Router.Group(constants.BillPath)
+
+	routes.POST("/", ctrl.CreateBill)
+	routes.GET("/", ctrl.ListBills)
+	routes.GET("/owed", ctrl.ListOwedBillShares)
+	routes.GET("/:id", ctrl.GetBill)
+	routes.POST("/:id/pay", ctrl.PayBillShare)
+	routes.POST("/:id/remind", ctrl.RemindBillParticipants)
+}
+
+// BillV1Docs documents BillV1Routes
+func BillV1Docs() []openapi.Route {
+	path := constants.ApiV1BasePath + constants.BillPath
+
+	return []openapi.Route{
+		{
+			Method:      http.MethodPost,
+			Path:        path + "/",
+			Summary:     "Split a bill with other users",
+			Description: "An equal split divides the total between the creator and the participants, the creator keeping the minor units left over. A custom split owes each participant its amount, at most the total altogether, and leaves the rest to the creator. An unknown participant fails with ER010.",
+			Tag:         "Bills",
+			Secured:     true,
+			Request:     request.CreateBillRequest{},
+			Response:    response.BuildSuccessResponse("success", response.BillResponse{}),
+			Errors:      response.ErrorResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    path + "/",
+			Summary: "List the bills the user created, newest first",
+			Tag:     "Bills",
+			Secured: true,
+			Query:   request.ListBillsQuery{},
+			Response: response.PaginatorResponse{
+				StandardResponse: response.BuildSuccessResponse("success", []response.BillResponse{}),
+			},
+			Errors: response.ErrorResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    path + "/owed",
+			Summary: "List the shares the user owes in the bills of others, newest first",
+			Tag:     "Bills",
+			Secured: true,
+			Query:   request.ListOwedBillSharesQuery{},
+			Response: response.PaginatorResponse{
+				StandardResponse: response.BuildSuccessResponse("success", []response.OwedBillShareResponse{}),
+			},
+			Errors: response.ErrorResponse{},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        path + "/:id",
+			Summary:     "Get a bill the user created or takes part in, with every share",
+			Description: "An unknown bill fails with ER033.",
+			Tag:         "Bills",
+			Secured:     true,
+			Response:    response.BuildSuccessResponse("success", response.BillResponse{}),
+			Errors:      response.ErrorResponse{},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        path + "/:id/pay",
+			Summary:     "Pay the share of the user",
+			Description: "Transfers the share to the creator and marks it paid in the same database transaction, the bill is settled with its last share. A share already paid fails with ER034.",
+			Tag:         "Bills",
+			Secured:     true,
+			Response:    response.BuildSuccessResponse("success", response.TransferResponse{}),
+			Errors:      response.ErrorResponse{},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        path + "/:id/remind",
+			Summary:     "Remind the participants with a pending share",
+			Description: "Only the creator can remind. Each participant gets a " + string(stream.EventBillReminder) + " event on its event stream, at most once per reminder interval. Returns the shares reminded.",
+			Tag:         "Bills",
+			Secured:     true,
+			Response:    response.BuildSuccessResponse("success", []response.BillShareResponse{}),
+			Errors:      response.ErrorResponse{},
+		},
+	}
+}
@@ -0,0 +1,59 @@
+package routes
+
+import (
+	"kc-ewallet/constants"
+	"kc-ewallet/domains/repository/stream"
+	"kc-ewallet/internals/helpers/openapi"
+	"kc-ewallet/protocols/http/controller"
+	"kc-ewallet/protocols/http/middleware"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterEventRoutes registers the event stream, it is not rate limited since
+// open streams are already capped per user by the realtime usecase
+func RegisterEventRoutes(router *gin.Engine, jwtSigningKey string, ctrl *controller.EventController) {
+	v1RouterGroup := router.Group(constants.ApiV1BasePath)
+	v1RouterGroup.Use(
+		middleware.AuthorizeToken(
+			jwtSigningKey,
+			middleware.RegisterHandlers(
+				map[string]bool{
+					"StreamEvents": true,
+				},
+			),
+		),
+	)
+
+	EventV1Routes(v1RouterGroup, ctrl)
+}
+
+func EventV1Routes(v1Router *gin.RouterGroup, ctrl *controller.EventController) {
+	routes := v1Router.Group(constants.EventPath)
+
+	routes.GET("/", ctrl.StreamEvents)
+}
+
+// EventV1Docs documents EventV1Routes
+func EventV1Docs() []openapi.Route {
+	path := constants.ApiV1BasePath + constants.EventPath
+
+	return []openapi.Route{
+		{
+			Method:  http.MethodGet,
+			Path:    path + "/",
+			Summary: "Stream the balance and transaction events of the user",
+			Description: "Server-Sent Events named " + string(stream.EventBalanceChanged) + ", " + string(stream.EventTransactionCreated) +
+				" and " + string(stream.EventBillReminder) + ", the data is the JSON event. Reconnect with the Last-Event-ID header, or last_event_id," +
+				" to receive the events missed in between. Idle streams get a comment line as heartbeat.",
+			Tag:          "Events",
+			Secured:      true,
+			Query:        request.StreamEventsQuery{},
+			ResponseType: "text/event-stream",
+			Errors:       response.ErrorResponse{},
+		},
+	}
+}
@@ -0,0 +1,79 @@
+package routes
+
+import (
+	"kc-ewallet/constants"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/internals/helpers/openapi"
+	rate_limit "kc-ewallet/internals/helpers/rate_limiter"
+	"kc-ewallet/internals/metric"
+	"kc-ewallet/protocols/http/controller"
+	"kc-ewallet/protocols/http/middleware"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterExchangeRoutes(router *gin.Engine, jwtSigningKey string, appMetric metric.Metric, auditUsecase usecase.IAuditUsecase, ctrl *controller.ExchangeController) {
+	v1RouterGroup := router.Group(constants.ApiV1BasePath)
+	v1RouterGroup.Use(
+		middleware.AuthorizeToken(
+			jwtSigningKey,
+			middleware.RegisterHandlers(
+				map[string]bool{
+					"QuoteExchange":  true,
+					"CreateExchange": true,
+				},
+			),
+		),
+		middleware.CheckRateLimit(
+			middleware.NewRateLimiter(rate_limit.NewCacheService(), []string{}).WithMetric(appMetric).WithAudit(auditUsecase),
+			middleware.RegisterHandlers(
+				map[string]bool{
+					"QuoteExchange":  true,
+					"CreateExchange": true,
+				},
+			),
+		),
+	)
+
+	ExchangeV1Routes(v1RouterGroup, ctrl)
+}
+
+func ExchangeV1Routes(v1Router *gin.RouterGroup, ctrl *controller.ExchangeController) {
+	routes := v1Router.Group(constants.ExchangePath)
+
+	routes.POST("/quote", ctrl.QuoteExchange)
+	routes.POST("/", ctrl.CreateExchange)
+}
+
+// ExchangeV1Docs documents ExchangeV1Routes
+func ExchangeV1Docs() []openapi.Route {
+	path := constants.ApiV1BasePath + constants.ExchangePath
+
+	return []openapi.Route{
+		{
+			Method:      http.MethodPost,
+			Path:        path + "/quote",
+			Summary:     "Quote an exchange between two wallets of the user",
+			Description: "The rate is the mid-market rate less the spread. Nothing is reserved, a pair without a rate fails with ER024.",
+			Tag:         "Exchanges",
+			Secured:     true,
+			Request:     request.CreateExchangeQuoteRequest{},
+			Response:    response.BuildSuccessResponse("success", response.ExchangeQuoteResponse{}),
+			Errors:      response.ErrorResponse{},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        path + "/",
+			Summary:     "Execute an exchange quote",
+			Description: "Debits the sold wallet and credits the bought one at the quoted rate, once; it fails with ER017 once expired and ER019 when invalid or used.",
+			Tag:         "Exchanges",
+			Secured:     true,
+			Request:     request.CreateExchangeRequest{},
+			Response:    response.BuildSuccessResponse("success", response.ExchangeResponse{}),
+			Errors:      response.ErrorResponse{},
+		},
+	}
+}
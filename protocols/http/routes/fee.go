@@ -0,0 +1,69 @@
+package routes
+
+import (
+	"kc-ewallet/constants"
+	"kc-ewallet/internals/helpers/openapi"
+	"kc-ewallet/protocols/http/controller"
+	"kc-ewallet/protocols/http/middleware"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterFeeRoutes(router *gin.Engine, jwtSigningKey string, ctrl *controller.FeeController) {
+	v1RouterGroup := router.Group(constants.ApiV1BasePath)
+	v1RouterGroup.Use(
+		middleware.AuthorizeToken(jwtSigningKey),
+		middleware.CheckPermission([]middleware.PagePermission{middleware.FeePage}),
+	)
+
+	FeeV1Routes(v1RouterGroup, ctrl)
+}
+
+func FeeV1Routes(v1Router *gin.RouterGroup, ctrl *controller.FeeController) {
+	routes := v1Router.Group(constants.AdminFeePath)
+
+	routes.POST("/", ctrl.CreateFeeRule)
+	routes.GET("/", ctrl.ListFeeRules)
+	routes.DELETE("/:id", ctrl.DeactivateFeeRule)
+}
+
+// FeeV1Docs documents FeeV1Routes
+func FeeV1Docs() []openapi.Route {
+	path := constants.ApiV1BasePath + constants.AdminFeePath
+
+	return []openapi.Route{
+		{
+			Method:      http.MethodPost,
+			Path:        path + "/",
+			Summary:     "Create a fee rule",
+			Description: "Replaces the active rule of the same transaction type, channel and user tier.",
+			Tag:         "Fees",
+			Secured:     true,
+			Request:     request.CreateFeeRuleRequest{},
+			Response:    response.BuildSuccessResponse("success", response.FeeRuleResponse{}),
+			Errors:      response.ErrorResponse{},
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     path + "/",
+			Summary:  "List the active fee rules, or every rule with all=true",
+			Tag:      "Fees",
+			Secured:  true,
+			Query:    request.ListFeeRulesQuery{},
+			Response: response.BuildSuccessResponse("success", []response.FeeRuleResponse{}),
+			Errors:   response.ErrorResponse{},
+		},
+		{
+			Method:   http.MethodDelete,
+			Path:     path + "/:id",
+			Summary:  "Deactivate a fee rule",
+			Tag:      "Fees",
+			Secured:  true,
+			Response: response.BuildSuccessResponse("success", nil),
+			Errors:   response.ErrorResponse{},
+		},
+	}
+}
@@ -0,0 +1,116 @@
+package routes
+
+import (
+	"kc-ewallet/constants"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/domains/usecase/merchant"
+	"kc-ewallet/internals/helpers/openapi"
+	"kc-ewallet/protocols/http/controller"
+	"kc-ewallet/protocols/http/middleware"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MerchantSignatureAuth is the security scheme name of the signed merchant requests
+const MerchantSignatureAuth = "merchantSignature"
+
+// MerchantSignatureScheme documents how a merchant signs its requests
+var MerchantSignatureScheme = openapi.SecurityScheme{
+	Type: "apiKey",
+	Name: merchant.KeyIDHeader,
+	In:   "header",
+	Description: "Send the key id in " + merchant.KeyIDHeader + ", the unix time in " + merchant.TimestampHeader +
+		", a unique 16 to 64 character nonce in " + merchant.NonceHeader + " and " + merchant.SignatureHeader +
+		": v1=hex(HMAC-SHA256(secret, canonical)). The canonical string is the method, the path with" +
+		" query, the timestamp, the nonce and the hex SHA-256 of the body, joined by newlines.",
+}
+
+// RegisterMerchantRoutes serves the admin key management behind the access token
+// and the merchant api behind the request signature
+func RegisterMerchantRoutes(router *gin.Engine, jwtSigningKey string, merchantUsecase usecase.IMerchantUsecase, ctrl *controller.MerchantController) {
+	adminRouterGroup := router.Group(constants.ApiV1BasePath)
+	adminRouterGroup.Use(
+		middleware.AuthorizeToken(jwtSigningKey),
+		middleware.CheckPermission([]middleware.PagePermission{middleware.MerchantPage}),
+	)
+	AdminMerchantV1Routes(adminRouterGroup, ctrl)
+
+	merchantRouterGroup := router.Group(constants.ApiV1BasePath)
+	merchantRouterGroup.Use(middleware.AuthorizeMerchantSignature(merchantUsecase))
+	MerchantV1Routes(merchantRouterGroup, ctrl)
+}
+
+func AdminMerchantV1Routes(v1Router *gin.RouterGroup, ctrl *controller.MerchantController) {
+	routes := v1Router.Group(constants.AdminMerchantPath)
+
+	routes.POST("/", ctrl.CreateMerchant)
+	routes.GET("/:id/keys", ctrl.ListMerchantKeys)
+	routes.POST("/:id/keys", ctrl.RotateMerchantKey)
+	routes.DELETE("/:id/keys/:key_id", ctrl.RevokeMerchantKey)
+}
+
+func MerchantV1Routes(v1Router *gin.RouterGroup, ctrl *controller.MerchantController) {
+	routes := v1Router.Group(constants.MerchantPath)
+
+	routes.GET("/profile", ctrl.GetMerchantProfile)
+}
+
+// MerchantV1Docs documents AdminMerchantV1Routes and MerchantV1Routes
+func MerchantV1Docs() []openapi.Route {
+	adminPath := constants.ApiV1BasePath + constants.AdminMerchantPath
+	path := constants.ApiV1BasePath + constants.MerchantPath
+
+	return []openapi.Route{
+		{
+			Method:      http.MethodPost,
+			Path:        adminPath + "/",
+			Summary:     "Create a merchant with its first api key",
+			Description: "The key secret is only returned here and by a rotation.",
+			Tag:         "Merchants",
+			Secured:     true,
+			Request:     request.CreateMerchantRequest{},
+			Response:    response.BuildSuccessResponse("success", response.CreateMerchantResponse{}),
+			Errors:      response.ErrorResponse{},
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     adminPath + "/:id/keys",
+			Summary:  "List the api keys of a merchant",
+			Tag:      "Merchants",
+			Secured:  true,
+			Response: response.BuildSuccessResponse("success", []response.MerchantKeyResponse{}),
+			Errors:   response.ErrorResponse{},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        adminPath + "/:id/keys",
+			Summary:     "Rotate the api key of a merchant",
+			Description: "Issues a new key, the previous keys stay valid for MERCHANT_KEY_ROTATION_OVERLAP_HOUR.",
+			Tag:         "Merchants",
+			Secured:     true,
+			Response:    response.BuildSuccessResponse("success", response.IssuedMerchantKeyResponse{}),
+			Errors:      response.ErrorResponse{},
+		},
+		{
+			Method:   http.MethodDelete,
+			Path:     adminPath + "/:id/keys/:key_id",
+			Summary:  "Revoke an api key immediately",
+			Tag:      "Merchants",
+			Secured:  true,
+			Response: response.BuildSuccessResponse("success", nil),
+			Errors:   response.ErrorResponse{},
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     path + "/profile",
+			Summary:  "Get the calling merchant",
+			Tag:      "Merchants",
+			Security: MerchantSignatureAuth,
+			Response: response.BuildSuccessResponse("success", response.MerchantResponse{}),
+			Errors:   response.ErrorResponse{},
+		},
+	}
+}
@@ -0,0 +1,186 @@
+package routes
+
+import (
+	"kc-ewallet/internals/health"
+	"kc-ewallet/internals/helpers/openapi"
+	"kc-ewallet/protocols/http/response"
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+const OpenAPI string = "/openapi.json"
+const SwaggerUI string = "/docs"
+const SwaggerUIInitializer string = "/docs/swagger-initializer.js"
+
+// swaggerUIDist is the pinned swagger-ui bundle the docs page loads
+const swaggerUIDist = "https://unpkg.com/swagger-ui-dist@5.17.14"
+
+// swaggerUICSP relaxes SecurityCheck for the docs page only, the bundle is served by unpkg
+const swaggerUICSP = "default-src 'self'; script-src 'self' " + swaggerUIDist + "/; style-src 'self' " + swaggerUIDist + "/; img-src 'self' data: ;"
+
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8" />
+  <title>API documentation</title>
+  <link rel="stylesheet" href="` + swaggerUIDist + `/swagger-ui.css" />
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="` + swaggerUIDist + `/swagger-ui-bundle.js"></script>
+  <script src="` + SwaggerUIInitializer + `"></script>
+</body>
+</html>`
+
+const swaggerUIInitializerScript = `window.onload = function () {
+  window.ui = SwaggerUIBundle({ url: "` + OpenAPI + `", dom_id: "#swagger-ui" });
+};`
+
+type logLevelBody struct {
+	Level string `json:"level" binding:"required,oneof=debug info warn error dpanic panic fatal"`
+}
+
+// NewOpenAPIBuilder documents every route of the service, a route registered
+// without documentation here fails TestOpenAPI_EveryRouteIsDocumented
+func NewOpenAPIBuilder(appName string) *openapi.Builder {
+	return openapi.NewBuilder(openapi.Info{
+		Title:       appName,
+		Description: "Generated from the registered routes, request binding tags and response types.",
+		Version:     "1.0.0",
+	}).
+		SecurityScheme(MerchantSignatureAuth, MerchantSignatureScheme).
+		Tag("Users", "Registration, login and profile").
+		Tag("Wallets", "Per-currency wallets and the supported currencies").
+		Tag("Pockets", "Named pockets splitting the balance of a wallet").
+		Tag("Savings", "Savings goals filled by round ups and scheduled sweeps").
+		Tag("Transfers", "Transfers between the wallets of two users").
+		Tag("Scheduled transfers", "One-off and recurring transfers made by a worker").
+		Tag("Payment requests", "Money requested from another user, paid by a transfer").
+		Tag("Bills", "Group expenses split into shares paid by transfers").
+		Tag("Transactions", "Balance credit and debit with fee quotes").
+		Tag("Exchanges", "Currency exchange between the wallets of a user").
+		Tag("Events", "Real-time balance and transaction events").
+		Tag("Webhooks", "Signed event deliveries to partner systems").
+		Tag("Merchants", "Merchant accounts, api keys and signed merchant requests").
+		Tag("Payments", "Merchant payments and their daily settlements").
+		Tag("Fees", "Fee rules charged on transactions").
+		Tag("Operations", "Probes, metrics and runtime settings").
+		Document(UserV1Docs()...).
+		Document(WalletV1Docs()...).
+		Document(PocketV1Docs()...).
+		Document(SavingsV1Docs()...).
+		Document(TransferV1Docs()...).
+		Document(ScheduleV1Docs()...).
+		Document(PaymentRequestV1Docs()...).
+		Document(BillV1Docs()...).
+		Document(TransactionV1Docs()...).
+		Document(ExchangeV1Docs()...).
+		Document(EventV1Docs()...).
+		Document(WebhookV1Docs()...).
+		Document(MerchantV1Docs()...).
+		Document(PaymentV1Docs()...).
+		Document(SettlementV1Docs()...).
+		Document(FeeV1Docs()...).
+		Document(OperationDocs()...)
+}
+
+// OperationDocs documents the routes registered by this file and router.go
+func OperationDocs() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method:       http.MethodGet,
+			Path:         Healthz,
+			Summary:      "Liveness probe",
+			Tag:          "Operations",
+			ResponseType: openapi.TextContentType,
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     Readyz,
+			Summary:  "Readiness probe, 503 when a critical dependency is down or the service is draining",
+			Tag:      "Operations",
+			Response: health.Report{},
+		},
+		{
+			Method:       http.MethodGet,
+			Path:         Metrics,
+			Summary:      "Prometheus metrics, only registered when ENABLE_PROMETHEUS is set",
+			Tag:          "Operations",
+			ResponseType: openapi.TextContentType,
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     LogLevel,
+			Summary:  "Get the log level",
+			Tag:      "Operations",
+			Secured:  true,
+			Response: logLevelBody{},
+			Errors:   response.ErrorResponse{},
+		},
+		{
+			Method:   http.MethodPut,
+			Path:     LogLevel,
+			Summary:  "Change the log level",
+			Tag:      "Operations",
+			Secured:  true,
+			Request:  logLevelBody{},
+			Response: logLevelBody{},
+			Errors:   response.ErrorResponse{},
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     OpenAPI,
+			Summary:  "This OpenAPI document",
+			Tag:      "Operations",
+			Response: map[string]interface{}{},
+		},
+		{
+			Method:       http.MethodGet,
+			Path:         SwaggerUI,
+			Summary:      "Swagger UI of the OpenAPI document",
+			Tag:          "Operations",
+			ResponseType: openapi.HTMLContentType,
+		},
+		{
+			Method:       http.MethodGet,
+			Path:         SwaggerUIInitializer,
+			Summary:      "Swagger UI bootstrap script",
+			Tag:          "Operations",
+			ResponseType: "text/javascript",
+		},
+	}
+}
+
+// RegisterOpenAPIRoutes serves the OpenAPI document and its Swagger UI. The document
+// is built on the first request so routes registered after this call are included
+func RegisterOpenAPIRoutes(router *gin.Engine, builder *openapi.Builder) {
+	var (
+		once     sync.Once
+		document *openapi.Document
+	)
+
+	router.GET(OpenAPI, func(c *gin.Context) {
+		once.Do(func() {
+			var undocumented gin.RoutesInfo
+			document, undocumented, _ = builder.Build(router.Routes())
+			for _, route := range undocumented {
+				log.Printf("openapi: %s %s is not documented", route.Method, route.Path)
+			}
+		})
+		c.JSON(http.StatusOK, document)
+	})
+
+	router.GET(SwaggerUI, func(c *gin.Context) {
+		c.Header("Content-Security-Policy", swaggerUICSP)
+		c.Header("X-Content-Security-Policy", swaggerUICSP)
+		c.Header("X-WebKit-CSP", swaggerUICSP)
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUIPage))
+	})
+
+	router.GET(SwaggerUIInitializer, func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/javascript; charset=utf-8", []byte(swaggerUIInitializerScript))
+	})
+}
@@ -0,0 +1,75 @@
+package routes
+
+import (
+	"encoding/json"
+	"kc-ewallet/internals/health"
+	"kc-ewallet/internals/helpers/openapi"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newDocumentedRouter registers the routes the way main does, with every optional group enabled
+func newDocumentedRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	RegisterRoutes(router, Dependencies{
+		AppName:          "kc-ewallet",
+		EnablePrometheus: true,
+		Health:           health.NewRegistry(health.DefaultCacheTTL),
+	})
+
+	return router
+}
+
+func TestOpenAPI_EveryRouteIsDocumented(t *testing.T) {
+	router := newDocumentedRouter()
+
+	_, undocumented, stale := NewOpenAPIBuilder("kc-ewallet").Build(router.Routes())
+
+	for _, route := range undocumented {
+		t.Errorf("%s %s is registered without documentation, add it to the route group docs", route.Method, route.Path)
+	}
+	for _, route := range stale {
+		t.Errorf("%s %s is documented but not registered", route.Method, route.Path)
+	}
+}
+
+func TestOpenAPI_Serve(t *testing.T) {
+	router := newDocumentedRouter()
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, OpenAPI, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var document openapi.Document
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &document))
+	assert.Equal(t, openapi.Version, document.OpenAPI)
+
+	login := document.Paths["/api/users/login"]["post"]
+	assert.Equal(t, "Login", login.OperationID)
+	body := login.RequestBody.Content[openapi.JSONContentType].Schema
+	assert.ElementsMatch(t, []string{"username", "password"}, body.Required)
+	assert.Equal(t, 6, *body.Properties["password"].MinLength)
+	assert.True(t, body.Properties["password"].WriteOnly)
+
+	data := login.Responses["200"].Content[openapi.JSONContentType].Schema.Properties["data"]
+	assert.Contains(t, data.Properties, "access_token")
+
+	profile := document.Paths["/api/users/"]["get"]
+	assert.Equal(t, []map[string][]string{{openapi.BearerAuth: {}}}, profile.Security)
+
+	merchantProfile := document.Paths["/api/merchant/profile"]["get"]
+	assert.Equal(t, []map[string][]string{{MerchantSignatureAuth: {}}}, merchantProfile.Security)
+	assert.Equal(t, "apiKey", document.Components.SecuritySchemes[MerchantSignatureAuth].Type)
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, SwaggerUI, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "swagger-ui-bundle.js")
+}
@@ -0,0 +1,65 @@
+package routes
+
+import (
+	"kc-ewallet/constants"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/internals/helpers/openapi"
+	rate_limit "kc-ewallet/internals/helpers/rate_limiter"
+	"kc-ewallet/internals/metric"
+	"kc-ewallet/protocols/http/controller"
+	"kc-ewallet/protocols/http/middleware"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterPaymentRoutes(router *gin.Engine, jwtSigningKey string, appMetric metric.Metric, auditUsecase usecase.IAuditUsecase, ctrl *controller.PaymentController) {
+	v1RouterGroup := router.Group(constants.ApiV1BasePath)
+	v1RouterGroup.Use(
+		middleware.AuthorizeToken(
+			jwtSigningKey,
+			middleware.RegisterHandlers(
+				map[string]bool{
+					"CreatePayment": true,
+				},
+			),
+		),
+		middleware.CheckRateLimit(
+			middleware.NewRateLimiter(rate_limit.NewCacheService(), []string{}).WithMetric(appMetric).WithAudit(auditUsecase),
+			middleware.RegisterHandlers(
+				map[string]bool{
+					"CreatePayment": true,
+				},
+			),
+		),
+	)
+
+	PaymentV1Routes(v1RouterGroup, ctrl)
+}
+
+func PaymentV1Routes(v1Router *gin.RouterGroup, ctrl *controller.PaymentController) {
+	routes := v1Router.Group(constants.PaymentPath)
+
+	routes.POST("/", ctrl.CreatePayment)
+}
+
+// PaymentV1Docs documents PaymentV1Routes
+func PaymentV1Docs() []openapi.Route {
+	path := constants.ApiV1BasePath + constants.PaymentPath
+
+	return []openapi.Route{
+		{
+			Method:      http.MethodPost,
+			Path:        path + "/",
+			Summary:     "Pay a merchant order",
+			Description: "Debits the user now, the merchant wallet is credited by the daily settlement. An order reference is paid once per merchant.",
+			Tag:         "Payments",
+			Secured:     true,
+			Request:     request.CreatePaymentRequest{},
+			Response:    response.BuildSuccessResponse("success", response.CreatePaymentResponse{}),
+			Errors:      response.ErrorResponse{},
+		},
+	}
+}
@@ -0,0 +1,142 @@
+package routes
+
+import (
+	"kc-ewallet/constants"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/internals/helpers/openapi"
+	rate_limit "kc-ewallet/internals/helpers/rate_limiter"
+	"kc-ewallet/internals/metric"
+	"kc-ewallet/protocols/http/controller"
+	"kc-ewallet/protocols/http/middleware"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterPaymentRequestRoutes(router *gin.Engine, jwtSigningKey string, appMetric metric.Metric, auditUsecase usecase.IAuditUsecase, ctrl *controller.PaymentRequestController) {
+	v1RouterGroup := router.Group(constants.ApiV1BasePath)
+	v1RouterGroup.Use(
+		middleware.AuthorizeToken(
+			jwtSigningKey,
+			middleware.RegisterHandlers(
+				map[string]bool{
+					"CreatePaymentRequest":        true,
+					"ListIncomingPaymentRequests": true,
+					"ListOutgoingPaymentRequests": true,
+					"GetPaymentRequest":           true,
+					"PayPaymentRequest":           true,
+					"DeclinePaymentRequest":       true,
+					"CancelPaymentRequest":        true,
+				},
+			),
+		),
+		middleware.CheckRateLimit(
+			middleware.NewRateLimiter(rate_limit.NewCacheService(), []string{}).WithMetric(appMetric).WithAudit(auditUsecase),
+			middleware.RegisterHandlers(
+				map[string]bool{
+					"CreatePaymentRequest": true,
+					"PayPaymentRequest":    true,
+				},
+			),
+		),
+	)
+
+	PaymentRequestV1Routes(v1RouterGroup, ctrl)
+}
+
+func PaymentRequestV1Routes(v1Router *gin.RouterGroup, ctrl *controller.PaymentRequestController) {
+	routes := v1Router.Group(constants.PaymentRequestPath)
+
+	routes.POST("/", ctrl.CreatePaymentRequest)
+	routes.GET("/incoming", ctrl.ListIncomingPaymentRequests)
+	routes.GET("/outgoing", ctrl.ListOutgoingPaymentRequests)
+	routes.GET("/:id", ctrl.GetPaymentRequest)
+	routes.POST("/:id/pay", ctrl.PayPaymentRequest)
+	routes.POST("/:id/decline", ctrl.DeclinePaymentRequest)
+	routes.POST("/:id/cancel", ctrl.CancelPaymentRequest)
+}
+
+// PaymentRequestV1Docs documents PaymentRequestV1Routes
+func PaymentRequestV1Docs() []openapi.Route {
+	path := constants.ApiV1BasePath + constants.PaymentRequestPath
+
+	return []openapi.Route{
+		{
+			Method:      http.MethodPost,
+			Path:        path + "/",
+			Summary:     "Request money from another user",
+			Description: "The request can be paid until expires_at, which defaults to the configured expiry and can't be further than the maximum one. An unknown payer fails with ER010.",
+			Tag:         "Payment requests",
+			Secured:     true,
+			Request:     request.CreatePaymentRequestRequest{},
+			Response:    response.BuildSuccessResponse("success", response.PaymentRequestResponse{}),
+			Errors:      response.ErrorResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    path + "/incoming",
+			Summary: "List the payment requests the user has to pay, newest first",
+			Tag:     "Payment requests",
+			Secured: true,
+			Query:   request.ListPaymentRequestsQuery{},
+			Response: response.PaginatorResponse{
+				StandardResponse: response.BuildSuccessResponse("success", []response.PaymentRequestResponse{}),
+			},
+			Errors: response.ErrorResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    path + "/outgoing",
+			Summary: "List the payment requests the user made, newest first",
+			Tag:     "Payment requests",
+			Secured: true,
+			Query:   request.ListPaymentRequestsQuery{},
+			Response: response.PaginatorResponse{
+				StandardResponse: response.BuildSuccessResponse("success", []response.PaymentRequestResponse{}),
+			},
+			Errors: response.ErrorResponse{},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        path + "/:id",
+			Summary:     "Get a payment request the user made or has to pay",
+			Description: "An unknown payment request fails with ER031.",
+			Tag:         "Payment requests",
+			Secured:     true,
+			Response:    response.BuildSuccessResponse("success", response.PaymentRequestResponse{}),
+			Errors:      response.ErrorResponse{},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        path + "/:id/pay",
+			Summary:     "Pay a payment request",
+			Description: "Transfers the amount to the requester and marks the request paid in the same database transaction. A request that is no longer pending or is past its expiry fails with ER032.",
+			Tag:         "Payment requests",
+			Secured:     true,
+			Response:    response.BuildSuccessResponse("success", response.TransferResponse{}),
+			Errors:      response.ErrorResponse{},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        path + "/:id/decline",
+			Summary:     "Decline a payment request",
+			Description: "Only the payer can decline a pending request, one that is no longer pending fails with ER032.",
+			Tag:         "Payment requests",
+			Secured:     true,
+			Response:    response.BuildSuccessResponse("success", response.PaymentRequestResponse{}),
+			Errors:      response.ErrorResponse{},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        path + "/:id/cancel",
+			Summary:     "Cancel a payment request",
+			Description: "Only the requester can cancel a pending request, one that is no longer pending fails with ER032.",
+			Tag:         "Payment requests",
+			Secured:     true,
+			Response:    response.BuildSuccessResponse("success", response.PaymentRequestResponse{}),
+			Errors:      response.ErrorResponse{},
+		},
+	}
+}
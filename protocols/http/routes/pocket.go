@@ -0,0 +1,127 @@
+package routes
+
+import (
+	"kc-ewallet/constants"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/internals/helpers/openapi"
+	rate_limit "kc-ewallet/internals/helpers/rate_limiter"
+	"kc-ewallet/internals/metric"
+	"kc-ewallet/protocols/http/controller"
+	"kc-ewallet/protocols/http/middleware"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterPocketRoutes(router *gin.Engine, jwtSigningKey string, appMetric metric.Metric, auditUsecase usecase.IAuditUsecase, ctrl *controller.PocketController) {
+	v1RouterGroup := router.Group(constants.ApiV1BasePath)
+	v1RouterGroup.Use(
+		middleware.AuthorizeToken(
+			jwtSigningKey,
+			middleware.RegisterHandlers(
+				map[string]bool{
+					"CreatePocket":       true,
+					"ListPockets":        true,
+					"MovePocketMoney":    true,
+					"SetDefaultPocket":   true,
+					"UnsetDefaultPocket": true,
+					"ListPocketEntries":  true,
+				},
+			),
+		),
+		middleware.CheckRateLimit(
+			middleware.NewRateLimiter(rate_limit.NewCacheService(), []string{}).WithMetric(appMetric).WithAudit(auditUsecase),
+			middleware.RegisterHandlers(
+				map[string]bool{
+					"CreatePocket":    true,
+					"MovePocketMoney": true,
+				},
+			),
+		),
+	)
+
+	PocketV1Routes(v1RouterGroup, ctrl)
+}
+
+func PocketV1Routes(v1Router *gin.RouterGroup, ctrl *controller.PocketController) {
+	routes := v1Router.Group(constants.PocketPath)
+
+	routes.POST("/", ctrl.CreatePocket)
+	routes.GET("/", ctrl.ListPockets)
+	routes.POST("/moves", ctrl.MovePocketMoney)
+	routes.PUT("/:id/default", ctrl.SetDefaultPocket)
+	routes.DELETE("/:id/default", ctrl.UnsetDefaultPocket)
+	routes.GET("/:id/entries", ctrl.ListPocketEntries)
+}
+
+// PocketV1Docs documents PocketV1Routes
+func PocketV1Docs() []openapi.Route {
+	path := constants.ApiV1BasePath + constants.PocketPath
+
+	return []openapi.Route{
+		{
+			Method:      http.MethodPost,
+			Path:        path + "/",
+			Summary:     "Open an empty pocket in a wallet of the user",
+			Description: "Pocket names are unique per wallet, a taken name fails with ER026. A default pocket replaces the previous one.",
+			Tag:         "Pockets",
+			Secured:     true,
+			Request:     request.CreatePocketRequest{},
+			Response:    response.BuildSuccessResponse("success", response.PocketResponse{}),
+			Errors:      response.ErrorResponse{},
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     path + "/",
+			Summary:  "List the wallets of the user split into their pockets",
+			Tag:      "Pockets",
+			Secured:  true,
+			Response: response.BuildSuccessResponse("success", []response.WalletBalanceResponse{}),
+			Errors:   response.ErrorResponse{},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        path + "/moves",
+			Summary:     "Move money between two pockets of a wallet",
+			Description: "A missing from_pocket_id or to_pocket_id is the unallocated balance of the wallet, which is the only part debits can spend.",
+			Tag:         "Pockets",
+			Secured:     true,
+			Request:     request.MovePocketMoneyRequest{},
+			Response:    response.BuildSuccessResponse("success", response.WalletBalanceResponse{}),
+			Errors:      response.ErrorResponse{},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        path + "/:id/default",
+			Summary:     "Make the pocket receive the credits of its wallet",
+			Description: "A wallet has at most one default pocket, the previous one stops being the default.",
+			Tag:         "Pockets",
+			Secured:     true,
+			Response:    response.BuildSuccessResponse("success", response.PocketResponse{}),
+			Errors:      response.ErrorResponse{},
+		},
+		{
+			Method:   http.MethodDelete,
+			Path:     path + "/:id/default",
+			Summary:  "Stop the pocket receiving the credits of its wallet",
+			Tag:      "Pockets",
+			Secured:  true,
+			Response: response.BuildSuccessResponse("success", response.PocketResponse{}),
+			Errors:   response.ErrorResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    path + "/:id/entries",
+			Summary: "List the history of a pocket, newest first",
+			Tag:     "Pockets",
+			Secured: true,
+			Query:   request.ListPocketEntriesQuery{},
+			Response: response.PaginatorResponse{
+				StandardResponse: response.BuildSuccessResponse("success", []response.PocketEntryResponse{}),
+			},
+			Errors: response.ErrorResponse{},
+		},
+	}
+}
@@ -2,19 +2,29 @@ package routes
 
 import (
 	"kc-ewallet/configurations"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/domains/usecase/audit"
 	"kc-ewallet/internals/errors"
+	"kc-ewallet/internals/health"
+	"kc-ewallet/internals/helpers/logging"
+	"kc-ewallet/internals/metric"
+	"kc-ewallet/protocols/http/controller"
 	"kc-ewallet/protocols/http/middleware"
 	"kc-ewallet/protocols/http/response"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
 )
 
 const Healthz string = "/healthz"
 const Readyz string = "/readyz"
+const Metrics string = "/metrics"
+const LogLevel string = "/log-level"
 
 // InitRouter initializes the Gin router with middleware
 func InitRouter(appConfig configurations.IAppConfiguration, tracer trace.Tracer) *gin.Engine {
@@ -25,6 +35,7 @@ func InitRouter(appConfig configurations.IAppConfiguration, tracer trace.Tracer)
 	router := gin.New()
 	router.Use(middleware.SlogMiddleware()) // Use our custom slog middleware
 	router.Use(middleware.RequestIDInjector())
+	router.Use(middleware.AuditContext())
 	router.Use(middleware.PanicRecoveryHandler())
 	router.Use(middleware.API())
 	router.Use(middleware.CORS(appConfig.GetCorsAllowedOrigins()))
@@ -37,24 +48,120 @@ func InitRouter(appConfig configurations.IAppConfiguration, tracer trace.Tracer)
 
 	router.MaxMultipartMemory = 8 << 20 // 8 MiB memory for multipart forms
 
+	router.NoRoute(func(c *gin.Context) {
+		response.RespondError(c, errors.NotFound.New("Endpoint not found."))
+	})
+
+	return router
+}
+
+// Dependencies is what the route groups are built from, the zero value still
+// registers every route (without working handlers) so they can be listed
+type Dependencies struct {
+	AppName          string
+	JWTSigningKey    string
+	EnablePrometheus bool
+	Metric           metric.Metric
+	Audit            usecase.IAuditUsecase
+	Merchant         usecase.IMerchantUsecase
+	Health           *health.Registry
+
+	User           *controller.UserController
+	Wallet         *controller.WalletController
+	Pocket         *controller.PocketController
+	Savings        *controller.SavingsController
+	Transfer       *controller.TransferController
+	Schedule       *controller.ScheduleController
+	PaymentRequest *controller.PaymentRequestController
+	Bill           *controller.BillController
+	Transaction    *controller.TransactionController
+	Exchange       *controller.ExchangeController
+	Event          *controller.EventController
+	Webhook        *controller.WebhookController
+	MerchantAPI    *controller.MerchantController
+	Payment        *controller.PaymentController
+	Settlement     *controller.SettlementController
+	Fee            *controller.FeeController
+}
+
+// RegisterRoutes registers every route group the service serves, the openapi
+// test lists the routes from here so a new group can't go undocumented
+func RegisterRoutes(router *gin.Engine, deps Dependencies) {
+	RegisterHealthRoutes(router, deps.Health)
+	RegisterUserRoutes(router, deps.JWTSigningKey, deps.Metric, deps.Audit, deps.User)
+	RegisterTransactionRoutes(router, deps.JWTSigningKey, deps.Metric, deps.Audit, deps.Transaction)
+	RegisterExchangeRoutes(router, deps.JWTSigningKey, deps.Metric, deps.Audit, deps.Exchange)
+	RegisterEventRoutes(router, deps.JWTSigningKey, deps.Event)
+	RegisterWalletRoutes(router, deps.JWTSigningKey, deps.Wallet)
+	RegisterPocketRoutes(router, deps.JWTSigningKey, deps.Metric, deps.Audit, deps.Pocket)
+	RegisterSavingsRoutes(router, deps.JWTSigningKey, deps.Metric, deps.Audit, deps.Savings)
+	RegisterTransferRoutes(router, deps.JWTSigningKey, deps.Metric, deps.Audit, deps.Transfer)
+	RegisterScheduleRoutes(router, deps.JWTSigningKey, deps.Metric, deps.Audit, deps.Schedule)
+	RegisterPaymentRequestRoutes(router, deps.JWTSigningKey, deps.Metric, deps.Audit, deps.PaymentRequest)
+	RegisterBillRoutes(router, deps.JWTSigningKey, deps.Metric, deps.Audit, deps.Bill)
+	RegisterWebhookRoutes(router, deps.JWTSigningKey, deps.Metric, deps.Audit, deps.Webhook)
+	RegisterMerchantRoutes(router, deps.JWTSigningKey, deps.Merchant, deps.MerchantAPI)
+	RegisterPaymentRoutes(router, deps.JWTSigningKey, deps.Metric, deps.Audit, deps.Payment)
+	RegisterSettlementRoutes(router, deps.Merchant, deps.Settlement)
+	RegisterFeeRoutes(router, deps.JWTSigningKey, deps.Fee)
+	if deps.EnablePrometheus {
+		RegisterMetricRoutes(router)
+	}
+	RegisterLogLevelRoutes(router, deps.JWTSigningKey, deps.Audit)
+	RegisterOpenAPIRoutes(router, NewOpenAPIBuilder(deps.AppName))
+}
+
+// RegisterHealthRoutes registers the liveness and readiness probes,
+// liveness never touches dependencies, readiness aggregates the registry checks
+func RegisterHealthRoutes(router *gin.Engine, registry *health.Registry) {
 	router.GET(Healthz, func(c *gin.Context) {
 		c.String(http.StatusOK, "healthy")
 	})
 
 	router.GET(Readyz, func(c *gin.Context) {
-		c.String(http.StatusOK, "ready")
-	})
+		report := registry.Report(c.Request.Context())
 
-	router.NoRoute(func(c *gin.Context) {
-		response.RespondError(c, errors.NotFound.New("Endpoint not found."))
+		statusCode := http.StatusOK
+		if !report.IsReady() {
+			statusCode = http.StatusServiceUnavailable
+		}
+		c.JSON(statusCode, report)
 	})
+}
 
-	return router
+// RegisterMetricRoutes exposes the prometheus reader for local scraping
+func RegisterMetricRoutes(router *gin.Engine) {
+	router.GET(Metrics, gin.WrapH(promhttp.Handler()))
 }
 
-// RegisterHealthRoutes registers health check routes
-func RegisterHealthRoutes(router *gin.Engine) {
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{"status": "OK"})
+// RegisterLogLevelRoutes lets operators read and change the log level at runtime,
+// GET returns {"level":"info"} and PUT accepts the same body, changes are audited
+func RegisterLogLevelRoutes(router *gin.Engine, jwtSigningKey string, auditUsecase usecase.IAuditUsecase) {
+	routes := router.Group(LogLevel)
+	routes.Use(
+		middleware.AuthorizeToken(jwtSigningKey),
+		middleware.CheckPermission([]middleware.PagePermission{middleware.LogLevelPage}),
+	)
+
+	routes.GET("", func(c *gin.Context) {
+		logging.Level.ServeHTTP(c.Writer, c.Request)
+	})
+	routes.PUT("", func(c *gin.Context) {
+		before := logging.Level.String()
+		logging.Level.ServeHTTP(c.Writer, c.Request)
+
+		after := logging.Level.String()
+		if before == after || auditUsecase == nil {
+			return
+		}
+
+		ctx := c.Request.Context()
+		if err := auditUsecase.Record(ctx, audit.Event{
+			Type:   audit.EventAdminLogLevelChanged,
+			Before: map[string]interface{}{"level": before},
+			After:  map[string]interface{}{"level": after},
+		}); err != nil {
+			logging.NewFromContext(ctx).Error("failed to record log level change", zap.Error(err))
+		}
 	})
 }
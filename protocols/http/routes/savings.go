@@ -0,0 +1,87 @@
+package routes
+
+import (
+	"kc-ewallet/constants"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/internals/helpers/openapi"
+	rate_limit "kc-ewallet/internals/helpers/rate_limiter"
+	"kc-ewallet/internals/metric"
+	"kc-ewallet/protocols/http/controller"
+	"kc-ewallet/protocols/http/middleware"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterSavingsRoutes(router *gin.Engine, jwtSigningKey string, appMetric metric.Metric, auditUsecase usecase.IAuditUsecase, ctrl *controller.SavingsController) {
+	v1RouterGroup := router.Group(constants.ApiV1BasePath)
+	v1RouterGroup.Use(
+		middleware.AuthorizeToken(
+			jwtSigningKey,
+			middleware.RegisterHandlers(
+				map[string]bool{
+					"CreateSavingsGoal": true,
+					"ListSavingsGoals":  true,
+					"GetSavingsGoal":    true,
+				},
+			),
+		),
+		middleware.CheckRateLimit(
+			middleware.NewRateLimiter(rate_limit.NewCacheService(), []string{}).WithMetric(appMetric).WithAudit(auditUsecase),
+			middleware.RegisterHandlers(
+				map[string]bool{
+					"CreateSavingsGoal": true,
+				},
+			),
+		),
+	)
+
+	SavingsV1Routes(v1RouterGroup, ctrl)
+}
+
+func SavingsV1Routes(v1Router *gin.RouterGroup, ctrl *controller.SavingsController) {
+	routes := v1Router.Group(constants.SavingsPath)
+
+	routes.POST("/", ctrl.CreateSavingsGoal)
+	routes.GET("/", ctrl.ListSavingsGoals)
+	routes.GET("/:id", ctrl.GetSavingsGoal)
+}
+
+// SavingsV1Docs documents SavingsV1Routes
+func SavingsV1Docs() []openapi.Route {
+	path := constants.ApiV1BasePath + constants.SavingsPath
+
+	return []openapi.Route{
+		{
+			Method:      http.MethodPost,
+			Path:        path + "/",
+			Summary:     "Set a savings goal on a pocket of the user",
+			Description: "round_up_to saves the change of each debit of the wallet rounded up to a multiple of it, sweep_amount is saved every sweep_frequency. Both come out of the unallocated balance and are skipped when it can't cover them. A pocket with a goal fails with ER028.",
+			Tag:         "Savings",
+			Secured:     true,
+			Request:     request.CreateSavingsGoalRequest{},
+			Response:    response.BuildSuccessResponse("success", response.SavingsGoalResponse{}),
+			Errors:      response.ErrorResponse{},
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     path + "/",
+			Summary:  "List the savings goals of the user with their progress",
+			Tag:      "Savings",
+			Secured:  true,
+			Response: response.BuildSuccessResponse("success", []response.SavingsGoalResponse{}),
+			Errors:   response.ErrorResponse{},
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     path + "/:id",
+			Summary:  "Get the progress of a savings goal",
+			Tag:      "Savings",
+			Secured:  true,
+			Response: response.BuildSuccessResponse("success", response.SavingsGoalResponse{}),
+			Errors:   response.ErrorResponse{},
+		},
+	}
+}
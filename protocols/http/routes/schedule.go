@@ -0,0 +1,113 @@
+package routes
+
+import (
+	"kc-ewallet/constants"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/internals/helpers/openapi"
+	rate_limit "kc-ewallet/internals/helpers/rate_limiter"
+	"kc-ewallet/internals/metric"
+	"kc-ewallet/protocols/http/controller"
+	"kc-ewallet/protocols/http/middleware"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterScheduleRoutes(router *gin.Engine, jwtSigningKey string, appMetric metric.Metric, auditUsecase usecase.IAuditUsecase, ctrl *controller.ScheduleController) {
+	v1RouterGroup := router.Group(constants.ApiV1BasePath)
+	v1RouterGroup.Use(
+		middleware.AuthorizeToken(
+			jwtSigningKey,
+			middleware.RegisterHandlers(
+				map[string]bool{
+					"CreateScheduledTransfer":   true,
+					"ListScheduledTransfers":    true,
+					"GetScheduledTransfer":      true,
+					"CancelScheduledTransfer":   true,
+					"ListScheduledTransferRuns": true,
+				},
+			),
+		),
+		middleware.CheckRateLimit(
+			middleware.NewRateLimiter(rate_limit.NewCacheService(), []string{}).WithMetric(appMetric).WithAudit(auditUsecase),
+			middleware.RegisterHandlers(
+				map[string]bool{
+					"CreateScheduledTransfer": true,
+				},
+			),
+		),
+	)
+
+	ScheduleV1Routes(v1RouterGroup, ctrl)
+}
+
+func ScheduleV1Routes(v1Router *gin.RouterGroup, ctrl *controller.ScheduleController) {
+	routes := v1Router.Group(constants.SchedulePath)
+
+	routes.POST("/", ctrl.CreateScheduledTransfer)
+	routes.GET("/", ctrl.ListScheduledTransfers)
+	routes.GET("/:id", ctrl.GetScheduledTransfer)
+	routes.DELETE("/:id", ctrl.CancelScheduledTransfer)
+	routes.GET("/:id/runs", ctrl.ListScheduledTransferRuns)
+}
+
+// ScheduleV1Docs documents ScheduleV1Routes
+func ScheduleV1Docs() []openapi.Route {
+	path := constants.ApiV1BasePath + constants.SchedulePath
+
+	return []openapi.Route{
+		{
+			Method:      http.MethodPost,
+			Path:        path + "/",
+			Summary:     "Schedule a transfer to another user, once or following a recurrence",
+			Description: "recurrence is an RFC 5545 RRULE counted from start_at, e.g. FREQ=MONTHLY;BYMONTHDAY=1, and ends at end_at or after max_occurrences. start_at must be an occurrence of the rule, which repeats at most once a day. An occurrence the balance can't cover is retried later or skipped following on_insufficient_funds. An unknown recipient fails with ER029.",
+			Tag:         "Scheduled transfers",
+			Secured:     true,
+			Request:     request.CreateScheduleRequest{},
+			Response:    response.BuildSuccessResponse("success", response.ScheduledTransferResponse{}),
+			Errors:      response.ErrorResponse{},
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     path + "/",
+			Summary:  "List the scheduled transfers of the user, newest first",
+			Tag:      "Scheduled transfers",
+			Secured:  true,
+			Response: response.BuildSuccessResponse("success", []response.ScheduledTransferResponse{}),
+			Errors:   response.ErrorResponse{},
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     path + "/:id",
+			Summary:  "Get a scheduled transfer",
+			Tag:      "Scheduled transfers",
+			Secured:  true,
+			Response: response.BuildSuccessResponse("success", response.ScheduledTransferResponse{}),
+			Errors:   response.ErrorResponse{},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        path + "/:id",
+			Summary:     "Cancel a scheduled transfer",
+			Description: "Only an active scheduled transfer can be cancelled, any other fails with ER030.",
+			Tag:         "Scheduled transfers",
+			Secured:     true,
+			Response:    response.BuildSuccessResponse("success", response.ScheduledTransferResponse{}),
+			Errors:      response.ErrorResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    path + "/:id/runs",
+			Summary: "List the runs of a scheduled transfer, newest first",
+			Tag:     "Scheduled transfers",
+			Secured: true,
+			Query:   request.ListScheduleRunsQuery{},
+			Response: response.PaginatorResponse{
+				StandardResponse: response.BuildSuccessResponse("success", []response.ScheduledTransferRunResponse{}),
+			},
+			Errors: response.ErrorResponse{},
+		},
+	}
+}
@@ -0,0 +1,59 @@
+package routes
+
+import (
+	"kc-ewallet/constants"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/internals/helpers/openapi"
+	"kc-ewallet/protocols/http/controller"
+	"kc-ewallet/protocols/http/middleware"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterSettlementRoutes serves the settlements to the merchant behind the request signature
+func RegisterSettlementRoutes(router *gin.Engine, merchantUsecase usecase.IMerchantUsecase, ctrl *controller.SettlementController) {
+	v1RouterGroup := router.Group(constants.ApiV1BasePath)
+	v1RouterGroup.Use(middleware.AuthorizeMerchantSignature(merchantUsecase))
+
+	SettlementV1Routes(v1RouterGroup, ctrl)
+}
+
+func SettlementV1Routes(v1Router *gin.RouterGroup, ctrl *controller.SettlementController) {
+	routes := v1Router.Group(constants.MerchantPath + constants.SettlementPath)
+
+	routes.GET("/", ctrl.ListSettlements)
+	routes.GET("/:id/report", ctrl.GetSettlementReport)
+}
+
+// SettlementV1Docs documents SettlementV1Routes
+func SettlementV1Docs() []openapi.Route {
+	path := constants.ApiV1BasePath + constants.MerchantPath + constants.SettlementPath
+
+	return []openapi.Route{
+		{
+			Method:   http.MethodGet,
+			Path:     path + "/",
+			Summary:  "List the settlements of the calling merchant, newest day first",
+			Tag:      "Payments",
+			Security: MerchantSignatureAuth,
+			Query:    request.ListSettlementsQuery{},
+			Response: response.PaginatorResponse{
+				StandardResponse: response.BuildSuccessResponse("success", []response.SettlementResponse{}),
+			},
+			Errors: response.ErrorResponse{},
+		},
+		{
+			Method:       http.MethodGet,
+			Path:         path + "/:id/report",
+			Summary:      "Download the report of a settlement",
+			Description:  "One csv row per settled payment followed by the gross, fee and net totals.",
+			Tag:          "Payments",
+			Security:     MerchantSignatureAuth,
+			ResponseType: "text/csv",
+			Errors:       response.ErrorResponse{},
+		},
+	}
+}
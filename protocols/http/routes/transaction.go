@@ -2,14 +2,20 @@ package routes
 
 import (
 	"kc-ewallet/constants"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/internals/helpers/openapi"
 	rate_limit "kc-ewallet/internals/helpers/rate_limiter"
+	"kc-ewallet/internals/metric"
 	"kc-ewallet/protocols/http/controller"
 	"kc-ewallet/protocols/http/middleware"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterTransactionRoutes(router *gin.Engine, jwtSigningKey string, ctrl *controller.TransactionController) {
+func RegisterTransactionRoutes(router *gin.Engine, jwtSigningKey string, appMetric metric.Metric, auditUsecase usecase.IAuditUsecase, ctrl *controller.TransactionController) {
 	v1RouterGroup := router.Group(constants.ApiV1BasePath)
 	v1RouterGroup.Use(
 		middleware.AuthorizeToken(
@@ -18,15 +24,17 @@ func RegisterTransactionRoutes(router *gin.Engine, jwtSigningKey string, ctrl *c
 				map[string]bool{
 					"CreateCreditTransaction": true,
 					"CreateDebitTransaction":  true,
+					"QuoteTransaction":        true,
 				},
 			),
 		),
 		middleware.CheckRateLimit(
-			middleware.NewRateLimiter(rate_limit.NewCacheService(), []string{}),
+			middleware.NewRateLimiter(rate_limit.NewCacheService(), []string{}).WithMetric(appMetric).WithAudit(auditUsecase),
 			middleware.RegisterHandlers(
 				map[string]bool{
 					"CreateCreditTransaction": true,
 					"CreateDebitTransaction":  true,
+					"QuoteTransaction":        true,
 				},
 			),
 		),
@@ -40,4 +48,44 @@ func TransactionV1Routes(v1Router *gin.RouterGroup, ctrl *controller.Transaction
 
 	routes.POST("/credit", ctrl.CreateCreditTransaction)
 	routes.POST("/debit", ctrl.CreateDebitTransaction)
+	routes.POST("/quote", ctrl.QuoteTransaction)
+}
+
+// TransactionV1Docs documents TransactionV1Routes
+func TransactionV1Docs() []openapi.Route {
+	path := constants.ApiV1BasePath + constants.TransactionPath
+
+	return []openapi.Route{
+		{
+			Method:   http.MethodPost,
+			Path:     path + "/credit",
+			Summary:  "Credit a wallet of the user",
+			Tag:      "Transactions",
+			Secured:  true,
+			Request:  request.CreateCreditTransactionRequest{},
+			Response: response.BuildSuccessResponse("success", response.CreateCreditTransactionResponse{}),
+			Errors:   response.ErrorResponse{},
+		},
+		{
+			Method:   http.MethodPost,
+			Path:     path + "/debit",
+			Summary:  "Debit a wallet of the user",
+			Tag:      "Transactions",
+			Secured:  true,
+			Request:  request.CreateDebitTransactionRequest{},
+			Response: response.BuildSuccessResponse("success", response.CreateDebitTransactionResponse{}),
+			Errors:   response.ErrorResponse{},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        path + "/quote",
+			Summary:     "Preview the fee and resulting balance of a transaction",
+			Description: "Nothing is executed. Sending the quote_id with the transaction before expires_at charges the quoted fee, once; it fails with ER017 once expired, ER018 when the balance changed and ER019 when invalid or used.",
+			Tag:         "Transactions",
+			Secured:     true,
+			Request:     request.CreateTransactionQuoteRequest{},
+			Response:    response.BuildSuccessResponse("success", response.TransactionQuoteResponse{}),
+			Errors:      response.ErrorResponse{},
+		},
+	}
 }
@@ -0,0 +1,65 @@
+package routes
+
+import (
+	"kc-ewallet/constants"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/internals/helpers/openapi"
+	rate_limit "kc-ewallet/internals/helpers/rate_limiter"
+	"kc-ewallet/internals/metric"
+	"kc-ewallet/protocols/http/controller"
+	"kc-ewallet/protocols/http/middleware"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterTransferRoutes(router *gin.Engine, jwtSigningKey string, appMetric metric.Metric, auditUsecase usecase.IAuditUsecase, ctrl *controller.TransferController) {
+	v1RouterGroup := router.Group(constants.ApiV1BasePath)
+	v1RouterGroup.Use(
+		middleware.AuthorizeToken(
+			jwtSigningKey,
+			middleware.RegisterHandlers(
+				map[string]bool{
+					"CreateTransfer": true,
+				},
+			),
+		),
+		middleware.CheckRateLimit(
+			middleware.NewRateLimiter(rate_limit.NewCacheService(), []string{}).WithMetric(appMetric).WithAudit(auditUsecase),
+			middleware.RegisterHandlers(
+				map[string]bool{
+					"CreateTransfer": true,
+				},
+			),
+		),
+	)
+
+	TransferV1Routes(v1RouterGroup, ctrl)
+}
+
+func TransferV1Routes(v1Router *gin.RouterGroup, ctrl *controller.TransferController) {
+	routes := v1Router.Group(constants.TransferPath)
+
+	routes.POST("/", ctrl.CreateTransfer)
+}
+
+// TransferV1Docs documents TransferV1Routes
+func TransferV1Docs() []openapi.Route {
+	path := constants.ApiV1BasePath + constants.TransferPath
+
+	return []openapi.Route{
+		{
+			Method:      http.MethodPost,
+			Path:        path + "/",
+			Summary:     "Transfer an amount to the wallet of another user in the same currency",
+			Description: "The amount and its fee come out of the unallocated balance, the amount goes to the default pocket of the recipient, if any. A quote_id of a transfer quote charges the quoted fee. A recipient without wallet in the currency fails with ER029.",
+			Tag:         "Transfers",
+			Secured:     true,
+			Request:     request.CreateTransferRequest{},
+			Response:    response.BuildSuccessResponse("success", response.TransferResponse{}),
+			Errors:      response.ErrorResponse{},
+		},
+	}
+}
@@ -2,14 +2,20 @@ package routes
 
 import (
 	"kc-ewallet/constants"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/internals/helpers/openapi"
 	rate_limit "kc-ewallet/internals/helpers/rate_limiter"
+	"kc-ewallet/internals/metric"
 	"kc-ewallet/protocols/http/controller"
 	"kc-ewallet/protocols/http/middleware"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterUserRoutes(router *gin.Engine, jwtSigningKey string, ctrl *controller.UserController) {
+func RegisterUserRoutes(router *gin.Engine, jwtSigningKey string, appMetric metric.Metric, auditUsecase usecase.IAuditUsecase, ctrl *controller.UserController) {
 	v1RouterGroup := router.Group(constants.ApiV1BasePath)
 	v1RouterGroup.Use(
 		middleware.AuthorizeToken(
@@ -21,7 +27,7 @@ func RegisterUserRoutes(router *gin.Engine, jwtSigningKey string, ctrl *controll
 			),
 		),
 		middleware.CheckRateLimit(
-			middleware.NewRateLimiter(rate_limit.NewCacheService(), []string{}),
+			middleware.NewRateLimiter(rate_limit.NewCacheService(), []string{}).WithMetric(appMetric).WithAudit(auditUsecase),
 			middleware.RegisterHandlers(
 				map[string]bool{
 					"GetUserByID": true,
@@ -40,3 +46,38 @@ func UserV1Routes(v1Router *gin.RouterGroup, ctrl *controller.UserController) {
 	routes.POST("/login", ctrl.Login)
 	routes.GET("/", ctrl.GetUserByID)
 }
+
+// UserV1Docs documents UserV1Routes
+func UserV1Docs() []openapi.Route {
+	path := constants.ApiV1BasePath + constants.UserPath
+
+	return []openapi.Route{
+		{
+			Method:   http.MethodPost,
+			Path:     path + "/",
+			Summary:  "Register a user",
+			Tag:      "Users",
+			Request:  request.RegisterUserRequest{},
+			Response: response.BuildSuccessResponse("success", nil),
+			Errors:   response.ErrorResponse{},
+		},
+		{
+			Method:   http.MethodPost,
+			Path:     path + "/login",
+			Summary:  "Login and get an access token",
+			Tag:      "Users",
+			Request:  request.LoginRequest{},
+			Response: response.BuildSuccessResponse("success", response.LoginResponse{}),
+			Errors:   response.ErrorResponse{},
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     path + "/",
+			Summary:  "Get the logged in user with its wallets and their pockets",
+			Tag:      "Users",
+			Secured:  true,
+			Response: response.BuildSuccessResponse("success", response.GetUserByIDResponse{}),
+			Errors:   response.ErrorResponse{},
+		},
+	}
+}
@@ -0,0 +1,74 @@
+package routes
+
+import (
+	"kc-ewallet/constants"
+	"kc-ewallet/internals/helpers/openapi"
+	"kc-ewallet/protocols/http/controller"
+	"kc-ewallet/protocols/http/middleware"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterWalletRoutes(router *gin.Engine, jwtSigningKey string, ctrl *controller.WalletController) {
+	v1RouterGroup := router.Group(constants.ApiV1BasePath)
+	v1RouterGroup.Use(
+		middleware.AuthorizeToken(
+			jwtSigningKey,
+			middleware.RegisterHandlers(
+				map[string]bool{
+					"OpenWallet":  true,
+					"ListWallets": true,
+				},
+			),
+		),
+	)
+
+	WalletV1Routes(v1RouterGroup, ctrl)
+}
+
+func WalletV1Routes(v1Router *gin.RouterGroup, ctrl *controller.WalletController) {
+	routes := v1Router.Group(constants.WalletPath)
+
+	routes.POST("/", ctrl.OpenWallet)
+	routes.GET("/", ctrl.ListWallets)
+	routes.GET("/currencies", ctrl.ListCurrencies)
+}
+
+// WalletV1Docs documents WalletV1Routes
+func WalletV1Docs() []openapi.Route {
+	path := constants.ApiV1BasePath + constants.WalletPath
+
+	return []openapi.Route{
+		{
+			Method:      http.MethodPost,
+			Path:        path + "/",
+			Summary:     "Open an empty wallet in a supported currency",
+			Description: "A user holds one wallet per currency, opening a second one fails with ER023.",
+			Tag:         "Wallets",
+			Secured:     true,
+			Request:     request.OpenWalletRequest{},
+			Response:    response.BuildSuccessResponse("success", response.WalletResponse{}),
+			Errors:      response.ErrorResponse{},
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     path + "/",
+			Summary:  "List the wallets of the user with their balances",
+			Tag:      "Wallets",
+			Secured:  true,
+			Response: response.BuildSuccessResponse("success", []response.WalletResponse{}),
+			Errors:   response.ErrorResponse{},
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     path + "/currencies",
+			Summary:  "List the supported currencies with their minor units",
+			Tag:      "Wallets",
+			Response: response.BuildSuccessResponse("success", []response.CurrencyResponse{}),
+			Errors:   response.ErrorResponse{},
+		},
+	}
+}
@@ -0,0 +1,115 @@
+package routes
+
+import (
+	"kc-ewallet/constants"
+	"kc-ewallet/domains/usecase"
+	"kc-ewallet/internals/helpers/openapi"
+	rate_limit "kc-ewallet/internals/helpers/rate_limiter"
+	"kc-ewallet/internals/metric"
+	"kc-ewallet/protocols/http/controller"
+	"kc-ewallet/protocols/http/middleware"
+	"kc-ewallet/protocols/http/request"
+	"kc-ewallet/protocols/http/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterWebhookRoutes(router *gin.Engine, jwtSigningKey string, appMetric metric.Metric, auditUsecase usecase.IAuditUsecase, ctrl *controller.WebhookController) {
+	v1RouterGroup := router.Group(constants.ApiV1BasePath)
+	v1RouterGroup.Use(
+		middleware.AuthorizeToken(
+			jwtSigningKey,
+			middleware.RegisterHandlers(
+				map[string]bool{
+					"CreateWebhook":         true,
+					"ListWebhooks":          true,
+					"DeleteWebhook":         true,
+					"ListWebhookDeliveries": true,
+					"RedeliverWebhook":      true,
+				},
+			),
+		),
+		middleware.CheckRateLimit(
+			middleware.NewRateLimiter(rate_limit.NewCacheService(), []string{}).WithMetric(appMetric).WithAudit(auditUsecase),
+			middleware.RegisterHandlers(
+				map[string]bool{
+					"CreateWebhook":    true,
+					"RedeliverWebhook": true,
+				},
+			),
+		),
+	)
+
+	WebhookV1Routes(v1RouterGroup, ctrl)
+}
+
+func WebhookV1Routes(v1Router *gin.RouterGroup, ctrl *controller.WebhookController) {
+	routes := v1Router.Group(constants.WebhookPath)
+
+	routes.POST("/", ctrl.CreateWebhook)
+	routes.GET("/", ctrl.ListWebhooks)
+	routes.DELETE("/:id", ctrl.DeleteWebhook)
+	routes.GET("/:id/deliveries", ctrl.ListWebhookDeliveries)
+	routes.POST("/:id/deliveries/:delivery_id/redeliver", ctrl.RedeliverWebhook)
+}
+
+// WebhookV1Docs documents WebhookV1Routes
+func WebhookV1Docs() []openapi.Route {
+	path := constants.ApiV1BasePath + constants.WebhookPath
+
+	return []openapi.Route{
+		{
+			Method:  http.MethodPost,
+			Path:    path + "/",
+			Summary: "Subscribe a url to the events of the user",
+			Description: "Deliveries are POSTed with the Webhook-Id, Webhook-Event, Webhook-Timestamp and Webhook-Signature headers." +
+				" The signature is v1=hex(HMAC-SHA256(secret, \"<timestamp>.<body>\")). The secret is only returned here.",
+			Tag:      "Webhooks",
+			Secured:  true,
+			Request:  request.CreateWebhookRequest{},
+			Response: response.BuildSuccessResponse("success", response.CreateWebhookResponse{}),
+			Errors:   response.ErrorResponse{},
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     path + "/",
+			Summary:  "List the webhooks of the user",
+			Tag:      "Webhooks",
+			Secured:  true,
+			Response: response.BuildSuccessResponse("success", []response.WebhookResponse{}),
+			Errors:   response.ErrorResponse{},
+		},
+		{
+			Method:   http.MethodDelete,
+			Path:     path + "/:id",
+			Summary:  "Delete a webhook with its delivery log",
+			Tag:      "Webhooks",
+			Secured:  true,
+			Response: response.BuildSuccessResponse("success", nil),
+			Errors:   response.ErrorResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    path + "/:id/deliveries",
+			Summary: "List the deliveries of a webhook, newest first",
+			Tag:     "Webhooks",
+			Secured: true,
+			Query:   request.ListWebhookDeliveriesQuery{},
+			Response: response.PaginatorResponse{
+				StandardResponse: response.BuildSuccessResponse("success", []response.WebhookDeliveryResponse{}),
+			},
+			Errors: response.ErrorResponse{},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        path + "/:id/deliveries/:delivery_id/redeliver",
+			Summary:     "Queue a succeeded or dead delivery again",
+			Description: "The delivery keeps its payload and Webhook-Id and gets a fresh retry budget.",
+			Tag:         "Webhooks",
+			Secured:     true,
+			Response:    response.BuildSuccessResponse("success", response.WebhookDeliveryResponse{}),
+			Errors:      response.ErrorResponse{},
+		},
+	}
+}
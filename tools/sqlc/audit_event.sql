@@ -0,0 +1,25 @@
+-- name: LockAuditChain :exec
+SELECT pg_advisory_xact_lock($1);
+
+-- name: GetLastAuditEvent :one
+SELECT id, hash
+FROM audit_events
+WHERE COALESCE(subject_id, 0) = $1
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: CreateAuditEvent :one
+INSERT INTO audit_events (
+    event_type, actor_id, actor_role, subject_id, ip, user_agent, request_id,
+    before_value, after_value, metadata, prev_hash, hash, created_at
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+RETURNING id;
+
+-- name: ListAuditEventsAfterID :many
+SELECT id, event_type, actor_id, actor_role, subject_id, ip, user_agent, request_id,
+    before_value, after_value, metadata, prev_hash, hash, created_at
+FROM audit_events
+WHERE id > $1
+ORDER BY id
+LIMIT $2;
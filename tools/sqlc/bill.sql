@@ -0,0 +1,79 @@
+-- name: CreateBill :one
+INSERT INTO bills (creator_id, title, currency, total, split, outstanding_amount, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, NOW())
+RETURNING *;
+
+-- name: CreateBillShare :one
+INSERT INTO bill_shares (bill_id, user_id, amount, created_at)
+VALUES ($1, $2, $3, NOW())
+RETURNING *;
+
+-- name: GetBill :one
+-- visible to its creator and its participants
+SELECT *
+FROM bills
+WHERE id = @id
+    AND (creator_id = @user_id OR EXISTS (
+        SELECT 1 FROM bill_shares WHERE bill_shares.bill_id = bills.id AND bill_shares.user_id = @user_id
+    ));
+
+-- name: ListBillsByCreatorID :many
+SELECT *
+FROM bills
+WHERE creator_id = @creator_id
+ORDER BY id DESC
+LIMIT @row_limit OFFSET @row_offset;
+
+-- name: CountBillsByCreatorID :one
+SELECT COUNT(*)
+FROM bills
+WHERE creator_id = @creator_id;
+
+-- name: ListBillShares :many
+SELECT *
+FROM bill_shares
+WHERE bill_id = @bill_id
+ORDER BY id;
+
+-- name: ListBillSharesByUserID :many
+SELECT bill_shares.id, bill_shares.bill_id, bill_shares.user_id, bill_shares.amount, bill_shares.status,
+    bill_shares.transfer_id, bill_shares.paid_at, bill_shares.reminded_at, bill_shares.created_at,
+    bills.creator_id, bills.title, bills.currency
+FROM bill_shares
+JOIN bills ON bills.id = bill_shares.bill_id
+WHERE bill_shares.user_id = @user_id
+    AND (sqlc.narg(status)::varchar IS NULL OR bill_shares.status = sqlc.narg(status))
+ORDER BY bill_shares.id DESC
+LIMIT @row_limit OFFSET @row_offset;
+
+-- name: CountBillSharesByUserID :one
+SELECT COUNT(*)
+FROM bill_shares
+WHERE user_id = @user_id
+    AND (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status));
+
+-- name: PayBillShare :one
+-- only the pending share of the same amount is paid, a share paid meanwhile rolls the
+-- transfer back
+UPDATE bill_shares
+SET status = 'paid', transfer_id = @transfer_id::int, paid_at = @now::timestamp
+WHERE bill_id = @bill_id AND user_id = @user_id AND amount = @amount AND status = 'pending'
+RETURNING *;
+
+-- name: RecordBillPayment :one
+-- moves a paid share from outstanding to paid, the bill is settled with its last share
+UPDATE bills
+SET paid_amount = paid_amount + @amount,
+    outstanding_amount = outstanding_amount - @amount,
+    status = CASE WHEN outstanding_amount - @amount <= 0 THEN 'settled' ELSE status END,
+    settled_at = CASE WHEN outstanding_amount - @amount <= 0 THEN @now::timestamp ELSE settled_at END
+WHERE id = @id AND creator_id = @creator_id AND currency = @currency AND status = 'open'
+RETURNING *;
+
+-- name: RemindBillShares :many
+-- the pending shares not reminded since remind_before
+UPDATE bill_shares
+SET reminded_at = @now::timestamp
+WHERE bill_id = @bill_id AND status = 'pending'
+    AND (reminded_at IS NULL OR reminded_at <= @remind_before::timestamp)
+RETURNING *;
@@ -0,0 +1,9 @@
+-- name: GetCurrency :one
+SELECT *
+FROM currencies
+WHERE code = $1;
+
+-- name: ListCurrencies :many
+SELECT *
+FROM currencies
+ORDER BY code;
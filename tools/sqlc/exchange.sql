@@ -0,0 +1,7 @@
+-- name: CreateExchange :one
+INSERT INTO exchanges (
+    user_id, quote_nonce, from_currency, to_currency, from_amount, to_amount, rate, mid_rate, spread_percent,
+    debit_transaction_id, credit_transaction_id, created_at
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW())
+RETURNING id, user_id, quote_nonce, from_currency, to_currency, from_amount, to_amount, rate, mid_rate, spread_percent, debit_transaction_id, credit_transaction_id, created_at;
@@ -0,0 +1,36 @@
+-- name: CreateFeeRule :one
+INSERT INTO fee_rules (transaction_type, currency, channel, user_tier, kind, flat_amount, percent, tiers, min_fee, max_fee, active, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, TRUE, NOW())
+RETURNING *;
+
+-- name: DeactivateFeeRule :execrows
+UPDATE fee_rules
+SET active = FALSE
+WHERE id = $1 AND active;
+
+-- name: DeactivateFeeRulesByKey :exec
+UPDATE fee_rules
+SET active = FALSE
+WHERE active AND transaction_type = @transaction_type AND currency = @currency
+    AND channel IS NOT DISTINCT FROM sqlc.narg(channel)
+    AND user_tier IS NOT DISTINCT FROM sqlc.narg(user_tier);
+
+-- name: GetApplicableFeeRule :one
+-- the most specific active rule wins, a channel match before a tier match
+SELECT *
+FROM fee_rules
+WHERE active AND transaction_type = @transaction_type AND currency = @currency
+    AND (channel IS NULL OR channel = @channel)
+    AND (user_tier IS NULL OR user_tier = @user_tier)
+ORDER BY channel IS NULL, user_tier IS NULL
+LIMIT 1;
+
+-- name: ListFeeRules :many
+SELECT *
+FROM fee_rules
+WHERE active OR NOT @active_only::boolean
+ORDER BY id DESC;
+
+-- name: CreateTransactionFee :exec
+INSERT INTO transaction_fees (transaction_id, fee_rule_id, amount, charge_transaction_id, revenue_transaction_id, created_at)
+VALUES ($1, $2, $3, $4, $5, NOW());
@@ -0,0 +1,37 @@
+-- name: CreateMerchant :one
+INSERT INTO merchants (name, user_id, created_at)
+VALUES ($1, $2, NOW())
+RETURNING id, name, created_at, user_id;
+
+-- name: GetMerchantByID :one
+SELECT id, name, created_at, user_id
+FROM merchants
+WHERE id = $1;
+
+-- name: CreateMerchantApiKey :one
+INSERT INTO merchant_api_keys (merchant_id, key_id, secret_ciphertext, created_at)
+VALUES ($1, $2, $3, NOW())
+RETURNING id, merchant_id, key_id, secret_ciphertext, expires_at, created_at;
+
+-- name: GetMerchantApiKeyByKeyID :one
+SELECT id, merchant_id, key_id, secret_ciphertext, expires_at, created_at
+FROM merchant_api_keys
+WHERE key_id = $1;
+
+-- name: ListMerchantApiKeys :many
+SELECT id, merchant_id, key_id, secret_ciphertext, expires_at, created_at
+FROM merchant_api_keys
+WHERE merchant_id = $1
+ORDER BY id;
+
+-- name: ExpireMerchantApiKeys :execrows
+UPDATE merchant_api_keys
+SET expires_at = @expires_at
+WHERE merchant_id = @merchant_id
+    AND (expires_at IS NULL OR expires_at > @expires_at);
+
+-- name: ExpireMerchantApiKey :execrows
+UPDATE merchant_api_keys
+SET expires_at = @expires_at
+WHERE merchant_id = @merchant_id AND key_id = @key_id
+    AND (expires_at IS NULL OR expires_at > @expires_at);
@@ -0,0 +1,26 @@
+-- name: CreatePayment :one
+INSERT INTO payments (merchant_id, user_id, currency, amount, order_reference, metadata, status, transaction_id, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, 'captured', $7, NOW())
+RETURNING id, merchant_id, user_id, currency, amount, order_reference, metadata, status, transaction_id, settlement_id, created_at;
+
+-- name: ListCapturedPaymentBatches :many
+SELECT DISTINCT merchant_id, currency
+FROM payments
+WHERE status = 'captured' AND created_at < @captured_before
+ORDER BY merchant_id, currency;
+
+-- name: SettlePayments :one
+WITH settled AS (
+    UPDATE payments
+    SET status = 'settled', settlement_id = @settlement_id
+    WHERE merchant_id = @merchant_id AND currency = @currency AND status = 'captured' AND created_at < @captured_before
+    RETURNING amount
+)
+SELECT COUNT(*)::integer AS payment_count, COALESCE(SUM(amount), 0)::numeric AS gross_amount
+FROM settled;
+
+-- name: ListPaymentsBySettlementID :many
+SELECT id, merchant_id, user_id, currency, amount, order_reference, metadata, status, transaction_id, settlement_id, created_at
+FROM payments
+WHERE settlement_id = $1
+ORDER BY id;
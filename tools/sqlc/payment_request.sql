@@ -0,0 +1,53 @@
+-- name: CreatePaymentRequest :one
+INSERT INTO payment_requests (requester_id, payer_id, currency, amount, note, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, NOW())
+RETURNING *;
+
+-- name: GetPaymentRequest :one
+SELECT *
+FROM payment_requests
+WHERE id = @id AND (requester_id = @user_id OR payer_id = @user_id);
+
+-- name: ListPaymentRequests :many
+SELECT *
+FROM payment_requests
+WHERE (sqlc.narg(requester_id)::int IS NULL OR requester_id = sqlc.narg(requester_id))
+    AND (sqlc.narg(payer_id)::int IS NULL OR payer_id = sqlc.narg(payer_id))
+    AND (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status))
+ORDER BY id DESC
+LIMIT @row_limit OFFSET @row_offset;
+
+-- name: CountPaymentRequests :one
+SELECT COUNT(*)
+FROM payment_requests
+WHERE (sqlc.narg(requester_id)::int IS NULL OR requester_id = sqlc.narg(requester_id))
+    AND (sqlc.narg(payer_id)::int IS NULL OR payer_id = sqlc.narg(payer_id))
+    AND (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status));
+
+-- name: PayPaymentRequest :one
+-- only the pending request of the same terms is paid, a request cancelled, declined or
+-- paid meanwhile rolls the transfer back
+UPDATE payment_requests
+SET status = 'paid', transfer_id = @transfer_id::int, resolved_at = @now::timestamp
+WHERE id = @id AND payer_id = @payer_id AND requester_id = @requester_id
+    AND currency = @currency AND amount = @amount
+    AND status = 'pending' AND expires_at > @now::timestamp
+RETURNING *;
+
+-- name: DeclinePaymentRequest :one
+UPDATE payment_requests
+SET status = 'declined', resolved_at = @now::timestamp
+WHERE id = @id AND payer_id = @payer_id AND status = 'pending' AND expires_at > @now::timestamp
+RETURNING *;
+
+-- name: CancelPaymentRequest :one
+UPDATE payment_requests
+SET status = 'cancelled', resolved_at = @now::timestamp
+WHERE id = @id AND requester_id = @requester_id AND status = 'pending'
+RETURNING *;
+
+-- name: ExpirePaymentRequests :many
+UPDATE payment_requests
+SET status = 'expired', resolved_at = @now::timestamp
+WHERE status = 'pending' AND expires_at <= @now::timestamp
+RETURNING *;
@@ -0,0 +1,63 @@
+-- name: CreatePocket :one
+INSERT INTO pockets (user_id, currency, name, is_default, created_at)
+VALUES ($1, $2, $3, $4, NOW())
+RETURNING *;
+
+-- name: GetPocket :one
+SELECT *
+FROM pockets
+WHERE id = $1 AND user_id = $2;
+
+-- name: GetPocketLock :one
+SELECT *
+FROM pockets
+WHERE id = $1 AND user_id = $2
+FOR UPDATE;
+
+-- name: GetDefaultPocketLock :one
+SELECT *
+FROM pockets
+WHERE user_id = $1 AND currency = $2 AND is_default
+FOR UPDATE;
+
+-- name: ListPocketsByUserID :many
+SELECT *
+FROM pockets
+WHERE user_id = $1
+ORDER BY currency, name;
+
+-- name: SumPocketBalances :one
+SELECT COALESCE(SUM(balance), 0)::numeric AS allocated
+FROM pockets
+WHERE user_id = $1 AND currency = $2;
+
+-- name: UpdatePocketBalance :exec
+UPDATE pockets
+SET balance = $2
+WHERE id = $1;
+
+-- name: ClearDefaultPocket :exec
+UPDATE pockets
+SET is_default = FALSE
+WHERE user_id = $1 AND currency = $2 AND is_default;
+
+-- name: SetDefaultPocket :exec
+UPDATE pockets
+SET is_default = TRUE
+WHERE id = $1;
+
+-- name: CreatePocketEntry :exec
+INSERT INTO pocket_entries (pocket_id, type, amount, balance, transaction_id, counterpart_pocket_id, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, NOW());
+
+-- name: ListPocketEntries :many
+SELECT *
+FROM pocket_entries
+WHERE pocket_id = @pocket_id
+ORDER BY id DESC
+LIMIT @row_limit OFFSET @row_offset;
+
+-- name: CountPocketEntries :one
+SELECT COUNT(*)
+FROM pocket_entries
+WHERE pocket_id = $1;
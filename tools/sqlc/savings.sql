@@ -0,0 +1,35 @@
+-- name: CreateSavingsGoal :one
+INSERT INTO savings_goals (user_id, pocket_id, target_amount, target_date, round_up_to, sweep_amount, sweep_frequency, next_sweep_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+RETURNING *;
+
+-- name: GetSavingsGoal :one
+SELECT *
+FROM savings_goals
+WHERE id = $1 AND user_id = $2;
+
+-- name: ListSavingsGoalsByUserID :many
+SELECT *
+FROM savings_goals
+WHERE user_id = $1
+ORDER BY id;
+
+-- name: ListRoundUpSavingsGoals :many
+SELECT g.*
+FROM savings_goals g
+JOIN pockets p ON p.id = g.pocket_id
+WHERE g.user_id = $1 AND p.currency = $2 AND g.round_up_to IS NOT NULL
+ORDER BY g.id;
+
+-- name: GetDueSavingsGoalLock :one
+SELECT *
+FROM savings_goals
+WHERE next_sweep_at <= $1
+ORDER BY next_sweep_at
+LIMIT 1
+FOR UPDATE SKIP LOCKED;
+
+-- name: UpdateSavingsGoalNextSweep :exec
+UPDATE savings_goals
+SET next_sweep_at = $2
+WHERE id = $1;
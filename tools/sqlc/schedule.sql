@@ -0,0 +1,58 @@
+-- name: CreateScheduledTransfer :one
+INSERT INTO scheduled_transfers (
+    user_id, to_user_id, currency, amount, note, recurrence, start_at, end_at, max_occurrences,
+    on_insufficient_funds, occurrence_at, next_run_at, created_at
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $7, $7, NOW())
+RETURNING *;
+
+-- name: GetScheduledTransfer :one
+SELECT *
+FROM scheduled_transfers
+WHERE id = $1 AND user_id = $2;
+
+-- name: ListScheduledTransfersByUserID :many
+SELECT *
+FROM scheduled_transfers
+WHERE user_id = $1
+ORDER BY id DESC;
+
+-- name: CancelScheduledTransfer :one
+UPDATE scheduled_transfers
+SET status = 'cancelled', next_run_at = NULL
+WHERE id = $1 AND user_id = $2 AND status = 'active'
+RETURNING *;
+
+-- name: ClaimScheduledTransfers :many
+UPDATE scheduled_transfers
+SET next_run_at = @lease_until::timestamp
+WHERE id IN (
+    SELECT id
+    FROM scheduled_transfers
+    WHERE status = 'active' AND next_run_at <= @now::timestamp
+    ORDER BY next_run_at
+    LIMIT @batch_size
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: UpdateScheduledTransferState :exec
+UPDATE scheduled_transfers
+SET status = $2, occurrence_at = $3, occurrence_count = $4, attempts = $5, next_run_at = $6
+WHERE id = $1 AND status = 'active';
+
+-- name: CreateScheduledTransferRun :exec
+INSERT INTO scheduled_transfer_runs (scheduled_transfer_id, occurrence_at, attempt, status, transfer_id, error, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, NOW());
+
+-- name: ListScheduledTransferRuns :many
+SELECT *
+FROM scheduled_transfer_runs
+WHERE scheduled_transfer_id = @scheduled_transfer_id
+ORDER BY id DESC
+LIMIT @row_limit OFFSET @row_offset;
+
+-- name: CountScheduledTransferRuns :one
+SELECT COUNT(*)
+FROM scheduled_transfer_runs
+WHERE scheduled_transfer_id = $1;
@@ -0,0 +1,28 @@
+-- name: CreateSettlement :one
+INSERT INTO settlements (merchant_id, settlement_date, currency, created_at)
+VALUES ($1, $2, $3, NOW())
+ON CONFLICT (merchant_id, settlement_date, currency) DO NOTHING
+RETURNING id, merchant_id, settlement_date, currency, payment_count, gross_amount, fee_amount, net_amount, transaction_id, created_at;
+
+-- name: UpdateSettlementTotals :one
+UPDATE settlements
+SET payment_count = $2, gross_amount = $3, fee_amount = $4, net_amount = $5, transaction_id = $6
+WHERE id = $1
+RETURNING id, merchant_id, settlement_date, currency, payment_count, gross_amount, fee_amount, net_amount, transaction_id, created_at;
+
+-- name: GetSettlement :one
+SELECT id, merchant_id, settlement_date, currency, payment_count, gross_amount, fee_amount, net_amount, transaction_id, created_at
+FROM settlements
+WHERE id = $1 AND merchant_id = $2;
+
+-- name: ListSettlementsByMerchantID :many
+SELECT id, merchant_id, settlement_date, currency, payment_count, gross_amount, fee_amount, net_amount, transaction_id, created_at
+FROM settlements
+WHERE merchant_id = @merchant_id
+ORDER BY settlement_date DESC, currency
+LIMIT @row_limit OFFSET @row_offset;
+
+-- name: CountSettlementsByMerchantID :one
+SELECT COUNT(*)
+FROM settlements
+WHERE merchant_id = $1;
@@ -1,4 +1,8 @@
 -- name: CreateTransaction :one
-INSERT INTO transactions (user_id, amount, type, created_at)
-VALUES ($1, $2, $3, NOW())
-RETURNING id;
\ No newline at end of file
+INSERT INTO transactions (user_id, amount, type, currency, created_at)
+VALUES ($1, $2, $3, $4, NOW())
+RETURNING id;
+
+-- name: CreateQuoteRedemption :exec
+INSERT INTO quote_redemptions (nonce, user_id, expires_at, created_at)
+VALUES ($1, $2, $3, NOW());
@@ -0,0 +1,9 @@
+-- name: CreateTransfer :one
+INSERT INTO transfers (from_user_id, to_user_id, currency, amount, note, idempotency_key, debit_transaction_id, credit_transaction_id, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+RETURNING *;
+
+-- name: GetTransferByIdempotencyKey :one
+SELECT *
+FROM transfers
+WHERE idempotency_key = $1;
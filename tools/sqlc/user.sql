@@ -3,6 +3,11 @@ INSERT INTO users (username, password, created_at)
 VALUES ($1, $2, NOW())
 RETURNING id;
 
+-- name: GetUserByID :one
+SELECT *
+FROM users
+WHERE id = $1;
+
 -- name: GetUserByIDLock :one
 SELECT *
 FROM users
@@ -13,8 +18,3 @@ FOR UPDATE;
 SELECT *
 FROM users
 WHERE username = $1;
-
--- name: UpdateUserBalanceByID :exec
-UPDATE users
-SET balance = $2
-WHERE id = $1;
\ No newline at end of file
@@ -0,0 +1,31 @@
+-- name: CreateWallet :one
+INSERT INTO wallets (user_id, currency, created_at)
+VALUES ($1, $2, NOW())
+RETURNING *;
+
+-- name: GetWallet :one
+SELECT *
+FROM wallets
+WHERE user_id = $1 AND currency = $2;
+
+-- name: GetWalletLock :one
+SELECT *
+FROM wallets
+WHERE user_id = $1 AND currency = $2
+FOR UPDATE;
+
+-- name: IncrementWalletBalance :execrows
+UPDATE wallets
+SET balance = balance + @amount
+WHERE user_id = @user_id AND currency = @currency;
+
+-- name: ListWalletsByUserID :many
+SELECT *
+FROM wallets
+WHERE user_id = $1
+ORDER BY currency;
+
+-- name: UpdateWalletBalanceByID :exec
+UPDATE wallets
+SET balance = $2
+WHERE id = $1;
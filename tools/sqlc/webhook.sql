@@ -0,0 +1,73 @@
+-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (user_id, url, secret, event_types, created_at)
+VALUES ($1, $2, $3, $4, NOW())
+RETURNING id, user_id, url, secret, event_types, created_at;
+
+-- name: GetWebhookSubscriptionByID :one
+SELECT id, user_id, url, secret, event_types, created_at
+FROM webhook_subscriptions
+WHERE id = $1;
+
+-- name: ListWebhookSubscriptionsByUserID :many
+SELECT id, user_id, url, secret, event_types, created_at
+FROM webhook_subscriptions
+WHERE user_id = $1
+ORDER BY id;
+
+-- name: DeleteWebhookSubscription :execrows
+DELETE FROM webhook_subscriptions
+WHERE id = $1 AND user_id = $2;
+
+-- name: CreateWebhookDeliveries :execrows
+INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, created_at)
+SELECT id, @event_id::uuid, @event_type::varchar, @payload::jsonb, 'pending', 0, @created_at::timestamp, @created_at::timestamp
+FROM webhook_subscriptions
+WHERE user_id = @user_id
+    AND (cardinality(event_types) = 0 OR @event_type::varchar = ANY(event_types));
+
+-- name: ClaimWebhookDeliveries :many
+UPDATE webhook_deliveries
+SET next_attempt_at = @lease_until
+WHERE id IN (
+    SELECT id
+    FROM webhook_deliveries
+    WHERE status = 'pending' AND next_attempt_at <= @now
+    ORDER BY next_attempt_at
+    LIMIT @batch_size
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, subscription_id, event_id, event_type, payload, status, attempts,
+    next_attempt_at, last_attempt_at, response_status, last_error, created_at;
+
+-- name: GetOldestDueWebhookDelivery :one
+SELECT next_attempt_at
+FROM webhook_deliveries
+WHERE status = 'pending'
+ORDER BY next_attempt_at
+LIMIT 1;
+
+-- name: UpdateWebhookDeliveryAttempt :exec
+UPDATE webhook_deliveries
+SET status = $2, attempts = $3, next_attempt_at = $4, last_attempt_at = $5, response_status = $6, last_error = $7
+WHERE id = $1;
+
+-- name: GetWebhookDelivery :one
+SELECT id, subscription_id, event_id, event_type, payload, status, attempts,
+    next_attempt_at, last_attempt_at, response_status, last_error, created_at
+FROM webhook_deliveries
+WHERE id = $1 AND subscription_id = $2;
+
+-- name: ListWebhookDeliveries :many
+SELECT id, subscription_id, event_id, event_type, payload, status, attempts,
+    next_attempt_at, last_attempt_at, response_status, last_error, created_at
+FROM webhook_deliveries
+WHERE subscription_id = @subscription_id
+    AND (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status))
+ORDER BY id DESC
+LIMIT @row_limit OFFSET @row_offset;
+
+-- name: CountWebhookDeliveries :one
+SELECT COUNT(*)
+FROM webhook_deliveries
+WHERE subscription_id = @subscription_id
+    AND (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status));